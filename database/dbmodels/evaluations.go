@@ -24,72 +24,132 @@ import (
 
 // Evaluation is an object representing the database table.
 type Evaluation struct {
-	ID                string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	PatientName       string      `boil:"patient_name" json:"patient_name" toml:"patient_name" yaml:"patient_name"`
-	PatientAge        int         `boil:"patient_age" json:"patient_age" toml:"patient_age" yaml:"patient_age"`
-	SpecialistMail    string      `boil:"specialist_mail" json:"specialist_mail" toml:"specialist_mail" yaml:"specialist_mail"`
-	SpecialistID      string      `boil:"specialist_id" json:"specialist_id" toml:"specialist_id" yaml:"specialist_id"`
-	AssistantAnalysis null.String `boil:"assistant_analysis" json:"assistant_analysis,omitempty" toml:"assistant_analysis" yaml:"assistant_analysis,omitempty"`
-	StorageURL        null.String `boil:"storage_url" json:"storage_url,omitempty" toml:"storage_url" yaml:"storage_url,omitempty"`
-	StorageKey        null.String `boil:"storage_key" json:"storage_key,omitempty" toml:"storage_key" yaml:"storage_key,omitempty"`
-	CurrentStatus     string      `boil:"current_status" json:"current_status" toml:"current_status" yaml:"current_status"`
-	CreatedAt         time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt         time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID                      string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PatientName             string      `boil:"patient_name" json:"patient_name" toml:"patient_name" yaml:"patient_name"`
+	PatientAge              int         `boil:"patient_age" json:"patient_age" toml:"patient_age" yaml:"patient_age"`
+	SpecialistMail          string      `boil:"specialist_mail" json:"specialist_mail" toml:"specialist_mail" yaml:"specialist_mail"`
+	SpecialistID            string      `boil:"specialist_id" json:"specialist_id" toml:"specialist_id" yaml:"specialist_id"`
+	AssistantAnalysis       null.String `boil:"assistant_analysis" json:"assistant_analysis,omitempty" toml:"assistant_analysis" yaml:"assistant_analysis,omitempty"`
+	StorageURL              null.String `boil:"storage_url" json:"storage_url,omitempty" toml:"storage_url" yaml:"storage_url,omitempty"`
+	StorageKey              null.String `boil:"storage_key" json:"storage_key,omitempty" toml:"storage_key" yaml:"storage_key,omitempty"`
+	CurrentStatus           string      `boil:"current_status" json:"current_status" toml:"current_status" yaml:"current_status"`
+	CreatedAt               time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt               time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	AnalysisProvider        string      `boil:"analysis_provider" json:"analysis_provider" toml:"analysis_provider" yaml:"analysis_provider"`
+	AnalysisModel           string      `boil:"analysis_model" json:"analysis_model" toml:"analysis_model" yaml:"analysis_model"`
+	AnalysisLatencyMS       int         `boil:"analysis_latency_ms" json:"analysis_latency_ms" toml:"analysis_latency_ms" yaml:"analysis_latency_ms"`
+	AnalysisPromptVersion   string      `boil:"analysis_prompt_version" json:"analysis_prompt_version" toml:"analysis_prompt_version" yaml:"analysis_prompt_version"`
+	AnalysisTitle           string      `boil:"analysis_title" json:"analysis_title" toml:"analysis_title" yaml:"analysis_title"`
+	AnalysisProfile         string      `boil:"analysis_profile" json:"analysis_profile" toml:"analysis_profile" yaml:"analysis_profile"`
+	AnalysisJustification   null.String `boil:"analysis_justification" json:"analysis_justification,omitempty" toml:"analysis_justification" yaml:"analysis_justification,omitempty"`
+	AnalysisFindings        null.JSON   `boil:"analysis_findings" json:"analysis_findings,omitempty" toml:"analysis_findings" yaml:"analysis_findings,omitempty"`
+	AnalysisSummary         null.String `boil:"analysis_summary" json:"analysis_summary,omitempty" toml:"analysis_summary" yaml:"analysis_summary,omitempty"`
+	AnalysisRecommendations null.JSON   `boil:"analysis_recommendations" json:"analysis_recommendations,omitempty" toml:"analysis_recommendations" yaml:"analysis_recommendations,omitempty"`
+	AnalysisUncertainty     string      `boil:"analysis_uncertainty" json:"analysis_uncertainty" toml:"analysis_uncertainty" yaml:"analysis_uncertainty"`
+	AnalysisAttempts        int         `boil:"analysis_attempts" json:"analysis_attempts" toml:"analysis_attempts" yaml:"analysis_attempts"`
 
 	R *evaluationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L evaluationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var EvaluationColumns = struct {
-	ID                string
-	PatientName       string
-	PatientAge        string
-	SpecialistMail    string
-	SpecialistID      string
-	AssistantAnalysis string
-	StorageURL        string
-	StorageKey        string
-	CurrentStatus     string
-	CreatedAt         string
-	UpdatedAt         string
+	ID                      string
+	PatientName             string
+	PatientAge              string
+	SpecialistMail          string
+	SpecialistID            string
+	AssistantAnalysis       string
+	StorageURL              string
+	StorageKey              string
+	CurrentStatus           string
+	CreatedAt               string
+	UpdatedAt               string
+	AnalysisProvider        string
+	AnalysisModel           string
+	AnalysisLatencyMS       string
+	AnalysisPromptVersion   string
+	AnalysisTitle           string
+	AnalysisProfile         string
+	AnalysisJustification   string
+	AnalysisFindings        string
+	AnalysisSummary         string
+	AnalysisRecommendations string
+	AnalysisUncertainty     string
+	AnalysisAttempts        string
 }{
-	ID:                "id",
-	PatientName:       "patient_name",
-	PatientAge:        "patient_age",
-	SpecialistMail:    "specialist_mail",
-	SpecialistID:      "specialist_id",
-	AssistantAnalysis: "assistant_analysis",
-	StorageURL:        "storage_url",
-	StorageKey:        "storage_key",
-	CurrentStatus:     "current_status",
-	CreatedAt:         "created_at",
-	UpdatedAt:         "updated_at",
+	ID:                      "id",
+	PatientName:             "patient_name",
+	PatientAge:              "patient_age",
+	SpecialistMail:          "specialist_mail",
+	SpecialistID:            "specialist_id",
+	AssistantAnalysis:       "assistant_analysis",
+	StorageURL:              "storage_url",
+	StorageKey:              "storage_key",
+	CurrentStatus:           "current_status",
+	CreatedAt:               "created_at",
+	UpdatedAt:               "updated_at",
+	AnalysisProvider:        "analysis_provider",
+	AnalysisModel:           "analysis_model",
+	AnalysisLatencyMS:       "analysis_latency_ms",
+	AnalysisPromptVersion:   "analysis_prompt_version",
+	AnalysisTitle:           "analysis_title",
+	AnalysisProfile:         "analysis_profile",
+	AnalysisJustification:   "analysis_justification",
+	AnalysisFindings:        "analysis_findings",
+	AnalysisSummary:         "analysis_summary",
+	AnalysisRecommendations: "analysis_recommendations",
+	AnalysisUncertainty:     "analysis_uncertainty",
+	AnalysisAttempts:        "analysis_attempts",
 }
 
 var EvaluationTableColumns = struct {
-	ID                string
-	PatientName       string
-	PatientAge        string
-	SpecialistMail    string
-	SpecialistID      string
-	AssistantAnalysis string
-	StorageURL        string
-	StorageKey        string
-	CurrentStatus     string
-	CreatedAt         string
-	UpdatedAt         string
+	ID                      string
+	PatientName             string
+	PatientAge              string
+	SpecialistMail          string
+	SpecialistID            string
+	AssistantAnalysis       string
+	StorageURL              string
+	StorageKey              string
+	CurrentStatus           string
+	CreatedAt               string
+	UpdatedAt               string
+	AnalysisProvider        string
+	AnalysisModel           string
+	AnalysisLatencyMS       string
+	AnalysisPromptVersion   string
+	AnalysisTitle           string
+	AnalysisProfile         string
+	AnalysisJustification   string
+	AnalysisFindings        string
+	AnalysisSummary         string
+	AnalysisRecommendations string
+	AnalysisUncertainty     string
+	AnalysisAttempts        string
 }{
-	ID:                "evaluations.id",
-	PatientName:       "evaluations.patient_name",
-	PatientAge:        "evaluations.patient_age",
-	SpecialistMail:    "evaluations.specialist_mail",
-	SpecialistID:      "evaluations.specialist_id",
-	AssistantAnalysis: "evaluations.assistant_analysis",
-	StorageURL:        "evaluations.storage_url",
-	StorageKey:        "evaluations.storage_key",
-	CurrentStatus:     "evaluations.current_status",
-	CreatedAt:         "evaluations.created_at",
-	UpdatedAt:         "evaluations.updated_at",
+	ID:                      "evaluations.id",
+	PatientName:             "evaluations.patient_name",
+	PatientAge:              "evaluations.patient_age",
+	SpecialistMail:          "evaluations.specialist_mail",
+	SpecialistID:            "evaluations.specialist_id",
+	AssistantAnalysis:       "evaluations.assistant_analysis",
+	StorageURL:              "evaluations.storage_url",
+	StorageKey:              "evaluations.storage_key",
+	CurrentStatus:           "evaluations.current_status",
+	CreatedAt:               "evaluations.created_at",
+	UpdatedAt:               "evaluations.updated_at",
+	AnalysisProvider:        "evaluations.analysis_provider",
+	AnalysisModel:           "evaluations.analysis_model",
+	AnalysisLatencyMS:       "evaluations.analysis_latency_ms",
+	AnalysisPromptVersion:   "evaluations.analysis_prompt_version",
+	AnalysisTitle:           "evaluations.analysis_title",
+	AnalysisProfile:         "evaluations.analysis_profile",
+	AnalysisJustification:   "evaluations.analysis_justification",
+	AnalysisFindings:        "evaluations.analysis_findings",
+	AnalysisSummary:         "evaluations.analysis_summary",
+	AnalysisRecommendations: "evaluations.analysis_recommendations",
+	AnalysisUncertainty:     "evaluations.analysis_uncertainty",
+	AnalysisAttempts:        "evaluations.analysis_attempts",
 }
 
 // Generated where
@@ -208,29 +268,53 @@ func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
 }
 
 var EvaluationWhere = struct {
-	ID                whereHelperstring
-	PatientName       whereHelperstring
-	PatientAge        whereHelperint
-	SpecialistMail    whereHelperstring
-	SpecialistID      whereHelperstring
-	AssistantAnalysis whereHelpernull_String
-	StorageURL        whereHelpernull_String
-	StorageKey        whereHelpernull_String
-	CurrentStatus     whereHelperstring
-	CreatedAt         whereHelpertime_Time
-	UpdatedAt         whereHelpertime_Time
+	ID                      whereHelperstring
+	PatientName             whereHelperstring
+	PatientAge              whereHelperint
+	SpecialistMail          whereHelperstring
+	SpecialistID            whereHelperstring
+	AssistantAnalysis       whereHelpernull_String
+	StorageURL              whereHelpernull_String
+	StorageKey              whereHelpernull_String
+	CurrentStatus           whereHelperstring
+	CreatedAt               whereHelpertime_Time
+	UpdatedAt               whereHelpertime_Time
+	AnalysisProvider        whereHelperstring
+	AnalysisModel           whereHelperstring
+	AnalysisLatencyMS       whereHelperint
+	AnalysisPromptVersion   whereHelperstring
+	AnalysisTitle           whereHelperstring
+	AnalysisProfile         whereHelperstring
+	AnalysisJustification   whereHelpernull_String
+	AnalysisFindings        whereHelpernull_JSON
+	AnalysisSummary         whereHelpernull_String
+	AnalysisRecommendations whereHelpernull_JSON
+	AnalysisUncertainty     whereHelperstring
+	AnalysisAttempts        whereHelperint
 }{
-	ID:                whereHelperstring{field: "`evaluations`.`id`"},
-	PatientName:       whereHelperstring{field: "`evaluations`.`patient_name`"},
-	PatientAge:        whereHelperint{field: "`evaluations`.`patient_age`"},
-	SpecialistMail:    whereHelperstring{field: "`evaluations`.`specialist_mail`"},
-	SpecialistID:      whereHelperstring{field: "`evaluations`.`specialist_id`"},
-	AssistantAnalysis: whereHelpernull_String{field: "`evaluations`.`assistant_analysis`"},
-	StorageURL:        whereHelpernull_String{field: "`evaluations`.`storage_url`"},
-	StorageKey:        whereHelpernull_String{field: "`evaluations`.`storage_key`"},
-	CurrentStatus:     whereHelperstring{field: "`evaluations`.`current_status`"},
-	CreatedAt:         whereHelpertime_Time{field: "`evaluations`.`created_at`"},
-	UpdatedAt:         whereHelpertime_Time{field: "`evaluations`.`updated_at`"},
+	ID:                      whereHelperstring{field: "`evaluations`.`id`"},
+	PatientName:             whereHelperstring{field: "`evaluations`.`patient_name`"},
+	PatientAge:              whereHelperint{field: "`evaluations`.`patient_age`"},
+	SpecialistMail:          whereHelperstring{field: "`evaluations`.`specialist_mail`"},
+	SpecialistID:            whereHelperstring{field: "`evaluations`.`specialist_id`"},
+	AssistantAnalysis:       whereHelpernull_String{field: "`evaluations`.`assistant_analysis`"},
+	StorageURL:              whereHelpernull_String{field: "`evaluations`.`storage_url`"},
+	StorageKey:              whereHelpernull_String{field: "`evaluations`.`storage_key`"},
+	CurrentStatus:           whereHelperstring{field: "`evaluations`.`current_status`"},
+	CreatedAt:               whereHelpertime_Time{field: "`evaluations`.`created_at`"},
+	UpdatedAt:               whereHelpertime_Time{field: "`evaluations`.`updated_at`"},
+	AnalysisProvider:        whereHelperstring{field: "`evaluations`.`analysis_provider`"},
+	AnalysisModel:           whereHelperstring{field: "`evaluations`.`analysis_model`"},
+	AnalysisLatencyMS:       whereHelperint{field: "`evaluations`.`analysis_latency_ms`"},
+	AnalysisPromptVersion:   whereHelperstring{field: "`evaluations`.`analysis_prompt_version`"},
+	AnalysisTitle:           whereHelperstring{field: "`evaluations`.`analysis_title`"},
+	AnalysisProfile:         whereHelperstring{field: "`evaluations`.`analysis_profile`"},
+	AnalysisJustification:   whereHelpernull_String{field: "`evaluations`.`analysis_justification`"},
+	AnalysisFindings:        whereHelpernull_JSON{field: "`evaluations`.`analysis_findings`"},
+	AnalysisSummary:         whereHelpernull_String{field: "`evaluations`.`analysis_summary`"},
+	AnalysisRecommendations: whereHelpernull_JSON{field: "`evaluations`.`analysis_recommendations`"},
+	AnalysisUncertainty:     whereHelperstring{field: "`evaluations`.`analysis_uncertainty`"},
+	AnalysisAttempts:        whereHelperint{field: "`evaluations`.`analysis_attempts`"},
 }
 
 // EvaluationRels is where relationship names are stored.
@@ -346,9 +430,9 @@ func (r *evaluationR) GetVisualMemorySubtests() VisualMemorySubtestSlice {
 type evaluationL struct{}
 
 var (
-	evaluationAllColumns            = []string{"id", "patient_name", "patient_age", "specialist_mail", "specialist_id", "assistant_analysis", "storage_url", "storage_key", "current_status", "created_at", "updated_at"}
-	evaluationColumnsWithoutDefault = []string{"id", "patient_name", "patient_age", "specialist_mail", "specialist_id", "assistant_analysis", "storage_url", "storage_key", "current_status", "created_at"}
-	evaluationColumnsWithDefault    = []string{"updated_at"}
+	evaluationAllColumns            = []string{"id", "patient_name", "patient_age", "specialist_mail", "specialist_id", "assistant_analysis", "storage_url", "storage_key", "current_status", "created_at", "updated_at", "analysis_provider", "analysis_model", "analysis_latency_ms", "analysis_prompt_version", "analysis_title", "analysis_profile", "analysis_justification", "analysis_findings", "analysis_summary", "analysis_recommendations", "analysis_uncertainty", "analysis_attempts"}
+	evaluationColumnsWithoutDefault = []string{"id", "patient_name", "patient_age", "specialist_mail", "specialist_id", "assistant_analysis", "storage_url", "storage_key", "current_status", "created_at", "analysis_justification", "analysis_findings", "analysis_summary", "analysis_recommendations"}
+	evaluationColumnsWithDefault    = []string{"updated_at", "analysis_provider", "analysis_model", "analysis_latency_ms", "analysis_prompt_version", "analysis_title", "analysis_profile", "analysis_uncertainty", "analysis_attempts"}
 	evaluationPrimaryKeyColumns     = []string{"id"}
 	evaluationGeneratedColumns      = []string{}
 )
@@ -39,6 +39,7 @@ type ExecutiveFunctionsSubtest struct {
 	DurationSec       float64     `boil:"duration_sec" json:"duration_sec" toml:"duration_sec" yaml:"duration_sec"`
 	AssistantAnalysis null.String `boil:"assistant_analysis" json:"assistant_analysis,omitempty" toml:"assistant_analysis" yaml:"assistant_analysis,omitempty"`
 	CreatedAt         time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ScoringProfile    string      `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *executiveFunctionsSubtestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L executiveFunctionsSubtestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -60,6 +61,7 @@ var ExecutiveFunctionsSubtestColumns = struct {
 	DurationSec       string
 	AssistantAnalysis string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "id",
 	EvaluationID:      "evaluation_id",
@@ -76,6 +78,7 @@ var ExecutiveFunctionsSubtestColumns = struct {
 	DurationSec:       "duration_sec",
 	AssistantAnalysis: "assistant_analysis",
 	CreatedAt:         "created_at",
+	ScoringProfile:    "scoring_profile",
 }
 
 var ExecutiveFunctionsSubtestTableColumns = struct {
@@ -94,6 +97,7 @@ var ExecutiveFunctionsSubtestTableColumns = struct {
 	DurationSec       string
 	AssistantAnalysis string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "executive_functions_subtests.id",
 	EvaluationID:      "executive_functions_subtests.evaluation_id",
@@ -110,6 +114,7 @@ var ExecutiveFunctionsSubtestTableColumns = struct {
 	DurationSec:       "executive_functions_subtests.duration_sec",
 	AssistantAnalysis: "executive_functions_subtests.assistant_analysis",
 	CreatedAt:         "executive_functions_subtests.created_at",
+	ScoringProfile:    "executive_functions_subtests.scoring_profile",
 }
 
 // Generated where
@@ -159,6 +164,7 @@ var ExecutiveFunctionsSubtestWhere = struct {
 	DurationSec       whereHelperfloat64
 	AssistantAnalysis whereHelpernull_String
 	CreatedAt         whereHelpertime_Time
+	ScoringProfile    whereHelperstring
 }{
 	ID:                whereHelperstring{field: "`executive_functions_subtests`.`id`"},
 	EvaluationID:      whereHelperstring{field: "`executive_functions_subtests`.`evaluation_id`"},
@@ -175,6 +181,7 @@ var ExecutiveFunctionsSubtestWhere = struct {
 	DurationSec:       whereHelperfloat64{field: "`executive_functions_subtests`.`duration_sec`"},
 	AssistantAnalysis: whereHelpernull_String{field: "`executive_functions_subtests`.`assistant_analysis`"},
 	CreatedAt:         whereHelpertime_Time{field: "`executive_functions_subtests`.`created_at`"},
+	ScoringProfile:    whereHelperstring{field: "`executive_functions_subtests`.`scoring_profile`"},
 }
 
 // ExecutiveFunctionsSubtestRels is where relationship names are stored.
@@ -214,9 +221,9 @@ func (r *executiveFunctionsSubtestR) GetEvaluation() *Evaluation {
 type executiveFunctionsSubtestL struct{}
 
 var (
-	executiveFunctionsSubtestAllColumns            = []string{"id", "evaluation_id", "number_of_items", "total_clicks", "total_errors", "total_correct", "total_time_sec", "type", "score", "accuracy", "speed_index", "commission_rate", "duration_sec", "assistant_analysis", "created_at"}
+	executiveFunctionsSubtestAllColumns            = []string{"id", "evaluation_id", "number_of_items", "total_clicks", "total_errors", "total_correct", "total_time_sec", "type", "score", "accuracy", "speed_index", "commission_rate", "duration_sec", "assistant_analysis", "created_at", "scoring_profile"}
 	executiveFunctionsSubtestColumnsWithoutDefault = []string{"id", "evaluation_id", "number_of_items", "total_clicks", "total_errors", "total_correct", "total_time_sec", "type", "score", "accuracy", "speed_index", "commission_rate", "duration_sec", "assistant_analysis"}
-	executiveFunctionsSubtestColumnsWithDefault    = []string{"created_at"}
+	executiveFunctionsSubtestColumnsWithDefault    = []string{"created_at", "scoring_profile"}
 	executiveFunctionsSubtestPrimaryKeyColumns     = []string{"id"}
 	executiveFunctionsSubtestGeneratedColumns      = []string{}
 )
@@ -40,6 +40,7 @@ type LanguageFluency struct {
 	PersevRate        float64     `boil:"persev_rate" json:"persev_rate" toml:"persev_rate" yaml:"persev_rate"`
 	AssistantAnalysis null.String `boil:"assistant_analysis" json:"assistant_analysis,omitempty" toml:"assistant_analysis" yaml:"assistant_analysis,omitempty"`
 	CreatedAt         time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ScoringProfile    string      `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *languageFluencyR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L languageFluencyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -62,6 +63,7 @@ var LanguageFluencyColumns = struct {
 	PersevRate        string
 	AssistantAnalysis string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "id",
 	EvaluationID:      "evaluation_id",
@@ -79,6 +81,7 @@ var LanguageFluencyColumns = struct {
 	PersevRate:        "persev_rate",
 	AssistantAnalysis: "assistant_analysis",
 	CreatedAt:         "created_at",
+	ScoringProfile:    "scoring_profile",
 }
 
 var LanguageFluencyTableColumns = struct {
@@ -98,6 +101,7 @@ var LanguageFluencyTableColumns = struct {
 	PersevRate        string
 	AssistantAnalysis string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "language_fluencies.id",
 	EvaluationID:      "language_fluencies.evaluation_id",
@@ -115,6 +119,7 @@ var LanguageFluencyTableColumns = struct {
 	PersevRate:        "language_fluencies.persev_rate",
 	AssistantAnalysis: "language_fluencies.assistant_analysis",
 	CreatedAt:         "language_fluencies.created_at",
+	ScoringProfile:    "language_fluencies.scoring_profile",
 }
 
 // Generated where
@@ -160,6 +165,7 @@ var LanguageFluencyWhere = struct {
 	PersevRate        whereHelperfloat64
 	AssistantAnalysis whereHelpernull_String
 	CreatedAt         whereHelpertime_Time
+	ScoringProfile    whereHelperstring
 }{
 	ID:                whereHelperstring{field: "`language_fluencies`.`id`"},
 	EvaluationID:      whereHelperstring{field: "`language_fluencies`.`evaluation_id`"},
@@ -177,6 +183,7 @@ var LanguageFluencyWhere = struct {
 	PersevRate:        whereHelperfloat64{field: "`language_fluencies`.`persev_rate`"},
 	AssistantAnalysis: whereHelpernull_String{field: "`language_fluencies`.`assistant_analysis`"},
 	CreatedAt:         whereHelpertime_Time{field: "`language_fluencies`.`created_at`"},
+	ScoringProfile:    whereHelperstring{field: "`language_fluencies`.`scoring_profile`"},
 }
 
 // LanguageFluencyRels is where relationship names are stored.
@@ -216,9 +223,9 @@ func (r *languageFluencyR) GetEvaluation() *Evaluation {
 type languageFluencyL struct{}
 
 var (
-	languageFluencyAllColumns            = []string{"id", "evaluation_id", "language", "proficiency", "category", "answer_words", "score", "unique_valid", "intrusions", "perseverations", "total_produced", "words_per_minute", "intrusion_rate", "persev_rate", "assistant_analysis", "created_at"}
+	languageFluencyAllColumns            = []string{"id", "evaluation_id", "language", "proficiency", "category", "answer_words", "score", "unique_valid", "intrusions", "perseverations", "total_produced", "words_per_minute", "intrusion_rate", "persev_rate", "assistant_analysis", "created_at", "scoring_profile"}
 	languageFluencyColumnsWithoutDefault = []string{"id", "evaluation_id", "language", "proficiency", "category", "answer_words", "score", "unique_valid", "intrusions", "perseverations", "total_produced", "words_per_minute", "intrusion_rate", "persev_rate", "assistant_analysis"}
-	languageFluencyColumnsWithDefault    = []string{"created_at"}
+	languageFluencyColumnsWithDefault    = []string{"created_at", "scoring_profile"}
 	languageFluencyPrimaryKeyColumns     = []string{"id"}
 	languageFluencyGeneratedColumns      = []string{}
 )
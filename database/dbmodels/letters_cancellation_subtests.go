@@ -40,6 +40,7 @@ type LettersCancellationSubtest struct {
 	HitsPerMin        float64     `boil:"hits_per_min" json:"hits_per_min" toml:"hits_per_min" yaml:"hits_per_min"`
 	ErrorsPerMin      float64     `boil:"errors_per_min" json:"errors_per_min" toml:"errors_per_min" yaml:"errors_per_min"`
 	CreatedAt         time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ScoringProfile    string      `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *lettersCancellationSubtestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L lettersCancellationSubtestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -62,6 +63,7 @@ var LettersCancellationSubtestColumns = struct {
 	HitsPerMin        string
 	ErrorsPerMin      string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "id",
 	EvaluationID:      "evaluation_id",
@@ -79,6 +81,7 @@ var LettersCancellationSubtestColumns = struct {
 	HitsPerMin:        "hits_per_min",
 	ErrorsPerMin:      "errors_per_min",
 	CreatedAt:         "created_at",
+	ScoringProfile:    "scoring_profile",
 }
 
 var LettersCancellationSubtestTableColumns = struct {
@@ -98,6 +101,7 @@ var LettersCancellationSubtestTableColumns = struct {
 	HitsPerMin        string
 	ErrorsPerMin      string
 	CreatedAt         string
+	ScoringProfile    string
 }{
 	ID:                "letters_cancellation_subtests.id",
 	EvaluationID:      "letters_cancellation_subtests.evaluation_id",
@@ -115,6 +119,7 @@ var LettersCancellationSubtestTableColumns = struct {
 	HitsPerMin:        "letters_cancellation_subtests.hits_per_min",
 	ErrorsPerMin:      "letters_cancellation_subtests.errors_per_min",
 	CreatedAt:         "letters_cancellation_subtests.created_at",
+	ScoringProfile:    "letters_cancellation_subtests.scoring_profile",
 }
 
 // Generated where
@@ -136,6 +141,7 @@ var LettersCancellationSubtestWhere = struct {
 	HitsPerMin        whereHelperfloat64
 	ErrorsPerMin      whereHelperfloat64
 	CreatedAt         whereHelpertime_Time
+	ScoringProfile    whereHelperstring
 }{
 	ID:                whereHelperstring{field: "`letters_cancellation_subtests`.`id`"},
 	EvaluationID:      whereHelperstring{field: "`letters_cancellation_subtests`.`evaluation_id`"},
@@ -153,6 +159,7 @@ var LettersCancellationSubtestWhere = struct {
 	HitsPerMin:        whereHelperfloat64{field: "`letters_cancellation_subtests`.`hits_per_min`"},
 	ErrorsPerMin:      whereHelperfloat64{field: "`letters_cancellation_subtests`.`errors_per_min`"},
 	CreatedAt:         whereHelpertime_Time{field: "`letters_cancellation_subtests`.`created_at`"},
+	ScoringProfile:    whereHelperstring{field: "`letters_cancellation_subtests`.`scoring_profile`"},
 }
 
 // LettersCancellationSubtestRels is where relationship names are stored.
@@ -192,9 +199,9 @@ func (r *lettersCancellationSubtestR) GetEvaluation() *Evaluation {
 type lettersCancellationSubtestL struct{}
 
 var (
-	lettersCancellationSubtestAllColumns            = []string{"id", "evaluation_id", "total_targets", "correct", "errors", "time_in_secs", "assistant_analysis", "score", "cp_per_min", "accuracy", "omissions", "omissions_rate", "commission_rate", "hits_per_min", "errors_per_min", "created_at"}
+	lettersCancellationSubtestAllColumns            = []string{"id", "evaluation_id", "total_targets", "correct", "errors", "time_in_secs", "assistant_analysis", "score", "cp_per_min", "accuracy", "omissions", "omissions_rate", "commission_rate", "hits_per_min", "errors_per_min", "created_at", "scoring_profile"}
 	lettersCancellationSubtestColumnsWithoutDefault = []string{"id", "evaluation_id", "total_targets", "correct", "errors", "time_in_secs", "assistant_analysis", "score", "cp_per_min", "accuracy", "omissions", "omissions_rate", "commission_rate", "hits_per_min", "errors_per_min"}
-	lettersCancellationSubtestColumnsWithDefault    = []string{"created_at"}
+	lettersCancellationSubtestColumnsWithDefault    = []string{"created_at", "scoring_profile"}
 	lettersCancellationSubtestPrimaryKeyColumns     = []string{"id"}
 	lettersCancellationSubtestGeneratedColumns      = []string{}
 )
@@ -40,6 +40,7 @@ type VerbalMemorySubtest struct {
 	ScorePerseverationRate float64    `boil:"score_perseveration_rate" json:"score_perseveration_rate" toml:"score_perseveration_rate" yaml:"score_perseveration_rate"`
 	AssistanAnalysis       string     `boil:"assistan_analysis" json:"assistan_analysis" toml:"assistan_analysis" yaml:"assistan_analysis"`
 	CreatedAt              time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ScoringProfile         string     `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *verbalMemorySubtestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verbalMemorySubtestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -62,6 +63,7 @@ var VerbalMemorySubtestColumns = struct {
 	ScorePerseverationRate string
 	AssistanAnalysis       string
 	CreatedAt              string
+	ScoringProfile         string
 }{
 	ID:                     "id",
 	EvaluationID:           "evaluation_id",
@@ -79,6 +81,7 @@ var VerbalMemorySubtestColumns = struct {
 	ScorePerseverationRate: "score_perseveration_rate",
 	AssistanAnalysis:       "assistan_analysis",
 	CreatedAt:              "created_at",
+	ScoringProfile:         "scoring_profile",
 }
 
 var VerbalMemorySubtestTableColumns = struct {
@@ -98,6 +101,7 @@ var VerbalMemorySubtestTableColumns = struct {
 	ScorePerseverationRate string
 	AssistanAnalysis       string
 	CreatedAt              string
+	ScoringProfile         string
 }{
 	ID:                     "verbal_memory_subtests.id",
 	EvaluationID:           "verbal_memory_subtests.evaluation_id",
@@ -115,6 +119,7 @@ var VerbalMemorySubtestTableColumns = struct {
 	ScorePerseverationRate: "verbal_memory_subtests.score_perseveration_rate",
 	AssistanAnalysis:       "verbal_memory_subtests.assistan_analysis",
 	CreatedAt:              "verbal_memory_subtests.created_at",
+	ScoringProfile:         "verbal_memory_subtests.scoring_profile",
 }
 
 // Generated where
@@ -180,6 +185,7 @@ var VerbalMemorySubtestWhere = struct {
 	ScorePerseverationRate whereHelperfloat64
 	AssistanAnalysis       whereHelperstring
 	CreatedAt              whereHelpertime_Time
+	ScoringProfile         whereHelperstring
 }{
 	ID:                     whereHelperstring{field: "`verbal_memory_subtests`.`id`"},
 	EvaluationID:           whereHelperstring{field: "`verbal_memory_subtests`.`evaluation_id`"},
@@ -197,6 +203,7 @@ var VerbalMemorySubtestWhere = struct {
 	ScorePerseverationRate: whereHelperfloat64{field: "`verbal_memory_subtests`.`score_perseveration_rate`"},
 	AssistanAnalysis:       whereHelperstring{field: "`verbal_memory_subtests`.`assistan_analysis`"},
 	CreatedAt:              whereHelpertime_Time{field: "`verbal_memory_subtests`.`created_at`"},
+	ScoringProfile:         whereHelperstring{field: "`verbal_memory_subtests`.`scoring_profile`"},
 }
 
 // VerbalMemorySubtestRels is where relationship names are stored.
@@ -236,9 +243,9 @@ func (r *verbalMemorySubtestR) GetEvaluation() *Evaluation {
 type verbalMemorySubtestL struct{}
 
 var (
-	verbalMemorySubtestAllColumns            = []string{"id", "evaluation_id", "seconds_from_start", "type", "given_words", "recalled_words", "score_score", "score_hits", "score_omissions", "score_intrusions", "score_perseverations", "score_accuracy", "score_intrusion_rate", "score_perseveration_rate", "assistan_analysis", "created_at"}
+	verbalMemorySubtestAllColumns            = []string{"id", "evaluation_id", "seconds_from_start", "type", "given_words", "recalled_words", "score_score", "score_hits", "score_omissions", "score_intrusions", "score_perseverations", "score_accuracy", "score_intrusion_rate", "score_perseveration_rate", "assistan_analysis", "created_at", "scoring_profile"}
 	verbalMemorySubtestColumnsWithoutDefault = []string{"id", "evaluation_id", "seconds_from_start", "type", "given_words", "recalled_words", "score_score", "score_hits", "score_omissions", "score_intrusions", "score_perseverations", "score_accuracy", "score_intrusion_rate", "score_perseveration_rate", "assistan_analysis"}
-	verbalMemorySubtestColumnsWithDefault    = []string{"created_at"}
+	verbalMemorySubtestColumnsWithDefault    = []string{"created_at", "scoring_profile"}
 	verbalMemorySubtestPrimaryKeyColumns     = []string{"id"}
 	verbalMemorySubtestGeneratedColumns      = []string{}
 )
@@ -24,72 +24,79 @@ import (
 
 // VisualMemorySubtest is an object representing the database table.
 type VisualMemorySubtest struct {
-	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	EvaluationID string      `boil:"evaluation_id" json:"evaluation_id" toml:"evaluation_id" yaml:"evaluation_id"`
-	Score        int         `boil:"score" json:"score" toml:"score" yaml:"score"`
-	Note         string      `boil:"note" json:"note" toml:"note" yaml:"note"`
-	ImageSRC     null.String `boil:"image_src" json:"image_src,omitempty" toml:"image_src" yaml:"image_src,omitempty"`
-	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	EvaluationID   string      `boil:"evaluation_id" json:"evaluation_id" toml:"evaluation_id" yaml:"evaluation_id"`
+	Score          int         `boil:"score" json:"score" toml:"score" yaml:"score"`
+	Note           string      `boil:"note" json:"note" toml:"note" yaml:"note"`
+	ImageSRC       null.String `boil:"image_src" json:"image_src,omitempty" toml:"image_src" yaml:"image_src,omitempty"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ScoringProfile string      `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *visualMemorySubtestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L visualMemorySubtestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var VisualMemorySubtestColumns = struct {
-	ID           string
-	EvaluationID string
-	Score        string
-	Note         string
-	ImageSRC     string
-	CreatedAt    string
-	UpdatedAt    string
+	ID             string
+	EvaluationID   string
+	Score          string
+	Note           string
+	ImageSRC       string
+	CreatedAt      string
+	UpdatedAt      string
+	ScoringProfile string
 }{
-	ID:           "id",
-	EvaluationID: "evaluation_id",
-	Score:        "score",
-	Note:         "note",
-	ImageSRC:     "image_src",
-	CreatedAt:    "created_at",
-	UpdatedAt:    "updated_at",
+	ID:             "id",
+	EvaluationID:   "evaluation_id",
+	Score:          "score",
+	Note:           "note",
+	ImageSRC:       "image_src",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	ScoringProfile: "scoring_profile",
 }
 
 var VisualMemorySubtestTableColumns = struct {
-	ID           string
-	EvaluationID string
-	Score        string
-	Note         string
-	ImageSRC     string
-	CreatedAt    string
-	UpdatedAt    string
+	ID             string
+	EvaluationID   string
+	Score          string
+	Note           string
+	ImageSRC       string
+	CreatedAt      string
+	UpdatedAt      string
+	ScoringProfile string
 }{
-	ID:           "visual_memory_subtests.id",
-	EvaluationID: "visual_memory_subtests.evaluation_id",
-	Score:        "visual_memory_subtests.score",
-	Note:         "visual_memory_subtests.note",
-	ImageSRC:     "visual_memory_subtests.image_src",
-	CreatedAt:    "visual_memory_subtests.created_at",
-	UpdatedAt:    "visual_memory_subtests.updated_at",
+	ID:             "visual_memory_subtests.id",
+	EvaluationID:   "visual_memory_subtests.evaluation_id",
+	Score:          "visual_memory_subtests.score",
+	Note:           "visual_memory_subtests.note",
+	ImageSRC:       "visual_memory_subtests.image_src",
+	CreatedAt:      "visual_memory_subtests.created_at",
+	UpdatedAt:      "visual_memory_subtests.updated_at",
+	ScoringProfile: "visual_memory_subtests.scoring_profile",
 }
 
 // Generated where
 
 var VisualMemorySubtestWhere = struct {
-	ID           whereHelperstring
-	EvaluationID whereHelperstring
-	Score        whereHelperint
-	Note         whereHelperstring
-	ImageSRC     whereHelpernull_String
-	CreatedAt    whereHelpertime_Time
-	UpdatedAt    whereHelpertime_Time
+	ID             whereHelperstring
+	EvaluationID   whereHelperstring
+	Score          whereHelperint
+	Note           whereHelperstring
+	ImageSRC       whereHelpernull_String
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	ScoringProfile whereHelperstring
 }{
-	ID:           whereHelperstring{field: "`visual_memory_subtests`.`id`"},
-	EvaluationID: whereHelperstring{field: "`visual_memory_subtests`.`evaluation_id`"},
-	Score:        whereHelperint{field: "`visual_memory_subtests`.`score`"},
-	Note:         whereHelperstring{field: "`visual_memory_subtests`.`note`"},
-	ImageSRC:     whereHelpernull_String{field: "`visual_memory_subtests`.`image_src`"},
-	CreatedAt:    whereHelpertime_Time{field: "`visual_memory_subtests`.`created_at`"},
-	UpdatedAt:    whereHelpertime_Time{field: "`visual_memory_subtests`.`updated_at`"},
+	ID:             whereHelperstring{field: "`visual_memory_subtests`.`id`"},
+	EvaluationID:   whereHelperstring{field: "`visual_memory_subtests`.`evaluation_id`"},
+	Score:          whereHelperint{field: "`visual_memory_subtests`.`score`"},
+	Note:           whereHelperstring{field: "`visual_memory_subtests`.`note`"},
+	ImageSRC:       whereHelpernull_String{field: "`visual_memory_subtests`.`image_src`"},
+	CreatedAt:      whereHelpertime_Time{field: "`visual_memory_subtests`.`created_at`"},
+	UpdatedAt:      whereHelpertime_Time{field: "`visual_memory_subtests`.`updated_at`"},
+	ScoringProfile: whereHelperstring{field: "`visual_memory_subtests`.`scoring_profile`"},
 }
 
 // VisualMemorySubtestRels is where relationship names are stored.
@@ -129,9 +136,9 @@ func (r *visualMemorySubtestR) GetEvaluation() *Evaluation {
 type visualMemorySubtestL struct{}
 
 var (
-	visualMemorySubtestAllColumns            = []string{"id", "evaluation_id", "score", "note", "image_src", "created_at", "updated_at"}
+	visualMemorySubtestAllColumns            = []string{"id", "evaluation_id", "score", "note", "image_src", "created_at", "updated_at", "scoring_profile"}
 	visualMemorySubtestColumnsWithoutDefault = []string{"id", "evaluation_id", "score", "note", "image_src"}
-	visualMemorySubtestColumnsWithDefault    = []string{"created_at", "updated_at"}
+	visualMemorySubtestColumnsWithDefault    = []string{"created_at", "updated_at", "scoring_profile"}
 	visualMemorySubtestPrimaryKeyColumns     = []string{"id"}
 	visualMemorySubtestGeneratedColumns      = []string{}
 )
@@ -23,47 +23,52 @@ import (
 
 // VisualSpatialSubtest is an object representing the database table.
 type VisualSpatialSubtest struct {
-	ID           string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	EvaluationID string    `boil:"evaluation_id" json:"evaluation_id" toml:"evaluation_id" yaml:"evaluation_id"`
-	Score        int8      `boil:"score" json:"score" toml:"score" yaml:"score"`
-	Note         string    `boil:"note" json:"note" toml:"note" yaml:"note"`
-	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt    time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	EvaluationID   string    `boil:"evaluation_id" json:"evaluation_id" toml:"evaluation_id" yaml:"evaluation_id"`
+	Score          int8      `boil:"score" json:"score" toml:"score" yaml:"score"`
+	Note           string    `boil:"note" json:"note" toml:"note" yaml:"note"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ScoringProfile string    `boil:"scoring_profile" json:"scoring_profile" toml:"scoring_profile" yaml:"scoring_profile"`
 
 	R *visualSpatialSubtestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L visualSpatialSubtestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var VisualSpatialSubtestColumns = struct {
-	ID           string
-	EvaluationID string
-	Score        string
-	Note         string
-	CreatedAt    string
-	UpdatedAt    string
+	ID             string
+	EvaluationID   string
+	Score          string
+	Note           string
+	CreatedAt      string
+	UpdatedAt      string
+	ScoringProfile string
 }{
-	ID:           "id",
-	EvaluationID: "evaluation_id",
-	Score:        "score",
-	Note:         "note",
-	CreatedAt:    "created_at",
-	UpdatedAt:    "updated_at",
+	ID:             "id",
+	EvaluationID:   "evaluation_id",
+	Score:          "score",
+	Note:           "note",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	ScoringProfile: "scoring_profile",
 }
 
 var VisualSpatialSubtestTableColumns = struct {
-	ID           string
-	EvaluationID string
-	Score        string
-	Note         string
-	CreatedAt    string
-	UpdatedAt    string
+	ID             string
+	EvaluationID   string
+	Score          string
+	Note           string
+	CreatedAt      string
+	UpdatedAt      string
+	ScoringProfile string
 }{
-	ID:           "visual_spatial_subtest.id",
-	EvaluationID: "visual_spatial_subtest.evaluation_id",
-	Score:        "visual_spatial_subtest.score",
-	Note:         "visual_spatial_subtest.note",
-	CreatedAt:    "visual_spatial_subtest.created_at",
-	UpdatedAt:    "visual_spatial_subtest.updated_at",
+	ID:             "visual_spatial_subtest.id",
+	EvaluationID:   "visual_spatial_subtest.evaluation_id",
+	Score:          "visual_spatial_subtest.score",
+	Note:           "visual_spatial_subtest.note",
+	CreatedAt:      "visual_spatial_subtest.created_at",
+	UpdatedAt:      "visual_spatial_subtest.updated_at",
+	ScoringProfile: "visual_spatial_subtest.scoring_profile",
 }
 
 // Generated where
@@ -92,19 +97,21 @@ func (w whereHelperint8) NIN(slice []int8) qm.QueryMod {
 }
 
 var VisualSpatialSubtestWhere = struct {
-	ID           whereHelperstring
-	EvaluationID whereHelperstring
-	Score        whereHelperint8
-	Note         whereHelperstring
-	CreatedAt    whereHelpertime_Time
-	UpdatedAt    whereHelpertime_Time
+	ID             whereHelperstring
+	EvaluationID   whereHelperstring
+	Score          whereHelperint8
+	Note           whereHelperstring
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	ScoringProfile whereHelperstring
 }{
-	ID:           whereHelperstring{field: "`visual_spatial_subtest`.`id`"},
-	EvaluationID: whereHelperstring{field: "`visual_spatial_subtest`.`evaluation_id`"},
-	Score:        whereHelperint8{field: "`visual_spatial_subtest`.`score`"},
-	Note:         whereHelperstring{field: "`visual_spatial_subtest`.`note`"},
-	CreatedAt:    whereHelpertime_Time{field: "`visual_spatial_subtest`.`created_at`"},
-	UpdatedAt:    whereHelpertime_Time{field: "`visual_spatial_subtest`.`updated_at`"},
+	ID:             whereHelperstring{field: "`visual_spatial_subtest`.`id`"},
+	EvaluationID:   whereHelperstring{field: "`visual_spatial_subtest`.`evaluation_id`"},
+	Score:          whereHelperint8{field: "`visual_spatial_subtest`.`score`"},
+	Note:           whereHelperstring{field: "`visual_spatial_subtest`.`note`"},
+	CreatedAt:      whereHelpertime_Time{field: "`visual_spatial_subtest`.`created_at`"},
+	UpdatedAt:      whereHelpertime_Time{field: "`visual_spatial_subtest`.`updated_at`"},
+	ScoringProfile: whereHelperstring{field: "`visual_spatial_subtest`.`scoring_profile`"},
 }
 
 // VisualSpatialSubtestRels is where relationship names are stored.
@@ -124,9 +131,9 @@ func (*visualSpatialSubtestR) NewStruct() *visualSpatialSubtestR {
 type visualSpatialSubtestL struct{}
 
 var (
-	visualSpatialSubtestAllColumns            = []string{"id", "evaluation_id", "score", "note", "created_at", "updated_at"}
+	visualSpatialSubtestAllColumns            = []string{"id", "evaluation_id", "score", "note", "created_at", "updated_at", "scoring_profile"}
 	visualSpatialSubtestColumnsWithoutDefault = []string{"id", "evaluation_id", "score", "note"}
-	visualSpatialSubtestColumnsWithDefault    = []string{"created_at", "updated_at"}
+	visualSpatialSubtestColumnsWithDefault    = []string{"created_at", "updated_at", "scoring_profile"}
 	visualSpatialSubtestPrimaryKeyColumns     = []string{"id"}
 	visualSpatialSubtestGeneratedColumns      = []string{}
 )
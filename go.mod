@@ -1,6 +1,6 @@
 module neuro.app.jordi
 
-go 1.23.0
+go 1.24.0
 
 require (
 	github.com/aarondl/null/v8 v8.1.3
@@ -25,6 +25,7 @@ require (
 
 require (
 	github.com/go-pdf/fpdf v0.9.0
+	github.com/pion/opus v0.1.0
 	github.com/yuin/goldmark v1.7.13
 )
 
@@ -7,24 +7,77 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	answercardsortingcard "neuro.app.jordi/internal/evaluation/application/commands/answer-cardSorting-card"
+	createarchimedesspiralsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-archimedesSpiral-subtest"
+	createconfrontationnamingsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-confrontationNaming-subtest"
+	createdigitspansubtest "neuro.app.jordi/internal/evaluation/application/commands/create-digitSpan-subtest"
 	createevaluation "neuro.app.jordi/internal/evaluation/application/commands/create-evaluation"
 	createexecutivefunctionssubtest "neuro.app.jordi/internal/evaluation/application/commands/create-executiveFunctions-subtest"
+	createfingertappingsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-fingerTapping-subtest"
+	creategonogosubtest "neuro.app.jordi/internal/evaluation/application/commands/create-goNoGo-subtest"
+	createjlosubtest "neuro.app.jordi/internal/evaluation/application/commands/create-jlo-subtest"
 	createlanguagefluencysubtest "neuro.app.jordi/internal/evaluation/application/commands/create-languageFluency-subtest"
 	createlettercancelationsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-letterCancelation-subtest"
+	createmocasubtest "neuro.app.jordi/internal/evaluation/application/commands/create-moca-subtest"
+	createquestionnaireresponse "neuro.app.jordi/internal/evaluation/application/commands/create-questionnaire-response"
+	createreactiontimesubtest "neuro.app.jordi/internal/evaluation/application/commands/create-reactionTime-subtest"
+	createsdmtsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-sdmt-subtest"
+	createspeechprofile "neuro.app.jordi/internal/evaluation/application/commands/create-speechProfile"
+	createstroopsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-stroop-subtest"
 	createverbalmemorysubtest "neuro.app.jordi/internal/evaluation/application/commands/create-verbalMemory-subtest"
 	createvisualspatialsubtest "neuro.app.jordi/internal/evaluation/application/commands/create-visual-spatial-subtest"
 	createvisualmemorysubtest "neuro.app.jordi/internal/evaluation/application/commands/create-visualMemory-subtest"
 	finishevaluation "neuro.app.jordi/internal/evaluation/application/commands/finish-evaluation"
+	publishprompttemplate "neuro.app.jordi/internal/evaluation/application/commands/publish-prompt-template"
+	rescoresubtests "neuro.app.jordi/internal/evaluation/application/commands/rescore-subtests"
+	setanamnesis "neuro.app.jordi/internal/evaluation/application/commands/set-anamnesis"
+	setclinicalcontext "neuro.app.jordi/internal/evaluation/application/commands/set-clinical-context"
+	setexaminerobservation "neuro.app.jordi/internal/evaluation/application/commands/set-examiner-observation"
+	setscoringprotocol "neuro.app.jordi/internal/evaluation/application/commands/set-scoring-protocol"
+	startcardsortingsession "neuro.app.jordi/internal/evaluation/application/commands/start-cardSorting-session"
 	canfinishevaluation "neuro.app.jordi/internal/evaluation/application/queries/can-finish-evaluation"
+	getcardsortingsession "neuro.app.jordi/internal/evaluation/application/queries/get-cardSorting-session"
 	getevaluation "neuro.app.jordi/internal/evaluation/application/queries/get-evaluation"
 	listevaluations "neuro.app.jordi/internal/evaluation/application/queries/get-evaluations"
+	getprompttemplates "neuro.app.jordi/internal/evaluation/application/queries/get-prompt-templates"
+	getquestionnairedefinition "neuro.app.jordi/internal/evaluation/application/queries/get-questionnaire-definition"
+	getquestionnaires "neuro.app.jordi/internal/evaluation/application/queries/get-questionnaires"
+	getscoringprofiles "neuro.app.jordi/internal/evaluation/application/queries/get-scoring-profiles"
 	"neuro.app.jordi/internal/evaluation/domain"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	reports "neuro.app.jordi/internal/evaluation/domain/services"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
+	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+	services "neuro.app.jordi/internal/evaluation/services/openAI"
 )
 
 type EvaluationAPI struct {
@@ -165,7 +218,7 @@ func (app *App) GetEvaluation(c *gin.Context) {
 	var query getevaluation.GetEvaluationQuery
 	id := c.Params.ByName("id")
 	query.EvaluationID = id
-	evaluation, err := getevaluation.GetEvaluationQueryHandler(c.Request.Context(), query, app.Repositories.EvaluationsRepository, app.Repositories.VerbalMemorySubtestRepository, app.Repositories.VisualMemorySubtestRepository, app.Repositories.ExecutiveFunctionsSubtestRepository, app.Repositories.LetterCancellationRepository, app.Repositories.LanguageFluencyRepository, app.Repositories.VisualSpatialRepository)
+	evaluation, err := getevaluation.GetEvaluationQueryHandler(c.Request.Context(), query, app.Repositories.EvaluationsRepository, app.Repositories.Subtests())
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error getting evaluation", err, nil)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
@@ -210,12 +263,15 @@ func (app *App) FinnishEvaluation(c *gin.Context) {
 
 	evaluation, err := finishevaluation.FinisEvaluationCommanndHandler(c.Request.Context(),
 		command, app.Repositories.EvaluationsRepository,
-		app.Services.LLMService, app.Services.FileFormater, reportsPublisher, app.Repositories.VerbalMemorySubtestRepository,
-		app.Repositories.VisualMemorySubtestRepository, app.Repositories.ExecutiveFunctionsSubtestRepository, app.Repositories.LetterCancellationRepository, app.Repositories.LanguageFluencyRepository, app.Repositories.VisualSpatialRepository,
-		app.Services.MailService)
+		app.Services.LLMService, app.Services.FileFormater, reportsPublisher, app.Repositories.Subtests(),
+		app.Repositories.SubtestCommentaryRepository, app.Services.MailService, app.Logger)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error when finishiing evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrModelNotAllowed) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -233,10 +289,10 @@ func (app *App) CreateLetterCancellationSubtest(c *gin.Context) {
 		return
 	}
 
-	subtest, err := createlettercancelationsubtest.CreateLetterCancellationSubtestCommandHandler(c.Request.Context(), command, app.Repositories.LetterCancellationRepository, app.Repositories.EvaluationsRepository, app.Services.LLMService)
+	subtest, err := createlettercancelationsubtest.CreateLetterCancellationSubtestCommandHandler(c.Request.Context(), command, app.Repositories.LetterCancellationRepository, app.Repositories.EvaluationsRepository, app.Services.LLMService, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error  when creating letter cancellation evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -251,10 +307,10 @@ func (app *App) VerbalMemorySubtest(c *gin.Context) {
 		return
 	}
 
-	subtest, err := createverbalmemorysubtest.CreateVerbalMemorySubtestCommandhandler(c.Request.Context(), command, app.Repositories.EvaluationsRepository, app.Services.LLMService, app.Repositories.VerbalMemorySubtestRepository)
+	subtest, err := createverbalmemorysubtest.CreateVerbalMemorySubtestCommandhandler(c.Request.Context(), command, app.Repositories.EvaluationsRepository, app.Services.LLMService, app.Repositories.VerbalMemorySubtestRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error when creating letter cancellation evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -269,10 +325,10 @@ func (app *App) ExecutiveFunctionsSubtest(c *gin.Context) {
 		return
 	}
 
-	subtest, err := createexecutivefunctionssubtest.CreateExecutiveFunctionsSubtestCommandHandler(c.Request.Context(), command, app.Repositories.EvaluationsRepository, app.Services.LLMService, app.Repositories.ExecutiveFunctionsSubtestRepository)
+	subtest, err := createexecutivefunctionssubtest.CreateExecutiveFunctionsSubtestCommandHandler(c.Request.Context(), command, app.Repositories.EvaluationsRepository, app.Services.LLMService, app.Repositories.ExecutiveFunctionsSubtestRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error  when creating executive function evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -292,7 +348,9 @@ func (app *App) LanguageFluencySubtest(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		app.execLanguageFluencyCommand(c, command, "json")
+		if subtest, ok := app.execLanguageFluencyCommand(c, command, "json"); ok {
+			c.JSON(http.StatusOK, gin.H{"subtest": subtest, "inputSource": "json"})
+		}
 	}
 }
 
@@ -346,16 +404,30 @@ func (app *App) languageFluencyFromMultipart(c *gin.Context) {
 		return
 	}
 	transcript, err := app.Services.SpeechToText.GetTextFromSpeech(audioBytes)
-
-	// limpiar buffer (no persistimos)
-	for i := range audioBytes {
-		audioBytes[i] = 0
-	}
+	// limpiar buffer al salir (no persistimos)
+	defer func() {
+		for i := range audioBytes {
+			audioBytes[i] = 0
+		}
+	}()
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "speech-to-text error", err, c.Keys)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to transcribe audio"})
 		return
 	}
+	// con requireSpeechProfile=true un formato que no se puede decodificar se rechaza antes de
+	// guardar la fluencia
+	if c.PostForm("requireSpeechProfile") == "true" && app.Services.AudioDecoder != nil {
+		pcm, err := app.Services.AudioDecoder.Decode(audioBytes)
+		for i := range pcm.Samples {
+			pcm.Samples[i] = 0
+		}
+		if errors.Is(err, domain.ErrUnsupportedAudioFormat) {
+			speech := &speechProfileStatus{Status: speechProfileSkipped, Reason: speechProfileUnsupportedFormat, Error: err.Error()}
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": speech.Error, "speechProfile": speech})
+			return
+		}
+	}
 
 	// 5) Extraer palabras
 	cmd.Words = extractWords(transcript)
@@ -369,17 +441,59 @@ func (app *App) languageFluencyFromMultipart(c *gin.Context) {
 
 	cmd.Category = "animales"
 
-	app.execLanguageFluencyCommand(c, cmd, "audio+json")
+	subtest, ok := app.execLanguageFluencyCommand(c, cmd, "audio+json")
+	if !ok {
+		return
+	}
+	// Perfil acústico del habla (hipofonía, monotonía): se crea solo con la fluencia ya guardada
+	// y su resultado se devuelve junto a ella para que el cliente sepa si se generó
+	speech := app.createSpeechProfile(c, subtest.EvaluationID, audioBytes)
+	c.JSON(http.StatusOK, gin.H{"subtest": subtest, "inputSource": "audio+json", "speechProfile": speech})
+}
+
+const (
+	speechProfileCreated           = "created"
+	speechProfileSkipped           = "skipped"
+	speechProfileUnsupportedFormat = "unsupported_audio_format"
+	speechProfileNotConfigured     = "decoder_not_configured"
+	speechProfileFailed            = "analysis_failed"
+)
+
+// speechProfileStatus acompaña a la fluencia por audio: indica si se generó el perfil del habla y por qué no
+type speechProfileStatus struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (app *App) createSpeechProfile(c *gin.Context, evaluationID string, audio []byte) *speechProfileStatus {
+	if app.Services.AudioDecoder == nil {
+		return &speechProfileStatus{Status: speechProfileSkipped, Reason: speechProfileNotConfigured}
+	}
+	_, err := createspeechprofile.CreateSpeechProfileCommandHandler(c.Request.Context(), createspeechprofile.CreateSpeechProfileCommand{
+		EvaluationID: evaluationID,
+		Source:       SPdomain.SourceLanguageFluency,
+		Audio:        audio,
+	}, app.Services.AudioDecoder, app.Repositories.SpeechProfileRepository)
+	if err == nil {
+		return &speechProfileStatus{Status: speechProfileCreated}
+	}
+	app.Logger.Warn(c.Request.Context(), "speech profile skipped: "+err.Error(), c.Keys)
+	reason := speechProfileFailed
+	if errors.Is(err, domain.ErrUnsupportedAudioFormat) {
+		reason = speechProfileUnsupportedFormat
+	}
+	return &speechProfileStatus{Status: speechProfileSkipped, Reason: reason, Error: err.Error()}
 }
 
 func (app *App) execLanguageFluencyCommand(
 	c *gin.Context,
 	command createlanguagefluencysubtest.CreateLanguageFluencySubtestCommand,
 	inputSource string,
-) {
+) (LFdomain.LanguageFluency, bool) {
 	if strings.TrimSpace(command.EvaluationID) == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "evaluationId is required"})
-		return
+		return LFdomain.LanguageFluency{}, false
 	}
 	// Si quisieras forzar que haya palabras en el modo JSON:
 	// if len(command.Words) == 0 && inputSource == "json" {
@@ -393,22 +507,21 @@ func (app *App) execLanguageFluencyCommand(
 		app.Repositories.EvaluationsRepository,
 		app.Services.LLMService,
 		app.Repositories.LanguageFluencyRepository,
+		app.Repositories.ScoringProfileCatalog,
+		app.Repositories.ScoringSelectionRepository,
+		app.Repositories.DataQualityRepository,
 	)
 	if err != nil {
 		// Envuelve errores de dominio comunes para devolver 400 en vez de 500 si aplica
 		if errors.Is(err, context.DeadlineExceeded) {
 			c.JSON(http.StatusGatewayTimeout, gin.H{"error": "timeout"})
-			return
+			return LFdomain.LanguageFluency{}, false
 		}
 		app.Logger.Error(c.Request.Context(), "error when creating language fluency evaluation ("+inputSource+")", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return LFdomain.LanguageFluency{}, false
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"subtest":     subtest,
-		"inputSource": inputSource,
-	})
+	return subtest, true
 }
 
 // --- util de tokenización sencilla orientada a ES ---
@@ -444,10 +557,10 @@ func (app *App) CreateVisualMemorySubtest(c *gin.Context) {
 		return
 	}
 
-	sub, err := createvisualmemorysubtest.CreateVisualMemoryCommandHandler(c.Request.Context(), cmd, app.Repositories.VisualMemorySubtestRepository)
+	sub, err := createvisualmemorysubtest.CreateVisualMemoryCommandHandler(c.Request.Context(), cmd, app.Repositories.VisualMemorySubtestRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error when creating visual memory evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, sub)
@@ -461,15 +574,437 @@ func (app *App) CreateVisualSpatialSubtest(c *gin.Context) {
 		return
 	}
 
-	sub, err := createvisualspatialsubtest.CreateViusualSpatialCommandHandler(c.Request.Context(), cmd, app.Repositories.VisualSpatialRepository)
+	sub, err := createvisualspatialsubtest.CreateViusualSpatialCommandHandler(c.Request.Context(), cmd, app.Repositories.VisualSpatialRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error when creating visual spatial evaluation", err, c.Keys)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, sub)
 }
 
+func (app *App) CreateDigitSpanSubtest(c *gin.Context) {
+	var cmd createdigitspansubtest.CreateDigitSpanSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating digit span evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createdigitspansubtest.CreateDigitSpanSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.DigitSpanRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating digit span evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateStroopSubtest(c *gin.Context) {
+	var cmd createstroopsubtest.CreateStroopSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating stroop evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createstroopsubtest.CreateStroopSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.StroopRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating stroop evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateSDMTSubtest(c *gin.Context) {
+	var cmd createsdmtsubtest.CreateSDMTSubtestCommand
+
+	switch c.ContentType() {
+	case "multipart/form-data":
+		// Modo oral: audio + payload JSON con la clave esperada
+		const maxBytes = 20 << 20 // 20 MiB
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+
+		fileHeader, err := c.FormFile("audio")
+		if err != nil || fileHeader.Size == 0 {
+			app.Logger.Error(c.Request.Context(), "missing audio file", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'audio' file"})
+			return
+		}
+		f, err := fileHeader.Open()
+		if err != nil {
+			app.Logger.Error(c.Request.Context(), "cannot open audio", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot open audio file"})
+			return
+		}
+		defer f.Close()
+		if cmd.Audio, err = io.ReadAll(f); err != nil {
+			app.Logger.Error(c.Request.Context(), "cannot read audio", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot read audio file"})
+			return
+		}
+		if err := json.Unmarshal([]byte(c.PostForm("payload")), &cmd); err != nil {
+			app.Logger.Error(c.Request.Context(), "invalid payload JSON", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'payload' JSON"})
+			return
+		}
+		cmd.Mode = SDMTdomain.SDMTOral
+	default:
+		if err := c.ShouldBindJSON(&cmd); err != nil {
+			app.Logger.Error(c.Request.Context(), "error parsing when creating sdmt evaluation", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	sub, err := createsdmtsubtest.CreateSDMTSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.SDMTRepository, app.Services.SpeechToText, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating sdmt evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateConfrontationNamingSubtest(c *gin.Context) {
+	var cmd createconfrontationnamingsubtest.CreateConfrontationNamingSubtestCommand
+
+	switch c.ContentType() {
+	case "multipart/form-data":
+		// payload JSON + un fichero "audio_<lámina>" por respuesta grabada
+		const maxBytes = 60 << 20 // 60 MiB
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+
+		if err := json.Unmarshal([]byte(c.PostForm("payload")), &cmd); err != nil {
+			app.Logger.Error(c.Request.Context(), "invalid payload JSON", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'payload' JSON"})
+			return
+		}
+		cmd.Audio = map[int][]byte{}
+		for _, r := range cmd.Responses {
+			fileHeader, err := c.FormFile(fmt.Sprintf("audio_%d", r.Item))
+			if err != nil {
+				continue // sin audio: se usa el transcript del payload
+			}
+			f, err := fileHeader.Open()
+			if err != nil {
+				app.Logger.Error(c.Request.Context(), "cannot open audio", err, c.Keys)
+				c.JSON(http.StatusBadRequest, gin.H{"error": "cannot open audio file"})
+				return
+			}
+			audio, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				app.Logger.Error(c.Request.Context(), "cannot read audio", err, c.Keys)
+				c.JSON(http.StatusBadRequest, gin.H{"error": "cannot read audio file"})
+				return
+			}
+			cmd.Audio[r.Item] = audio
+		}
+	default:
+		if err := c.ShouldBindJSON(&cmd); err != nil {
+			app.Logger.Error(c.Request.Context(), "error parsing when creating confrontation naming evaluation", err, c.Keys)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	sub, err := createconfrontationnamingsubtest.CreateConfrontationNamingSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.ConfrontationNamingRepository, app.Services.SpeechToText, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating confrontation naming evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateReactionTimeSubtest(c *gin.Context) {
+	var cmd createreactiontimesubtest.CreateReactionTimeSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating reaction time evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createreactiontimesubtest.CreateReactionTimeSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.ReactionTimeRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating reaction time evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateFingerTappingSubtest(c *gin.Context) {
+	var cmd createfingertappingsubtest.CreateFingerTappingSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating finger tapping evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createfingertappingsubtest.CreateFingerTappingSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.FingerTappingRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating finger tapping evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateArchimedesSpiralSubtest(c *gin.Context) {
+	var cmd createarchimedesspiralsubtest.CreateArchimedesSpiralSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating archimedes spiral evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createarchimedesspiralsubtest.CreateArchimedesSpiralSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.ArchimedesSpiralRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating archimedes spiral evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateJLOSubtest(c *gin.Context) {
+	var cmd createjlosubtest.CreateJLOSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating jlo evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createjlosubtest.CreateJLOSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.JLORepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating jlo evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) CreateGoNoGoSubtest(c *gin.Context) {
+	var cmd creategonogosubtest.CreateGoNoGoSubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating go/no-go evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := creategonogosubtest.CreateGoNoGoSubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.GoNoGoRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating go/no-go evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+// cardSortingStatus traduce los errores de sesión del card sorting a códigos HTTP
+func cardSortingStatus(err error) int {
+	switch {
+	case errors.Is(err, WCSTdomain.ErrSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, WCSTdomain.ErrUnexpectedCard), errors.Is(err, WCSTdomain.ErrSessionCompleted), errors.Is(err, WCSTdomain.ErrConcurrentResponses):
+		return http.StatusConflict
+	case errors.Is(err, WCSTdomain.ErrInvalidKeyCard), errors.Is(err, WCSTdomain.ErrInvalidMaxCards):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (app *App) StartCardSortingSession(c *gin.Context) {
+	var cmd startcardsortingsession.StartCardSortingSessionCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when starting card sorting session", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := startcardsortingsession.StartCardSortingSessionCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.CardSortingRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when starting card sorting session", err, c.Keys)
+		c.JSON(cardSortingStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"session": getcardsortingsession.NewCardSortingSessionView(*session)})
+}
+
+func (app *App) GetCardSortingSession(c *gin.Context) {
+	query := getcardsortingsession.GetCardSortingSessionQuery{SessionID: c.Param("session_id")}
+	view, err := getcardsortingsession.GetCardSortingSessionQueryHandler(c.Request.Context(), query, app.Repositories.CardSortingRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error getting card sorting session", err, c.Keys)
+		c.JSON(cardSortingStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"session": view})
+}
+
+func (app *App) AnswerCardSortingCard(c *gin.Context) {
+	var cmd answercardsortingcard.AnswerCardSortingCardCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing card sorting response", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.SessionID = c.Param("session_id")
+
+	result, err := answercardsortingcard.AnswerCardSortingCardCommandHandler(c.Request.Context(), cmd, app.Repositories.CardSortingRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error answering card sorting card", err, c.Keys)
+		c.JSON(cardSortingStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// questionnaireStatus traduce los errores del motor de cuestionarios a códigos HTTP
+func questionnaireStatus(err error) int {
+	switch {
+	case errors.Is(err, QNdomain.ErrDefinitionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, QNdomain.ErrUnknownItem), errors.Is(err, QNdomain.ErrInvalidAnswer):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (app *App) ListQuestionnaires(c *gin.Context) {
+	list, err := getquestionnaires.GetQuestionnairesQueryHandler(c.Request.Context(), getquestionnaires.GetQuestionnairesQuery{}, app.Repositories.QuestionnaireCatalog)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error listing questionnaires", err, c.Keys)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"questionnaires": list})
+}
+
+func (app *App) GetQuestionnaireDefinition(c *gin.Context) {
+	query := getquestionnairedefinition.GetQuestionnaireDefinitionQuery{Code: c.Param("code")}
+	if v := c.Query("version"); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil || version < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+			return
+		}
+		query.Version = version
+	}
+
+	def, err := getquestionnairedefinition.GetQuestionnaireDefinitionQueryHandler(c.Request.Context(), query, app.Repositories.QuestionnaireCatalog)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error getting questionnaire definition", err, c.Keys)
+		c.JSON(questionnaireStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"questionnaire": def})
+}
+
+func (app *App) CreateQuestionnaireResponse(c *gin.Context) {
+	var cmd createquestionnaireresponse.CreateQuestionnaireResponseCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing questionnaire response", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := createquestionnaireresponse.CreateQuestionnaireResponseCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.QuestionnaireCatalog, app.Repositories.QuestionnaireRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when scoring questionnaire", err, c.Keys)
+		c.JSON(questionnaireStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"questionnaire": resp})
+}
+
+func (app *App) CreateMoCASubtest(c *gin.Context) {
+	var cmd createmocasubtest.CreateMoCASubtestCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing when creating MoCA evaluation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := createmocasubtest.CreateMoCASubtestCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.VisualSpatialRepository, app.Repositories.LanguageFluencyRepository, app.Repositories.MoCARepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when creating MoCA evaluation", err, c.Keys)
+		c.JSON(subtestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"subtest": sub})
+}
+
+func (app *App) SetClinicalContext(c *gin.Context) {
+	var cmd setclinicalcontext.SetClinicalContextCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing clinical context", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.EvaluationID = c.Param("id")
+
+	cc, err := setclinicalcontext.SetClinicalContextCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.ClinicalContextRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when saving clinical context", err, c.Keys)
+		status := http.StatusInternalServerError
+		if errors.Is(err, CCdomain.ErrInvalidClinicalContext) || errors.Is(err, CCdomain.ErrUnknownDrug) || errors.Is(err, CCdomain.ErrInvalidMedication) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"clinicalContext": cc})
+}
+
+func (app *App) SetAnamnesis(c *gin.Context) {
+	var cmd setanamnesis.SetAnamnesisCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing anamnesis", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.EvaluationID = c.Param("id")
+
+	a, err := setanamnesis.SetAnamnesisCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.AnamnesisRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when saving anamnesis", err, c.Keys)
+		status := http.StatusInternalServerError
+		if errors.Is(err, ANdomain.ErrInvalidAnamnesis) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"anamnesis": a, "alerts": a.Alerts()})
+}
+
+func (app *App) SetExaminerObservation(c *gin.Context) {
+	var cmd setexaminerobservation.SetExaminerObservationCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing examiner observation", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.EvaluationID = c.Param("id")
+	cmd.Subtest = c.Param("subtest")
+
+	o, err := setexaminerobservation.SetExaminerObservationCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.ExaminerObservationRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when saving examiner observation", err, c.Keys)
+		status := http.StatusInternalServerError
+		if errors.Is(err, EOdomain.ErrInvalidObservation) || errors.Is(err, EOdomain.ErrUnknownSubtest) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"observation": o, "alerts": o.Alerts()})
+}
+
 func (app *App) CanFinishEvaluation(c *gin.Context) {
 	evalID := c.Param("evaluation_id")
 	if evalID == "" {
@@ -486,7 +1021,7 @@ func (app *App) CanFinishEvaluation(c *gin.Context) {
 		EvaluationID: evalID,
 		SpecialistID: specialistID,
 	}
-	canFinish, err := canfinishevaluation.CanFinishEvaluationQueryHandler(c.Request.Context(), query, app.Repositories.EvaluationsRepository, app.Repositories.VerbalMemorySubtestRepository, app.Repositories.VisualMemorySubtestRepository, app.Repositories.ExecutiveFunctionsSubtestRepository, app.Repositories.LetterCancellationRepository, app.Repositories.LanguageFluencyRepository, app.Repositories.VisualSpatialRepository)
+	canFinish, err := canfinishevaluation.CanFinishEvaluationQueryHandler(c.Request.Context(), query, app.Repositories.EvaluationsRepository, app.Repositories.Subtests())
 	if err != nil {
 		app.Logger.Error(c.Request.Context(), "error checking if can finish evaluation", err, c.Keys)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -495,3 +1030,118 @@ func (app *App) CanFinishEvaluation(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"can_finish": canFinish})
 }
+
+func (app *App) ListScoringProfiles(c *gin.Context) {
+	res, err := getscoringprofiles.GetScoringProfilesQueryHandler(c.Request.Context(), getscoringprofiles.GetScoringProfilesQuery{}, app.Repositories.ScoringProfileCatalog)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error listing scoring profiles", err, c.Keys)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (app *App) SetScoringProtocol(c *gin.Context) {
+	var cmd setscoringprotocol.SetScoringProtocolCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing scoring protocol", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.EvaluationID = c.Param("id")
+
+	sel, err := setscoringprotocol.SetScoringProtocolCommandHandler(c.Request.Context(), cmd, app.Repositories.EvaluationsRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when setting scoring protocol", err, c.Keys)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, SCPdomain.ErrProtocolNotFound):
+			status = http.StatusBadRequest
+		case errors.Is(err, SCPdomain.ErrProtocolAlreadySet):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"scoringSelection": sel})
+}
+
+func (app *App) RescoreSubtests(c *gin.Context) {
+	var cmd rescoresubtests.RescoreSubtestsCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing rescoring request", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := rescoresubtests.RescoreSubtestsCommandHandler(c.Request.Context(), cmd, app.Repositories.ScoringProfileCatalog, app.Repositories.RescoringRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error when rescoring subtests", err, c.Keys)
+		status := http.StatusInternalServerError
+		if errors.Is(err, RSdomain.ErrUnknownSubtest) || errors.Is(err, SCPdomain.ErrInvalidProfile) || errors.Is(err, SCPdomain.ErrProfileNotFound) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (app *App) ListPromptTemplates(c *gin.Context) {
+	var query getprompttemplates.GetPromptTemplatesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	res, err := getprompttemplates.GetPromptTemplatesQueryHandler(c.Request.Context(), query, app.Repositories.PromptTemplateRepository)
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error listing prompt templates", err, c.Keys)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (app *App) PublishPromptTemplate(c *gin.Context) {
+	var cmd publishprompttemplate.PublishPromptTemplateCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		app.Logger.Error(c.Request.Context(), "error parsing prompt template", err, c.Keys)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := publishprompttemplate.PublishPromptTemplateCommandHandler(c.Request.Context(), cmd, app.Repositories.PromptTemplateRepository, services.NewPromptRenderer())
+	if err != nil {
+		app.Logger.Error(c.Request.Context(), "error publishing prompt template", err, c.Keys)
+		status := http.StatusInternalServerError
+		if errors.Is(err, PTdomain.ErrInvalidTemplate) || errors.Is(err, PTdomain.ErrUnsupportedLocale) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, t)
+}
+
+// subtestValidationErrors son los errores raíz de validación de cada subtest: todo error de
+// entrada del dominio o del handler los envuelve
+var subtestValidationErrors = []error{
+	DSdomain.ErrInvalidDigitSpan, STRdomain.ErrInvalidStroop, SDMTdomain.ErrInvalidSDMT, CNdomain.ErrInvalidNaming,
+	RTdomain.ErrInvalidReactionTime, FTdomain.ErrInvalidFingerTapping, ASdomain.ErrInvalidSpiral, JLOdomain.ErrInvalidJLO,
+	GNGdomain.ErrInvalidGoNoGo, MOCAdomain.ErrInvalidMoCA, LCdomain.ErrInvalidLettersCancellation, VEMdomain.ErrInvalidVerbalMemory,
+	EFdomain.ErrInvalidExecutiveFunctions, LFdomain.ErrInvalidLanguageFluency, VIMdomain.ErrInvalidVisualMemory, VPdomain.ErrInvalidVisualSpatial,
+}
+
+// subtestErrorStatus distingue la entrada inválida (400) y los envíos rechazados por datos
+// imposibles (422) de los fallos internos, como los del repositorio (500)
+func subtestErrorStatus(err error) int {
+	if errors.Is(err, DQdomain.ErrCorruptSubmission) {
+		return http.StatusUnprocessableEntity
+	}
+	for _, target := range subtestValidationErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
@@ -11,24 +11,70 @@ import (
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
 	authD "neuro.app.jordi/internal/auth/domain"
+	appservices "neuro.app.jordi/internal/evaluation/application/services"
 	"neuro.app.jordi/internal/evaluation/domain"
 	services "neuro.app.jordi/internal/evaluation/services/openAI"
 
 	authI "neuro.app.jordi/internal/auth/infra"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
 	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
 	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
 	"neuro.app.jordi/internal/evaluation/infra"
+	ANinfra "neuro.app.jordi/internal/evaluation/infra/anamnesis"
+	audiodecoder "neuro.app.jordi/internal/evaluation/infra/audio-decoder"
+	CCinfra "neuro.app.jordi/internal/evaluation/infra/clinical-context"
+	COMPinfra "neuro.app.jordi/internal/evaluation/infra/composites"
+	DQinfra "neuro.app.jordi/internal/evaluation/infra/data-quality"
+	EOinfra "neuro.app.jordi/internal/evaluation/infra/examiner-observations"
+	PTinfra "neuro.app.jordi/internal/evaluation/infra/prompt-templates"
+	QNinfra "neuro.app.jordi/internal/evaluation/infra/questionnaires"
+	RSinfra "neuro.app.jordi/internal/evaluation/infra/rescoring"
+	SCPinfra "neuro.app.jordi/internal/evaluation/infra/scoring-profiles"
+	SPinfra "neuro.app.jordi/internal/evaluation/infra/speech-profile"
 	speechtotext "neuro.app.jordi/internal/evaluation/infra/speech-to-text"
+	ASinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/archimedes-spiral"
+	WCSTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/card-sorting"
+	CNinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/confrontation-naming"
+	DSinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/digit-span"
 	EFinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/executive-functions"
+	FTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/finger-tapping"
+	GNGinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/go-no-go"
 	LFinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/language-fluency"
 	LCinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/letter-cancellation"
+	JLOinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/line-orientation"
+	MOCAinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/moca"
+	RTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/reaction-time"
+	SDMTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/sdmt"
+	STRinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/stroop"
 	VEMinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/verbal-memory"
 	VIMinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/visual-memory"
 	INFRAvisualspatial "neuro.app.jordi/internal/evaluation/infra/sub-tests/visual-spatial"
+	STCinfra "neuro.app.jordi/internal/evaluation/infra/subtest-commentary"
 	"neuro.app.jordi/internal/shared/encryption"
 	fileformatter "neuro.app.jordi/internal/shared/file-formatter"
 	jwtService "neuro.app.jordi/internal/shared/jwt"
@@ -63,14 +109,71 @@ type Repositories struct {
 	VisualMemorySubtestRepository       VIMdomain.VisualMemoryRepository
 	ExecutiveFunctionsSubtestRepository EFdomain.ExecutiveFunctionsSubtestRepository
 	VisualSpatialRepository             VPdomain.ResultRepository
+	DigitSpanRepository                 DSdomain.DigitSpanRepository
+	StroopRepository                    STRdomain.StroopRepository
+	SDMTRepository                      SDMTdomain.SDMTRepository
+	ConfrontationNamingRepository       CNdomain.ConfrontationNamingRepository
+	ReactionTimeRepository              RTdomain.ReactionTimeRepository
+	FingerTappingRepository             FTdomain.FingerTappingRepository
+	ArchimedesSpiralRepository          ASdomain.ArchimedesSpiralRepository
+	SpeechProfileRepository             SPdomain.SpeechProfileRepository
+	JLORepository                       JLOdomain.JLORepository
+	GoNoGoRepository                    GNGdomain.GoNoGoRepository
+	CardSortingRepository               WCSTdomain.CardSortingRepository
+	QuestionnaireRepository             QNdomain.QuestionnaireRepository
+	MoCARepository                      MOCAdomain.MoCARepository
+	ClinicalContextRepository           CCdomain.ClinicalContextRepository
+	AnamnesisRepository                 ANdomain.AnamnesisRepository
+	ExaminerObservationRepository       EOdomain.ExaminerObservationRepository
+	ScoringSelectionRepository          SCPdomain.ScoringSelectionRepository
+	RescoringRepository                 RSdomain.RescoringRepository
+	QuestionnaireCatalog                QNdomain.QuestionnaireCatalog
+	ScoringProfileCatalog               SCPdomain.ScoringProfileCatalog
+	CompositeConfigProvider             COMPdomain.ConfigProvider
+	PromptTemplateRepository            PTdomain.PromptTemplateRepository
+	SubtestCommentaryRepository         STCdomain.CommentaryRepository
+	DataQualityRepository               DQdomain.DataQualityRepository
 	UserRepository                      authD.UserRepository
 }
+
+// Subtests reúne los repositorios con que se monta una evaluación completa
+func (r Repositories) Subtests() appservices.SubtestRepositories {
+	return appservices.SubtestRepositories{
+		VerbalMemory:         r.VerbalMemorySubtestRepository,
+		VisualMemory:         r.VisualMemorySubtestRepository,
+		ExecutiveFunctions:   r.ExecutiveFunctionsSubtestRepository,
+		LetterCancellation:   r.LetterCancellationRepository,
+		LanguageFluency:      r.LanguageFluencyRepository,
+		VisualSpatial:        r.VisualSpatialRepository,
+		DigitSpan:            r.DigitSpanRepository,
+		Stroop:               r.StroopRepository,
+		SDMT:                 r.SDMTRepository,
+		ConfrontationNaming:  r.ConfrontationNamingRepository,
+		ReactionTime:         r.ReactionTimeRepository,
+		FingerTapping:        r.FingerTappingRepository,
+		ArchimedesSpiral:     r.ArchimedesSpiralRepository,
+		SpeechProfile:        r.SpeechProfileRepository,
+		JLO:                  r.JLORepository,
+		GoNoGo:               r.GoNoGoRepository,
+		CardSorting:          r.CardSortingRepository,
+		Questionnaires:       r.QuestionnaireRepository,
+		MoCA:                 r.MoCARepository,
+		ClinicalContext:      r.ClinicalContextRepository,
+		Anamnesis:            r.AnamnesisRepository,
+		ExaminerObservations: r.ExaminerObservationRepository,
+		ScoringSelection:     r.ScoringSelectionRepository,
+		DataQuality:          r.DataQualityRepository,
+		CompositeConfig:      r.CompositeConfigProvider,
+	}
+}
+
 type Services struct {
 	LLMService        domain.LLMService
 	MailService       mail.MailProvider
 	JwtService        *jwtService.Service
 	EncryptionService authD.EncryptionService
 	SpeechToText      domain.SpeechToTextService
+	AudioDecoder      domain.AudioDecoder
 	// TemplateResolver  VIMdomain.TemplateResolver
 	FileFormater fileformatter.FileFormaterService
 }
@@ -85,34 +188,60 @@ func getAppRepositories(db *sql.DB) Repositories {
 		LanguageFluencyRepository:           LFinfra.NewLanguageFluencyMYSQLRepository(db),
 		VisualSpatialRepository:             INFRAvisualspatial.NewVisualSpatialMYSQLRepo(db),
 		VisualMemorySubtestRepository:       VIMinfra.NewVisualMemoryMYSQLRepository(db),
+		DigitSpanRepository:                 DSinfra.NewDigitSpanMYSQLRepository(db),
+		StroopRepository:                    STRinfra.NewStroopMYSQLRepository(db),
+		SDMTRepository:                      SDMTinfra.NewSDMTMYSQLRepository(db),
+		ConfrontationNamingRepository:       CNinfra.NewConfrontationNamingMYSQLRepository(db),
+		ReactionTimeRepository:              RTinfra.NewReactionTimeMYSQLRepository(db),
+		FingerTappingRepository:             FTinfra.NewFingerTappingMYSQLRepository(db),
+		ArchimedesSpiralRepository:          ASinfra.NewArchimedesSpiralMYSQLRepository(db),
+		SpeechProfileRepository:             SPinfra.NewSpeechProfileMYSQLRepository(db),
+		JLORepository:                       JLOinfra.NewJLOMYSQLRepository(db),
+		GoNoGoRepository:                    GNGinfra.NewGoNoGoMYSQLRepository(db),
+		CardSortingRepository:               WCSTinfra.NewCardSortingMYSQLRepository(db),
+		QuestionnaireRepository:             QNinfra.NewQuestionnaireMYSQLRepository(db),
+		MoCARepository:                      MOCAinfra.NewMoCAMYSQLRepository(db),
+		ClinicalContextRepository:           CCinfra.NewClinicalContextMYSQLRepository(db),
+		AnamnesisRepository:                 ANinfra.NewAnamnesisMYSQLRepository(db),
+		ExaminerObservationRepository:       EOinfra.NewExaminerObservationMYSQLRepository(db),
+		ScoringSelectionRepository:          SCPinfra.NewScoringSelectionMYSQLRepository(db),
+		RescoringRepository:                 RSinfra.NewRescoringMYSQLRepository(db),
+		QuestionnaireCatalog:                QNinfra.NewEmbeddedQuestionnaireCatalog(),
+		ScoringProfileCatalog:               SCPinfra.NewScoringProfileCatalogFromEnv(),
+		CompositeConfigProvider:             COMPinfra.NewCompositeConfigProviderFromEnv(),
+		PromptTemplateRepository:            PTinfra.NewPromptTemplateMYSQLRepository(db),
+		SubtestCommentaryRepository:         STCinfra.NewSubtestCommentaryMYSQLRepository(db),
+		DataQualityRepository:               DQinfra.NewDataQualityMYSQLRepository(db),
 		UserRepository:                      authI.NewUseMYSQLRepository(db),
 	}
 }
 
-func getAppServices() Services {
+func getAppServices(repositories Repositories, logger logging.Logger) Services {
 	mailService, err := mail.NewSESEmailSender(context.Background())
 	if err != nil {
 		panic("failed to initialize SES email sender: " + err.Error())
 	}
 	return Services{
-		LLMService:        services.NewOpenAIService(),
+		LLMService:        services.NewFallbackService(services.NewOpenAIService(repositories.PromptTemplateRepository), services.NewRuleBasedService(), logger),
 		MailService:       mailService,
 		EncryptionService: encryption.NewEncryptionService(),
 		SpeechToText:      speechtotext.NewOpenAISpeechToText(),
+		AudioDecoder:      audiodecoder.NewAudioDecoder(),
 		JwtService:        jwtService.New(),
 		FileFormater:      fileformatter.NewWKHTMLFileFormatter(),
 	}
 }
 func NewApp(db *sql.DB) *App {
+	logger := logging.NewSlogLogger(os.Getenv("environment"))
 	appRepositories := getAppRepositories(db)
-	appServices := getAppServices()
+	appServices := getAppServices(appRepositories, logger)
 	return &App{
 		// FileFormater:      services.NewFileFormatter(),
 		Repositories: appRepositories,
 		Services:     appServices,
 		// ImageStorage: VIMinfra.NewLocalImageStorage("./images"),
 		MaxMemory: 10 << 20, // 10 MB
-		Logger:    logging.NewSlogLogger(os.Getenv("environment")),
+		Logger:    logger,
 	}
 }
 
@@ -163,12 +292,45 @@ func (app *App) SetupRouter() *gin.Engine {
 		eval.POST("/language-fluency", app.LanguageFluencySubtest)
 		eval.POST("/visual-memory", app.CreateVisualMemorySubtest)
 		eval.POST("/visual-spatial", app.CreateVisualSpatialSubtest)
+		eval.POST("/digit-span", app.CreateDigitSpanSubtest)
+		eval.POST("/stroop", app.CreateStroopSubtest)
+		eval.POST("/sdmt", app.CreateSDMTSubtest)
+		eval.POST("/confrontation-naming", app.CreateConfrontationNamingSubtest)
+		eval.POST("/reaction-time", app.CreateReactionTimeSubtest)
+		eval.POST("/finger-tapping", app.CreateFingerTappingSubtest)
+		eval.POST("/archimedes-spiral", app.CreateArchimedesSpiralSubtest)
+		eval.POST("/jlo", app.CreateJLOSubtest)
+		eval.POST("/go-no-go", app.CreateGoNoGoSubtest)
+		eval.POST("/moca", app.CreateMoCASubtest)
+		eval.POST("/card-sorting", app.StartCardSortingSession)
+		eval.GET("/card-sorting/:session_id", app.GetCardSortingSession)
+		eval.POST("/card-sorting/:session_id/responses", app.AnswerCardSortingCard)
+		eval.POST("/questionnaires", app.CreateQuestionnaireResponse)
 		eval.GET("/can-finish-evaluation/:evaluation_id/:specialist_id", app.CanFinishEvaluation)
 		eval.POST("/finish-evaluation", app.FinnishEvaluation)
 		eval.GET("/:id", app.GetEvaluation)
+		eval.PUT("/:id/clinical-context", app.SetClinicalContext)
+		eval.PUT("/:id/scoring-protocol", app.SetScoringProtocol)
+		eval.PUT("/:id/anamnesis", app.SetAnamnesis)
+		eval.PUT("/:id/observations/:subtest", app.SetExaminerObservation)
 		eval.GET("", app.ListEvaluations)
 	}
 
+	questionnaires := r.Group("/v1/questionnaires")
+	{
+		questionnaires.GET("", app.ListQuestionnaires)
+		questionnaires.GET("/:code", app.GetQuestionnaireDefinition)
+	}
+
+	r.GET("/v1/scoring-profiles", app.ListScoringProfiles)
+
+	admin := r.Group("/v1/admin", midleware.RequireAdminToken(os.Getenv("ADMIN_API_TOKEN")))
+	{
+		admin.POST("/rescoring", app.RescoreSubtests)
+		admin.GET("/prompt-templates", app.ListPromptTemplates)
+		admin.POST("/prompt-templates", app.PublishPromptTemplate)
+	}
+
 	user := r.Group("/v1/auth")
 	{
 		user.POST("/signup", app.SignUp)
@@ -0,0 +1,48 @@
+package answercardsortingcard
+
+import (
+	"context"
+	"errors"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+)
+
+func AnswerCardSortingCardCommandHandler(ctx context.Context, cmd AnswerCardSortingCardCommand, cardSortingRepo WCSTdomain.CardSortingRepository, qualityRepo DQdomain.DataQualityRepository) (AnswerCardSortingCardResult, error) {
+	if cmd.SessionID == "" {
+		return AnswerCardSortingCardResult{}, errors.New("session id is required")
+	}
+	session, err := cardSortingRepo.GetByID(ctx, cmd.SessionID)
+	if err != nil {
+		return AnswerCardSortingCardResult{}, err
+	}
+
+	previousTrials := len(session.Responses)
+	feedback, err := session.Respond(cmd.CardIndex, cmd.KeyCard, cmd.RespondedAtMs)
+	if err != nil {
+		return AnswerCardSortingCardResult{}, err
+	}
+	if session.Status == WCSTdomain.SessionCompleted {
+		score, err := WCSTdomain.ScoreCardSorting(session)
+		if err != nil {
+			return AnswerCardSortingCardResult{}, err
+		}
+		score.ScoringProfile = session.Score.ScoringProfile
+		session.Score = score
+	}
+
+	if err = cardSortingRepo.Update(ctx, &session, previousTrials); err != nil {
+		return AnswerCardSortingCardResult{}, err
+	}
+	// los flags solo existen al terminar la sesión
+	if session.Status == WCSTdomain.SessionCompleted {
+		if err = qualityRepo.Replace(ctx, session.EvaluationID, session.PK, session.QualityFlags()); err != nil {
+			return AnswerCardSortingCardResult{}, err
+		}
+	}
+	return AnswerCardSortingCardResult{
+		Feedback:  feedback,
+		Completed: session.Status == WCSTdomain.SessionCompleted,
+		Next:      session.NextCard(),
+	}, nil
+}
@@ -0,0 +1,158 @@
+package answercardsortingcard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	DQinfra "neuro.app.jordi/internal/evaluation/infra/data-quality"
+	"neuro.app.jordi/internal/pkg"
+)
+
+var rules = []WCSTdomain.Dimension{WCSTdomain.DimensionColor, WCSTdomain.DimensionShape, WCSTdomain.DimensionNumber}
+
+func value(c WCSTdomain.Card, d WCSTdomain.Dimension) any {
+	switch d {
+	case WCSTdomain.DimensionColor:
+		return c.Color
+	case WCSTdomain.DimensionShape:
+		return c.Shape
+	}
+	return c.Number
+}
+
+// keyCardBy devuelve la carta estímulo (1..4) que comparte la dimensión d con la carta
+func keyCardBy(c WCSTdomain.Card, d WCSTdomain.Dimension) int {
+	for i, k := range WCSTdomain.KeyCards {
+		if value(k, d) == value(c, d) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// keyCardAvoiding devuelve una carta estímulo que no coincide en ninguna de las dimensiones dadas
+func keyCardAvoiding(c WCSTdomain.Card, ds ...WCSTdomain.Dimension) int {
+	for i, k := range WCSTdomain.KeyCards {
+		ok := true
+		for _, d := range ds {
+			if value(k, d) == value(c, d) {
+				ok = false
+			}
+		}
+		if ok {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func TestAnswerCardSortingCardCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+	ctx := context.TODO()
+	repo := app.Repositories.CardSortingRepository
+
+	session, err := WCSTdomain.NewCardSortingSubtest("eval-123", WCSTdomain.FullMaxCards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.Score.ScoringProfile = "pd-motor@1"
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+
+	answer := func(index, keyCard int) AnswerCardSortingCardResult {
+		t.Helper()
+		res, err := AnswerCardSortingCardCommandHandler(ctx, AnswerCardSortingCardCommand{SessionID: session.PK, CardIndex: index, KeyCard: keyCard, RespondedAtMs: int64(index) * 2_000}, repo, app.Repositories.DataQualityRepository)
+		if err != nil {
+			t.Fatalf("card %d: unexpected error: %v", index, err)
+		}
+		return res
+	}
+
+	// Errores de validación antes de empezar
+	invalid := []struct {
+		name string
+		cmd  AnswerCardSortingCardCommand
+		want error
+	}{
+		{"unknown session", AnswerCardSortingCardCommand{SessionID: "missing", CardIndex: 0, KeyCard: 1}, WCSTdomain.ErrSessionNotFound},
+		{"card not presented", AnswerCardSortingCardCommand{SessionID: session.PK, CardIndex: 3, KeyCard: 1}, WCSTdomain.ErrUnexpectedCard},
+		{"key card out of range", AnswerCardSortingCardCommand{SessionID: session.PK, CardIndex: 0, KeyCard: 5}, WCSTdomain.ErrInvalidKeyCard},
+	}
+	for _, tt := range invalid {
+		t.Run("Invalid - "+tt.name, func(t *testing.T) {
+			if _, err := AnswerCardSortingCardCommandHandler(ctx, tt.cmd, repo, app.Repositories.DataQualityRepository); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Guion: categoría 1 perfecta por color; 5 cartas perseverando en color; en la
+	// categoría 3 se pierde el set tras 6 aciertos con un error no perseverativo; resto perfecto.
+	index, category, run := 0, 0, 0
+	perseverativeErrors, persevered, lostSet := 0, 0, false
+	var last AnswerCardSortingCardResult
+	for !last.Completed {
+		if index > 0 && (last.Next == nil || last.Next.Index != index) {
+			t.Fatalf("expected next card %d, got %+v", index, last.Next)
+		}
+		card := WCSTdomain.DeckCard(index)
+		rule := rules[category%3]
+		keyCard := keyCardBy(card, rule)
+		perseverating := category == 1 && persevered < 5
+		switch {
+		case perseverating:
+			keyCard = keyCardBy(card, WCSTdomain.DimensionColor)
+			persevered++
+		case category == 2 && run == 6 && !lostSet:
+			keyCard = keyCardAvoiding(card, rule, rules[1])
+			lostSet = true
+		}
+
+		last = answer(index, keyCard)
+		if last.Feedback.CardIndex != index {
+			t.Fatalf("feedback for wrong card: %+v", last.Feedback)
+		}
+		if last.Feedback.Correct {
+			run++
+			if run == 10 {
+				category, run = category+1, 0
+			}
+		} else {
+			if perseverating {
+				perseverativeErrors++
+			}
+			run = 0
+		}
+		index++
+	}
+
+	if _, err := AnswerCardSortingCardCommandHandler(ctx, AnswerCardSortingCardCommand{SessionID: session.PK, CardIndex: index, KeyCard: 1}, repo, app.Repositories.DataQualityRepository); !errors.Is(err, WCSTdomain.ErrSessionCompleted) {
+		t.Fatalf("expected completed session error, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, session.PK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := stored.Score
+	if stored.Status != WCSTdomain.SessionCompleted || s.CategoriesCompleted != 6 || s.Score != 100 || s.TrialsToFirstCategory != 10 {
+		t.Errorf("unexpected completion: status=%s score=%+v", stored.Status, s)
+	}
+	if perseverativeErrors == 0 || s.PerseverativeErrors != perseverativeErrors || s.NonPerseverativeErrors != 1 || s.FailuresToMaintainSet != 1 {
+		t.Errorf("expected %d perseverative errors and one failure to maintain set, got %+v", perseverativeErrors, s)
+	}
+	if s.TotalCorrect+s.TotalErrors != s.TrialsAdministered || s.TrialsAdministered != index {
+		t.Errorf("inconsistent totals: %+v (trials %d)", s, index)
+	}
+	if s.ScoringProfile != "pd-motor@1" {
+		t.Errorf("expected the final score to keep the profile pinned at session start, got %q", s.ScoringProfile)
+	}
+	flags, ok := app.Repositories.DataQualityRepository.(*DQinfra.MockDataQualityRepository).Get("eval-123", session.PK)
+	if !ok || len(flags) != 1 || flags[0].Code != DQdomain.CodeCeilingEffect || flags[0].Severity != DQdomain.SeverityInfo {
+		t.Errorf("expected the ceiling info flag to be stored on completion, got %+v", flags)
+	}
+}
@@ -0,0 +1,17 @@
+package answercardsortingcard
+
+import WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+
+type AnswerCardSortingCardCommand struct {
+	SessionID     string `json:"-"`
+	CardIndex     int    `json:"card_index"`
+	KeyCard       int    `json:"key_card"` // 1..4
+	RespondedAtMs int64  `json:"responded_at_ms"`
+}
+
+// AnswerCardSortingCardResult es lo que recibe la tableta: feedback y siguiente carta
+type AnswerCardSortingCardResult struct {
+	Feedback  WCSTdomain.Feedback       `json:"feedback"`
+	Completed bool                      `json:"completed"`
+	Next      *WCSTdomain.PresentedCard `json:"next,omitempty"`
+}
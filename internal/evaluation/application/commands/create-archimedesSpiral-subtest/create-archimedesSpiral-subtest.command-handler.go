@@ -0,0 +1,45 @@
+package createarchimedesspiralsubtest
+
+import (
+	"context"
+	"fmt"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+)
+
+func CreateArchimedesSpiralSubtestCommandHandler(ctx context.Context, cmd CreateArchimedesSpiralSubtestCommand, archimedesSpiralRepo ASdomain.ArchimedesSpiralRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*ASdomain.ArchimedesSpiralSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", ASdomain.ErrInvalidSpiral)
+	}
+
+	subtest, err := ASdomain.NewArchimedesSpiralSubtest(cmd.EvaluationID, cmd.Drawings)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := ASdomain.ScoreArchimedesSpiral(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = archimedesSpiralRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,158 @@
+package createarchimedesspiralsubtest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	"neuro.app.jordi/internal/pkg"
+)
+
+// spiral genera 4 vueltas de r = 10 + 5θ muestreadas a 100 Hz durante 8 s,
+// con una oscilación radial opcional de la frecuencia y amplitud indicadas.
+func spiral(tremorHz, tremorAmp, shrink float64) []ASdomain.SpiralPoint {
+	var pts []ASdomain.SpiralPoint
+	const n = 800
+	for i := 0; i < n; i++ {
+		t := float64(i) / 100
+		theta := 8 * math.Pi * float64(i) / n
+		b := 5 * (1 - shrink*theta/(8*math.Pi))
+		r := 10 + b*theta + tremorAmp*math.Sin(2*math.Pi*tremorHz*t)
+		pts = append(pts, ASdomain.SpiralPoint{
+			X:    200 + r*math.Cos(theta),
+			Y:    200 + r*math.Sin(theta),
+			AtMs: int64(i * 10),
+		})
+	}
+	return pts
+}
+
+func TestCreateArchimedesSpiralSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+	cx, cy := 200.0, 200.0
+
+	valid := CreateArchimedesSpiralSubtestCommand{
+		EvaluationID: "eval-123",
+		Drawings: []ASdomain.SpiralDrawing{
+			// Derecha: temblor de 5 Hz
+			{Hand: ASdomain.HandRight, CenterX: &cx, CenterY: &cy, Points: spiral(5, 3, 0)},
+			// Izquierda: sin temblor y con vueltas cada vez más juntas
+			{Hand: ASdomain.HandLeft, CenterX: &cx, CenterY: &cy, Points: spiral(0, 0, 0.5)},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateArchimedesSpiralSubtestCommand
+		shouldPass bool
+		wantErr    error
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateArchimedesSpiralSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - same hand twice",
+			cmd: func() CreateArchimedesSpiralSubtestCommand {
+				c := valid
+				c.Drawings = []ASdomain.SpiralDrawing{valid.Drawings[0], valid.Drawings[0]}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - too few points",
+			cmd: func() CreateArchimedesSpiralSubtestCommand {
+				c := valid
+				c.Drawings = []ASdomain.SpiralDrawing{{Hand: ASdomain.HandLeft, Points: valid.Drawings[1].Points[:10]}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - points out of order",
+			cmd: func() CreateArchimedesSpiralSubtestCommand {
+				c := valid
+				pts := append([]ASdomain.SpiralPoint{}, valid.Drawings[1].Points...)
+				pts[5], pts[6] = pts[6], pts[5]
+				c.Drawings = []ASdomain.SpiralDrawing{{Hand: ASdomain.HandLeft, Points: pts}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			// Sin tope, remuestrear 16 h a 100 Hz y su espectro bloquean el worker
+			name: "Invalid - drawing far longer than the clinical limit",
+			cmd: func() CreateArchimedesSpiralSubtestCommand {
+				c := valid
+				pts := append([]ASdomain.SpiralPoint{}, valid.Drawings[1].Points[:60]...)
+				for i := range pts {
+					pts[i].AtMs = int64(i) * 1_000_000
+				}
+				c.Drawings = []ASdomain.SpiralDrawing{{Hand: ASdomain.HandLeft, Points: pts}}
+				return c
+			}(),
+			shouldPass: false,
+			wantErr:    ASdomain.ErrSpiralTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateArchimedesSpiralSubtestCommandHandler(context.TODO(), tt.cmd, app.Repositories.ArchimedesSpiralRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				r, l := res.Score.Right, res.Score.Left
+				if !r.TremorDetected || math.Abs(r.TremorFrequencyHz-5) > 0.5 {
+					t.Errorf("expected ~5 Hz tremor on the right hand, got %+v", r)
+				}
+				if l.TremorDetected {
+					t.Errorf("unexpected tremor on the left hand: %+v", l)
+				}
+				if r.Loops < 3.9 || r.Loops > 4.1 {
+					t.Errorf("expected ~4 loops, got %v", r.Loops)
+				}
+				if r.FirstOrderSmoothness <= l.FirstOrderSmoothness {
+					t.Errorf("expected rougher right spiral: right %v left %v", r.FirstOrderSmoothness, l.FirstOrderSmoothness)
+				}
+				if l.SizeDecrementPct <= 20 || math.Abs(r.SizeDecrementPct) > 10 {
+					t.Errorf("expected size decrement only on the left hand: right %v left %v", r.SizeDecrementPct, l.SizeDecrementPct)
+				}
+				if r.SpeedPxPerSec <= 0 || res.Score.Score <= 0 || res.Score.Score >= 100 {
+					t.Errorf("unexpected speed/score: %+v", res.Score)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package createarchimedesspiralsubtest
+
+import ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+
+type CreateArchimedesSpiralSubtestCommand struct {
+	EvaluationID string                   `json:"evaluation_id"`
+	Drawings     []ASdomain.SpiralDrawing `json:"drawings"`
+}
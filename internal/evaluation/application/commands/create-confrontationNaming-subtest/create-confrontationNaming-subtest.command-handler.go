@@ -0,0 +1,67 @@
+package createconfrontationnamingsubtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+)
+
+func CreateConfrontationNamingSubtestCommandHandler(ctx context.Context, cmd CreateConfrontationNamingSubtestCommand, namingRepo CNdomain.ConfrontationNamingRepository, speechToText domain.SpeechToTextService, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*CNdomain.ConfrontationNamingSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", CNdomain.ErrInvalidNaming)
+	}
+
+	responses := make([]CNdomain.NamingResponse, 0, len(cmd.Responses))
+	for _, r := range cmd.Responses {
+		audio := cmd.Audio[r.Item]
+		if r.Transcript == "" && len(audio) > 0 {
+			if speechToText == nil {
+				return nil, errors.New("speech-to-text service not configured")
+			}
+			transcript, err := speechToText.GetTextFromSpeech(audio)
+			// limpiar buffer (no persistimos)
+			for i := range audio {
+				audio[i] = 0
+			}
+			if err != nil {
+				return nil, fmt.Errorf("transcribing item %d: %w", r.Item, err)
+			}
+			r.Transcript = transcript
+		}
+		responses = append(responses, r)
+	}
+
+	subtest, err := CNdomain.NewConfrontationNamingSubtest(cmd.EvaluationID, cmd.Version, responses)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := CNdomain.ScoreConfrontationNaming(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = namingRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,147 @@
+package createconfrontationnamingsubtest
+
+import (
+	"context"
+	"testing"
+
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	"neuro.app.jordi/internal/pkg"
+)
+
+type transcriptSTT struct{ text string }
+
+func (s transcriptSTT) GetTextFromSpeech(audio []byte) (string, error) {
+	return s.text, nil
+}
+
+func TestCreateConfrontationNamingSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateConfrontationNamingSubtestCommand{
+		EvaluationID: "eval-123",
+		Version:      CNdomain.NamingVersion15,
+		Responses: []CNdomain.NamingResponse{
+			{Item: 1, Transcript: "es una cama"},
+			{Item: 5, Transcript: "un pito"},
+			{Item: 9, Transcript: "serrucho", SemanticCue: true},
+			{Item: 13, Transcript: "pulpo", SemanticCue: true, PhonemicCue: true},
+			{Item: 17, Transcript: "camelo"},
+			{Item: 21, Transcript: "eso se usa para jugar al tenis"},
+			{Item: 25, Transcript: ""},
+			{Item: 29, Transcript: "perro", Override: &CNdomain.NamingOverride{Correct: false, ErrorType: CNdomain.NamingErrorSemantic}},
+			{Item: 33, Transcript: "casita de hielo", Override: &CNdomain.NamingOverride{Correct: true}},
+			// sin transcript: se transcribe el audio
+			{Item: 37},
+		},
+		Audio: map[int][]byte{37: []byte("fake-audio")},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateConfrontationNamingSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateConfrontationNamingSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unsupported version",
+			cmd: func() CreateConfrontationNamingSubtestCommand {
+				c := valid
+				c.Version = 20
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - item not in short form",
+			cmd: func() CreateConfrontationNamingSubtestCommand {
+				c := valid
+				c.Responses = []CNdomain.NamingResponse{{Item: 2, Transcript: "árbol"}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - repeated item",
+			cmd: func() CreateConfrontationNamingSubtestCommand {
+				c := valid
+				c.Responses = []CNdomain.NamingResponse{{Item: 1, Transcript: "cama"}, {Item: 1, Transcript: "cama"}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown override error type",
+			cmd: func() CreateConfrontationNamingSubtestCommand {
+				c := valid
+				c.Responses = []CNdomain.NamingResponse{{Item: 1, Transcript: "mesa", Override: &CNdomain.NamingOverride{ErrorType: "made-up"}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateConfrontationNamingSubtestCommandHandler(
+				context.TODO(),
+				tt.cmd,
+				app.Repositories.ConfrontationNamingRepository,
+				transcriptSTT{text: "unas escaleras mecánicas"},
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
+			)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				s := res.Score
+				if s.SpontaneousCorrect != 4 || s.SemanticCued != 1 || s.PhonemicCued != 1 || s.Total != 5 {
+					t.Errorf("unexpected correct counts: %+v", s)
+				}
+				want := map[CNdomain.NamingErrorType]int{
+					CNdomain.NamingErrorPhonemic:       1,
+					CNdomain.NamingErrorCircumlocution: 1,
+					CNdomain.NamingErrorOmission:       1,
+					CNdomain.NamingErrorSemantic:       1,
+				}
+				for k, v := range want {
+					if s.Errors[k] != v {
+						t.Errorf("expected %d %s errors, got %d (%+v)", v, k, s.Errors[k], s.Errors)
+					}
+				}
+				if s.Overrides != 2 {
+					t.Errorf("expected 2 examiner overrides, got %d", s.Overrides)
+				}
+				if s.Score == 0 {
+					t.Errorf("expected non-zero score to be calculated, got 0")
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package createconfrontationnamingsubtest
+
+import CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+
+type CreateConfrontationNamingSubtestCommand struct {
+	EvaluationID string                    `json:"evaluation_id"`
+	Version      CNdomain.NamingVersion    `json:"version"`
+	Responses    []CNdomain.NamingResponse `json:"responses"`
+	// Audio por número de lámina; se transcribe si la respuesta no trae transcript (no se persiste)
+	Audio map[int][]byte `json:"-"`
+}
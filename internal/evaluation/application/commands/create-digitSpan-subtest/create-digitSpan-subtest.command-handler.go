@@ -0,0 +1,51 @@
+package createdigitspansubtest
+
+import (
+	"context"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+)
+
+func CreateDigitSpanSubtestCommandHandler(ctx context.Context, cmd CreateDigitSpanSubtestCommand, evaluationRepo domain.EvaluationsRepository, digitSpanRepo DSdomain.DigitSpanRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*DSdomain.DigitSpanSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", DSdomain.ErrInvalidDigitSpan)
+	}
+	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtest, err := DSdomain.NewDigitSpanSubtest(cmd.EvaluationID, cmd.Trials)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las normas dependen de la edad del paciente
+	score, err := DSdomain.ScoreDigitSpan(*subtest, evaluation.PatientAge)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = digitSpanRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,121 @@
+package createdigitspansubtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestCreateDigitSpanSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateDigitSpanSubtestCommand{
+		EvaluationID: "eval-123",
+		Trials: []DSdomain.DigitSpanTrial{
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 2, Presented: []int{9, 7}, Response: []int{9, 7}},
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 3, Presented: []int{5, 8, 2}, Response: []int{5, 8, 2}},
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 4, Presented: []int{6, 4, 3, 9}, Response: []int{6, 3, 4, 9}},
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 4, Presented: []int{7, 2, 8, 6}, Response: []int{7, 2, 6}},
+			// tras dos fallos en longitud 4 ya no puntúa
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 5, Presented: []int{4, 2, 7, 3, 1}, Response: []int{4, 2, 7, 3, 1}},
+			{Condition: DSdomain.DigitSpanBackward, SpanLength: 2, Presented: []int{2, 4}, Response: []int{4, 2}},
+			{Condition: DSdomain.DigitSpanBackward, SpanLength: 3, Presented: []int{6, 2, 9}, Response: []int{9, 2, 6}},
+			{Condition: DSdomain.DigitSpanSequencing, SpanLength: 3, Presented: []int{3, 1, 2}, Response: []int{1, 2, 3}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateDigitSpanSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateDigitSpanSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - no trials",
+			cmd: func() CreateDigitSpanSubtestCommand {
+				c := valid
+				c.Trials = nil
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown condition",
+			cmd: func() CreateDigitSpanSubtestCommand {
+				c := valid
+				c.Trials = []DSdomain.DigitSpanTrial{{Condition: "reverse", SpanLength: 2, Presented: []int{1, 2}, Response: []int{2, 1}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - presented digits do not match span length",
+			cmd: func() CreateDigitSpanSubtestCommand {
+				c := valid
+				c.Trials = []DSdomain.DigitSpanTrial{{Condition: DSdomain.DigitSpanForward, SpanLength: 3, Presented: []int{1, 2}, Response: []int{1, 2}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateDigitSpanSubtestCommandHandler(
+				context.TODO(),
+				tt.cmd,
+				app.Repositories.EvaluationsRepository,
+				app.Repositories.DigitSpanRepository,
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
+			)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				fw := res.Score.Forward
+				if fw.LongestSpan != 3 || fw.TotalCorrect != 2 || fw.TrialsAdministered != 4 || !fw.Discontinued {
+					t.Errorf("unexpected forward score: %+v", fw)
+				}
+				if res.Score.Backward.LongestSpan != 3 || res.Score.Sequencing.TotalCorrect != 1 {
+					t.Errorf("unexpected backward/sequencing score: %+v", res.Score)
+				}
+				if res.Score.Score == 0 {
+					t.Errorf("expected non-zero score to be calculated, got 0")
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if !errors.Is(err, DSdomain.ErrInvalidDigitSpan) {
+					t.Errorf("expected a validation error wrapping ErrInvalidDigitSpan, got %v", err)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package createdigitspansubtest
+
+import DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+
+type CreateDigitSpanSubtestCommand struct {
+	EvaluationID string                    `json:"evaluation_id"`
+	Trials       []DSdomain.DigitSpanTrial `json:"trials"`
+}
@@ -4,25 +4,39 @@ import (
 	"context"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
 )
 
-func CreateExecutiveFunctionsSubtestCommandHandler(ctx context.Context, cmd CreateExecutiveFunctionsSubtestCommand, evaluationRepo domain.EvaluationsRepository, llmService domain.LLMService, executiveFunctionsSubtestRepo EFdomain.ExecutiveFunctionsSubtestRepository) (EFdomain.ExecutiveFunctionsSubtest, error) {
+func CreateExecutiveFunctionsSubtestCommandHandler(ctx context.Context, cmd CreateExecutiveFunctionsSubtestCommand, evaluationRepo domain.EvaluationsRepository, llmService domain.LLMService, executiveFunctionsSubtestRepo EFdomain.ExecutiveFunctionsSubtestRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (EFdomain.ExecutiveFunctionsSubtest, error) {
 	executiveFunctionsSubtest, err := EFdomain.NewExecutiveFunctionsSubtest(cmd.NumberOfItems, cmd.TotalErrors, cmd.TotalCorrect, cmd.TotalTime, EFdomain.ExuctiveFunctionSubtestType(cmd.Type), cmd.TotalClicks, cmd.EvaluationId, cmd.CreatedAt)
 	if err != nil {
 		return EFdomain.ExecutiveFunctionsSubtest{}, err
 	}
 
-	score, err := EFdomain.ScoreExecutiveFunctions(*executiveFunctionsSubtest)
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationId)
 	if err != nil {
 		return EFdomain.ExecutiveFunctionsSubtest{}, err
 	}
+	score, err := EFdomain.ScoreExecutiveFunctions(*executiveFunctionsSubtest, &profile.ExecutiveFunctions)
+	if err != nil {
+		return EFdomain.ExecutiveFunctionsSubtest{}, err
+	}
+	score.ScoringProfile = profile.Ref()
 	executiveFunctionsSubtest.Score = score
 
+	flags := executiveFunctionsSubtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return EFdomain.ExecutiveFunctionsSubtest{}, err
+	}
 	err = executiveFunctionsSubtestRepo.Save(ctx, *executiveFunctionsSubtest)
 	if err != nil {
 		return EFdomain.ExecutiveFunctionsSubtest{}, err
 	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationId, executiveFunctionsSubtest.PK, flags); err != nil {
+		return EFdomain.ExecutiveFunctionsSubtest{}, err
+	}
 
 	return *executiveFunctionsSubtest, nil
 }
@@ -2,9 +2,13 @@ package createexecutivefunctionssubtest
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	DQinfra "neuro.app.jordi/internal/evaluation/infra/data-quality"
 	"neuro.app.jordi/internal/pkg"
 )
 
@@ -26,11 +30,24 @@ func TestCreateExecutiveFunctionsSubtestCommandHandler(t *testing.T) {
 		name       string
 		command    CreateExecutiveFunctionsSubtestCommand
 		shouldPass bool
+		corrupt    bool
+		wantFlags  []DQdomain.Code
 	}{
 		{
 			name:       "Valid command",
 			command:    validCommand,
 			shouldPass: true,
+			wantFlags:  []DQdomain.Code{},
+		},
+		{
+			name: "Valid command - implausibly fast TMT is saved with a warning",
+			command: func() CreateExecutiveFunctionsSubtestCommand {
+				c := validCommand
+				c.TotalTime = 2 * time.Second
+				return c
+			}(),
+			shouldPass: true,
+			wantFlags:  []DQdomain.Code{DQdomain.CodeImplausiblyFast},
 		},
 		{
 			name: "Invalid command - negative items",
@@ -50,6 +67,27 @@ func TestCreateExecutiveFunctionsSubtestCommandHandler(t *testing.T) {
 			}(),
 			shouldPass: false,
 		},
+		{
+			name: "Invalid command - more correct answers than items",
+			command: func() CreateExecutiveFunctionsSubtestCommand {
+				c := validCommand
+				c.TotalCorrect = 12
+				c.TotalClicks = 14
+				return c
+			}(),
+			shouldPass: false,
+			corrupt:    true,
+		},
+		{
+			name: "Invalid command - fewer clicks than correct answers plus errors",
+			command: func() CreateExecutiveFunctionsSubtestCommand {
+				c := validCommand
+				c.TotalClicks = 9
+				return c
+			}(),
+			shouldPass: false,
+			corrupt:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -60,6 +98,9 @@ func TestCreateExecutiveFunctionsSubtestCommandHandler(t *testing.T) {
 				app.Repositories.EvaluationsRepository,
 				app.Services.LLMService,
 				app.Repositories.ExecutiveFunctionsSubtestRepository,
+				app.Repositories.ScoringProfileCatalog,
+				app.Repositories.ScoringSelectionRepository,
+				app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -69,10 +110,27 @@ func TestCreateExecutiveFunctionsSubtestCommandHandler(t *testing.T) {
 				if result.Score.Score == 0 {
 					t.Errorf("expected score to be calculated, got 0")
 				}
+				if result.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", result.Score.ScoringProfile)
+				}
+				flags, ok := app.Repositories.DataQualityRepository.(*DQinfra.MockDataQualityRepository).Get(tt.command.EvaluationId, result.PK)
+				if !ok {
+					t.Fatalf("expected quality flags to be stored for %s", result.PK)
+				}
+				codes := []DQdomain.Code{}
+				for _, f := range flags {
+					codes = append(codes, f.Code)
+				}
+				if !reflect.DeepEqual(codes, tt.wantFlags) {
+					t.Errorf("expected stored flags %v, got %+v", tt.wantFlags, flags)
+				}
 			} else {
 				if err == nil {
 					t.Errorf("expected error, got nil")
 				}
+				if tt.corrupt && !errors.Is(err, DQdomain.ErrCorruptSubmission) {
+					t.Errorf("expected ErrCorruptSubmission, got %v", err)
+				}
 			}
 		})
 	}
@@ -0,0 +1,45 @@
+package createfingertappingsubtest
+
+import (
+	"context"
+	"fmt"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+)
+
+func CreateFingerTappingSubtestCommandHandler(ctx context.Context, cmd CreateFingerTappingSubtestCommand, fingerTappingRepo FTdomain.FingerTappingRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*FTdomain.FingerTappingSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", FTdomain.ErrInvalidFingerTapping)
+	}
+
+	subtest, err := FTdomain.NewFingerTappingSubtest(cmd.EvaluationID, cmd.Trials)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := FTdomain.ScoreFingerTapping(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = fingerTappingRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
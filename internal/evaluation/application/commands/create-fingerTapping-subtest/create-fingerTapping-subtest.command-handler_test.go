@@ -0,0 +1,126 @@
+package createfingertappingsubtest
+
+import (
+	"context"
+	"testing"
+
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestCreateFingerTappingSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	// Derecha: 40 toques con una pausa y amplitud decreciente (efecto secuencia)
+	var right []FTdomain.Tap
+	for i := 0; i < 40; i++ {
+		at := int64(i * 230)
+		if i >= 20 {
+			at += 700
+		}
+		x := 0.0
+		if i%2 == 1 {
+			x = 200 - float64(i)*1.5
+		}
+		right = append(right, FTdomain.Tap{AtMs: at, X: x, Y: 300})
+	}
+	// Izquierda: 30 toques regulares con amplitud constante
+	var left []FTdomain.Tap
+	for i := 0; i < 30; i++ {
+		left = append(left, FTdomain.Tap{AtMs: int64(i * 330), X: float64(i%2) * 200, Y: 300})
+	}
+
+	valid := CreateFingerTappingSubtestCommand{
+		EvaluationID: "eval-123",
+		Trials: []FTdomain.HandTrial{
+			{Hand: FTdomain.HandRight, WindowMs: 10_000, Taps: right},
+			{Hand: FTdomain.HandLeft, WindowMs: 10_000, Taps: left},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateFingerTappingSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateFingerTappingSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - same hand twice",
+			cmd: func() CreateFingerTappingSubtestCommand {
+				c := valid
+				c.Trials = []FTdomain.HandTrial{valid.Trials[0], valid.Trials[0]}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - window too short",
+			cmd: func() CreateFingerTappingSubtestCommand {
+				c := valid
+				c.Trials = []FTdomain.HandTrial{{Hand: FTdomain.HandLeft, WindowMs: 1_000, Taps: left[:2]}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - taps out of order",
+			cmd: func() CreateFingerTappingSubtestCommand {
+				c := valid
+				c.Trials = []FTdomain.HandTrial{{Hand: FTdomain.HandLeft, WindowMs: 10_000, Taps: []FTdomain.Tap{{AtMs: 500}, {AtMs: 200}}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateFingerTappingSubtestCommandHandler(context.TODO(), tt.cmd, app.Repositories.FingerTappingRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				r, l := res.Score.Right, res.Score.Left
+				if r.RateHz != 4 || l.RateHz != 3 {
+					t.Errorf("unexpected tapping rates: right %+v left %+v", r, l)
+				}
+				if r.Hesitations != 1 || l.Hesitations != 0 {
+					t.Errorf("expected one hesitation on the right hand, got right=%d left=%d", r.Hesitations, l.Hesitations)
+				}
+				if r.AmplitudeDecrement <= 0 || r.AmplitudeSlopePerTap >= 0 || l.AmplitudeDecrement != 0 {
+					t.Errorf("expected amplitude decrement only on the right hand: right %+v left %+v", r, l)
+				}
+				if res.Score.MoreAffectedSide != FTdomain.HandLeft || res.Score.Score != 60 {
+					t.Errorf("unexpected asymmetry/score: %+v", res.Score)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package createfingertappingsubtest
+
+import FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+
+type CreateFingerTappingSubtestCommand struct {
+	EvaluationID string               `json:"evaluation_id"`
+	Trials       []FTdomain.HandTrial `json:"trials"`
+}
@@ -0,0 +1,45 @@
+package creategonogosubtest
+
+import (
+	"context"
+	"fmt"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
+)
+
+func CreateGoNoGoSubtestCommandHandler(ctx context.Context, cmd CreateGoNoGoSubtestCommand, goNoGoRepo GNGdomain.GoNoGoRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*GNGdomain.GoNoGoSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", GNGdomain.ErrInvalidGoNoGo)
+	}
+
+	subtest, err := GNGdomain.NewGoNoGoSubtest(cmd.EvaluationID, cmd.Blocks, cmd.Trials)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := GNGdomain.ScoreGoNoGo(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = goNoGoRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,144 @@
+package creategonogosubtest
+
+import (
+	"context"
+	"testing"
+
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
+	"neuro.app.jordi/internal/pkg"
+)
+
+// goNoGoTrials genera 80 ensayos (uno de cada cuatro no-go) en 4 bloques de 20.
+// El rendimiento empeora con el tiempo: TR go 400→460 ms, una comisión en el
+// bloque 2, tres comisiones y dos omisiones en el bloque 4.
+func goNoGoTrials() []GNGdomain.GoNoGoTrial {
+	respond := func(at int64) *int64 { return &at }
+	var trials []GNGdomain.GoNoGoTrial
+	for i := 0; i < 80; i++ {
+		block := i / 20
+		onset := int64(i * 1500)
+		t := GNGdomain.GoNoGoTrial{Stimulus: GNGdomain.StimulusGo, OnsetMs: onset}
+		if i%4 == 3 {
+			t.Stimulus = GNGdomain.StimulusNoGo
+			if (block == 1 && i == 23) || (block == 3 && i < 75) {
+				t.RespondedAtMs = respond(onset + 250)
+			}
+		} else if !(block == 3 && (i == 60 || i == 61)) {
+			t.RespondedAtMs = respond(onset + 400 + int64(block*20))
+		}
+		trials = append(trials, t)
+	}
+	return trials
+}
+
+func TestCreateGoNoGoSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateGoNoGoSubtestCommand{EvaluationID: "eval-123", Trials: goNoGoTrials()}
+
+	tests := []struct {
+		name       string
+		cmd        CreateGoNoGoSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateGoNoGoSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - too few trials",
+			cmd: func() CreateGoNoGoSubtestCommand {
+				c := valid
+				c.Trials = valid.Trials[:10]
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - only go trials",
+			cmd: func() CreateGoNoGoSubtestCommand {
+				c := valid
+				c.Trials = nil
+				for _, tr := range valid.Trials {
+					if tr.Stimulus == GNGdomain.StimulusGo {
+						c.Trials = append(c.Trials, tr)
+					}
+				}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown stimulus",
+			cmd: func() CreateGoNoGoSubtestCommand {
+				c := valid
+				c.Trials = append([]GNGdomain.GoNoGoTrial{}, valid.Trials...)
+				c.Trials[0].Stimulus = "stop"
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - response before onset",
+			cmd: func() CreateGoNoGoSubtestCommand {
+				c := valid
+				c.Trials = append([]GNGdomain.GoNoGoTrial{}, valid.Trials...)
+				early := c.Trials[5].OnsetMs - 10
+				c.Trials[5].RespondedAtMs = &early
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateGoNoGoSubtestCommandHandler(context.TODO(), tt.cmd, app.Repositories.GoNoGoRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				s := res.Score
+				if s.GoTrials != 60 || s.NoGoTrials != 20 || s.Commissions != 4 || s.Omissions != 2 {
+					t.Errorf("unexpected counts: %+v", s)
+				}
+				if s.CommissionRate != 0.2 || s.Score != 88 {
+					t.Errorf("unexpected rates/score: %+v", s)
+				}
+				if s.DPrime < 2.4 || s.DPrime > 2.7 || s.Criterion >= 0 {
+					t.Errorf("unexpected signal detection: d'=%v c=%v", s.DPrime, s.Criterion)
+				}
+				if s.MedianRTMs != 420 || s.CommissionRTMs != 250 {
+					t.Errorf("unexpected RTs: %+v", s)
+				}
+				if len(s.Blocks) != 4 || s.CommissionRateChange != 0.6 || s.MedianRTChangeMs != 60 {
+					t.Errorf("expected worsening across blocks: %+v", s.Blocks)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
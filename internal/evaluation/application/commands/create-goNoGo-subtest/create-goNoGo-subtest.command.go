@@ -0,0 +1,9 @@
+package creategonogosubtest
+
+import GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
+
+type CreateGoNoGoSubtestCommand struct {
+	EvaluationID string                  `json:"evaluation_id"`
+	Blocks       int                     `json:"blocks"` // 0 = 4 bloques
+	Trials       []GNGdomain.GoNoGoTrial `json:"trials"`
+}
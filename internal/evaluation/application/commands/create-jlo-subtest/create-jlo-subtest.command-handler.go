@@ -0,0 +1,50 @@
+package createjlosubtest
+
+import (
+	"context"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+)
+
+func CreateJLOSubtestCommandHandler(ctx context.Context, cmd CreateJLOSubtestCommand, evaluationRepo domain.EvaluationsRepository, jloRepo JLOdomain.JLORepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*JLOdomain.JLOSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", JLOdomain.ErrInvalidJLO)
+	}
+	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtest, err := JLOdomain.NewJLOSubtest(cmd.EvaluationID, cmd.Form, cmd.Sex, cmd.Responses)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := JLOdomain.ScoreJLO(*subtest, evaluation.PatientAge)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = jloRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,131 @@
+package createjlosubtest
+
+import (
+	"context"
+	"testing"
+
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	"neuro.app.jordi/internal/pkg"
+)
+
+// Respuestas de la forma impar: aciertos en los 10 primeros ítems, una parcial,
+// dos errores y dos omisiones. Clave de los impares: 1{1,6} 3{3,9} 5{5,10} ...
+func oddFormResponses() []JLOdomain.JLOResponse {
+	answers := map[int][2]int{
+		1: {6, 1}, 3: {3, 9}, 5: {5, 10}, 7: {1, 11}, 9: {2, 5},
+		11: {3, 8}, 13: {1, 4}, 15: {2, 10}, 17: {3, 6}, 19: {4, 7},
+		21: {2, 9},             // parcial
+		23: {4, 5}, 25: {1, 2}, // errores
+		27: {0, 0}, // omisión explícita; el 29 no se presenta
+	}
+	var out []JLOdomain.JLOResponse
+	for item := 1; item <= 27; item += 2 {
+		at := int64(item * 10_000)
+		out = append(out, JLOdomain.JLOResponse{Item: item, PresentedAtMs: at, RespondedAtMs: at + 4_000, Selected: answers[item]})
+	}
+	return out
+}
+
+func TestCreateJLOSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateJLOSubtestCommand{
+		EvaluationID: "eval-123",
+		Form:         JLOdomain.JLOFormOdd,
+		Sex:          JLOdomain.SexFemale,
+		Responses:    oddFormResponses(),
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateJLOSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateJLOSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown form",
+			cmd: func() CreateJLOSubtestCommand {
+				c := valid
+				c.Form = "short"
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - missing sex",
+			cmd: func() CreateJLOSubtestCommand {
+				c := valid
+				c.Sex = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - even item in odd form",
+			cmd: func() CreateJLOSubtestCommand {
+				c := valid
+				c.Responses = []JLOdomain.JLOResponse{{Item: 2, PresentedAtMs: 0, RespondedAtMs: 10, Selected: [2]int{4, 11}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - line out of range",
+			cmd: func() CreateJLOSubtestCommand {
+				c := valid
+				c.Responses = []JLOdomain.JLOResponse{{Item: 1, PresentedAtMs: 0, RespondedAtMs: 10, Selected: [2]int{1, 12}}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateJLOSubtestCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.JLORepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				s := res.Score
+				if s.RawCorrect != 10 || s.PartialResponses != 1 || s.Omissions != 2 || len(s.Items) != 15 {
+					t.Errorf("unexpected item scoring: %+v", s)
+				}
+				// 10/15 → 20 prorrateado; edad 65 → +3; mujer → +2
+				if s.Prorated != 20 || s.AgeCorrection != 3 || s.SexCorrection != 2 || s.Score != 25 {
+					t.Errorf("unexpected corrected score: %+v", s)
+				}
+				if s.Classification != JLOdomain.JLOAverage || s.MedianResponseMs != 4_000 {
+					t.Errorf("unexpected classification/median: %+v", s)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package createjlosubtest
+
+import JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+
+type CreateJLOSubtestCommand struct {
+	EvaluationID string                  `json:"evaluation_id"`
+	Form         JLOdomain.JLOForm       `json:"form"`
+	Sex          JLOdomain.Sex           `json:"sex"`
+	Responses    []JLOdomain.JLOResponse `json:"responses"`
+}
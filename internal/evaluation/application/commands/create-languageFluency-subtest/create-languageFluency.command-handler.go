@@ -2,15 +2,17 @@ package createlanguagefluencysubtest
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 )
 
-func CreateLanguageFluencySubtestCommandHandler(ctx context.Context, cmd CreateLanguageFluencySubtestCommand, evaluationRepo domain.EvaluationsRepository, llmService domain.LLMService, languageFluencyRepo LFdomain.LanguageFluencyRepository) (LFdomain.LanguageFluency, error) {
+func CreateLanguageFluencySubtestCommandHandler(ctx context.Context, cmd CreateLanguageFluencySubtestCommand, evaluationRepo domain.EvaluationsRepository, llmService domain.LLMService, languageFluencyRepo LFdomain.LanguageFluencyRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (LFdomain.LanguageFluency, error) {
 	if cmd.EvaluationID == "" {
-		return LFdomain.LanguageFluency{}, errors.New("evaluation id is required")
+		return LFdomain.LanguageFluency{}, fmt.Errorf("%w: evaluation id is required", LFdomain.ErrInvalidLanguageFluency)
 	}
 	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
 	if err != nil {
@@ -21,16 +23,28 @@ func CreateLanguageFluencySubtestCommandHandler(ctx context.Context, cmd CreateL
 		return LFdomain.LanguageFluency{}, err
 	}
 
-	score, err := LFdomain.ScoreLanguageFluency(*languageFluency)
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, evaluation.PK)
 	if err != nil {
 		return LFdomain.LanguageFluency{}, err
 	}
+	score, err := LFdomain.ScoreLanguageFluency(*languageFluency, &profile.LanguageFluency)
+	if err != nil {
+		return LFdomain.LanguageFluency{}, err
+	}
+	score.ScoringProfile = profile.Ref()
 	languageFluency.Score = score
 
+	flags := languageFluency.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return LFdomain.LanguageFluency{}, err
+	}
 	err = languageFluencyRepo.Save(ctx, *languageFluency)
 	if err != nil {
 		return LFdomain.LanguageFluency{}, err
 	}
+	if err = qualityRepo.Replace(ctx, evaluation.PK, languageFluency.PK, flags); err != nil {
+		return LFdomain.LanguageFluency{}, err
+	}
 	evaluation.CurrentStatus = domain.EvaluationCurrentStatusPending
 	err = evaluationRepo.Update(ctx, evaluation)
 
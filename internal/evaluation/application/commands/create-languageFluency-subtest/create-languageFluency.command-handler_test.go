@@ -67,6 +67,9 @@ func TestCreateLanguageFluencySubtestCommandHandler(t *testing.T) {
 				app.Repositories.EvaluationsRepository,
 				app.Services.LLMService,
 				app.Repositories.LanguageFluencyRepository,
+				app.Repositories.ScoringProfileCatalog,
+				app.Repositories.ScoringSelectionRepository,
+				app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -76,6 +79,9 @@ func TestCreateLanguageFluencySubtestCommandHandler(t *testing.T) {
 				if res.PK == "" {
 					t.Errorf("expected persisted entity with PK, got empty")
 				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
 				if res.Score.Score == 0 {
 					t.Errorf("expected non-zero score to be calculated, got 0")
 				}
@@ -4,22 +4,32 @@ import (
 	"context"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
 )
 
-func CreateLetterCancellationSubtestCommandHandler(ctx context.Context, command CreateLetterCancellationSubtestCommand, letterCancellationRepo LCdomain.LetterCancellationRepository, evaluationsRepo domain.EvaluationsRepository, llmService domain.LLMService) (*LCdomain.LettersCancellationSubtest, error) {
-
-	cfg := &LCdomain.CancellationScoreConfig{
-		CapErrorFactor: 2.0,
+func CreateLetterCancellationSubtestCommandHandler(ctx context.Context, command CreateLetterCancellationSubtestCommand, letterCancellationRepo LCdomain.LetterCancellationRepository, evaluationsRepo domain.EvaluationsRepository, llmService domain.LLMService, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*LCdomain.LettersCancellationSubtest, error) {
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, command.EvaluationID)
+	if err != nil {
+		return nil, err
 	}
-	subtest, err := LCdomain.NewLettersCancellationSubtest(command.TotalTargets, command.Correct, command.Errors, command.TimeInSecs, command.EvaluationID, cfg)
+	subtest, err := LCdomain.NewLettersCancellationSubtest(command.TotalTargets, command.Correct, command.Errors, command.TimeInSecs, command.EvaluationID, &profile.LetterCancellation)
 	if err != nil {
 		return nil, err
 	}
+	subtest.CancellationScore.ScoringProfile = profile.Ref()
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
 	err = letterCancellationRepo.Save(ctx, subtest)
 	if err != nil {
 		return nil, err
 	}
+	if err = qualityRepo.Replace(ctx, command.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
 	return subtest, nil
 
 }
@@ -93,6 +93,9 @@ func TestCreateLetterCancellationSubtestCommandHandler(t *testing.T) {
 				app.Repositories.LetterCancellationRepository,
 				app.Repositories.EvaluationsRepository, // no se usa en el handler, pero seguimos la firma
 				app.Services.LLMService,                // idem
+				app.Repositories.ScoringProfileCatalog,
+				app.Repositories.ScoringSelectionRepository,
+				app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -102,6 +105,9 @@ func TestCreateLetterCancellationSubtestCommandHandler(t *testing.T) {
 				if sub == nil {
 					t.Fatalf("expected non-nil subtest")
 				}
+				if sub.CancellationScore.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", sub.CancellationScore.ScoringProfile)
+				}
 				// Chequeos básicos de consistencia
 				if sub.EvaluationID != tt.cmd.EvaluationID {
 					t.Errorf("expected EvaluationID=%q, got %q", tt.cmd.EvaluationID, sub.EvaluationID)
@@ -0,0 +1,85 @@
+package createmocasubtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+)
+
+func CreateMoCASubtestCommandHandler(ctx context.Context, cmd CreateMoCASubtestCommand, evaluationRepo domain.EvaluationsRepository, visualSpatialRepo VPdomain.ResultRepository, languageFluencyRepo LFdomain.LanguageFluencyRepository, mocaRepo MOCAdomain.MoCARepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*MOCAdomain.MoCASubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", MOCAdomain.ErrInvalidMoCA)
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	subtest, err := MOCAdomain.NewMoCASubtest(cmd.EvaluationID, cmd.Version, cmd.EducationYears, cmd.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtest.Items.Clock == nil {
+		cdt, err := visualSpatialRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", MOCAdomain.ErrMissingClockItem, err)
+		}
+		if cdt == nil || cdt.Id == "" {
+			return nil, MOCAdomain.ErrMissingClockItem
+		}
+		subtest.UseClock(MOCAdomain.ClockFromCDT(cdt.Score.Val), MOCAdomain.SourceClockDrawing)
+	}
+
+	if subtest.Items.FluencyWords == nil {
+		lf, err := languageFluencyRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", MOCAdomain.ErrMissingFluencyItem, err)
+		}
+		// La fluencia MoCA es fonémica: una fluencia semántica no es equivalente
+		if lf.PK == "" || !isPhonemic(lf.Category) {
+			return nil, MOCAdomain.ErrMissingFluencyItem
+		}
+		subtest.UseFluency(lf.Score.UniqueValid, MOCAdomain.SourceLanguageFluency)
+	}
+
+	score, err := MOCAdomain.ScoreMoCA(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = mocaRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
+
+// isPhonemic acepta la categoría "phonemic"/"letter" o directamente una letra
+func isPhonemic(category string) bool {
+	c := strings.ToLower(strings.TrimSpace(category))
+	switch c {
+	case "phonemic", "letter", "fonemica", "fonémica", "fonetica", "fonética":
+		return true
+	}
+	return len([]rune(c)) == 1
+}
@@ -0,0 +1,173 @@
+package createmocasubtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+	"neuro.app.jordi/internal/pkg"
+)
+
+// cdtRepository simula un CDT (Shulman) ya administrado en la evaluación
+type cdtRepository struct {
+	VPdomain.ResultRepository
+	score int
+}
+
+func (r cdtRepository) GetByEvaluationID(ctx context.Context, id string) (*VPdomain.VisualSpatialSubtest, error) {
+	return &VPdomain.VisualSpatialSubtest{Id: "cdt1", EvalautionId: id, Score: VPdomain.VisualSpatialScore{Val: r.score}}, nil
+}
+
+func fullItems() MOCAdomain.MoCAItems {
+	fluency := 12
+	return MOCAdomain.MoCAItems{
+		Trails:           1,
+		Cube:             1,
+		Clock:            &MOCAdomain.MoCAClock{Contour: true, Numbers: true, Hands: true},
+		Naming:           3,
+		ImmediateRecall:  [2]int{5, 5},
+		DigitsForward:    true,
+		DigitsBackward:   true,
+		Serial7Correct:   5,
+		SentencesCorrect: 2,
+		FluencyWords:     &fluency,
+		Abstraction:      2,
+		DelayedRecall:    MOCAdomain.MoCADelayedRecall{Free: 4, CategoryCued: 1},
+		Orientation:      6,
+	}
+}
+
+func TestCreateMoCASubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+	ctx := context.TODO()
+
+	// fluencia ya administrada en otra evaluación (el mock busca por evaluation id)
+	fluencyIn := func(evaluationID, category string) string {
+		lf := LFdomain.LanguageFluency{PK: evaluationID + "-lf", EvaluationID: evaluationID, Category: category, Score: LFdomain.LanguageFluencyScore{UniqueValid: 5}}
+		if err := app.Repositories.LanguageFluencyRepository.Save(ctx, lf); err != nil {
+			t.Fatal(err)
+		}
+		return evaluationID
+	}
+	withEvaluation := func(c CreateMoCASubtestCommand, evaluationID string) CreateMoCASubtestCommand {
+		c.EvaluationID = evaluationID
+		return c
+	}
+
+	withItems := func(mutate func(*MOCAdomain.MoCAItems)) CreateMoCASubtestCommand {
+		items := fullItems()
+		mutate(&items)
+		return CreateMoCASubtestCommand{EvaluationID: "eval-123", Version: MOCAdomain.MoCAVersion72, EducationYears: 10, Items: items}
+	}
+
+	tests := []struct {
+		name        string
+		cmd         CreateMoCASubtestCommand
+		cdt         VPdomain.ResultRepository
+		wantErr     error
+		wantTotal   int
+		wantClock   MOCAdomain.ItemSource
+		wantFluency MOCAdomain.ItemSource
+	}{
+		{
+			name:        "Valid - all items entered, education point capped at 30",
+			cmd:         withItems(func(*MOCAdomain.MoCAItems) {}),
+			wantTotal:   30,
+			wantClock:   MOCAdomain.SourceEntered,
+			wantFluency: MOCAdomain.SourceEntered,
+		},
+		{
+			// CDT 4/5 → contorno y números (2/3)
+			name:        "Valid - clock reused from clock drawing test",
+			cmd:         withItems(func(it *MOCAdomain.MoCAItems) { it.Clock = nil }),
+			cdt:         cdtRepository{score: 4},
+			wantTotal:   29,
+			wantClock:   MOCAdomain.SourceClockDrawing,
+			wantFluency: MOCAdomain.SourceEntered,
+		},
+		{
+			// 5 palabras únicas en la fluencia fonémica → 0 puntos
+			name:        "Valid - fluency reused from phonemic fluency subtest",
+			cmd:         withEvaluation(withItems(func(it *MOCAdomain.MoCAItems) { it.FluencyWords = nil }), fluencyIn("eval-phonemic", "phonemic")),
+			wantTotal:   29,
+			wantClock:   MOCAdomain.SourceEntered,
+			wantFluency: MOCAdomain.SourceLanguageFluency,
+		},
+		{
+			name:    "Invalid - no clock entered and no clock drawing test",
+			cmd:     withItems(func(it *MOCAdomain.MoCAItems) { it.Clock = nil }),
+			wantErr: MOCAdomain.ErrMissingClockItem,
+		},
+		{
+			name:    "Invalid - semantic fluency is not reusable",
+			cmd:     withEvaluation(withItems(func(it *MOCAdomain.MoCAItems) { it.FluencyWords = nil }), fluencyIn("eval-semantic", "semantic")),
+			wantErr: MOCAdomain.ErrMissingFluencyItem,
+		},
+		{
+			name:    "Invalid - naming out of range",
+			cmd:     withItems(func(it *MOCAdomain.MoCAItems) { it.Naming = 4 }),
+			wantErr: MOCAdomain.ErrInvalidItem,
+		},
+		{
+			name: "Invalid - unknown version",
+			cmd: func() CreateMoCASubtestCommand {
+				c := withItems(func(*MOCAdomain.MoCAItems) {})
+				c.Version = "9.9"
+				return c
+			}(),
+			wantErr: MOCAdomain.ErrInvalidVersion,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateMoCASubtestCommand {
+				c := withItems(func(*MOCAdomain.MoCAItems) {})
+				c.EvaluationID = ""
+				return c
+			}(),
+			wantErr: MOCAdomain.ErrInvalidMoCA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cdt := tt.cdt
+			if cdt == nil {
+				cdt = app.Repositories.VisualSpatialRepository
+			}
+			res, err := CreateMoCASubtestCommandHandler(ctx, tt.cmd, app.Repositories.EvaluationsRepository, cdt, app.Repositories.LanguageFluencyRepository, app.Repositories.MoCARepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				// toda entrada inválida envuelve ErrInvalidMoCA (400 en la API)
+				if !errors.Is(err, MOCAdomain.ErrInvalidMoCA) {
+					t.Errorf("expected %v to wrap ErrInvalidMoCA", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Score.Total != tt.wantTotal || res.ClockSource != tt.wantClock || res.FluencySource != tt.wantFluency {
+				t.Errorf("unexpected result: total=%d clock=%s fluency=%s", res.Score.Total, res.ClockSource, res.FluencySource)
+			}
+			if res.Score.ScoringProfile != "standard@1" {
+				t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+			}
+		})
+	}
+
+	t.Run("Domain indexes", func(t *testing.T) {
+		res, err := CreateMoCASubtestCommandHandler(ctx, withItems(func(*MOCAdomain.MoCAItems) {}), app.Repositories.EvaluationsRepository, app.Repositories.VisualSpatialRepository, app.Repositories.LanguageFluencyRepository, app.Repositories.MoCARepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := MOCAdomain.MoCAIndexes{Memory: 14, Executive: 13, Attention: 18, Language: 6, Visuospatial: 7, Orientation: 6}
+		if res.Score.Indexes != want || res.Score.RawTotal != 29 || res.Score.EducationPoint != 1 || res.Score.Classification != "normal" {
+			t.Errorf("unexpected score: %+v", res.Score)
+		}
+	})
+}
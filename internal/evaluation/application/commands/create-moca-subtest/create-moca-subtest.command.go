@@ -0,0 +1,11 @@
+package createmocasubtest
+
+import MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+
+type CreateMoCASubtestCommand struct {
+	EvaluationID   string                 `json:"evaluation_id"`
+	Version        MOCAdomain.MoCAVersion `json:"version"`
+	EducationYears int                    `json:"education_years"`
+	// Items.Clock / Items.FluencyWords vacíos: se reutilizan el CDT y la fluencia fonémica de la evaluación
+	Items MOCAdomain.MoCAItems `json:"items"`
+}
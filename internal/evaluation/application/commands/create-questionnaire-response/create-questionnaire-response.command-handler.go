@@ -0,0 +1,34 @@
+package createquestionnaireresponse
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+)
+
+func CreateQuestionnaireResponseCommandHandler(ctx context.Context, cmd CreateQuestionnaireResponseCommand, evaluationRepo domain.EvaluationsRepository, catalog QNdomain.QuestionnaireCatalog, questionnaireRepo QNdomain.QuestionnaireRepository) (*QNdomain.QuestionnaireResponse, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if cmd.Code == "" {
+		return nil, errors.New("questionnaire code is required")
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	def, err := catalog.Get(ctx, cmd.Code, cmd.Version)
+	if err != nil {
+		return nil, err
+	}
+	response, err := QNdomain.NewQuestionnaireResponse(cmd.EvaluationID, def, cmd.Answers)
+	if err != nil {
+		return nil, err
+	}
+	if err = questionnaireRepo.Save(ctx, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,125 @@
+package createquestionnaireresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	"neuro.app.jordi/internal/pkg"
+)
+
+// answersRange responde value a los ítems q<from>..q<to>
+func answersRange(into map[string]int, from, to, value int) map[string]int {
+	if into == nil {
+		into = map[string]int{}
+	}
+	for i := from; i <= to; i++ {
+		into[fmt.Sprintf("q%d", i)] = value
+	}
+	return into
+}
+
+func TestCreateQuestionnaireResponseCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	gdsMissing := answersRange(nil, 1, 13, 1)
+	gdsTooManyMissing := answersRange(nil, 1, 11, 1)
+	pdq := answersRange(answersRange(nil, 1, 10, 4), 11, 39, 2)
+	delete(pdq, "q28") // sin pareja: un ítem perdido tolerado en apoyo social
+
+	tests := []struct {
+		name           string
+		cmd            CreateQuestionnaireResponseCommand
+		wantErr        error
+		wantValid      bool
+		wantTotal      float64
+		classification string
+		imputed        bool
+	}{
+		{
+			// "sí" a todo: los 5 ítems inversos puntúan 0
+			name:           "GDS-15 complete",
+			cmd:            CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "gds-15", Answers: answersRange(nil, 1, 15, 1)},
+			wantValid:      true,
+			wantTotal:      10,
+			classification: "moderate",
+		},
+		{
+			name:           "GDS-15 prorated with two missing items",
+			cmd:            CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "gds-15", Version: 1, Answers: gdsMissing},
+			wantValid:      true,
+			wantTotal:      9.2, // 8 * 15/13
+			classification: "moderate",
+			imputed:        true,
+		},
+		{
+			name:      "GDS-15 not scorable with four missing items",
+			cmd:       CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "gds-15", Answers: gdsTooManyMissing},
+			wantValid: false,
+		},
+		{
+			// "nada" a todo: los 8 ítems positivos invertidos puntúan 3
+			name:           "Apathy scale reverse keyed",
+			cmd:            CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "apathy-scale", Answers: answersRange(nil, 1, 14, 0)},
+			wantValid:      true,
+			wantTotal:      24,
+			classification: "apathy",
+		},
+		{
+			name:      "PDQ-39 summary index",
+			cmd:       CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "pdq-39", Answers: pdq},
+			wantValid: true,
+			wantTotal: 56.3, // (100 + 7·50) / 8
+			imputed:   true,
+		},
+		{
+			name:    "Invalid - answer outside options",
+			cmd:     CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "faq", Answers: map[string]int{"q1": 5}},
+			wantErr: QNdomain.ErrInvalidAnswer,
+		},
+		{
+			name:    "Invalid - unknown item",
+			cmd:     CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "faq", Answers: map[string]int{"q42": 1}},
+			wantErr: QNdomain.ErrUnknownItem,
+		},
+		{
+			name:    "Invalid - unknown questionnaire",
+			cmd:     CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "hads"},
+			wantErr: QNdomain.ErrDefinitionNotFound,
+		},
+		{
+			name:    "Invalid - unknown version",
+			cmd:     CreateQuestionnaireResponseCommand{EvaluationID: "eval-123", Code: "gds-15", Version: 9},
+			wantErr: QNdomain.ErrDefinitionNotFound,
+		},
+		{
+			name:    "Invalid - missing evaluation id",
+			cmd:     CreateQuestionnaireResponseCommand{Code: "gds-15"},
+			wantErr: errors.New("evaluation id is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := CreateQuestionnaireResponseCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.QuestionnaireCatalog, app.Repositories.QuestionnaireRepository)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s := resp.Score
+			if s.Valid != tt.wantValid || s.Total != tt.wantTotal || s.Classification != tt.classification || s.Imputed != tt.imputed {
+				t.Errorf("unexpected score: %+v", s)
+			}
+			if resp.Version != 1 || resp.Code != tt.cmd.Code {
+				t.Errorf("expected definition %s v1, got %s v%d", tt.cmd.Code, resp.Code, resp.Version)
+			}
+		})
+	}
+}
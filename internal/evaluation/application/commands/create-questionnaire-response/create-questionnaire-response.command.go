@@ -0,0 +1,8 @@
+package createquestionnaireresponse
+
+type CreateQuestionnaireResponseCommand struct {
+	EvaluationID string         `json:"evaluation_id"`
+	Code         string         `json:"code"`
+	Version      int            `json:"version"` // 0 = versión vigente
+	Answers      map[string]int `json:"answers"` // id de ítem -> valor de la opción; los ausentes cuentan como perdidos
+}
@@ -0,0 +1,51 @@
+package createreactiontimesubtest
+
+import (
+	"context"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+)
+
+func CreateReactionTimeSubtestCommandHandler(ctx context.Context, cmd CreateReactionTimeSubtestCommand, evaluationRepo domain.EvaluationsRepository, reactionTimeRepo RTdomain.ReactionTimeRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*RTdomain.ReactionTimeSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", RTdomain.ErrInvalidReactionTime)
+	}
+	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtest, err := RTdomain.NewReactionTimeSubtest(cmd.EvaluationID, cmd.Trials)
+	if err != nil {
+		return nil, err
+	}
+
+	// La covariable motora se compara con normas por edad
+	score, err := RTdomain.ScoreReactionTime(*subtest, evaluation.PatientAge)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = reactionTimeRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
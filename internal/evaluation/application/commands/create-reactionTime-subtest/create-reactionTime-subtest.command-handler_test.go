@@ -0,0 +1,121 @@
+package createreactiontimesubtest
+
+import (
+	"context"
+	"testing"
+
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestCreateReactionTimeSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateReactionTimeSubtestCommand{
+		EvaluationID: "eval-123",
+		Trials: []RTdomain.RTTrial{
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 1_000, ResponseAtMs: 1_400},
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 3_000, ResponseAtMs: 3_420},
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 5_000, ResponseAtMs: 5_440},
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 7_000, ResponseAtMs: 7_060},   // anticipación
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 9_000},                        // sin respuesta
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 11_000, ResponseAtMs: 12_200}, // lapso
+			{Condition: RTdomain.RTChoice, Stimulus: "left", Response: "left", StimulusAtMs: 20_000, ResponseAtMs: 20_600},
+			{Condition: RTdomain.RTChoice, Stimulus: "right", Response: "right", StimulusAtMs: 22_000, ResponseAtMs: 22_650},
+			{Condition: RTdomain.RTChoice, Stimulus: "left", Response: "right", StimulusAtMs: 24_000, ResponseAtMs: 24_700},
+			{Condition: RTdomain.RTChoice, Stimulus: "right", Response: "right", StimulusAtMs: 26_000, ResponseAtMs: 26_620},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateReactionTimeSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateReactionTimeSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown condition",
+			cmd: func() CreateReactionTimeSubtestCommand {
+				c := valid
+				c.Trials = []RTdomain.RTTrial{{Condition: "go", StimulusAtMs: 0, ResponseAtMs: 300}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - response before stimulus",
+			cmd: func() CreateReactionTimeSubtestCommand {
+				c := valid
+				c.Trials = []RTdomain.RTTrial{{Condition: RTdomain.RTSimple, StimulusAtMs: 1_000, ResponseAtMs: 900}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - choice trial without stimulus side",
+			cmd: func() CreateReactionTimeSubtestCommand {
+				c := valid
+				c.Trials = []RTdomain.RTTrial{{Condition: RTdomain.RTChoice, StimulusAtMs: 1_000, ResponseAtMs: 1_500}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateReactionTimeSubtestCommandHandler(
+				context.TODO(),
+				tt.cmd,
+				app.Repositories.EvaluationsRepository,
+				app.Repositories.ReactionTimeRepository,
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
+			)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				s := res.Score.Simple
+				if s.Valid != 3 || s.MedianMs != 420 || s.Anticipations != 1 || s.Lapses != 2 {
+					t.Errorf("unexpected simple score: %+v", s)
+				}
+				c := res.Score.Choice
+				if c.Valid != 3 || c.MedianMs != 620 || c.Errors != 1 {
+					t.Errorf("unexpected choice score: %+v", c)
+				}
+				m := res.Score.MotorSpeed
+				// paciente mock de 65 años: norma 320 ± 55 ms
+				if !m.Available || !m.Slowed || m.DecisionTimeMs != 200 {
+					t.Errorf("unexpected motor speed covariate: %+v", m)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
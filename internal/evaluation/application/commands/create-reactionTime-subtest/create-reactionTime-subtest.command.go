@@ -0,0 +1,8 @@
+package createreactiontimesubtest
+
+import RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+
+type CreateReactionTimeSubtestCommand struct {
+	EvaluationID string             `json:"evaluation_id"`
+	Trials       []RTdomain.RTTrial `json:"trials"`
+}
@@ -0,0 +1,70 @@
+package createsdmtsubtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+)
+
+func CreateSDMTSubtestCommandHandler(ctx context.Context, cmd CreateSDMTSubtestCommand, evaluationRepo domain.EvaluationsRepository, sdmtRepo SDMTdomain.SDMTRepository, speechToText domain.SpeechToTextService, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*SDMTdomain.SDMTSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", SDMTdomain.ErrInvalidSDMT)
+	}
+	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := cmd.Responses
+	if cmd.Mode == SDMTdomain.SDMTOral && len(cmd.Audio) > 0 {
+		if speechToText == nil {
+			return nil, errors.New("speech-to-text service not configured")
+		}
+		transcript, err := speechToText.GetTextFromSpeech(cmd.Audio)
+		// limpiar buffer (no persistimos)
+		for i := range cmd.Audio {
+			cmd.Audio[i] = 0
+		}
+		if err != nil {
+			return nil, err
+		}
+		responses, err = SDMTdomain.ResponsesFromSpokenDigits(cmd.Expected, SDMTdomain.ParseSpokenDigits(transcript))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subtest, err := SDMTdomain.NewSDMTSubtest(cmd.EvaluationID, cmd.Mode, responses)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := SDMTdomain.ScoreSDMT(*subtest, evaluation.PatientAge)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = sdmtRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
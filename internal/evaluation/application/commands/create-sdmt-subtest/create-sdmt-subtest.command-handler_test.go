@@ -0,0 +1,144 @@
+package createsdmtsubtest
+
+import (
+	"context"
+	"testing"
+
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	"neuro.app.jordi/internal/pkg"
+)
+
+type transcriptSTT struct{ text string }
+
+func (s transcriptSTT) GetTextFromSpeech(audio []byte) (string, error) {
+	return s.text, nil
+}
+
+func TestCreateSDMTSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateSDMTSubtestCommand{
+		EvaluationID: "eval-123",
+		Mode:         SDMTdomain.SDMTWritten,
+		Responses: []SDMTdomain.SDMTResponse{
+			{Expected: 3, Given: 3, ElapsedMs: 2_000},
+			{Expected: 7, Given: 7, ElapsedMs: 25_000},
+			{Expected: 1, Given: 4, ElapsedMs: 40_000},
+			{Expected: 9, Given: 9, ElapsedMs: 75_000},
+			// fuera de los 90 s, no cuenta
+			{Expected: 2, Given: 2, ElapsedMs: 95_000},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		cmd           CreateSDMTSubtestCommand
+		stt           transcriptSTT
+		shouldPass    bool
+		wantCorrect   int
+		wantErrors    int
+		wantIntervals bool
+	}{
+		{
+			name:          "Valid written command",
+			cmd:           valid,
+			shouldPass:    true,
+			wantCorrect:   3,
+			wantErrors:    1,
+			wantIntervals: true,
+		},
+		{
+			name: "Valid oral command transcribed from audio",
+			cmd: CreateSDMTSubtestCommand{
+				EvaluationID: "eval-123",
+				Mode:         SDMTdomain.SDMTOral,
+				Expected:     []int{3, 7, 1, 9},
+				Audio:        []byte("fake-audio"),
+			},
+			stt:         transcriptSTT{text: "tres, siete... 4 nueve"},
+			shouldPass:  true,
+			wantCorrect: 3,
+			wantErrors:  1,
+		},
+		{
+			name: "Invalid - oral audio without recognisable digits",
+			cmd: CreateSDMTSubtestCommand{
+				EvaluationID: "eval-123",
+				Mode:         SDMTdomain.SDMTOral,
+				Expected:     []int{3, 7},
+				Audio:        []byte("fake-audio"),
+			},
+			stt:        transcriptSTT{text: "no me acuerdo"},
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateSDMTSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - unknown mode",
+			cmd: func() CreateSDMTSubtestCommand {
+				c := valid
+				c.Mode = "typed"
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - digit out of range",
+			cmd: func() CreateSDMTSubtestCommand {
+				c := valid
+				c.Responses = []SDMTdomain.SDMTResponse{{Expected: 3, Given: 0, ElapsedMs: 1_000}}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateSDMTSubtestCommandHandler(
+				context.TODO(),
+				tt.cmd,
+				app.Repositories.EvaluationsRepository,
+				app.Repositories.SDMTRepository,
+				tt.stt,
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
+			)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				if res.Score.Correct != tt.wantCorrect || res.Score.Errors != tt.wantErrors {
+					t.Errorf("expected %d correct / %d errors, got %+v", tt.wantCorrect, tt.wantErrors, res.Score)
+				}
+				if tt.wantIntervals {
+					if len(res.Score.Intervals) != 3 || res.Score.Intervals[0].Correct != 2 || res.Score.Intervals[1].Attempted != 1 {
+						t.Errorf("unexpected 30 s intervals: %+v", res.Score.Intervals)
+					}
+				} else if len(res.Score.Intervals) != 0 {
+					t.Errorf("expected no intervals without timestamps, got %+v", res.Score.Intervals)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package createsdmtsubtest
+
+import SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+
+type CreateSDMTSubtestCommand struct {
+	EvaluationID string                    `json:"evaluation_id"`
+	Mode         SDMTdomain.SDMTMode       `json:"mode"`
+	Responses    []SDMTdomain.SDMTResponse `json:"responses"`
+	// Modo oral: clave esperada en orden y audio a transcribir (no se persiste)
+	Expected []int  `json:"expected"`
+	Audio    []byte `json:"-"`
+}
@@ -0,0 +1,40 @@
+package createspeechprofile
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+)
+
+func CreateSpeechProfileCommandHandler(ctx context.Context, cmd CreateSpeechProfileCommand, audioDecoder domain.AudioDecoder, speechProfileRepo SPdomain.SpeechProfileRepository) (*SPdomain.SpeechProfile, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if len(cmd.Audio) == 0 {
+		return nil, errors.New("audio is required")
+	}
+
+	pcm, err := audioDecoder.Decode(cmd.Audio)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := SPdomain.AnalyzeSpeech(pcm.Samples, pcm.SampleRate)
+	// limpiar muestras decodificadas (no persistimos audio)
+	for i := range pcm.Samples {
+		pcm.Samples[i] = 0
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := SPdomain.NewSpeechProfile(cmd.EvaluationID, cmd.Source, metrics)
+	if err != nil {
+		return nil, err
+	}
+	if err = speechProfileRepo.Save(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,161 @@
+package createspeechprofile
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	"neuro.app.jordi/internal/pkg"
+)
+
+const sampleRate = 16000
+
+// synthSpeech genera 4 grupos de 5 "sílabas" sonoras (180 ms + 70 ms de silencio)
+// separados por pausas de 600 ms. f0 devuelve la F0 de cada sílaba.
+func synthSpeech(amplitude float64, f0 func(syllable int) float64) []float64 {
+	silence := func(sec float64) []float64 { return make([]float64, int(sec*sampleRate)) }
+	out := silence(0.3)
+	syllable := 0
+	for group := 0; group < 4; group++ {
+		for s := 0; s < 5; s++ {
+			n := int(0.18 * sampleRate)
+			f := f0(syllable)
+			for i := 0; i < n; i++ {
+				t := float64(i) / sampleRate
+				env := 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(n-1)))
+				v := 0.0
+				for h := 1; h <= 5; h++ {
+					v += math.Sin(2*math.Pi*f*float64(h)*t) / float64(h)
+				}
+				out = append(out, amplitude*env*v/2)
+			}
+			out = append(out, silence(0.07)...)
+			syllable++
+		}
+		out = append(out, silence(0.6)...)
+	}
+	return out
+}
+
+func wav16(samples []float64) []byte {
+	data := make([]byte, 2*len(samples))
+	for i, v := range samples {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(int16(math.Max(-1, math.Min(1, v))*32767)))
+	}
+	h := make([]byte, 44)
+	copy(h[0:], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:], uint32(36+len(data)))
+	copy(h[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(h[16:], 16)
+	binary.LittleEndian.PutUint16(h[20:], 1)
+	binary.LittleEndian.PutUint16(h[22:], 1)
+	binary.LittleEndian.PutUint32(h[24:], sampleRate)
+	binary.LittleEndian.PutUint32(h[28:], sampleRate*2)
+	binary.LittleEndian.PutUint16(h[32:], 2)
+	binary.LittleEndian.PutUint16(h[34:], 16)
+	copy(h[36:], "data")
+	binary.LittleEndian.PutUint32(h[40:], uint32(len(data)))
+	return append(h, data...)
+}
+
+func TestCreateSpeechProfileCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	expressive := wav16(synthSpeech(0.6, func(s int) float64 { return []float64{100, 160, 120, 180, 110}[s%5] }))
+	flatQuiet := wav16(synthSpeech(0.01, func(int) float64 { return 120 }))
+	// grabación WebM/Opus con la estructura de MediaRecorder (la del navegador de la tablet)
+	webmOpus, err := os.ReadFile("../../../infra/audio-decoder/testdata/mediarecorder-opus.webm")
+	if err != nil {
+		t.Fatalf("cannot read webm fixture: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		cmd            CreateSpeechProfileCommand
+		shouldPass     bool
+		wantPitchHz    float64
+		wantHypophonia bool
+		wantMonotone   bool
+		wantErr        error
+	}{
+		{
+			name:        "Valid - expressive speech",
+			cmd:         CreateSpeechProfileCommand{EvaluationID: "eval-123", Source: SPdomain.SourceLanguageFluency, Audio: expressive},
+			shouldPass:  true,
+			wantPitchHz: 134,
+		},
+		{
+			name:           "Valid - quiet monotone speech",
+			cmd:            CreateSpeechProfileCommand{EvaluationID: "eval-123", Source: SPdomain.SourceLanguageFluency, Audio: flatQuiet},
+			shouldPass:     true,
+			wantPitchHz:    120,
+			wantHypophonia: true,
+			wantMonotone:   true,
+		},
+		{
+			name:       "Invalid - missing evaluation id",
+			cmd:        CreateSpeechProfileCommand{Source: SPdomain.SourceLanguageFluency, Audio: expressive},
+			shouldPass: false,
+		},
+		{
+			name:       "Invalid - unsupported format",
+			cmd:        CreateSpeechProfileCommand{EvaluationID: "eval-123", Source: SPdomain.SourceLanguageFluency, Audio: []byte("OggS not really audio")},
+			shouldPass: false,
+			wantErr:    domain.ErrUnsupportedAudioFormat,
+		},
+		{
+			name:       "Invalid - MediaRecorder WebM/Opus is decoded but one second of silence is too short",
+			cmd:        CreateSpeechProfileCommand{EvaluationID: "eval-123", Source: SPdomain.SourceLanguageFluency, Audio: webmOpus},
+			shouldPass: false,
+			wantErr:    SPdomain.ErrInsufficientAudio,
+		},
+		{
+			name:       "Invalid - silence only",
+			cmd:        CreateSpeechProfileCommand{EvaluationID: "eval-123", Source: SPdomain.SourceLanguageFluency, Audio: wav16(make([]float64, 3*sampleRate))},
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateSpeechProfileCommandHandler(context.TODO(), tt.cmd, app.Services.AudioDecoder, app.Repositories.SpeechProfileRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil profile")
+				}
+				m := res.Metrics
+				if math.Abs(m.PitchMeanHz-tt.wantPitchHz) > 8 {
+					t.Errorf("unexpected mean pitch: %+v", m)
+				}
+				if m.Pauses != 3 || m.PauseRatio < 0.2 || m.PauseRatio > 0.4 {
+					t.Errorf("expected 3 pauses (~30%% of the span), got %+v", m)
+				}
+				if m.SyllableNuclei < 17 || m.SyllableNuclei > 23 || m.ArticulationRate < 3 || m.ArticulationRate > 5.5 {
+					t.Errorf("expected ~20 syllables at ~4/s, got %+v", m)
+				}
+				if m.Hypophonia != tt.wantHypophonia || m.Monotone != tt.wantMonotone {
+					t.Errorf("unexpected flags: %+v", m)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd.EvaluationID)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if res != nil {
+					t.Errorf("expected nil profile on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
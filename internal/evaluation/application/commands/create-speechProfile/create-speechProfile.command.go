@@ -0,0 +1,10 @@
+package createspeechprofile
+
+import SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+
+type CreateSpeechProfileCommand struct {
+	EvaluationID string          `json:"evaluation_id"`
+	Source       SPdomain.Source `json:"source"`
+	// Audio en bruto: solo vive en memoria durante el análisis, nunca se persiste
+	Audio []byte `json:"-"`
+}
@@ -0,0 +1,45 @@
+package createstroopsubtest
+
+import (
+	"context"
+	"fmt"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
+)
+
+func CreateStroopSubtestCommandHandler(ctx context.Context, cmd CreateStroopSubtestCommand, stroopRepo STRdomain.StroopRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*STRdomain.StroopSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, fmt.Errorf("%w: evaluation id is required", STRdomain.ErrInvalidStroop)
+	}
+
+	subtest, err := STRdomain.NewStroopSubtest(cmd.EvaluationID, cmd.Word, cmd.Color, cmd.ColorWord)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := STRdomain.ScoreStroop(*subtest)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score = score
+
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
+	if err = stroopRepo.Save(ctx, subtest); err != nil {
+		return nil, err
+	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.PK, flags); err != nil {
+		return nil, err
+	}
+	return subtest, nil
+}
@@ -0,0 +1,106 @@
+package createstroopsubtest
+
+import (
+	"context"
+	"testing"
+
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestCreateStroopSubtestCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := CreateStroopSubtestCommand{
+		EvaluationID: "eval-123",
+		Word:         STRdomain.StroopConditionResult{ItemsCompleted: 100, Errors: 1},
+		Color:        STRdomain.StroopConditionResult{ItemsCompleted: 75, Errors: 3, ItemTimesMs: []int{600, 700, 650}},
+		ColorWord:    STRdomain.StroopConditionResult{ItemsCompleted: 36, Errors: 4, SelfCorrections: 2},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        CreateStroopSubtestCommand
+		shouldPass bool
+	}{
+		{
+			name:       "Valid command",
+			cmd:        valid,
+			shouldPass: true,
+		},
+		{
+			name: "Invalid - missing evaluation id",
+			cmd: func() CreateStroopSubtestCommand {
+				c := valid
+				c.EvaluationID = ""
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - items above sheet size",
+			cmd: func() CreateStroopSubtestCommand {
+				c := valid
+				c.Word.ItemsCompleted = 120
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - more errors than items",
+			cmd: func() CreateStroopSubtestCommand {
+				c := valid
+				c.ColorWord.Errors = 40
+				return c
+			}(),
+			shouldPass: false,
+		},
+		{
+			name: "Invalid - negative response time",
+			cmd: func() CreateStroopSubtestCommand {
+				c := valid
+				c.Color.ItemTimesMs = []int{500, -1}
+				return c
+			}(),
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CreateStroopSubtestCommandHandler(context.TODO(), tt.cmd, app.Repositories.StroopRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res == nil {
+					t.Fatalf("expected non-nil subtest")
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				// PC' = 100·75 / 175 = 42.86 → I = 36 − 42.86
+				if res.Score.PredictedColorWord != 42.86 || res.Score.Interference != -6.86 {
+					t.Errorf("unexpected interference: %+v", res.Score)
+				}
+				if res.Score.ColorWordErrorRatio != 0.11 || res.Score.InterferenceErrorRatio != 0.07 {
+					t.Errorf("unexpected error ratios: %+v", res.Score)
+				}
+				if res.Score.ColorMedianMs != 650 || res.Score.SelfCorrections != 2 {
+					t.Errorf("unexpected median/self-corrections: %+v", res.Score)
+				}
+				if res.Score.Score == 0 {
+					t.Errorf("expected non-zero score to be calculated, got 0")
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil subtest on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
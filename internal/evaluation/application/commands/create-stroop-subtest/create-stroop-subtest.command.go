@@ -0,0 +1,10 @@
+package createstroopsubtest
+
+import STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
+
+type CreateStroopSubtestCommand struct {
+	EvaluationID string                          `json:"evaluation_id"`
+	Word         STRdomain.StroopConditionResult `json:"word"`
+	Color        STRdomain.StroopConditionResult `json:"color"`
+	ColorWord    STRdomain.StroopConditionResult `json:"color_word"`
+}
@@ -4,26 +4,40 @@ import (
 	"context"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
 )
 
-func CreateVerbalMemorySubtestCommandhandler(ctx context.Context, command CreateVerbalMemorySubtestCommand, evaluationRepository domain.EvaluationsRepository, llmService domain.LLMService, verbalMemorySubtestRepo VEMdomain.VerbalMemoryRepository) (VEMdomain.VerbalMemorySubtest, error) {
+func CreateVerbalMemorySubtestCommandhandler(ctx context.Context, command CreateVerbalMemorySubtestCommand, evaluationRepository domain.EvaluationsRepository, llmService domain.LLMService, verbalMemorySubtestRepo VEMdomain.VerbalMemoryRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (VEMdomain.VerbalMemorySubtest, error) {
 
 	verbalSubtest, err := VEMdomain.NewVerbalMemorySubtest(command.EvaluationID, command.StartAt, command.GivenWords, command.RecalledWords, command.Subtype)
 	if err != nil {
 		return VEMdomain.VerbalMemorySubtest{}, err
 	}
 
-	score, err := VEMdomain.ScoreVerbalMemory(verbalSubtest)
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, command.EvaluationID)
 	if err != nil {
 		return VEMdomain.VerbalMemorySubtest{}, err
 	}
+	score, err := VEMdomain.ScoreVerbalMemory(verbalSubtest, &profile.VerbalMemory)
+	if err != nil {
+		return VEMdomain.VerbalMemorySubtest{}, err
+	}
+	score.ScoringProfile = profile.Ref()
 	verbalSubtest.Score = score
 
+	flags := verbalSubtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return VEMdomain.VerbalMemorySubtest{}, err
+	}
 	err = verbalMemorySubtestRepo.Save(ctx, verbalSubtest)
 	if err != nil {
 		return VEMdomain.VerbalMemorySubtest{}, err
 	}
+	if err = qualityRepo.Replace(ctx, command.EvaluationID, verbalSubtest.Pk, flags); err != nil {
+		return VEMdomain.VerbalMemorySubtest{}, err
+	}
 
 	return verbalSubtest, nil
 }
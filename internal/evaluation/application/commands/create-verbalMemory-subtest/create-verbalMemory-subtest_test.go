@@ -2,9 +2,13 @@ package createverbalmemorysubtest
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	DQinfra "neuro.app.jordi/internal/evaluation/infra/data-quality"
 	"neuro.app.jordi/internal/pkg"
 )
 
@@ -22,11 +26,47 @@ func TestCreateVerbalMemorySubtestCommandhandler(t *testing.T) {
 		name       string
 		cmd        CreateVerbalMemorySubtestCommand
 		shouldPass bool
+		corrupt    bool
+		wantFlags  []DQdomain.Severity
 	}{
 		{
 			name:       "Valid command",
 			cmd:        valid,
 			shouldPass: true,
+			wantFlags:  []DQdomain.Severity{},
+		},
+		{
+			name: "Valid - all words recalled is stored as an info flag",
+			cmd: func() CreateVerbalMemorySubtestCommand {
+				c := valid
+				c.RecalledWords = []string{"casa", "perro", "mar", "luz", "flor"}
+				return c
+			}(),
+			shouldPass: true,
+			wantFlags:  []DQdomain.Severity{DQdomain.SeverityInfo},
+		},
+		{
+			name: "Valid - more recalled than given words is stored as a warning",
+			cmd: func() CreateVerbalMemorySubtestCommand {
+				c := valid
+				c.RecalledWords = []string{"casa", "mar", "flor", "sol", "pan", "tren"}
+				return c
+			}(),
+			shouldPass: true,
+			wantFlags:  []DQdomain.Severity{DQdomain.SeverityWarning},
+		},
+		{
+			name: "Invalid - more than three times as many recalled as given words",
+			cmd: func() CreateVerbalMemorySubtestCommand {
+				c := valid
+				c.RecalledWords = nil
+				for i := 0; i < 4; i++ {
+					c.RecalledWords = append(c.RecalledWords, valid.GivenWords...)
+				}
+				return c
+			}(),
+			shouldPass: false,
+			corrupt:    true,
 		},
 		{
 			name: "Invalid - missing evaluation id",
@@ -75,6 +115,9 @@ func TestCreateVerbalMemorySubtestCommandhandler(t *testing.T) {
 				app.Repositories.EvaluationsRepository,
 				app.Services.LLMService,
 				app.Repositories.VerbalMemorySubtestRepository,
+				app.Repositories.ScoringProfileCatalog,
+				app.Repositories.ScoringSelectionRepository,
+				app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -85,6 +128,9 @@ func TestCreateVerbalMemorySubtestCommandhandler(t *testing.T) {
 				if res.EvaluationID != tt.cmd.EvaluationID {
 					t.Errorf("expected EvaluationID=%q, got %q", tt.cmd.EvaluationID, res.EvaluationID)
 				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
 				if len(res.GivenWords) != len(tt.cmd.GivenWords) {
 					t.Errorf("expected %d given words, got %d", len(tt.cmd.GivenWords), len(res.GivenWords))
 				}
@@ -92,10 +138,24 @@ func TestCreateVerbalMemorySubtestCommandhandler(t *testing.T) {
 				if res.Score.Score == 0 {
 					t.Errorf("expected non-zero score to be calculated, got 0")
 				}
+				flags, ok := app.Repositories.DataQualityRepository.(*DQinfra.MockDataQualityRepository).Get(tt.cmd.EvaluationID, res.Pk)
+				if !ok {
+					t.Fatalf("expected quality flags to be stored for %s", res.Pk)
+				}
+				severities := []DQdomain.Severity{}
+				for _, f := range flags {
+					severities = append(severities, f.Severity)
+				}
+				if !reflect.DeepEqual(severities, tt.wantFlags) {
+					t.Errorf("expected stored flags %v, got %+v", tt.wantFlags, flags)
+				}
 			} else {
 				if err == nil {
 					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
 				}
+				if tt.corrupt && !errors.Is(err, DQdomain.ErrCorruptSubmission) {
+					t.Errorf("expected ErrCorruptSubmission, got %v", err)
+				}
 			}
 		})
 	}
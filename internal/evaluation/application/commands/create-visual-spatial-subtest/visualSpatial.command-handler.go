@@ -2,22 +2,37 @@ package createvisualspatialsubtest
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
 )
 
-func CreateViusualSpatialCommandHandler(ctx context.Context, cmd CreateVisualSpatialSubtestCommand, repo VPdomain.ResultRepository) (*VPdomain.VisualSpatialSubtest, error) {
+func CreateViusualSpatialCommandHandler(ctx context.Context, cmd CreateVisualSpatialSubtestCommand, repo VPdomain.ResultRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*VPdomain.VisualSpatialSubtest, error) {
 	if cmd.EvaluationID == "" {
-		return nil, errors.New("evaluation ID is required")
+		return nil, fmt.Errorf("%w: evaluation ID is required", VPdomain.ErrInvalidVisualSpatial)
 	}
 	subtest, err := VPdomain.NewVisualSpatialSubtest(cmd.EvaluationID, cmd.Note, cmd.Score)
 	if err != nil {
 		return nil, err
 	}
 
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	subtest.Score.ScoringProfile = profile.Ref()
+
+	flags := subtest.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
 	if err = repo.Save(ctx, subtest); err != nil {
 		return nil, err
 	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, subtest.Id, flags); err != nil {
+		return nil, err
+	}
 	return subtest, nil
 }
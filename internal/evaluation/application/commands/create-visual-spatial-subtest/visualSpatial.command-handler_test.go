@@ -61,6 +61,7 @@ func TestCreateViusualSpatialCommandHandler(t *testing.T) {
 				context.TODO(),
 				tt.cmd,
 				app.Repositories.VisualSpatialRepository, // ajusta el nombre si tu MockApp expone otro
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -70,6 +71,9 @@ func TestCreateViusualSpatialCommandHandler(t *testing.T) {
 				if res == nil {
 					t.Fatalf("expected non-nil subtest")
 				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
 				// Chequeos básicos
 				if res.EvalautionId != tt.cmd.EvaluationID {
 					t.Errorf("expected EvaluationID=%q, got %q", tt.cmd.EvaluationID, res.EvalautionId)
@@ -78,7 +82,7 @@ func TestCreateViusualSpatialCommandHandler(t *testing.T) {
 					t.Errorf("expected Note=%q, got %q", tt.cmd.Note, res.Note)
 				}
 				if res.Score.Val != tt.cmd.Score {
-					t.Errorf("expected Score=%d, got %d", tt.cmd.Score, res.Score)
+					t.Errorf("expected Score=%d, got %d", tt.cmd.Score, res.Score.Val)
 				}
 			} else {
 				if err == nil {
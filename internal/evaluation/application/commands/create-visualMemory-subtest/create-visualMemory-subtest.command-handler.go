@@ -3,16 +3,31 @@ package createvisualmemorysubtest
 import (
 	"context"
 
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
 	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
 )
 
-func CreateVisualMemoryCommandHandler(ctx context.Context, cmd CreateVisualMemorySubtestCommand, repo VIMdomain.VisualMemoryRepository) (*VIMdomain.VisualMemorySubtest, error) {
+func CreateVisualMemoryCommandHandler(ctx context.Context, cmd CreateVisualMemorySubtestCommand, repo VIMdomain.VisualMemoryRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository, qualityRepo DQdomain.DataQualityRepository) (*VIMdomain.VisualMemorySubtest, error) {
 	sub, err := VIMdomain.NewVisualMemorySubtest(cmd.EvaluationID, nil, cmd.Score, cmd.Note)
 	if err != nil {
 		return nil, err
 	}
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	sub.Score.ScoringProfile = profile.Ref()
+
+	flags := sub.QualityFlags()
+	if err := DQdomain.Blocking(flags); err != nil {
+		return nil, err
+	}
 	if err := repo.Save(ctx, &sub); err != nil {
 		return nil, err
 	}
+	if err = qualityRepo.Replace(ctx, cmd.EvaluationID, sub.PK, flags); err != nil {
+		return nil, err
+	}
 	return &sub, nil
 }
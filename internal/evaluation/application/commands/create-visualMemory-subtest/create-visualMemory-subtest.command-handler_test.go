@@ -61,6 +61,7 @@ func TestCreateVisualMemoryCommandHandler(t *testing.T) {
 				context.TODO(),
 				tt.cmd,
 				app.Repositories.VisualMemorySubtestRepository,
+				app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, app.Repositories.DataQualityRepository,
 			)
 
 			if tt.shouldPass {
@@ -70,6 +71,9 @@ func TestCreateVisualMemoryCommandHandler(t *testing.T) {
 				if res == nil {
 					t.Fatalf("expected non-nil subtest")
 				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
 				// Chequeos básicos de consistencia
 				if res.EvaluationID != tt.cmd.EvaluationID {
 					t.Errorf("expected EvaluationID=%q, got %q", tt.cmd.EvaluationID, res.EvaluationID)
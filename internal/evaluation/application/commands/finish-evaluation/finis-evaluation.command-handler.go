@@ -8,13 +8,9 @@ import (
 	"neuro.app.jordi/internal/evaluation/application/services"
 	"neuro.app.jordi/internal/evaluation/domain"
 	reports "neuro.app.jordi/internal/evaluation/domain/services"
-	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
-	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
-	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
-	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
-	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
-	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
 	fileformatter "neuro.app.jordi/internal/shared/file-formatter"
+	logging "neuro.app.jordi/internal/shared/logger"
 	"neuro.app.jordi/internal/shared/mail"
 )
 
@@ -22,27 +18,40 @@ func FinisEvaluationCommanndHandler(
 	ctx context.Context, command FinisEvaluationCommannd,
 	evaluationRepository domain.EvaluationsRepository, llmService domain.LLMService,
 	fileFormatterService fileformatter.FileFormaterService,
-	evaluationPublisher reports.Publisher, verbalMemoryRepository VEMdomain.VerbalMemoryRepository,
-	visualMemoryRepository VIMdomain.VisualMemoryRepository, executiveFunctionsRepository EFdomain.ExecutiveFunctionsSubtestRepository,
-	letterCancellationRepository LCdomain.LetterCancellationRepository, languageFluencyRepository LFdomain.LanguageFluencyRepository, visualSpatialRepository VPdomain.ResultRepository, mailService mail.MailProvider) (domain.Evaluation, error) {
+	evaluationPublisher reports.Publisher, subtestRepos services.SubtestRepositories, commentaryRepository STCdomain.CommentaryRepository, mailService mail.MailProvider, logger logging.Logger) (domain.Evaluation, error) {
 	if command.EvaluationID == "" {
 		return domain.Evaluation{}, errors.New("evaluation ID is required")
 	}
+	// El modelo pedido se valida antes de cualquier llamada al proveedor
+	if checker, ok := llmService.(domain.ModelChecker); ok {
+		if err := checker.CheckModel(command.Model); err != nil {
+			return domain.Evaluation{}, err
+		}
+	}
 	evaluation, err := evaluationRepository.GetByID(ctx, command.EvaluationID)
 	if err != nil {
 		return domain.Evaluation{}, err
 	}
 
-	err = services.PopulateEvaluationWithSubtests(ctx, &evaluation, verbalMemoryRepository, visualMemoryRepository, executiveFunctionsRepository, letterCancellationRepository, languageFluencyRepository, visualSpatialRepository)
+	err = services.PopulateEvaluationWithSubtests(ctx, &evaluation, subtestRepos)
 	if err != nil {
 		return domain.Evaluation{}, err
 	}
 
-	res, err := llmService.GenerateAnalysis(evaluation)
+	opts := domain.LLMOptions{Model: command.Model, Locale: command.Locale}
+	// Primero el comentario de cada subtest; el análisis global se compone a partir de ellos
+	if err = services.CommentSubtests(ctx, &evaluation, llmService, commentaryRepository, opts, command.RegenerateCommentary, logger); err != nil {
+		return domain.Evaluation{}, err
+	}
+
+	res, err := llmService.GenerateAnalysis(ctx, evaluation, opts)
 	if err != nil {
 		return domain.Evaluation{}, err
 	}
-	evaluation.AssistantAnalysis = res
+	evaluation.AssistantAnalysis = res.Text
+	evaluation.AnalysisUsage = res.Usage
+	evaluation.StructuredAnalysis = res.Analysis
+	evaluation.NeuroProfileCheck = evaluation.AnalysisProfileCheck()
 	evaluation.CurrentStatus = domain.EvaluationCurrentStatusCompleted
 
 	if err = evaluationRepository.Update(ctx, evaluation); err != nil {
@@ -2,11 +2,20 @@ package finishevaluation
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	openai "github.com/sashabaranov/go-openai"
 	"neuro.app.jordi/internal/evaluation/domain"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
 	reports "neuro.app.jordi/internal/evaluation/domain/services"
+	STCinfra "neuro.app.jordi/internal/evaluation/infra/subtest-commentary"
+	services "neuro.app.jordi/internal/evaluation/services/openAI"
 	"neuro.app.jordi/internal/pkg"
+	"neuro.app.jordi/internal/pkg/llmtest"
 	fileformatter "neuro.app.jordi/internal/shared/file-formatter"
 )
 
@@ -17,12 +26,50 @@ func TestFinisEvaluationCommanndHandler(t *testing.T) {
 		EvaluationID: "eval-123", // usa un ID que tu mock resuelva con GetByID
 	}
 
+	// Servidores compatibles con OpenAI de guion fijo, sin red
+	analysis := `{"title":"Rendimiento cognitivo preservado","profile":"normal","justification":"MoCA y subtests dentro de la norma.",` +
+		`"findings":[{"domain":"memory","severity":"preserved","subtests":["verbal_memory"],"finding":"Recuerdo inmediato y diferido conservados."}],` +
+		`"summary":"Sin alteraciones cognitivas relevantes.","recommendations":["Reevaluar en 12 meses."],"uncertainty":"low"}`
+	local := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Content: analysis})
+	defer local.Close()
+	// Primero Markdown libre (no cumple el esquema) y, tras la corrección, el JSON válido
+	retrying := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Content: "**Perfil predominante**\nFuncionamiento normal"}, llmtest.ScriptedReply{Content: analysis})
+	defer retrying.Close()
+	malformed := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Content: `{"profile":"parkinsonian","justification":"","uncertainty":"low"}`})
+	defer malformed.Close()
+	slow := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Content: "tarde", Delay: time.Second})
+	defer slow.Close()
+	slowCfg := slow.Config()
+	slowCfg.Timeout = 50 * time.Millisecond
+	failing := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Status: http.StatusServiceUnavailable})
+	defer failing.Close()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Responde en texto a los comentarios por subtest y en JSON al análisis global
+	commenting := llmtest.NewScriptedLLMServer(llmtest.ScriptedReply{Content: "Rendimiento dentro de la norma para la edad.", JSONContent: analysis})
+	defer commenting.Close()
+	commentary := app.Repositories.SubtestCommentaryRepository.(*STCinfra.MockSubtestCommentaryRepository)
+	// Solo los modelos de LLM_MODEL y LLM_ALLOWED_MODELS se pueden pedir
+	allowing := local.Config()
+	allowing.AllowedModels = []string{"llama3.1:70b"}
+
 	tests := []struct {
 		name         string
 		cmd          FinisEvaluationCommannd
 		shouldPass   bool
 		expectStatus domain.EvaluationCurrentStatus
 		expectHasLLM bool
+		llm          domain.LLMService // nil = app.Services.LLMService
+		ctx          context.Context   // nil = context.TODO()
+		expectAgrees bool
+		expectUsage  domain.LLMUsage // LatencyMs no se compara
+		// expectCommentary: todas las filas de subtest acaban con este comentario, guardado en su columna
+		expectCommentary string
+		expectInAnalysis string
+		// expectProfile: perfil del análisis estructurado; expectAttempts: llamadas hasta obtener JSON válido
+		expectProfile  NPdomain.Profile
+		expectAttempts int
+		wantErr        error
 	}{
 		{
 			name:         "Valid - completes evaluation and sets assistant analysis",
@@ -31,6 +78,142 @@ func TestFinisEvaluationCommanndHandler(t *testing.T) {
 			expectStatus: domain.EvaluationCurrentStatusCompleted,
 			expectHasLLM: true,
 		},
+		{
+			name:         "Valid - LLM unavailable falls back to rule-based analysis",
+			cmd:          valid,
+			shouldPass:   true,
+			expectStatus: domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM: true,
+			llm:          services.NewFallbackService(services.OpenAIService{}, services.NewRuleBasedService(), app.Logger),
+			expectAgrees: true,
+			expectUsage:  domain.LLMUsage{Provider: services.ProviderRules, Model: "np-rules@1"},
+			// Los comentarios ya guardados en los subtests entran en el informe por reglas
+			expectInAnalysis: "**Interpretación por subtest**",
+		},
+		{
+			name:             "Valid - rule-based fallback follows the report locale",
+			cmd:              FinisEvaluationCommannd{EvaluationID: "eval-123", Locale: "en"},
+			shouldPass:       true,
+			expectStatus:     domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM:     true,
+			llm:              services.NewFallbackService(services.OpenAIService{}, services.NewRuleBasedService(), app.Logger),
+			expectAgrees:     true,
+			expectUsage:      domain.LLMUsage{Provider: services.ProviderRules, Model: "np-rules@1"},
+			expectInAnalysis: "**Automatic rule-based analysis**",
+		},
+		{
+			name:         "Valid - OpenAI-compatible local server records provider and model",
+			cmd:          valid,
+			shouldPass:   true,
+			expectStatus: domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM: true,
+			llm:          services.NewOpenAICompatibleService(local.Config(), app.Repositories.PromptTemplateRepository),
+			expectUsage:  domain.LLMUsage{Provider: "scripted", Model: "scripted-model", PromptVersion: "clinical-analysis/es@3"},
+			// El Markdown del informe se genera desde el JSON
+			expectInAnalysis: "**Perfil predominante**: Funcionamiento normal",
+			expectProfile:    NPdomain.ProfileNormal,
+			expectAttempts:   1,
+		},
+		{
+			name:             "Valid - report locale selects the prompt template",
+			cmd:              FinisEvaluationCommannd{EvaluationID: "eval-123", Locale: "en"},
+			shouldPass:       true,
+			expectStatus:     domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM:     true,
+			llm:              services.NewOpenAICompatibleService(local.Config(), app.Repositories.PromptTemplateRepository),
+			expectUsage:      domain.LLMUsage{Provider: "scripted", Model: "scripted-model", PromptVersion: "clinical-analysis/en@3"},
+			expectInAnalysis: "**Predominant profile**: Normal functioning",
+			expectProfile:    NPdomain.ProfileNormal,
+		},
+		{
+			name:         "Valid - model from the command overrides the configured one",
+			cmd:          FinisEvaluationCommannd{EvaluationID: "eval-123", Model: "llama3.1:70b"},
+			shouldPass:   true,
+			expectStatus: domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM: true,
+			llm:          services.NewOpenAICompatibleService(allowing, app.Repositories.PromptTemplateRepository),
+			expectUsage:  domain.LLMUsage{Provider: "scripted", Model: "llama3.1:70b"},
+		},
+		{
+			name:       "Invalid - model outside the allowlist",
+			cmd:        FinisEvaluationCommannd{EvaluationID: "eval-123", Model: "gpt-4.5-preview"},
+			shouldPass: false,
+			llm:        services.NewOpenAICompatibleService(allowing, app.Repositories.PromptTemplateRepository),
+			wantErr:    domain.ErrModelNotAllowed,
+		},
+		{
+			name:       "Invalid - model outside the allowlist is not hidden by the fallback",
+			cmd:        FinisEvaluationCommannd{EvaluationID: "eval-123", Model: "gpt-4.5-preview"},
+			shouldPass: false,
+			llm:        services.NewFallbackService(services.NewOpenAICompatibleService(local.Config(), app.Repositories.PromptTemplateRepository), services.NewRuleBasedService(), app.Logger),
+			wantErr:    domain.ErrModelNotAllowed,
+		},
+		{
+			name:         "Valid - provider timeout falls back to rule-based analysis",
+			cmd:          valid,
+			shouldPass:   true,
+			expectStatus: domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM: true,
+			llm:          services.NewFallbackService(services.NewOpenAICompatibleService(slowCfg, app.Repositories.PromptTemplateRepository), services.NewRuleBasedService(), app.Logger),
+			expectUsage:  domain.LLMUsage{Provider: services.ProviderRules, Model: "np-rules@1"},
+		},
+		{
+			name:             "Valid - regenerates per-subtest commentary and composes the analysis from it",
+			cmd:              FinisEvaluationCommannd{EvaluationID: "eval-123", RegenerateCommentary: true},
+			shouldPass:       true,
+			expectStatus:     domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM:     true,
+			llm:              services.NewOpenAICompatibleService(commenting.Config(), app.Repositories.PromptTemplateRepository),
+			expectUsage:      domain.LLMUsage{Provider: "scripted", Model: "scripted-model", PromptVersion: "clinical-analysis/es@3"},
+			expectCommentary: "Rendimiento dentro de la norma para la edad.",
+		},
+		{
+			name:             "Valid - malformed output is retried with the validation errors",
+			cmd:              valid,
+			shouldPass:       true,
+			expectStatus:     domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM:     true,
+			llm:              services.NewOpenAICompatibleService(retrying.Config(), app.Repositories.PromptTemplateRepository),
+			expectUsage:      domain.LLMUsage{Provider: "scripted", Model: "scripted-model", PromptVersion: "clinical-analysis/es@3"},
+			expectInAnalysis: "### Hallazgos por dominio",
+			expectProfile:    NPdomain.ProfileNormal,
+			expectAttempts:   2,
+		},
+		{
+			name:         "Valid - output that never satisfies the schema falls back to rule-based analysis",
+			cmd:          valid,
+			shouldPass:   true,
+			expectStatus: domain.EvaluationCurrentStatusCompleted,
+			expectHasLLM: true,
+			llm:          services.NewFallbackService(services.NewOpenAICompatibleService(malformed.Config(), app.Repositories.PromptTemplateRepository), services.NewRuleBasedService(), app.Logger),
+			expectAgrees: true,
+			expectUsage:  domain.LLMUsage{Provider: services.ProviderRules, Model: "np-rules@1"},
+		},
+		{
+			name:       "Invalid - output that never satisfies the schema without fallback",
+			cmd:        valid,
+			shouldPass: false,
+			llm:        services.NewOpenAICompatibleService(malformed.Config(), app.Repositories.PromptTemplateRepository),
+		},
+		{
+			name:       "Invalid - provider error without fallback",
+			cmd:        valid,
+			shouldPass: false,
+			llm:        services.NewOpenAICompatibleService(failing.Config(), app.Repositories.PromptTemplateRepository),
+		},
+		{
+			name:       "Invalid - request cancelled before the provider answers",
+			cmd:        valid,
+			shouldPass: false,
+			llm:        services.NewOpenAICompatibleService(local.Config(), app.Repositories.PromptTemplateRepository),
+			ctx:        cancelled,
+		},
+		{
+			name:       "Invalid - unsupported report locale",
+			cmd:        FinisEvaluationCommannd{EvaluationID: "eval-123", Locale: "fr"},
+			shouldPass: false,
+			llm:        services.NewOpenAICompatibleService(local.Config(), app.Repositories.PromptTemplateRepository),
+		},
 		{
 			name:       "Invalid - missing evaluation id",
 			cmd:        FinisEvaluationCommannd{EvaluationID: ""},
@@ -40,20 +223,25 @@ func TestFinisEvaluationCommanndHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			llm := tt.llm
+			if llm == nil {
+				llm = app.Services.LLMService
+			}
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.TODO()
+			}
 			got, err := FinisEvaluationCommanndHandler(
-				context.TODO(),
+				ctx,
 				tt.cmd,
 				app.Repositories.EvaluationsRepository,
-				app.Services.LLMService,
+				llm,
 				fileformatter.MockFileFormatterService{}, // no se usa en el handler actual, pero mantenemos la firma
 				reports.Publisher{},                      // idem
-				app.Repositories.VerbalMemorySubtestRepository,
-				app.Repositories.VisualMemorySubtestRepository,
-				app.Repositories.ExecutiveFunctionsSubtestRepository,
-				app.Repositories.LetterCancellationRepository,
-				app.Repositories.LanguageFluencyRepository,
-				app.Repositories.VisualSpatialRepository,
+				app.Repositories.Subtests(),
+				app.Repositories.SubtestCommentaryRepository,
 				app.Services.MailService,
+				app.Logger,
 			)
 
 			if tt.shouldPass {
@@ -68,6 +256,70 @@ func TestFinisEvaluationCommanndHandler(t *testing.T) {
 				if tt.expectHasLLM && got.AssistantAnalysis == "" {
 					t.Errorf("expected non-empty AssistantAnalysis, got empty")
 				}
+				// El análisis por reglas debe coincidir con su propio perfil en el contraste
+				if tt.expectAgrees && !got.NeuroProfileCheck.Agrees {
+					t.Errorf("expected rule-based analysis to agree with rule profile, got %+v", got.NeuroProfileCheck)
+				}
+				// El perfil estructurado es el que se contrasta con las reglas
+				if got.StructuredAnalysis.Profile != got.NeuroProfileCheck.LLMProfile {
+					t.Errorf("expected cross-check on structured profile %q, got %+v", got.StructuredAnalysis.Profile, got.NeuroProfileCheck)
+				}
+				if tt.expectProfile != "" && got.StructuredAnalysis.Profile != tt.expectProfile {
+					t.Errorf("expected structured profile %q, got %q", tt.expectProfile, got.StructuredAnalysis.Profile)
+				}
+				if tt.expectAttempts != 0 && got.AnalysisUsage.Attempts != tt.expectAttempts {
+					t.Errorf("expected %d attempts, got %d", tt.expectAttempts, got.AnalysisUsage.Attempts)
+				}
+				// El reintento devuelve al modelo su respuesta y los errores de validación, en modo JSON
+				if tt.expectAttempts > 1 {
+					reqs := retrying.Requests()
+					if len(reqs) != tt.expectAttempts {
+						t.Fatalf("expected %d requests, got %d", tt.expectAttempts, len(reqs))
+					}
+					last := reqs[len(reqs)-1]
+					if last.ResponseFormat == nil || last.ResponseFormat.Type != openai.ChatCompletionResponseFormatTypeJSONObject {
+						t.Errorf("expected JSON response format, got %+v", last.ResponseFormat)
+					}
+					if n := len(last.Messages); n != 4 || !strings.Contains(last.Messages[n-1].Content, "invalid structured analysis") {
+						t.Errorf("expected previous answer and validation errors in the retry, got %d messages", n)
+					}
+				}
+				if tt.expectUsage.Provider != "" {
+					u := got.AnalysisUsage
+					if u.Provider != tt.expectUsage.Provider || u.Model != tt.expectUsage.Model {
+						t.Errorf("expected usage %s/%s, got %s/%s", tt.expectUsage.Provider, tt.expectUsage.Model, u.Provider, u.Model)
+					}
+					if tt.expectUsage.PromptVersion != "" && u.PromptVersion != tt.expectUsage.PromptVersion {
+						t.Errorf("expected prompt %s, got %s", tt.expectUsage.PromptVersion, u.PromptVersion)
+					}
+				}
+				if tt.expectInAnalysis != "" && !strings.Contains(got.AssistantAnalysis, tt.expectInAnalysis) {
+					t.Errorf("expected analysis to contain %q, got %q", tt.expectInAnalysis, got.AssistantAnalysis)
+				}
+				if tt.expectCommentary != "" {
+					targets := got.CommentaryTargets()
+					if len(targets) == 0 {
+						t.Fatalf("expected administered subtests to comment on")
+					}
+					for _, target := range targets {
+						saved, ok := commentary.Get(target.Subtest, target.SubtestID)
+						if target.Commentary != tt.expectCommentary || !ok || saved != tt.expectCommentary {
+							t.Errorf("%s %s: expected commentary %q, got %q (saved %q)", target.Subtest, target.SubtestID, tt.expectCommentary, target.Commentary, saved)
+						}
+					}
+					// Una petición por fila y la última, el análisis global, lleva los comentarios
+					reqs := commenting.Requests()
+					if len(reqs) != len(targets)+1 {
+						t.Fatalf("expected %d requests, got %d", len(targets)+1, len(reqs))
+					}
+					if !strings.Contains(reqs[0].Messages[1].Content, `"subtest":"`+targets[0].Subtest+`"`) {
+						t.Errorf("expected first request to comment on %s", targets[0].Subtest)
+					}
+					last := reqs[len(reqs)-1].Messages[1].Content
+					if !strings.Contains(last, "subtest_commentary") || !strings.Contains(last, tt.expectCommentary) {
+						t.Errorf("expected global analysis prompt to include the subtest commentary")
+					}
+				}
 				// Sanity: es la misma evaluación
 				if got.PK == "" {
 					t.Errorf("expected evaluation PK to be set, got empty")
@@ -76,6 +328,9 @@ func TestFinisEvaluationCommanndHandler(t *testing.T) {
 				if err == nil {
 					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
 				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
 			}
 		})
 	}
@@ -2,4 +2,8 @@ package finishevaluation
 
 type FinisEvaluationCommannd struct {
 	EvaluationID string `json:"evaluation_id"`
+	Model        string `json:"model"`  // opcional: uno de LLM_MODEL o LLM_ALLOWED_MODELS
+	Locale       string `json:"locale"` // es (por defecto), ca o en: idioma del prompt y del análisis
+	// RegenerateCommentary vuelve a pedir el comentario de los subtests que ya lo tienen
+	RegenerateCommentary bool `json:"regenerate_commentary"`
 }
@@ -0,0 +1,23 @@
+package publishprompttemplate
+
+import (
+	"context"
+
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+)
+
+// PublishPromptTemplateCommandHandler valida la plantilla contra el payload tipado y la
+// publica como versión siguiente; los análisis posteriores la usan y guardan su referencia
+func PublishPromptTemplateCommandHandler(ctx context.Context, cmd PublishPromptTemplateCommand, repo PTdomain.PromptTemplateRepository, validator PTdomain.Validator) (*PTdomain.PromptTemplate, error) {
+	t, err := PTdomain.NewPromptTemplate(cmd.ID, cmd.Locale, cmd.Body, cmd.Notes)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(t); err != nil {
+		return nil, err
+	}
+	if err := repo.Publish(ctx, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
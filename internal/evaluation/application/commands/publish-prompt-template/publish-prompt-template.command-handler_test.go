@@ -0,0 +1,97 @@
+package publishprompttemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	services "neuro.app.jordi/internal/evaluation/services/openAI"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestPublishPromptTemplateCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+	body := `{{define "system"}}Neuropsicólogo.{{end}}Analiza (MoCA {{.Summary.MoCA.Total}}): {{.Payload}} Responde en JSON: {{.Schema}}`
+
+	// Los casos comparten repositorio: el orden importa para comprobar la numeración
+	tests := []struct {
+		name        string
+		cmd         PublishPromptTemplateCommand
+		wantErr     error
+		wantVersion int
+	}{
+		{
+			name:        "Valid - publishes the next version after the packaged one",
+			cmd:         PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: body, Notes: "texto más breve"},
+			wantVersion: 4,
+		},
+		{
+			name:        "Valid - versions keep increasing",
+			cmd:         PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: body},
+			wantVersion: 5,
+		},
+		{
+			name:        "Valid - each locale is versioned separately",
+			cmd:         PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "ca", Body: body},
+			wantVersion: 4,
+		},
+		{
+			name:    "Invalid - placeholder not present in the payload",
+			cmd:     PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: "{{.Payload}} {{.Summary.PatientName}}"},
+			wantErr: PTdomain.ErrInvalidTemplate,
+		},
+		{
+			name:    "Invalid - template never sends the evaluation data",
+			cmd:     PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: "Analiza la evaluación."},
+			wantErr: PTdomain.ErrInvalidTemplate,
+		},
+		{
+			name:    "Invalid - analysis template never sends the output schema",
+			cmd:     PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: "Analiza: {{.Payload}}"},
+			wantErr: PTdomain.ErrInvalidTemplate,
+		},
+		{
+			name:    "Invalid - template syntax error",
+			cmd:     PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "es", Body: "{{.Payload"},
+			wantErr: PTdomain.ErrInvalidTemplate,
+		},
+		{
+			name:    "Invalid - unsupported locale",
+			cmd:     PublishPromptTemplateCommand{ID: services.AnalysisPromptID, Locale: "fr", Body: body},
+			wantErr: PTdomain.ErrUnsupportedLocale,
+		},
+		{
+			name:    "Invalid - malformed id",
+			cmd:     PublishPromptTemplateCommand{ID: "Clinical Analysis", Locale: "es", Body: body},
+			wantErr: PTdomain.ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PublishPromptTemplateCommandHandler(context.TODO(), tt.cmd, app.Repositories.PromptTemplateRepository, services.NewPromptRenderer())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Version != tt.wantVersion {
+				t.Errorf("expected version %d, got %d", tt.wantVersion, got.Version)
+			}
+			if got.Checksum != PTdomain.Checksum(tt.cmd.Body) {
+				t.Errorf("expected checksum of the body, got %s", got.Checksum)
+			}
+
+			// La versión publicada pasa a ser la vigente
+			current, err := app.Repositories.PromptTemplateRepository.Latest(context.TODO(), got.ID, got.Locale)
+			if err != nil || current.Ref() != got.Ref() {
+				t.Errorf("expected current template %s, got %s (%v)", got.Ref(), current.Ref(), err)
+			}
+		})
+	}
+}
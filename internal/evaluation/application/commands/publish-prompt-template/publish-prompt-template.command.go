@@ -0,0 +1,8 @@
+package publishprompttemplate
+
+type PublishPromptTemplateCommand struct {
+	ID     string `json:"id"`
+	Locale string `json:"locale"`
+	Body   string `json:"body"`
+	Notes  string `json:"notes"`
+}
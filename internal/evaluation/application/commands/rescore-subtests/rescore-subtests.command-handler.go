@@ -0,0 +1,159 @@
+package rescoresubtests
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+)
+
+// row es el resultado del recálculo de una fila, con la escritura diferida para el modo apply
+type row struct {
+	subtest      RSdomain.Subtest
+	id           string
+	evaluationID string
+	fromProfile  string
+	toProfile    string
+	oldScore     int
+	newScore     int
+	old, new     any
+	apply        func() error
+}
+
+// RescoreSubtestsCommandHandler recalcula los scores guardados a partir de los datos brutos. Un
+// error en una fila no detiene la ejecución: queda en Failures y el resto se procesa.
+func RescoreSubtestsCommandHandler(ctx context.Context, cmd RescoreSubtestsCommand, catalog SCPdomain.ScoringProfileCatalog, repo RSdomain.RescoringRepository) (RSdomain.Report, error) {
+	subtests := RSdomain.AllSubtests
+	if len(cmd.Subtests) > 0 {
+		subtests = nil
+		for _, s := range cmd.Subtests {
+			st, err := RSdomain.ParseSubtest(s)
+			if err != nil {
+				return RSdomain.Report{}, err
+			}
+			subtests = append(subtests, st)
+		}
+	}
+
+	rescorer := RSdomain.Rescorer{Catalog: catalog}
+	if cmd.TargetProfile != "" {
+		if _, _, err := SCPdomain.ParseRef(cmd.TargetProfile); err != nil {
+			return RSdomain.Report{}, err
+		}
+		target, err := catalog.Get(ctx, cmd.TargetProfile)
+		if err != nil {
+			return RSdomain.Report{}, err
+		}
+		rescorer.Target = &target
+	}
+
+	report := RSdomain.Report{
+		RunID:         uuid.NewString(),
+		TargetProfile: cmd.TargetProfile,
+		DryRun:        !cmd.Apply,
+		Diffs:         []RSdomain.Diff{},
+		Failures:      []RSdomain.Failure{},
+		StartedAt:     time.Now().UTC(),
+	}
+	record := func(r row, err error) {
+		report.Scanned++
+		if err != nil {
+			report.Failures = append(report.Failures, RSdomain.Failure{Subtest: r.subtest, SubtestID: r.id, Error: err.Error()})
+			return
+		}
+		fields, err := RSdomain.ChangedFields(r.old, r.new)
+		if err != nil {
+			report.Failures = append(report.Failures, RSdomain.Failure{Subtest: r.subtest, SubtestID: r.id, Error: err.Error()})
+			return
+		}
+		if len(fields) == 0 {
+			report.Unchanged++
+			return
+		}
+		report.Changed++
+		diff := RSdomain.Diff{
+			Subtest: r.subtest, SubtestID: r.id, EvaluationID: r.evaluationID,
+			FromProfile: r.fromProfile, ToProfile: r.toProfile,
+			OldScore: r.oldScore, NewScore: r.newScore,
+			Fields: fields, Old: r.old, New: r.new,
+		}
+		if cmd.Apply {
+			if err := r.apply(); err != nil {
+				report.Failures = append(report.Failures, RSdomain.Failure{Subtest: r.subtest, SubtestID: r.id, Error: err.Error()})
+			} else {
+				diff.Applied = true
+			}
+		}
+		report.Diffs = append(report.Diffs, diff)
+	}
+
+	for _, st := range subtests {
+		switch st {
+		case RSdomain.LetterCancellation:
+			subs, err := repo.ListLetterCancellation(ctx, cmd.EvaluationID)
+			if err != nil {
+				return RSdomain.Report{}, err
+			}
+			for _, sub := range subs {
+				score, err := rescorer.LetterCancellation(ctx, sub)
+				record(row{
+					subtest: st, id: sub.PK, evaluationID: sub.EvaluationID,
+					fromProfile: sub.CancellationScore.ScoringProfile, toProfile: score.ScoringProfile,
+					oldScore: sub.CancellationScore.Score, newScore: score.Score,
+					old: sub.CancellationScore, new: score,
+					apply: func() error { return repo.ApplyLetterCancellation(ctx, sub, score, report.RunID) },
+				}, err)
+			}
+		case RSdomain.VerbalMemory:
+			subs, err := repo.ListVerbalMemory(ctx, cmd.EvaluationID)
+			if err != nil {
+				return RSdomain.Report{}, err
+			}
+			for _, sub := range subs {
+				score, err := rescorer.VerbalMemory(ctx, sub)
+				record(row{
+					subtest: st, id: sub.Pk, evaluationID: sub.EvaluationID,
+					fromProfile: sub.Score.ScoringProfile, toProfile: score.ScoringProfile,
+					oldScore: sub.Score.Score, newScore: score.Score,
+					old: sub.Score, new: score,
+					apply: func() error { return repo.ApplyVerbalMemory(ctx, sub, score, report.RunID) },
+				}, err)
+			}
+		case RSdomain.ExecutiveFunctions:
+			subs, err := repo.ListExecutiveFunctions(ctx, cmd.EvaluationID)
+			if err != nil {
+				return RSdomain.Report{}, err
+			}
+			for _, sub := range subs {
+				rebuilt, score, err := rescorer.ExecutiveFunctions(ctx, sub)
+				record(row{
+					subtest: st, id: sub.PK, evaluationID: sub.EvauluationId,
+					fromProfile: sub.Score.ScoringProfile, toProfile: score.ScoringProfile,
+					oldScore: sub.Score.Score, newScore: score.Score,
+					old: sub.Score, new: score,
+					apply: func() error { return repo.ApplyExecutiveFunctions(ctx, rebuilt, score, report.RunID) },
+				}, err)
+			}
+		case RSdomain.LanguageFluency:
+			subs, err := repo.ListLanguageFluency(ctx, cmd.EvaluationID)
+			if err != nil {
+				return RSdomain.Report{}, err
+			}
+			for _, sub := range subs {
+				score, err := rescorer.LanguageFluency(ctx, sub)
+				record(row{
+					subtest: st, id: sub.PK, evaluationID: sub.EvaluationID,
+					fromProfile: sub.Score.ScoringProfile, toProfile: score.ScoringProfile,
+					oldScore: sub.Score.Score, newScore: score.Score,
+					old: sub.Score, new: score,
+					apply: func() error { return repo.ApplyLanguageFluency(ctx, sub, score, report.RunID) },
+				}, err)
+			}
+		}
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	return report, nil
+}
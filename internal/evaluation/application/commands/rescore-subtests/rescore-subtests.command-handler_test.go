@@ -0,0 +1,134 @@
+package rescoresubtests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestRescoreSubtestsCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+	repo := app.Repositories.RescoringRepository
+
+	// Los casos comparten repositorio: el orden importa para comprobar el modo apply
+	tests := []struct {
+		name          string
+		cmd           RescoreSubtestsCommand
+		wantErr       error
+		wantScanned   int
+		wantChanged   int
+		wantRevisions map[string]int // subtest/id -> número de revisiones tras la ejecución
+	}{
+		{
+			name:        "Valid - dry run with stored profiles only reports the stale row",
+			cmd:         RescoreSubtestsCommand{},
+			wantScanned: 5,
+			wantChanged: 1,
+			wantRevisions: map[string]int{
+				"letter_cancellation/lc-stale": 0,
+			},
+		},
+		{
+			name:        "Valid - dry run filtered by evaluation",
+			cmd:         RescoreSubtestsCommand{EvaluationID: "eval-2"},
+			wantScanned: 1,
+			wantChanged: 1,
+		},
+		{
+			name:        "Valid - apply fixes the stale row and keeps the original as revision 1",
+			cmd:         RescoreSubtestsCommand{Subtests: []string{"letter_cancellation"}, Apply: true},
+			wantScanned: 2,
+			wantChanged: 1,
+			wantRevisions: map[string]int{
+				"letter_cancellation/lc-stale": 2,
+				"letter_cancellation/lc-1":     0,
+			},
+		},
+		{
+			name:        "Valid - apply with a new profile version",
+			cmd:         RescoreSubtestsCommand{TargetProfile: "pd-motor@1", Subtests: []string{"executive_functions"}, Apply: true},
+			wantScanned: 1,
+			wantChanged: 1,
+			wantRevisions: map[string]int{
+				"executive_functions/ef-1": 2,
+			},
+		},
+		{
+			name:        "Valid - rerunning the same target finds nothing to change",
+			cmd:         RescoreSubtestsCommand{TargetProfile: "pd-motor@1", Subtests: []string{"executive_functions"}},
+			wantScanned: 1,
+			wantChanged: 0,
+			wantRevisions: map[string]int{
+				"executive_functions/ef-1": 2,
+			},
+		},
+		{
+			name:    "Invalid - unknown subtest",
+			cmd:     RescoreSubtestsCommand{Subtests: []string{"stroop"}},
+			wantErr: RSdomain.ErrUnknownSubtest,
+		},
+		{
+			name:    "Invalid - malformed target profile",
+			cmd:     RescoreSubtestsCommand{TargetProfile: "pd-motor"},
+			wantErr: SCPdomain.ErrInvalidProfile,
+		},
+		{
+			name:    "Invalid - unknown target profile",
+			cmd:     RescoreSubtestsCommand{TargetProfile: "pd-motor@9"},
+			wantErr: SCPdomain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := RescoreSubtestsCommandHandler(context.TODO(), tt.cmd, app.Repositories.ScoringProfileCatalog, repo)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(report.Failures) > 0 {
+				t.Fatalf("unexpected failures: %+v", report.Failures)
+			}
+			if report.Scanned != tt.wantScanned || report.Changed != tt.wantChanged {
+				t.Errorf("expected scanned=%d changed=%d, got scanned=%d changed=%d", tt.wantScanned, tt.wantChanged, report.Scanned, report.Changed)
+			}
+			if report.DryRun == tt.cmd.Apply {
+				t.Errorf("expected dryRun=%v", !tt.cmd.Apply)
+			}
+			for _, d := range report.Diffs {
+				if d.Applied != tt.cmd.Apply {
+					t.Errorf("%s/%s: expected applied=%v", d.Subtest, d.SubtestID, tt.cmd.Apply)
+				}
+				if tt.cmd.TargetProfile != "" && d.ToProfile != tt.cmd.TargetProfile {
+					t.Errorf("%s/%s: expected profile %s, got %s", d.Subtest, d.SubtestID, tt.cmd.TargetProfile, d.ToProfile)
+				}
+			}
+			for key, want := range tt.wantRevisions {
+				st, id, _ := strings.Cut(key, "/")
+				revs, _ := repo.GetRevisions(context.TODO(), RSdomain.Subtest(st), id)
+				if len(revs) != want {
+					t.Errorf("%s: expected %d revisions, got %d", key, want, len(revs))
+				}
+				if want == 0 {
+					continue
+				}
+				if revs[0].Revision != 1 || revs[0].RunID != "" {
+					t.Errorf("%s: expected the original score as revision 1", key)
+				}
+				if tt.cmd.Apply && revs[len(revs)-1].RunID != report.RunID {
+					t.Errorf("%s: expected last revision from run %s", key, report.RunID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package rescoresubtests
+
+type RescoreSubtestsCommand struct {
+	// id@versión exacto; vacío recalcula cada fila con el perfil que tiene registrado
+	TargetProfile string `json:"targetProfile"`
+	// vacío = los cuatro subtests recalculables
+	Subtests     []string `json:"subtests"`
+	EvaluationID string   `json:"evaluationId"`
+	// false = dry run: solo informa de las diferencias
+	Apply bool `json:"apply"`
+}
@@ -0,0 +1,44 @@
+package setanamnesis
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+)
+
+func SetAnamnesisCommandHandler(ctx context.Context, cmd SetAnamnesisCommand, evaluationRepo domain.EvaluationsRepository, anamnesisRepo ANdomain.AnamnesisRepository) (*ANdomain.Anamnesis, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	a, err := ANdomain.NewAnamnesis(cmd.EvaluationID, ANdomain.AnamnesisInput{
+		ReferralReason:     cmd.ReferralReason,
+		Comorbidities:      cmd.Comorbidities,
+		Sensory:            cmd.Sensory,
+		Sleep:              cmd.Sleep,
+		MoodComplaints:     cmd.MoodComplaints,
+		CurrentMedications: cmd.CurrentMedications,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Al sustituir una anamnesis existente se conserva la fecha de alta
+	previous, err := anamnesisRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if previous.EvaluationID == cmd.EvaluationID && !previous.CreatedAt.IsZero() {
+		a.CreatedAt = previous.CreatedAt
+	}
+
+	if err = anamnesisRepo.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,99 @@
+package setanamnesis
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSetAnamnesisCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := SetAnamnesisCommand{
+		EvaluationID:       "eval-123",
+		ReferralReason:     "  Quejas de memoria  ",
+		Comorbidities:      []string{"diabetes tipo 2", "Diabetes tipo 2", " "},
+		Sensory:            ANdomain.Sensory{Vision: ANdomain.SensoryCorrected},
+		Sleep:              ANdomain.Sleep{Quality: ANdomain.SleepGood, HoursPerNight: ptr(7.0)},
+		CurrentMedications: []string{"metformina"},
+	}
+	with := func(mutate func(*SetAnamnesisCommand)) SetAnamnesisCommand {
+		c := valid
+		mutate(&c)
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		cmd        SetAnamnesisCommand
+		wantErr    error
+		wantAlerts []string
+	}{
+		{
+			name:       "Valid - trims and deduplicates lists, no alerts",
+			cmd:        valid,
+			wantAlerts: []string{},
+		},
+		{
+			name: "Valid - uncorrected hearing, poor sleep and mood complaints raise alerts",
+			cmd: with(func(c *SetAnamnesisCommand) {
+				c.Sensory = ANdomain.Sensory{Hearing: ANdomain.SensoryUncorrected}
+				c.Sleep = ANdomain.Sleep{Quality: ANdomain.SleepFair, HoursPerNight: ptr(4.0)}
+				c.MoodComplaints = ANdomain.MoodComplaints{Anxiety: true}
+			}),
+			wantAlerts: []string{ANdomain.AlertUncorrectedHearing, ANdomain.AlertPoorSleep, ANdomain.AlertMoodComplaints},
+		},
+		{
+			name:    "Invalid - missing referral reason",
+			cmd:     with(func(c *SetAnamnesisCommand) { c.ReferralReason = "   " }),
+			wantErr: ANdomain.ErrInvalidAnamnesis,
+		},
+		{
+			name:    "Invalid - unknown sensory status",
+			cmd:     with(func(c *SetAnamnesisCommand) { c.Sensory.Vision = "blind" }),
+			wantErr: ANdomain.ErrInvalidAnamnesis,
+		},
+		{
+			name:    "Invalid - missing sleep quality",
+			cmd:     with(func(c *SetAnamnesisCommand) { c.Sleep.Quality = "" }),
+			wantErr: ANdomain.ErrInvalidAnamnesis,
+		},
+		{
+			name:    "Invalid - hours of sleep out of range",
+			cmd:     with(func(c *SetAnamnesisCommand) { c.Sleep.HoursPerNight = ptr(30.0) }),
+			wantErr: ANdomain.ErrInvalidAnamnesis,
+		},
+		{
+			name:    "Invalid - missing evaluation id",
+			cmd:     with(func(c *SetAnamnesisCommand) { c.EvaluationID = "" }),
+			wantErr: errors.New("evaluation id is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := SetAnamnesisCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.AnamnesisRepository)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a.ReferralReason != "Quejas de memoria" || len(a.Comorbidities) != 1 {
+				t.Errorf("expected cleaned free text, got %q %v", a.ReferralReason, a.Comorbidities)
+			}
+			if got := a.Alerts(); !reflect.DeepEqual(got, tt.wantAlerts) {
+				t.Errorf("expected alerts %v, got %v", tt.wantAlerts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package setanamnesis
+
+import ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+
+type SetAnamnesisCommand struct {
+	EvaluationID       string                  `json:"-"`
+	ReferralReason     string                  `json:"referral_reason"`
+	Comorbidities      []string                `json:"comorbidities"`
+	Sensory            ANdomain.Sensory        `json:"sensory"`
+	Sleep              ANdomain.Sleep          `json:"sleep"`
+	MoodComplaints     ANdomain.MoodComplaints `json:"mood_complaints"`
+	CurrentMedications []string                `json:"current_medications"`
+}
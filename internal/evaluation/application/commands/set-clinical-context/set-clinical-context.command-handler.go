@@ -0,0 +1,45 @@
+package setclinicalcontext
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+)
+
+func SetClinicalContextCommandHandler(ctx context.Context, cmd SetClinicalContextCommand, evaluationRepo domain.EvaluationsRepository, clinicalContextRepo CCdomain.ClinicalContextRepository) (*CCdomain.ClinicalContext, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	evaluation, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := CCdomain.NewClinicalContext(cmd.EvaluationID, evaluation.PatientAge, CCdomain.ClinicalContextInput{
+		MedicationState:          cmd.MedicationState,
+		MinutesSinceLastLevodopa: cmd.MinutesSinceLastLevodopa,
+		Medications:              cmd.Medications,
+		HoehnYahr:                cmd.HoehnYahr,
+		DBS:                      cmd.DBS,
+		DiseaseDurationYears:     cmd.DiseaseDurationYears,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Al sustituir un contexto existente se conserva la fecha de alta
+	previous, err := clinicalContextRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if previous.EvaluationID == cmd.EvaluationID && !previous.CreatedAt.IsZero() {
+		cc.CreatedAt = previous.CreatedAt
+	}
+
+	if err = clinicalContextRepo.Save(ctx, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
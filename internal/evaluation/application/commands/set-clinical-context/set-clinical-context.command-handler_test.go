@@ -0,0 +1,121 @@
+package setclinicalcontext
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSetClinicalContextCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	valid := SetClinicalContextCommand{
+		EvaluationID:             "eval-123",
+		MedicationState:          CCdomain.MedicationOn,
+		MinutesSinceLastLevodopa: ptr(75),
+		Medications: []CCdomain.Medication{
+			{Drug: "levodopa", DailyDoseMg: 300},
+			{Drug: "levodopa_cr", DailyDoseMg: 200},
+			{Drug: "entacapone", DailyDoseMg: 800},
+			{Drug: "ropinirole", DailyDoseMg: 8},
+			{Drug: "rasagiline", DailyDoseMg: 1},
+		},
+		HoehnYahr:            ptr(2.5),
+		DBS:                  CCdomain.DBS{Stimulation: CCdomain.DBSOn, Target: "stn"},
+		DiseaseDurationYears: ptr(9.0),
+	}
+	with := func(mutate func(*SetClinicalContextCommand)) SetClinicalContextCommand {
+		c := valid
+		mutate(&c)
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		cmd      SetClinicalContextCommand
+		wantErr  error
+		wantLEDD CCdomain.LEDD
+	}{
+		{
+			// levodopa 300 + 200·0.75 = 450, ×1.33 con entacapona = 598.5; ropinirol 8·20 = 160; rasagilina 100
+			name:     "Valid - LEDD with COMT inhibitor, agonist and MAO-B",
+			cmd:      valid,
+			wantLEDD: CCdomain.LEDD{Total: 859, Levodopa: 599, Agonists: 160},
+		},
+		{
+			name: "Valid - untreated patient",
+			cmd: with(func(c *SetClinicalContextCommand) {
+				c.MedicationState, c.Medications, c.MinutesSinceLastLevodopa = CCdomain.MedicationUntreated, nil, nil
+				c.DBS = CCdomain.DBS{}
+			}),
+		},
+		{
+			name: "Invalid - unknown drug",
+			cmd: with(func(c *SetClinicalContextCommand) {
+				c.Medications = []CCdomain.Medication{{Drug: "aspirin", DailyDoseMg: 100}}
+			}),
+			wantErr: CCdomain.ErrUnknownDrug,
+		},
+		{
+			name: "Invalid - COMT inhibitor without levodopa",
+			cmd: with(func(c *SetClinicalContextCommand) {
+				c.Medications = []CCdomain.Medication{{Drug: "opicapone", DailyDoseMg: 50}}
+				c.MinutesSinceLastLevodopa = nil
+			}),
+			wantErr: CCdomain.ErrInvalidMedication,
+		},
+		{
+			name:    "Invalid - Hoehn & Yahr stage",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.HoehnYahr = ptr(3.5) }),
+			wantErr: CCdomain.ErrInvalidClinicalContext,
+		},
+		{
+			name:    "Invalid - medication state",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.MedicationState = "partial" }),
+			wantErr: CCdomain.ErrInvalidClinicalContext,
+		},
+		{
+			name:    "Invalid - ON state without medication list",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.Medications, c.MinutesSinceLastLevodopa = nil, nil }),
+			wantErr: CCdomain.ErrInvalidClinicalContext,
+		},
+		{
+			name:    "Invalid - DBS without target",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.DBS = CCdomain.DBS{Stimulation: CCdomain.DBSOff} }),
+			wantErr: CCdomain.ErrInvalidClinicalContext,
+		},
+		{
+			name:    "Invalid - negative disease duration",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.DiseaseDurationYears = ptr(-1.0) }),
+			wantErr: CCdomain.ErrInvalidClinicalContext,
+		},
+		{
+			name:    "Invalid - missing evaluation id",
+			cmd:     with(func(c *SetClinicalContextCommand) { c.EvaluationID = "" }),
+			wantErr: errors.New("evaluation id is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, err := SetClinicalContextCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.ClinicalContextRepository)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cc.LEDD != tt.wantLEDD {
+				t.Errorf("expected LEDD %+v, got %+v", tt.wantLEDD, cc.LEDD)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package setclinicalcontext
+
+import CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+
+type SetClinicalContextCommand struct {
+	EvaluationID             string                   `json:"-"`
+	MedicationState          CCdomain.MedicationState `json:"medication_state"`
+	MinutesSinceLastLevodopa *int                     `json:"minutes_since_last_levodopa"`
+	Medications              []CCdomain.Medication    `json:"medications"`
+	HoehnYahr                *float64                 `json:"hoehn_yahr"`
+	DBS                      CCdomain.DBS             `json:"dbs"`
+	DiseaseDurationYears     *float64                 `json:"disease_duration_years"`
+}
@@ -0,0 +1,42 @@
+package setexaminerobservation
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+)
+
+func SetExaminerObservationCommandHandler(ctx context.Context, cmd SetExaminerObservationCommand, evaluationRepo domain.EvaluationsRepository, observationRepo EOdomain.ExaminerObservationRepository) (*EOdomain.ExaminerObservation, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	o, err := EOdomain.NewExaminerObservation(cmd.EvaluationID, cmd.Subtest, EOdomain.ExaminerObservationInput{
+		Fatigue:             cmd.Fatigue,
+		Cooperation:         cmd.Cooperation,
+		ComprehensionIssues: cmd.ComprehensionIssues,
+		Interruptions:       cmd.Interruptions,
+		Notes:               cmd.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := observationRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if prev, ok := EOdomain.ForSubtest(previous, cmd.Subtest); ok && !prev.CreatedAt.IsZero() {
+		o.CreatedAt = prev.CreatedAt
+	}
+
+	if err = observationRepo.Save(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,86 @@
+package setexaminerobservation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestSetExaminerObservationCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	tests := []struct {
+		name       string
+		cmd        SetExaminerObservationCommand
+		wantErr    error
+		wantAlerts []string
+	}{
+		{
+			name:       "Valid - defaults to no fatigue and good cooperation",
+			cmd:        SetExaminerObservationCommand{EvaluationID: "eval-123", Subtest: "verbal_memory"},
+			wantAlerts: []string{},
+		},
+		{
+			name: "Valid - mild fatigue and fair cooperation do not raise alerts",
+			cmd: SetExaminerObservationCommand{
+				EvaluationID: "eval-123", Subtest: "sdmt",
+				Fatigue: EOdomain.FatigueMild, Cooperation: EOdomain.CooperationFair,
+			},
+			wantAlerts: []string{},
+		},
+		{
+			name: "Valid - compromised administration raises every alert",
+			cmd: SetExaminerObservationCommand{
+				EvaluationID: "eval-123", Subtest: "stroop",
+				Fatigue: EOdomain.FatigueMarked, Cooperation: EOdomain.CooperationPoor,
+				ComprehensionIssues: true, Interruptions: 2, Notes: "Se repiten instrucciones",
+			},
+			wantAlerts: []string{
+				EOdomain.AlertMarkedFatigue, EOdomain.AlertPoorCooperation,
+				EOdomain.AlertComprehensionIssues, EOdomain.AlertInterrupted,
+			},
+		},
+		{
+			name:    "Invalid - unknown subtest",
+			cmd:     SetExaminerObservationCommand{EvaluationID: "eval-123", Subtest: "tower_of_london"},
+			wantErr: EOdomain.ErrUnknownSubtest,
+		},
+		{
+			name:    "Invalid - unknown fatigue level",
+			cmd:     SetExaminerObservationCommand{EvaluationID: "eval-123", Subtest: "moca", Fatigue: "extreme"},
+			wantErr: EOdomain.ErrInvalidObservation,
+		},
+		{
+			name:    "Invalid - negative interruptions",
+			cmd:     SetExaminerObservationCommand{EvaluationID: "eval-123", Subtest: "moca", Interruptions: -1},
+			wantErr: EOdomain.ErrInvalidObservation,
+		},
+		{
+			name:    "Invalid - missing evaluation id",
+			cmd:     SetExaminerObservationCommand{Subtest: "moca"},
+			wantErr: errors.New("evaluation id is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := SetExaminerObservationCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.ExaminerObservationRepository)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := o.Alerts(); !reflect.DeepEqual(got, tt.wantAlerts) {
+				t.Errorf("expected alerts %v, got %v", tt.wantAlerts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package setexaminerobservation
+
+import EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+
+type SetExaminerObservationCommand struct {
+	EvaluationID        string               `json:"-"`
+	Subtest             string               `json:"-"`
+	Fatigue             EOdomain.Fatigue     `json:"fatigue"`
+	Cooperation         EOdomain.Cooperation `json:"cooperation"`
+	ComprehensionIssues bool                 `json:"comprehension_issues"`
+	Interruptions       int                  `json:"interruptions"`
+	Notes               string               `json:"notes"`
+}
@@ -0,0 +1,49 @@
+package setscoringprotocol
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+)
+
+// SetScoringProtocolCommandHandler fija el perfil vigente del protocolo en la evaluación. La
+// selección no se puede cambiar después: todos los resultados de la evaluación se puntúan con
+// el mismo id@versión aunque el protocolo pase a apuntar a una versión nueva.
+func SetScoringProtocolCommandHandler(ctx context.Context, cmd SetScoringProtocolCommand, evaluationRepo domain.EvaluationsRepository, catalog SCPdomain.ScoringProfileCatalog, selectionRepo SCPdomain.ScoringSelectionRepository) (*SCPdomain.ScoringSelection, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	profile, err := catalog.ForProtocol(ctx, cmd.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := selectionRepo.GetByEvaluationID(ctx, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if current.ProfileRef != "" {
+		if current.Protocol == cmd.Protocol {
+			return &current, nil
+		}
+		return nil, SCPdomain.ErrProtocolAlreadySet
+	}
+
+	selection := &SCPdomain.ScoringSelection{
+		EvaluationID: cmd.EvaluationID,
+		Protocol:     cmd.Protocol,
+		ProfileRef:   profile.Ref(),
+		CreatedAt:    time.Now(),
+	}
+	if err := selectionRepo.Save(ctx, selection); err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
@@ -0,0 +1,77 @@
+package setscoringprotocol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestSetScoringProtocolCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	// Los casos comparten repositorio: el orden importa para comprobar la fijación
+	tests := []struct {
+		name        string
+		cmd         SetScoringProtocolCommand
+		wantErr     error
+		wantProfile string
+	}{
+		{
+			name:        "Valid - protocol pins its current profile version",
+			cmd:         SetScoringProtocolCommand{EvaluationID: "eval-pd", Protocol: "parkinson-motor"},
+			wantProfile: "pd-motor@1",
+		},
+		{
+			name:        "Valid - setting the same protocol again is idempotent",
+			cmd:         SetScoringProtocolCommand{EvaluationID: "eval-pd", Protocol: "parkinson-motor"},
+			wantProfile: "pd-motor@1",
+		},
+		{
+			name:    "Invalid - protocol cannot change once set",
+			cmd:     SetScoringProtocolCommand{EvaluationID: "eval-pd", Protocol: "default"},
+			wantErr: SCPdomain.ErrProtocolAlreadySet,
+		},
+		{
+			name:        "Valid - default protocol",
+			cmd:         SetScoringProtocolCommand{EvaluationID: "eval-std", Protocol: "default"},
+			wantProfile: "standard@1",
+		},
+		{
+			name:    "Invalid - unknown protocol",
+			cmd:     SetScoringProtocolCommand{EvaluationID: "eval-other", Protocol: "pediatric"},
+			wantErr: SCPdomain.ErrProtocolNotFound,
+		},
+		{
+			name:    "Invalid - missing evaluation id",
+			cmd:     SetScoringProtocolCommand{Protocol: "default"},
+			wantErr: errors.New("evaluation id is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := SetScoringProtocolCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sel.ProfileRef != tt.wantProfile {
+				t.Errorf("expected profile %s, got %s", tt.wantProfile, sel.ProfileRef)
+			}
+
+			// Los subtests de la evaluación se puntúan con el perfil fijado
+			profile, err := SCPdomain.ResolveForEvaluation(context.TODO(), app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository, tt.cmd.EvaluationID)
+			if err != nil || profile.Ref() != tt.wantProfile {
+				t.Errorf("expected evaluation resolved to %s, got %s (%v)", tt.wantProfile, profile.Ref(), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package setscoringprotocol
+
+type SetScoringProtocolCommand struct {
+	EvaluationID string `json:"-"`
+	Protocol     string `json:"protocol"`
+}
@@ -0,0 +1,34 @@
+package startcardsortingsession
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+)
+
+func StartCardSortingSessionCommandHandler(ctx context.Context, cmd StartCardSortingSessionCommand, evaluationRepo domain.EvaluationsRepository, cardSortingRepo WCSTdomain.CardSortingRepository, scoringCatalog SCPdomain.ScoringProfileCatalog, scoringSelectionRepo SCPdomain.ScoringSelectionRepository) (*WCSTdomain.CardSortingSubtest, error) {
+	if cmd.EvaluationID == "" {
+		return nil, errors.New("evaluation id is required")
+	}
+	if _, err := evaluationRepo.GetByID(ctx, cmd.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	session, err := WCSTdomain.NewCardSortingSubtest(cmd.EvaluationID, cmd.MaxCards)
+	if err != nil {
+		return nil, err
+	}
+	// el perfil se fija al empezar la sesión; la puntuación final lo conserva
+	profile, err := SCPdomain.ResolveForEvaluation(ctx, scoringCatalog, scoringSelectionRepo, cmd.EvaluationID)
+	if err != nil {
+		return nil, err
+	}
+	session.Score.ScoringProfile = profile.Ref()
+	if err = cardSortingRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,75 @@
+package startcardsortingsession
+
+import (
+	"context"
+	"testing"
+
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	"neuro.app.jordi/internal/pkg"
+)
+
+func TestStartCardSortingSessionCommandHandler(t *testing.T) {
+	app := pkg.NewMockApp()
+
+	tests := []struct {
+		name         string
+		cmd          StartCardSortingSessionCommand
+		shouldPass   bool
+		wantMaxCards int
+	}{
+		{
+			name:         "Valid command - default deck",
+			cmd:          StartCardSortingSessionCommand{EvaluationID: "eval-123"},
+			shouldPass:   true,
+			wantMaxCards: 64,
+		},
+		{
+			name:         "Valid command - full deck",
+			cmd:          StartCardSortingSessionCommand{EvaluationID: "eval-123", MaxCards: 128},
+			shouldPass:   true,
+			wantMaxCards: 128,
+		},
+		{
+			name:       "Invalid - missing evaluation id",
+			cmd:        StartCardSortingSessionCommand{},
+			shouldPass: false,
+		},
+		{
+			name:       "Invalid - unsupported deck size",
+			cmd:        StartCardSortingSessionCommand{EvaluationID: "eval-123", MaxCards: 100},
+			shouldPass: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := StartCardSortingSessionCommandHandler(context.TODO(), tt.cmd, app.Repositories.EvaluationsRepository, app.Repositories.CardSortingRepository, app.Repositories.ScoringProfileCatalog, app.Repositories.ScoringSelectionRepository)
+
+			if tt.shouldPass {
+				if err != nil {
+					t.Fatalf("expected success, got error: %v", err)
+				}
+				if res.Status != WCSTdomain.SessionInProgress || res.MaxCards != tt.wantMaxCards || len(res.Responses) != 0 {
+					t.Errorf("unexpected session: %+v", res)
+				}
+				if res.Score.ScoringProfile != "standard@1" {
+					t.Errorf("expected default scoring profile standard@1, got %q", res.Score.ScoringProfile)
+				}
+				next := res.NextCard()
+				if next == nil || next.Index != 0 || next.Card != WCSTdomain.DeckCard(0) {
+					t.Errorf("expected first card to be presented, got %+v", next)
+				}
+				if _, err := app.Repositories.CardSortingRepository.GetByID(context.TODO(), res.PK); err != nil {
+					t.Errorf("expected session to be persisted: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error, got nil (cmd=%+v)", tt.cmd)
+				}
+				if res != nil {
+					t.Errorf("expected nil session on error, got %+v", res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package startcardsortingsession
+
+type StartCardSortingSessionCommand struct {
+	EvaluationID string `json:"evaluation_id"`
+	MaxCards     int    `json:"max_cards"` // 64 (por defecto) o 128
+}
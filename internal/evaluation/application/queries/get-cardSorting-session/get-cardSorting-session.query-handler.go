@@ -0,0 +1,19 @@
+package getcardsortingsession
+
+import (
+	"context"
+	"errors"
+
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+)
+
+func GetCardSortingSessionQueryHandler(ctx context.Context, query GetCardSortingSessionQuery, cardSortingRepo WCSTdomain.CardSortingRepository) (CardSortingSessionView, error) {
+	if query.SessionID == "" {
+		return CardSortingSessionView{}, errors.New("session id is required")
+	}
+	session, err := cardSortingRepo.GetByID(ctx, query.SessionID)
+	if err != nil {
+		return CardSortingSessionView{}, err
+	}
+	return NewCardSortingSessionView(session), nil
+}
@@ -0,0 +1,30 @@
+package getcardsortingsession
+
+import WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+
+type GetCardSortingSessionQuery struct {
+	SessionID string
+}
+
+// CardSortingSessionView es el estado visible para la tableta (sin reglas ni puntuación)
+type CardSortingSessionView struct {
+	SessionID    string                    `json:"sessionId"`
+	Status       WCSTdomain.SessionStatus  `json:"status"`
+	KeyCards     [4]WCSTdomain.Card        `json:"keyCards"`
+	Trial        int                       `json:"trial"`
+	MaxCards     int                       `json:"maxCards"`
+	Next         *WCSTdomain.PresentedCard `json:"next,omitempty"`
+	LastFeedback *WCSTdomain.Feedback      `json:"lastFeedback,omitempty"`
+}
+
+func NewCardSortingSessionView(session WCSTdomain.CardSortingSubtest) CardSortingSessionView {
+	return CardSortingSessionView{
+		SessionID:    session.PK,
+		Status:       session.Status,
+		KeyCards:     WCSTdomain.KeyCards,
+		Trial:        len(session.Responses),
+		MaxCards:     session.MaxCards,
+		Next:         session.NextCard(),
+		LastFeedback: session.LastFeedback(),
+	}
+}
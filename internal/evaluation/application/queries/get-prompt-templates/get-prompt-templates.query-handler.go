@@ -0,0 +1,24 @@
+package getprompttemplates
+
+import (
+	"context"
+
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+)
+
+func GetPromptTemplatesQueryHandler(ctx context.Context, query GetPromptTemplatesQuery, repo PTdomain.PromptTemplateRepository) (PromptTemplatesResponse, error) {
+	all, err := repo.List(ctx)
+	if err != nil {
+		return PromptTemplatesResponse{}, err
+	}
+	res := PromptTemplatesResponse{Templates: []PTdomain.PromptTemplate{}, Current: map[string]string{}}
+	for _, t := range all {
+		if (query.ID != "" && t.ID != query.ID) || (query.Locale != "" && string(t.Locale) != query.Locale) {
+			continue
+		}
+		res.Templates = append(res.Templates, t)
+		// List viene ordenada por versión: la última de cada id/idioma es la vigente
+		res.Current[t.ID+"/"+string(t.Locale)] = t.Ref()
+	}
+	return res, nil
+}
@@ -0,0 +1,14 @@
+package getprompttemplates
+
+import PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+
+// GetPromptTemplatesQuery filtra por prompt e idioma; vacío = todos
+type GetPromptTemplatesQuery struct {
+	ID     string `form:"id"`
+	Locale string `form:"locale"`
+}
+
+type PromptTemplatesResponse struct {
+	Templates []PTdomain.PromptTemplate `json:"templates"`
+	Current   map[string]string         `json:"current"` // id/idioma -> id/idioma@versión vigente
+}
@@ -0,0 +1,15 @@
+package getquestionnairedefinition
+
+import (
+	"context"
+	"errors"
+
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+)
+
+func GetQuestionnaireDefinitionQueryHandler(ctx context.Context, query GetQuestionnaireDefinitionQuery, catalog QNdomain.QuestionnaireCatalog) (QNdomain.Definition, error) {
+	if query.Code == "" {
+		return QNdomain.Definition{}, errors.New("questionnaire code is required")
+	}
+	return catalog.Get(ctx, query.Code, query.Version)
+}
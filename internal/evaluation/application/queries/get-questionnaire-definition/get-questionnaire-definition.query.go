@@ -0,0 +1,6 @@
+package getquestionnairedefinition
+
+type GetQuestionnaireDefinitionQuery struct {
+	Code    string
+	Version int // 0 = versión vigente
+}
@@ -0,0 +1,26 @@
+package getquestionnaires
+
+import (
+	"context"
+
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+)
+
+func GetQuestionnairesQueryHandler(ctx context.Context, query GetQuestionnairesQuery, catalog QNdomain.QuestionnaireCatalog) ([]QuestionnaireSummary, error) {
+	defs, err := catalog.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]QuestionnaireSummary, 0, len(defs))
+	for _, d := range defs {
+		out = append(out, QuestionnaireSummary{
+			Code:      d.Code,
+			Version:   d.Version,
+			Name:      d.Name,
+			Construct: d.Construct,
+			Language:  d.Language,
+			Items:     len(d.Items),
+		})
+	}
+	return out, nil
+}
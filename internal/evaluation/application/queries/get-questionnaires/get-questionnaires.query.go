@@ -0,0 +1,13 @@
+package getquestionnaires
+
+type GetQuestionnairesQuery struct{}
+
+// QuestionnaireSummary es la entrada del listado (sin ítems)
+type QuestionnaireSummary struct {
+	Code      string `json:"code"`
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	Construct string `json:"construct"`
+	Language  string `json:"language"`
+	Items     int    `json:"items"`
+}
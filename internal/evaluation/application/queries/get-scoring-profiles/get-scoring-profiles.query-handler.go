@@ -0,0 +1,19 @@
+package getscoringprofiles
+
+import (
+	"context"
+
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+)
+
+func GetScoringProfilesQueryHandler(ctx context.Context, query GetScoringProfilesQuery, catalog SCPdomain.ScoringProfileCatalog) (ScoringProfilesResponse, error) {
+	profiles, err := catalog.List(ctx)
+	if err != nil {
+		return ScoringProfilesResponse{}, err
+	}
+	protocols, err := catalog.Protocols(ctx)
+	if err != nil {
+		return ScoringProfilesResponse{}, err
+	}
+	return ScoringProfilesResponse{Profiles: profiles, Protocols: protocols}, nil
+}
@@ -0,0 +1,10 @@
+package getscoringprofiles
+
+import SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+
+type GetScoringProfilesQuery struct{}
+
+type ScoringProfilesResponse struct {
+	Profiles  []SCPdomain.ScoringProfile `json:"profiles"`
+	Protocols map[string]string          `json:"protocols"` // protocolo -> id@versión vigente
+}
@@ -5,64 +5,227 @@ import (
 	"errors"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	PDMCIdomain "neuro.app.jordi/internal/evaluation/domain/pd-mci"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
 	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
 	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
 )
 
-func PopulateEvaluationWithSubtests(ctx context.Context,
-	evaluation *domain.Evaluation,
-	verbalMemoryRepository VEMdomain.VerbalMemoryRepository,
-	visualMemoryRepository VIMdomain.VisualMemoryRepository,
-	executiveFunctionsRepository EFdomain.ExecutiveFunctionsSubtestRepository,
-	letterCancellationRepository LCdomain.LetterCancellationRepository,
-	languageFluencyRepository LFdomain.LanguageFluencyRepository,
-	visualSpatialMemotry VPdomain.ResultRepository,
-) error {
+// SubtestRepositories agrupa lo que se lee para montar una evaluación completa: subtests,
+// cuestionarios, contexto clínico y la configuración de los compuestos
+type SubtestRepositories struct {
+	VerbalMemory         VEMdomain.VerbalMemoryRepository
+	VisualMemory         VIMdomain.VisualMemoryRepository
+	ExecutiveFunctions   EFdomain.ExecutiveFunctionsSubtestRepository
+	LetterCancellation   LCdomain.LetterCancellationRepository
+	LanguageFluency      LFdomain.LanguageFluencyRepository
+	VisualSpatial        VPdomain.ResultRepository
+	DigitSpan            DSdomain.DigitSpanRepository
+	Stroop               STRdomain.StroopRepository
+	SDMT                 SDMTdomain.SDMTRepository
+	ConfrontationNaming  CNdomain.ConfrontationNamingRepository
+	ReactionTime         RTdomain.ReactionTimeRepository
+	FingerTapping        FTdomain.FingerTappingRepository
+	ArchimedesSpiral     ASdomain.ArchimedesSpiralRepository
+	SpeechProfile        SPdomain.SpeechProfileRepository
+	JLO                  JLOdomain.JLORepository
+	GoNoGo               GNGdomain.GoNoGoRepository
+	CardSorting          WCSTdomain.CardSortingRepository
+	Questionnaires       QNdomain.QuestionnaireRepository
+	MoCA                 MOCAdomain.MoCARepository
+	ClinicalContext      CCdomain.ClinicalContextRepository
+	Anamnesis            ANdomain.AnamnesisRepository
+	ExaminerObservations EOdomain.ExaminerObservationRepository
+	ScoringSelection     SCPdomain.ScoringSelectionRepository
+	DataQuality          DQdomain.DataQualityRepository
+	CompositeConfig      COMPdomain.ConfigProvider
+}
+
+func PopulateEvaluationWithSubtests(ctx context.Context, evaluation *domain.Evaluation, repos SubtestRepositories) error {
 	if evaluation == nil {
 		return errors.New("populateEvaluationWithSubtests: evaluation is nil")
 	}
 
 	var merr error
 
-	vm, err := verbalMemoryRepository.GetByEvaluationID(ctx, evaluation.PK)
+	vm, err := repos.VerbalMemory.GetByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.VerbalmemorySubTest = vm
 
-	vim, err := visualMemoryRepository.GetLastByEvaluationID(ctx, evaluation.PK)
+	vim, err := repos.VisualMemory.GetLastByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.VisualMemorySubTest = vim
 
-	ef, err := executiveFunctionsRepository.GetByEvaluationID(ctx, evaluation.PK)
+	ef, err := repos.ExecutiveFunctions.GetByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.ExecutiveFunctionSubTest = ef
 
-	lc, err := letterCancellationRepository.GetByEvaluationID(ctx, evaluation.PK)
+	lc, err := repos.LetterCancellation.GetByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.LetterCancellationSubTest = lc
 
-	lf, err := languageFluencyRepository.GetByEvaluationID(ctx, evaluation.PK)
+	lf, err := repos.LanguageFluency.GetByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.LanguageFluencySubTest = lf
 
-	vp, err := visualSpatialMemotry.GetByEvaluationID(ctx, evaluation.PK)
+	vp, err := repos.VisualSpatial.GetByEvaluationID(ctx, evaluation.PK)
 	if err != nil {
 		return err
 	}
 	evaluation.VisualSpatialSubTest = *vp
 
+	ds, err := repos.DigitSpan.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.DigitSpanSubTest = ds
+
+	st, err := repos.Stroop.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.StroopSubTest = st
+
+	sdmt, err := repos.SDMT.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.SDMTSubTest = sdmt
+
+	cn, err := repos.ConfrontationNaming.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ConfrontationNamingSubTest = cn
+
+	rt, err := repos.ReactionTime.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ReactionTimeSubTest = rt
+
+	ft, err := repos.FingerTapping.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.FingerTappingSubTest = ft
+
+	as, err := repos.ArchimedesSpiral.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ArchimedesSpiralSubTest = as
+
+	sp, err := repos.SpeechProfile.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.SpeechProfile = sp
+
+	jlo, err := repos.JLO.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.JLOSubTest = jlo
+
+	gng, err := repos.GoNoGo.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.GoNoGoSubTest = gng
+
+	wcst, err := repos.CardSorting.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.CardSortingSubTest = wcst
+
+	qn, err := repos.Questionnaires.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.Questionnaires = qn
+
+	moca, err := repos.MoCA.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.MoCASubTest = moca
+
+	cc, err := repos.ClinicalContext.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ClinicalContext = cc
+
+	anamnesis, err := repos.Anamnesis.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.Anamnesis = anamnesis
+
+	observations, err := repos.ExaminerObservations.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ExaminerObservations = observations
+
+	scoringSelection, err := repos.ScoringSelection.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.ScoringSelection = scoringSelection
+
+	storedFlags, err := repos.DataQuality.GetByEvaluationID(ctx, evaluation.PK)
+	if err != nil {
+		return err
+	}
+	evaluation.DataQuality = DQdomain.NewReport(evaluation.QualityFlags(storedFlags))
+
+	// Los compuestos se calculan al leer: dependen solo de los scores y de la configuración vigente
+	compositeConfig, err := repos.CompositeConfig.Current(ctx)
+	if err != nil {
+		return err
+	}
+	evaluation.CognitiveProfile = COMPdomain.Compute(compositeConfig, evaluation.CompositeInputs())
+	evaluation.PDMCICriteria = PDMCIdomain.Evaluate(PDMCIdomain.MDSLevelII, evaluation.CognitiveProfile, evaluation.FunctionalImpairment())
+	evaluation.NeuroProfile = NPdomain.Classify(evaluation.NeuroProfileInput())
+	if evaluation.AssistantAnalysis != "" {
+		evaluation.NeuroProfileCheck = evaluation.AnalysisProfileCheck()
+	}
+
 	return merr
 }
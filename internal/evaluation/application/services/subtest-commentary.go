@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+	logging "neuro.app.jordi/internal/shared/logger"
+)
+
+// CommentSubtests pide el comentario breve de cada fila de subtest administrada que aún no lo
+// tiene (todas si regenerate), lo guarda en la columna del subtest y lo copia a la evaluación
+// para que el análisis global se componga a partir de ellos. Si el servicio no comenta subtests
+// no hace nada; si el proveedor falla lo registra en logger, deja de insistir y el análisis global
+// sigue sin comentarios.
+func CommentSubtests(ctx context.Context, evaluation *domain.Evaluation, llm domain.LLMService, repo STCdomain.CommentaryRepository, opts domain.LLMOptions, regenerate bool, logger logging.Logger) error {
+	commentator, ok := llm.(domain.SubtestCommentator)
+	if !ok {
+		return nil
+	}
+	for _, t := range evaluation.CommentaryTargets() {
+		if t.Commentary != "" && !regenerate {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		res, err := commentator.CommentSubtest(ctx, *evaluation, t, opts)
+		if err != nil {
+			if !errors.Is(err, STCdomain.ErrCommentaryUnavailable) && logger != nil {
+				logger.Error(ctx, "subtest commentary failed, skipping the rest", err, map[string]any{"subtest": t.Subtest, "subtestId": t.SubtestID})
+			}
+			return nil
+		}
+		if err := repo.Save(ctx, t.Subtest, t.SubtestID, res.Text); err != nil {
+			return err
+		}
+		evaluation.SetSubtestCommentary(t, res.Text)
+	}
+	return nil
+}
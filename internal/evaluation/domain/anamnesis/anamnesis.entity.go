@@ -0,0 +1,187 @@
+package ANdomain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SensoryStatus describe un déficit sensorial y si está corregido (gafas, audífonos)
+type SensoryStatus string
+
+const (
+	SensoryNone        SensoryStatus = "none"
+	SensoryCorrected   SensoryStatus = "corrected"
+	SensoryUncorrected SensoryStatus = "uncorrected"
+)
+
+type SleepQuality string
+
+const (
+	SleepGood SleepQuality = "good"
+	SleepFair SleepQuality = "fair"
+	SleepPoor SleepQuality = "poor"
+)
+
+const (
+	MaxFreeTextLength = 500
+	MaxListItems      = 20
+)
+
+// Alertas de calidad derivadas de la anamnesis
+const (
+	AlertUncorrectedVision  = "uncorrected_vision"
+	AlertUncorrectedHearing = "uncorrected_hearing"
+	AlertPoorSleep          = "poor_sleep"
+	AlertMoodComplaints     = "mood_complaints"
+)
+
+var ErrInvalidAnamnesis = errors.New("invalid anamnesis")
+
+type Sensory struct {
+	Vision  SensoryStatus `json:"vision"`
+	Hearing SensoryStatus `json:"hearing"`
+}
+
+type Sleep struct {
+	Quality           SleepQuality `json:"quality"`
+	HoursPerNight     *float64     `json:"hoursPerNight,omitempty"`
+	DaytimeSleepiness bool         `json:"daytimeSleepiness"`
+	RBDSuspected      bool         `json:"rbdSuspected"` // trastorno de conducta del sueño REM referido
+}
+
+type MoodComplaints struct {
+	Depression bool `json:"depression"`
+	Anxiety    bool `json:"anxiety"`
+	Apathy     bool `json:"apathy"`
+}
+
+func (m MoodComplaints) Any() bool {
+	return m.Depression || m.Anxiety || m.Apathy
+}
+
+type Anamnesis struct {
+	EvaluationID   string         `json:"evaluationId"`
+	ReferralReason string         `json:"referralReason"`
+	Comorbidities  []string       `json:"comorbidities"`
+	Sensory        Sensory        `json:"sensory"`
+	Sleep          Sleep          `json:"sleep"`
+	MoodComplaints MoodComplaints `json:"moodComplaints"`
+	// Medicación actual no antiparkinsoniana (la antiparkinsoniana va en el contexto clínico)
+	CurrentMedications []string  `json:"currentMedications"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+type AnamnesisInput struct {
+	ReferralReason     string
+	Comorbidities      []string
+	Sensory            Sensory
+	Sleep              Sleep
+	MoodComplaints     MoodComplaints
+	CurrentMedications []string
+}
+
+func NewAnamnesis(evaluationID string, in AnamnesisInput) (*Anamnesis, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidAnamnesis, fmt.Sprintf(format, args...))
+	}
+	if evaluationID == "" {
+		return nil, invalid("evaluation id is required")
+	}
+
+	reason := strings.TrimSpace(in.ReferralReason)
+	if reason == "" {
+		return nil, invalid("referral reason is required")
+	}
+	if len(reason) > MaxFreeTextLength {
+		return nil, invalid("referral reason too long")
+	}
+
+	comorbidities, err := cleanList(in.Comorbidities)
+	if err != nil {
+		return nil, invalid("comorbidities: %v", err)
+	}
+	medications, err := cleanList(in.CurrentMedications)
+	if err != nil {
+		return nil, invalid("current medications: %v", err)
+	}
+
+	sensory := in.Sensory
+	if sensory.Vision == "" {
+		sensory.Vision = SensoryNone
+	}
+	if sensory.Hearing == "" {
+		sensory.Hearing = SensoryNone
+	}
+	if !validSensory(sensory.Vision) || !validSensory(sensory.Hearing) {
+		return nil, invalid("unknown sensory status")
+	}
+
+	switch in.Sleep.Quality {
+	case SleepGood, SleepFair, SleepPoor:
+	default:
+		return nil, invalid("unknown sleep quality %q", in.Sleep.Quality)
+	}
+	if h := in.Sleep.HoursPerNight; h != nil && (*h < 0 || *h > 24) {
+		return nil, invalid("hours of sleep out of range")
+	}
+
+	now := time.Now()
+	return &Anamnesis{
+		EvaluationID:       evaluationID,
+		ReferralReason:     reason,
+		Comorbidities:      comorbidities,
+		Sensory:            sensory,
+		Sleep:              in.Sleep,
+		MoodComplaints:     in.MoodComplaints,
+		CurrentMedications: medications,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, nil
+}
+
+// Alerts devuelve los factores de la anamnesis que pueden sesgar el rendimiento en las pruebas
+func (a Anamnesis) Alerts() []string {
+	alerts := []string{}
+	if a.Sensory.Vision == SensoryUncorrected {
+		alerts = append(alerts, AlertUncorrectedVision)
+	}
+	if a.Sensory.Hearing == SensoryUncorrected {
+		alerts = append(alerts, AlertUncorrectedHearing)
+	}
+	if a.Sleep.Quality == SleepPoor || (a.Sleep.HoursPerNight != nil && *a.Sleep.HoursPerNight < 5) {
+		alerts = append(alerts, AlertPoorSleep)
+	}
+	if a.MoodComplaints.Any() {
+		alerts = append(alerts, AlertMoodComplaints)
+	}
+	return alerts
+}
+
+func validSensory(s SensoryStatus) bool {
+	return s == SensoryNone || s == SensoryCorrected || s == SensoryUncorrected
+}
+
+// cleanList recorta, descarta vacíos y duplicados
+func cleanList(items []string) ([]string, error) {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, it := range items {
+		it = strings.TrimSpace(it)
+		key := strings.ToLower(it)
+		if it == "" || seen[key] {
+			continue
+		}
+		if len(it) > MaxFreeTextLength {
+			return nil, errors.New("item too long")
+		}
+		seen[key] = true
+		out = append(out, it)
+	}
+	if len(out) > MaxListItems {
+		return nil, fmt.Errorf("more than %d items", MaxListItems)
+	}
+	return out, nil
+}
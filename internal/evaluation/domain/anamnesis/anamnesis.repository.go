@@ -0,0 +1,10 @@
+package ANdomain
+
+import "context"
+
+type AnamnesisRepository interface {
+	// Save crea o sustituye la anamnesis de la evaluación
+	Save(ctx context.Context, a *Anamnesis) error
+	// GetByEvaluationID devuelve la anamnesis vacía (EvaluationID == "") si no se ha registrado
+	GetByEvaluationID(ctx context.Context, evaluationID string) (Anamnesis, error)
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+var ErrUnsupportedAudioFormat = errors.New("unsupported audio format")
+
+// PCMAudio es audio mono normalizado a [-1, 1]
+type PCMAudio struct {
+	SampleRate int
+	Samples    []float32
+}
+
+type AudioDecoder interface {
+	// Decode devuelve ErrUnsupportedAudioFormat si no hay decodificador para el formato
+	Decode(audio []byte) (PCMAudio, error)
+}
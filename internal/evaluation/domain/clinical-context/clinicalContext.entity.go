@@ -0,0 +1,144 @@
+package CCdomain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type MedicationState string
+
+const (
+	MedicationOn        MedicationState = "on"
+	MedicationOff       MedicationState = "off"
+	MedicationUntreated MedicationState = "untreated" // sin tratamiento dopaminérgico
+)
+
+type DBSStimulation string
+
+const (
+	DBSNone DBSStimulation = "none"
+	DBSOn   DBSStimulation = "on"
+	DBSOff  DBSStimulation = "off"
+)
+
+var (
+	ErrInvalidClinicalContext = errors.New("invalid clinical context")
+	ErrUnknownDrug            = errors.New("unknown antiparkinsonian drug")
+	ErrInvalidMedication      = errors.New("invalid medication")
+)
+
+// Estadios de Hoehn & Yahr modificada
+var hoehnYahrStages = map[float64]bool{0: true, 1: true, 1.5: true, 2: true, 2.5: true, 3: true, 4: true, 5: true}
+
+var dbsTargets = map[string]bool{"stn": true, "gpi": true, "vim": true}
+
+type Medication struct {
+	Drug        string  `json:"drug"` // código, ver Drugs()
+	DailyDoseMg float64 `json:"dailyDoseMg"`
+}
+
+type DBS struct {
+	Stimulation DBSStimulation `json:"stimulation"`
+	Target      string         `json:"target,omitempty"` // stn | gpi | vim
+}
+
+type ClinicalContext struct {
+	EvaluationID             string          `json:"evaluationId"`
+	MedicationState          MedicationState `json:"medicationState"`
+	MinutesSinceLastLevodopa *int            `json:"minutesSinceLastLevodopa,omitempty"`
+	Medications              []Medication    `json:"medications"`
+	LEDD                     LEDD            `json:"ledd"`
+	HoehnYahr                *float64        `json:"hoehnYahr,omitempty"`
+	DBS                      DBS             `json:"dbs"`
+	DiseaseDurationYears     *float64        `json:"diseaseDurationYears,omitempty"`
+	CreatedAt                time.Time       `json:"createdAt"`
+	UpdatedAt                time.Time       `json:"updatedAt"`
+}
+
+type ClinicalContextInput struct {
+	MedicationState          MedicationState
+	MinutesSinceLastLevodopa *int
+	Medications              []Medication
+	HoehnYahr                *float64
+	DBS                      DBS
+	DiseaseDurationYears     *float64
+}
+
+// NewClinicalContext valida el contexto frente a la edad del paciente y calcula la LEDD
+func NewClinicalContext(evaluationID string, patientAge int, in ClinicalContextInput) (*ClinicalContext, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidClinicalContext, fmt.Sprintf(format, args...))
+	}
+	if evaluationID == "" {
+		return nil, invalid("evaluation id is required")
+	}
+
+	switch in.MedicationState {
+	case MedicationOn, MedicationOff:
+		if len(in.Medications) == 0 {
+			return nil, invalid("medication state %s requires the medication list", in.MedicationState)
+		}
+	case MedicationUntreated:
+		if len(in.Medications) > 0 {
+			return nil, invalid("untreated patient with medication list")
+		}
+	default:
+		return nil, invalid("unknown medication state %q", in.MedicationState)
+	}
+
+	ledd, err := ComputeLEDD(in.Medications)
+	if err != nil {
+		return nil, err
+	}
+
+	if m := in.MinutesSinceLastLevodopa; m != nil {
+		if ledd.Levodopa == 0 {
+			return nil, invalid("time since last levodopa dose without levodopa")
+		}
+		if *m < 0 || *m > 72*60 {
+			return nil, invalid("minutes since last levodopa dose out of range")
+		}
+	}
+
+	if h := in.HoehnYahr; h != nil && !hoehnYahrStages[*h] {
+		return nil, invalid("invalid Hoehn & Yahr stage %v", *h)
+	}
+
+	dbs := in.DBS
+	if dbs.Stimulation == "" {
+		dbs.Stimulation = DBSNone
+	}
+	switch dbs.Stimulation {
+	case DBSNone:
+		if dbs.Target != "" {
+			return nil, invalid("DBS target without implant")
+		}
+	case DBSOn, DBSOff:
+		if !dbsTargets[dbs.Target] {
+			return nil, invalid("unknown DBS target %q", dbs.Target)
+		}
+	default:
+		return nil, invalid("unknown DBS stimulation %q", dbs.Stimulation)
+	}
+
+	if d := in.DiseaseDurationYears; d != nil {
+		if *d < 0 || (patientAge > 0 && *d >= float64(patientAge)) {
+			return nil, invalid("disease duration out of range")
+		}
+	}
+
+	now := time.Now()
+	return &ClinicalContext{
+		EvaluationID:             evaluationID,
+		MedicationState:          in.MedicationState,
+		MinutesSinceLastLevodopa: in.MinutesSinceLastLevodopa,
+		Medications:              in.Medications,
+		LEDD:                     ledd,
+		HoehnYahr:                in.HoehnYahr,
+		DBS:                      dbs,
+		DiseaseDurationYears:     in.DiseaseDurationYears,
+		CreatedAt:                now,
+		UpdatedAt:                now,
+	}, nil
+}
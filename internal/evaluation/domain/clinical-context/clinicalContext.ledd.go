@@ -0,0 +1,80 @@
+package CCdomain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Factores de conversión a dosis equivalente de levodopa (Tomlinson 2010, actualización Jost 2023).
+// Los inhibidores de la COMT no tienen equivalencia propia: multiplican la levodopa diaria.
+type drugConversion struct {
+	Factor  float64 // mg LEDD por mg de fármaco
+	COMT    float64 // fracción de la levodopa que se suma (sólo inhibidores COMT)
+	Agonist bool
+}
+
+var leddConversions = map[string]drugConversion{
+	"levodopa":              {Factor: 1},
+	"levodopa_cr":           {Factor: 0.75},
+	"levodopa_gel":          {Factor: 1.11}, // infusión intestinal
+	"entacapone":            {COMT: 0.33},
+	"tolcapone":             {COMT: 0.5},
+	"opicapone":             {COMT: 0.5},
+	"pramipexole":           {Factor: 100, Agonist: true}, // dosis expresada como base
+	"ropinirole":            {Factor: 20, Agonist: true},
+	"rotigotine":            {Factor: 30, Agonist: true},
+	"apomorphine":           {Factor: 10, Agonist: true},
+	"piribedil":             {Factor: 1, Agonist: true},
+	"bromocriptine":         {Factor: 10, Agonist: true},
+	"rasagiline":            {Factor: 100},
+	"selegiline":            {Factor: 10},
+	"selegiline_sublingual": {Factor: 80},
+	"safinamide":            {Factor: 1},
+	"amantadine":            {Factor: 1},
+}
+
+type LEDD struct {
+	Total    float64 `json:"total"`    // mg/día
+	Levodopa float64 `json:"levodopa"` // levodopa incluida la potenciación COMT
+	Agonists float64 `json:"agonists"`
+}
+
+// ComputeLEDD suma la dosis equivalente diaria de la lista de medicación
+func ComputeLEDD(meds []Medication) (LEDD, error) {
+	var out LEDD
+	comt := 0.0
+	for _, m := range meds {
+		conv, ok := leddConversions[m.Drug]
+		if !ok {
+			return LEDD{}, fmt.Errorf("%w: %q", ErrUnknownDrug, m.Drug)
+		}
+		if m.DailyDoseMg <= 0 {
+			return LEDD{}, fmt.Errorf("%w: %s daily dose must be positive", ErrInvalidMedication, m.Drug)
+		}
+		if conv.COMT > 0 {
+			// varios inhibidores COMT no se combinan: se toma el mayor
+			comt = math.Max(comt, conv.COMT)
+			continue
+		}
+		mg := m.DailyDoseMg * conv.Factor
+		switch {
+		case conv.Agonist:
+			out.Agonists += mg
+		case m.Drug == "levodopa" || m.Drug == "levodopa_cr" || m.Drug == "levodopa_gel":
+			out.Levodopa += mg
+		}
+		out.Total += mg
+	}
+	if comt > 0 {
+		if out.Levodopa == 0 {
+			return LEDD{}, fmt.Errorf("%w: COMT inhibitor without levodopa", ErrInvalidMedication)
+		}
+		extra := out.Levodopa * comt
+		out.Levodopa += extra
+		out.Total += extra
+	}
+	out.Total = math.Round(out.Total)
+	out.Levodopa = math.Round(out.Levodopa)
+	out.Agonists = math.Round(out.Agonists)
+	return out, nil
+}
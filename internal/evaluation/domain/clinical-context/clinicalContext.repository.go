@@ -0,0 +1,10 @@
+package CCdomain
+
+import "context"
+
+type ClinicalContextRepository interface {
+	// Save crea o sustituye el contexto clínico de la evaluación
+	Save(ctx context.Context, cc *ClinicalContext) error
+	// GetByEvaluationID devuelve el contexto vacío (EvaluationID == "") si no se ha registrado
+	GetByEvaluationID(ctx context.Context, evaluationID string) (ClinicalContext, error)
+}
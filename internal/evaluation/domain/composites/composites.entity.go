@@ -0,0 +1,183 @@
+package COMPdomain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrInvalidConfig = errors.New("invalid composite config")
+
+// CognitiveDomain agrupa métricas de distintos subtests que miden la misma función
+type CognitiveDomain string
+
+const (
+	Attention       CognitiveDomain = "attention"
+	ProcessingSpeed CognitiveDomain = "processing_speed"
+	Memory          CognitiveDomain = "memory"
+	Executive       CognitiveDomain = "executive"
+	Language        CognitiveDomain = "language"
+	Visuospatial    CognitiveDomain = "visuospatial"
+)
+
+// Domains fija el orden de presentación (y de los ejes del radar)
+var Domains = []CognitiveDomain{Attention, ProcessingSpeed, Memory, Executive, Language, Visuospatial}
+
+// Scale indica cómo llega la métrica: z ya normativa, T (50±10) o bruta con norma en la config
+type Scale string
+
+const (
+	ScaleZ   Scale = "z"
+	ScaleT   Scale = "t"
+	ScaleRaw Scale = "raw"
+)
+
+type Classification string
+
+const (
+	ClassificationNormal   Classification = "normal"   // índice ≥ 85 (z ≥ −1)
+	ClassificationLow      Classification = "low"      // 70..85 (−2 ≤ z < −1)
+	ClassificationImpaired Classification = "impaired" // < 70 (z < −2)
+)
+
+type Norm struct {
+	Mean float64 `json:"mean"`
+	SD   float64 `json:"sd"`
+}
+
+type IndicatorConfig struct {
+	Domain        CognitiveDomain `json:"domain"`
+	Weight        float64         `json:"weight"`
+	Reliability   float64         `json:"reliability"` // fiabilidad test-retest de la métrica (0..1)
+	Scale         Scale           `json:"scale"`
+	Norm          *Norm           `json:"norm,omitempty"` // obligatoria con scale raw
+	LowerIsBetter bool            `json:"lowerIsBetter"`
+}
+
+// Config define pesos, normas y fiabilidades del índice compuesto. Como los perfiles de
+// puntuación, se identifica con id@versión y la referencia acompaña a cada resultado.
+type Config struct {
+	ID                        string                      `json:"id"`
+	Version                   int                         `json:"version"`
+	Description               string                      `json:"description"`
+	ConfidenceLevel           float64                     `json:"confidenceLevel"`           // p. ej. 0.95
+	IndicatorIntercorrelation float64                     `json:"indicatorIntercorrelation"` // correlación media supuesta dentro de un dominio
+	DomainIntercorrelation    float64                     `json:"domainIntercorrelation"`    // correlación media supuesta entre dominios
+	MinDomainsForGlobal       int                         `json:"minDomainsForGlobal"`
+	Indicators                map[string]IndicatorConfig  `json:"indicators"`
+	DomainWeights             map[CognitiveDomain]float64 `json:"domainWeights"`
+}
+
+type ConfigProvider interface {
+	Current(ctx context.Context) (Config, error)
+}
+
+// Input es una métrica extraída de un subtest administrado
+type Input struct {
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+}
+
+type IndicatorScore struct {
+	Key    string  `json:"key"`
+	Value  float64 `json:"value"`
+	Z      float64 `json:"z"` // positivo = mejor que la norma
+	Weight float64 `json:"weight"`
+}
+
+// Score es un compuesto en z (media 0, DE 1) y en índice (100 ± 15) con su intervalo de confianza
+type Score struct {
+	Z              float64        `json:"z"`
+	Index          float64        `json:"index"`
+	CILow          float64        `json:"ciLow"`
+	CIHigh         float64        `json:"ciHigh"`
+	Reliability    float64        `json:"reliability"`
+	Percentile     float64        `json:"percentile"`
+	Classification Classification `json:"classification"`
+}
+
+type DomainComposite struct {
+	Domain CognitiveDomain `json:"domain"`
+	Score
+	Indicators []IndicatorScore `json:"indicators"`
+}
+
+type GlobalIndex struct {
+	Score
+	Domains []CognitiveDomain `json:"domains"`
+}
+
+type CognitiveProfile struct {
+	ConfigRef       string            `json:"configRef"`
+	ConfidenceLevel float64           `json:"confidenceLevel"`
+	Domains         []DomainComposite `json:"domains"`
+	Global          *GlobalIndex      `json:"global"` // nil si hay menos dominios que MinDomainsForGlobal
+}
+
+func (c Config) Ref() string {
+	return fmt.Sprintf("%s@%d", c.ID, c.Version)
+}
+
+func (c Config) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidConfig, c.Ref(), fmt.Sprintf(format, args...))
+	}
+	if c.ID == "" || c.Version <= 0 {
+		return fmt.Errorf("%w: id %q / version %d", ErrInvalidConfig, c.ID, c.Version)
+	}
+	if c.ConfidenceLevel <= 0 || c.ConfidenceLevel >= 1 {
+		return invalid("confidenceLevel must be in (0, 1)")
+	}
+	if c.IndicatorIntercorrelation < 0 || c.IndicatorIntercorrelation >= 1 || c.DomainIntercorrelation < 0 || c.DomainIntercorrelation >= 1 {
+		return invalid("intercorrelations must be in [0, 1)")
+	}
+	if c.MinDomainsForGlobal < 1 {
+		return invalid("minDomainsForGlobal must be >= 1")
+	}
+	known := map[CognitiveDomain]bool{}
+	for _, d := range Domains {
+		known[d] = true
+	}
+	for key, ic := range c.Indicators {
+		if !known[ic.Domain] {
+			return invalid("indicator %s: unknown domain %q", key, ic.Domain)
+		}
+		if ic.Weight <= 0 || ic.Reliability <= 0 || ic.Reliability > 1 {
+			return invalid("indicator %s: weight must be > 0 and reliability in (0, 1]", key)
+		}
+		switch ic.Scale {
+		case ScaleZ, ScaleT:
+		case ScaleRaw:
+			if ic.Norm == nil || ic.Norm.SD <= 0 {
+				return invalid("indicator %s: raw scale needs a norm with sd > 0", key)
+			}
+		default:
+			return invalid("indicator %s: unknown scale %q", key, ic.Scale)
+		}
+	}
+	for d, w := range c.DomainWeights {
+		if !known[d] || w <= 0 {
+			return invalid("domain weight %s must be a known domain with weight > 0", d)
+		}
+	}
+	return nil
+}
+
+// ToZ convierte el valor de la métrica a z con signo positivo = mejor rendimiento
+func (ic IndicatorConfig) ToZ(value float64) float64 {
+	var z float64
+	switch ic.Scale {
+	case ScaleT:
+		z = (value - 50) / 10
+	case ScaleRaw:
+		z = (value - ic.Norm.Mean) / ic.Norm.SD
+	default:
+		z = value
+	}
+	if ic.LowerIsBetter {
+		z = -z
+	}
+	// Un valor extremo no debe dominar el compuesto
+	return math.Max(-5, math.Min(5, z))
+}
@@ -0,0 +1,106 @@
+package COMPdomain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr bool
+	}{
+		{name: "Valid config", mutate: func(c *Config) {}},
+		{name: "Valid - known domain weight", mutate: func(c *Config) { c.DomainWeights = map[CognitiveDomain]float64{Memory: 2} }},
+		{name: "Invalid - missing id", mutate: func(c *Config) { c.ID = "" }, wantErr: true},
+		{name: "Invalid - confidence level of 1", mutate: func(c *Config) { c.ConfidenceLevel = 1 }, wantErr: true},
+		{name: "Invalid - intercorrelation of 1", mutate: func(c *Config) { c.DomainIntercorrelation = 1 }, wantErr: true},
+		{name: "Invalid - MinDomainsForGlobal of 0", mutate: func(c *Config) { c.MinDomainsForGlobal = 0 }, wantErr: true},
+		{
+			name: "Invalid - zero weight",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_total"] = IndicatorConfig{Domain: Memory, Weight: 0, Reliability: 0.8, Scale: ScaleT}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid - reliability above 1",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_total"] = IndicatorConfig{Domain: Memory, Weight: 1, Reliability: 1.2, Scale: ScaleT}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid - raw scale without norm",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_delayed"] = IndicatorConfig{Domain: Memory, Weight: 1, Reliability: 0.8, Scale: ScaleRaw}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid - raw scale with zero sd",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_delayed"] = IndicatorConfig{Domain: Memory, Weight: 1, Reliability: 0.8, Scale: ScaleRaw, Norm: &Norm{Mean: 10}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid - unknown scale",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_total"] = IndicatorConfig{Domain: Memory, Weight: 1, Reliability: 0.8, Scale: "percentile"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid - unknown domain",
+			mutate: func(c *Config) {
+				c.Indicators["hvlt_total"] = IndicatorConfig{Domain: "motor", Weight: 1, Reliability: 0.8, Scale: ScaleT}
+			},
+			wantErr: true,
+		},
+		{name: "Invalid - zero domain weight", mutate: func(c *Config) { c.DomainWeights = map[CognitiveDomain]float64{Memory: 0} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidConfig) {
+					t.Fatalf("expected ErrInvalidConfig, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected valid config, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIndicatorConfigToZ(t *testing.T) {
+	tests := []struct {
+		name  string
+		ic    IndicatorConfig
+		value float64
+		want  float64
+	}{
+		{name: "z scale", ic: IndicatorConfig{Scale: ScaleZ}, value: -1.5, want: -1.5},
+		{name: "T scale", ic: IndicatorConfig{Scale: ScaleT}, value: 35, want: -1.5},
+		{name: "raw scale", ic: IndicatorConfig{Scale: ScaleRaw, Norm: &Norm{Mean: 10, SD: 2}}, value: 13, want: 1.5},
+		{name: "lower is better", ic: IndicatorConfig{Scale: ScaleRaw, Norm: &Norm{Mean: 30, SD: 10}, LowerIsBetter: true}, value: 45, want: -1.5},
+		{name: "clamped at -5", ic: IndicatorConfig{Scale: ScaleZ}, value: -9, want: -5},
+		{name: "clamped at +5", ic: IndicatorConfig{Scale: ScaleT}, value: 120, want: 5},
+		{name: "clamped after inverting", ic: IndicatorConfig{Scale: ScaleZ, LowerIsBetter: true}, value: -9, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ic.ToZ(tt.value); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
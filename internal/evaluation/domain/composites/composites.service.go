@@ -0,0 +1,104 @@
+package COMPdomain
+
+import (
+	"math"
+
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type weighted struct {
+	z, weight, reliability float64
+}
+
+// combine suma ponderada estandarizada. La varianza de la suma asume una correlación media rho
+// entre componentes y la fiabilidad del compuesto sigue la fórmula de Mosier.
+func combine(parts []weighted, rho, confidence float64) Score {
+	var sum, sumW2, sumW, sumW2Err float64
+	for _, p := range parts {
+		sum += p.weight * p.z
+		sumW += p.weight
+		sumW2 += p.weight * p.weight
+		sumW2Err += p.weight * p.weight * (1 - p.reliability)
+	}
+	variance := sumW2 + rho*(sumW*sumW-sumW2)
+	z := sum / math.Sqrt(variance)
+	reliability := 1 - sumW2Err/variance
+
+	sem := math.Sqrt(math.Max(0, 1-reliability))
+	zCrit := utils.ProbabilityToZ(1 - (1-confidence)/2)
+	return Score{
+		Z:              round2(z),
+		Index:          round1(100 + 15*z),
+		CILow:          round1(100 + 15*(z-zCrit*sem)),
+		CIHigh:         round1(100 + 15*(z+zCrit*sem)),
+		Reliability:    round2(reliability),
+		Percentile:     round1(utils.ZToPercentile(z)),
+		Classification: classify(z),
+	}
+}
+
+func classify(z float64) Classification {
+	switch {
+	case z < -2:
+		return ClassificationImpaired
+	case z < -1:
+		return ClassificationLow
+	default:
+		return ClassificationNormal
+	}
+}
+
+// Compute agrupa las métricas disponibles por dominio y calcula los compuestos y el índice
+// global. Las métricas sin configuración se ignoran; un dominio sin métricas no aparece.
+func Compute(cfg Config, inputs []Input) CognitiveProfile {
+	byDomain := map[CognitiveDomain][]IndicatorScore{}
+	parts := map[CognitiveDomain][]weighted{}
+	for _, in := range inputs {
+		ic, ok := cfg.Indicators[in.Key]
+		if !ok {
+			continue
+		}
+		z := ic.ToZ(in.Value)
+		byDomain[ic.Domain] = append(byDomain[ic.Domain], IndicatorScore{Key: in.Key, Value: in.Value, Z: round2(z), Weight: ic.Weight})
+		parts[ic.Domain] = append(parts[ic.Domain], weighted{z: z, weight: ic.Weight, reliability: ic.Reliability})
+	}
+
+	profile := CognitiveProfile{ConfigRef: cfg.Ref(), ConfidenceLevel: cfg.ConfidenceLevel, Domains: []DomainComposite{}}
+	var global []weighted
+	var used []CognitiveDomain
+	for _, d := range Domains {
+		if len(parts[d]) == 0 {
+			continue
+		}
+		score := combine(parts[d], cfg.IndicatorIntercorrelation, cfg.ConfidenceLevel)
+		profile.Domains = append(profile.Domains, DomainComposite{Domain: d, Score: score, Indicators: byDomain[d]})
+
+		w := cfg.DomainWeights[d]
+		if w <= 0 {
+			w = 1
+		}
+		global = append(global, weighted{z: score.Z, weight: w, reliability: score.Reliability})
+		used = append(used, d)
+	}
+
+	if len(used) >= cfg.MinDomainsForGlobal {
+		profile.Global = &GlobalIndex{
+			Score:   combine(global, cfg.DomainIntercorrelation, cfg.ConfidenceLevel),
+			Domains: used,
+		}
+	}
+	return profile
+}
+
+// ForDomain devuelve el compuesto del dominio si se pudo calcular
+func (p CognitiveProfile) ForDomain(d CognitiveDomain) (DomainComposite, bool) {
+	for _, c := range p.Domains {
+		if c.Domain == d {
+			return c, true
+		}
+	}
+	return DomainComposite{}, false
+}
+
+func round1(x float64) float64 { return math.Round(x*10) / 10 }
+func round2(x float64) float64 { return math.Round(x*100) / 100 }
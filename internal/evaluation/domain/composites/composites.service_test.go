@@ -0,0 +1,126 @@
+package COMPdomain
+
+import (
+	"testing"
+)
+
+// testConfig: memoria con dos métricas (T y bruta) y velocidad con una métrica en segundos
+func testConfig() Config {
+	return Config{
+		ID:                        "composites-test",
+		Version:                   1,
+		ConfidenceLevel:           0.95,
+		IndicatorIntercorrelation: 0.5,
+		DomainIntercorrelation:    0.3,
+		MinDomainsForGlobal:       2,
+		Indicators: map[string]IndicatorConfig{
+			"hvlt_total":    {Domain: Memory, Weight: 1, Reliability: 0.8, Scale: ScaleT},
+			"hvlt_delayed":  {Domain: Memory, Weight: 1, Reliability: 0.8, Scale: ScaleRaw, Norm: &Norm{Mean: 10, SD: 2}},
+			"tmt_a_seconds": {Domain: ProcessingSpeed, Weight: 1, Reliability: 0.9, Scale: ScaleRaw, Norm: &Norm{Mean: 30, SD: 10}, LowerIsBetter: true},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	// Valores calculados a mano (IC 95 %, z crítica 1.96):
+	// memoria: z = (−2 − 1.5)/√(2 + 0.5·2) = −2.021; fiabilidad = 1 − 0.4/3 = 0.867
+	// velocidad: 50 s con norma 30±10 y lowerIsBetter → z = −2; fiabilidad 0.9
+	// global: z = (−2.02 − 2)/√(2 + 0.3·2) = −2.493; fiabilidad = 1 − (0.13 + 0.1)/2.6 = 0.912
+	memory := Score{Z: -2.02, Index: 69.7, CILow: 59.0, CIHigh: 80.4, Reliability: 0.87, Percentile: 2.2, Classification: ClassificationImpaired}
+	speed := Score{Z: -2, Index: 70, CILow: 60.7, CIHigh: 79.3, Reliability: 0.9, Percentile: 2.3, Classification: ClassificationLow}
+	global := Score{Z: -2.49, Index: 62.6, CILow: 53.9, CIHigh: 71.3, Reliability: 0.91, Percentile: 0.6, Classification: ClassificationImpaired}
+
+	inputs := []Input{
+		{Key: "hvlt_total", Value: 30},
+		{Key: "hvlt_delayed", Value: 7},
+		{Key: "tmt_a_seconds", Value: 50},
+		{Key: "not_configured", Value: 99},
+	}
+
+	tests := []struct {
+		name        string
+		minDomains  int
+		inputs      []Input
+		wantDomains map[CognitiveDomain]Score
+		wantGlobal  *Score
+	}{
+		{
+			name:        "Two domains with global index",
+			minDomains:  2,
+			inputs:      inputs,
+			wantDomains: map[CognitiveDomain]Score{Memory: memory, ProcessingSpeed: speed},
+			wantGlobal:  &global,
+		},
+		{
+			name:        "Global index needs MinDomainsForGlobal domains",
+			minDomains:  3,
+			inputs:      inputs,
+			wantDomains: map[CognitiveDomain]Score{Memory: memory, ProcessingSpeed: speed},
+		},
+		{
+			name:        "Single domain without global index",
+			minDomains:  2,
+			inputs:      inputs[:2],
+			wantDomains: map[CognitiveDomain]Score{Memory: memory},
+		},
+		{
+			name:        "Only unknown metrics",
+			minDomains:  1,
+			inputs:      inputs[3:],
+			wantDomains: map[CognitiveDomain]Score{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.MinDomainsForGlobal = tt.minDomains
+
+			profile := Compute(cfg, tt.inputs)
+
+			if profile.ConfigRef != "composites-test@1" || profile.ConfidenceLevel != 0.95 {
+				t.Errorf("unexpected config reference: %s / %v", profile.ConfigRef, profile.ConfidenceLevel)
+			}
+			if len(profile.Domains) != len(tt.wantDomains) {
+				t.Fatalf("expected %d domains, got %+v", len(tt.wantDomains), profile.Domains)
+			}
+			for d, want := range tt.wantDomains {
+				got, ok := profile.ForDomain(d)
+				if !ok {
+					t.Fatalf("missing domain %s", d)
+				}
+				if got.Score != want {
+					t.Errorf("%s: expected %+v, got %+v", d, want, got.Score)
+				}
+			}
+			if tt.wantGlobal == nil {
+				if profile.Global != nil {
+					t.Errorf("expected no global index, got %+v", profile.Global)
+				}
+				return
+			}
+			if profile.Global == nil {
+				t.Fatalf("expected a global index")
+			}
+			if profile.Global.Score != *tt.wantGlobal {
+				t.Errorf("global: expected %+v, got %+v", *tt.wantGlobal, profile.Global.Score)
+			}
+			if len(profile.Global.Domains) != 2 || profile.Global.Domains[0] != ProcessingSpeed || profile.Global.Domains[1] != Memory {
+				t.Errorf("expected global over processing_speed and memory (presentation order), got %v", profile.Global.Domains)
+			}
+		})
+	}
+}
+
+func TestComputeIndicatorScores(t *testing.T) {
+	profile := Compute(testConfig(), []Input{{Key: "tmt_a_seconds", Value: 50}, {Key: "hvlt_delayed", Value: -100}})
+
+	speed, _ := profile.ForDomain(ProcessingSpeed)
+	if len(speed.Indicators) != 1 || speed.Indicators[0] != (IndicatorScore{Key: "tmt_a_seconds", Value: 50, Z: -2, Weight: 1}) {
+		t.Errorf("lower-is-better time should score z = -2, got %+v", speed.Indicators)
+	}
+	memory, _ := profile.ForDomain(Memory)
+	if len(memory.Indicators) != 1 || memory.Indicators[0].Z != -5 || memory.Z != -5 {
+		t.Errorf("extreme value should be clamped to z = -5, got %+v", memory)
+	}
+}
@@ -0,0 +1,106 @@
+package DQdomain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCorruptSubmission se devuelve al crear un subtest con algún flag de severidad error
+var ErrCorruptSubmission = errors.New("corrupt submission")
+
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"    // contexto para interpretar (techo/suelo)
+	SeverityWarning Severity = "warning" // dato posible pero poco plausible: interpretar con cautela
+	SeverityError   Severity = "error"   // dato imposible: se rechaza al crear
+)
+
+type Code string
+
+const (
+	CodeImpossibleValue        Code = "impossible_value"
+	CodeImplausiblyFast        Code = "implausibly_fast"
+	CodeCeilingEffect          Code = "ceiling_effect"
+	CodeFloorEffect            Code = "floor_effect"
+	CodeExaminerDeviceMismatch Code = "examiner_device_mismatch"
+)
+
+// Flag es un aviso de calidad sobre un subtest; Subtest usa los mismos códigos que las observaciones del evaluador
+type Flag struct {
+	Subtest   string   `json:"subtest"`
+	SubtestID string   `json:"subtestId,omitempty"`
+	Code      Code     `json:"code"`
+	Severity  Severity `json:"severity"`
+	Field     string   `json:"field,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// Checker acumula flags de un subtest
+type Checker struct {
+	Subtest   string
+	SubtestID string
+	Flags     []Flag
+}
+
+func NewChecker(subtest, subtestID string) *Checker {
+	return &Checker{Subtest: subtest, SubtestID: subtestID, Flags: []Flag{}}
+}
+
+func (c *Checker) Add(code Code, severity Severity, field, format string, args ...any) {
+	c.Flags = append(c.Flags, Flag{Subtest: c.Subtest, SubtestID: c.SubtestID, Code: code, Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Blocking devuelve ErrCorruptSubmission con los mensajes de los flags de severidad error. Los
+// handlers de creación lo aplican tras puntuar y antes de guardar: un dato imposible (menos clics que
+// respuestas, más palabras recordadas de las posibles...) invalida el subtest y no debe llegar a
+// las normas ni al informe, así que se rechaza; los avisos e infos son lecturas posibles que el
+// especialista debe ver, y se guardan con el subtest (DataQualityRepository).
+func Blocking(flags []Flag) error {
+	var msgs []string
+	for _, f := range flags {
+		if f.Severity == SeverityError {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrCorruptSubmission, strings.Join(msgs, "; "))
+}
+
+// Report agrupa los flags de toda la evaluación
+type Report struct {
+	Flags    []Flag `json:"flags"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+	Infos    int    `json:"infos"`
+}
+
+func NewReport(flags []Flag) Report {
+	r := Report{Flags: []Flag{}}
+	for _, f := range flags {
+		r.Flags = append(r.Flags, f)
+		switch f.Severity {
+		case SeverityError:
+			r.Errors++
+		case SeverityWarning:
+			r.Warnings++
+		default:
+			r.Infos++
+		}
+	}
+	return r
+}
+
+// For filtra los flags de un subtest
+func (r Report) For(subtest string) []Flag {
+	out := []Flag{}
+	for _, f := range r.Flags {
+		if f.Subtest == subtest {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,9 @@
+package DQdomain
+
+import "context"
+
+type DataQualityRepository interface {
+	// Replace sustituye los flags guardados de una fila de subtest por flags (vacío los borra)
+	Replace(ctx context.Context, evaluationID, subtestID string, flags []Flag) error
+	GetByEvaluationID(ctx context.Context, evaluationID string) ([]Flag, error)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+// CompositeInputs extrae de los subtests administrados las métricas que alimentan los índices
+// compuestos. Las claves coinciden con las de COMPdomain.Config.Indicators.
+func (e Evaluation) CompositeInputs() []COMPdomain.Input {
+	var in []COMPdomain.Input
+	add := func(key string, v float64) {
+		in = append(in, COMPdomain.Input{Key: key, Value: v})
+	}
+
+	if ds := e.DigitSpanSubTest; ds.PK != "" {
+		if ds.Score.Forward.Present {
+			add("digit_span.forward_z", ds.Score.Forward.ZScore)
+		}
+		if ds.Score.Backward.Present {
+			add("digit_span.backward_z", ds.Score.Backward.ZScore)
+		}
+		if ds.Score.Sequencing.Present {
+			add("digit_span.sequencing_z", ds.Score.Sequencing.ZScore)
+		}
+	}
+	if lc := e.LetterCancellationSubTest; lc.PK != "" {
+		add("letter_cancellation.score", float64(lc.CancellationScore.Score))
+	}
+	if sd := e.SDMTSubTest; sd.PK != "" {
+		add("sdmt.z", sd.Score.ZScore)
+	}
+	if rt := e.ReactionTimeSubTest; rt.PK != "" && rt.Score.MotorSpeed.Available {
+		add("reaction_time.simple_z", rt.Score.MotorSpeed.ZScore)
+	}
+	for _, ef := range e.ExecutiveFunctionSubTest {
+		switch ef.Type {
+		case EFdomain.A:
+			add("trail_making.a_score", float64(ef.Score.Score))
+		case EFdomain.AB:
+			add("trail_making.ab_score", float64(ef.Score.Score))
+		}
+	}
+	if st := e.StroopSubTest; st.PK != "" {
+		add("stroop.interference_t", st.Score.InterferenceT)
+	}
+	if gng := e.GoNoGoSubTest; gng.PK != "" {
+		add("go_no_go.d_prime", gng.Score.DPrime)
+	}
+	if cs := e.CardSortingSubTest; cs.PK != "" && cs.Status == WCSTdomain.SessionCompleted {
+		add("card_sorting.categories", float64(cs.Score.CategoriesCompleted))
+	}
+
+	// Si hay varios ensayos del mismo tipo se usa la media
+	recall := map[VEMdomain.VerbalMemorySubtype][]float64{}
+	for _, vm := range e.VerbalmemorySubTest {
+		recall[vm.Type] = append(recall[vm.Type], float64(vm.Score.Score))
+	}
+	if xs := recall[VEMdomain.VerbalMemorySubtypeImmediate]; len(xs) > 0 {
+		add("verbal_memory.immediate_score", mean(xs))
+	}
+	if xs := recall[VEMdomain.VerbalMemorySubtypeDelayed]; len(xs) > 0 {
+		add("verbal_memory.delayed_score", mean(xs))
+	}
+	if vim := e.VisualMemorySubTest; vim.PK != "" {
+		add("visual_memory.score", float64(vim.Score.Val))
+	}
+
+	if lf := e.LanguageFluencySubTest; lf.PK != "" {
+		add("language_fluency.unique_valid", float64(lf.Score.UniqueValid))
+	}
+	if cn := e.ConfrontationNamingSubTest; cn.PK != "" {
+		add("confrontation_naming.score", float64(cn.Score.Score))
+	}
+
+	if jlo := e.JLOSubTest; jlo.PK != "" {
+		add("jlo.score", float64(jlo.Score.Score))
+	}
+	if vp := e.VisualSpatialSubTest; vp.Id != "" {
+		add("visual_spatial.score", float64(vp.Score.Val))
+	}
+	return in
+}
+
+func mean(xs []float64) float64 {
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	return s / float64(len(xs))
+}
+
+// FunctionalImpairment indica si el FAQ administrado (y válido) resulta alterado
+func (e Evaluation) FunctionalImpairment() bool {
+	for _, q := range e.Questionnaires {
+		if q.Code == "faq" && q.Score.Valid && q.Score.Abnormal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags parte de los flags guardados al crear cada subtest y se queda solo con los de
+// las filas vigentes (un subtest repetido sustituye al anterior). Los desacuerdos de la MoCA
+// con la tableta cruzan varios subtests, así que se calculan al leer.
+func (e Evaluation) QualityFlags(stored []DQdomain.Flag) []DQdomain.Flag {
+	current := e.subtestIDs()
+	flags := []DQdomain.Flag{}
+	for _, f := range stored {
+		if current[f.SubtestID] {
+			flags = append(flags, f)
+		}
+	}
+	if m := e.MoCASubTest; m.PK != "" {
+		var cdt, forward *int
+		if e.VisualSpatialSubTest.Id != "" {
+			v := e.VisualSpatialSubTest.Score.Val
+			cdt = &v
+		}
+		if f := e.DigitSpanSubTest.Score.Forward; e.DigitSpanSubTest.PK != "" && f.Present {
+			v := f.LongestSpan
+			forward = &v
+		}
+		flags = append(flags, m.MismatchFlags(cdt, forward)...)
+	}
+	return flags
+}
+
+func (e Evaluation) subtestIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, id := range []string{
+		e.LetterCancellationSubTest.PK,
+		e.VisualMemorySubTest.PK,
+		e.LanguageFluencySubTest.PK,
+		e.VisualSpatialSubTest.Id,
+		e.DigitSpanSubTest.PK,
+		e.StroopSubTest.PK,
+		e.SDMTSubTest.PK,
+		e.ConfrontationNamingSubTest.PK,
+		e.ReactionTimeSubTest.PK,
+		e.FingerTappingSubTest.PK,
+		e.ArchimedesSpiralSubTest.PK,
+		e.JLOSubTest.PK,
+		e.GoNoGoSubTest.PK,
+		e.CardSortingSubTest.PK,
+		e.MoCASubTest.PK,
+	} {
+		if id != "" {
+			ids[id] = true
+		}
+	}
+	for _, vm := range e.VerbalmemorySubTest {
+		ids[vm.Pk] = true
+	}
+	for _, ef := range e.ExecutiveFunctionSubTest {
+		ids[ef.PK] = true
+	}
+	return ids
+}
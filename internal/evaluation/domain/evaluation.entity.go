@@ -5,9 +5,31 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	PDMCIdomain "neuro.app.jordi/internal/evaluation/domain/pd-mci"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	SAdomain "neuro.app.jordi/internal/evaluation/domain/structured-analysis"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
 	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
 	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
@@ -31,22 +53,46 @@ const (
 )
 
 type Evaluation struct {
-	PK                        string                  `json:"pk"`
-	PatientName               string                  `json:"patientName"`
-	PatientAge                int                     `json:"patientAge"`
-	SpecialistMail            string                  `json:"specialistMail"`
-	SpecialistID              string                  `json:"specialistId"`
-	AssistantAnalysis         string                  `json:"assistantAnalysis"`
-	StorageURL                string                  `json:"storage_url"`
-	StorageKey                string                  `json:"storage_key"`
-	CreatedAt                 time.Time               `json:"createdAt"`
-	CurrentStatus             EvaluationCurrentStatus `json:"currentStatus"`
-	LetterCancellationSubTest LCdomain.LettersCancellationSubtest
-	VisualMemorySubTest       VIMdomain.VisualMemorySubtest
-	VerbalmemorySubTest       []VEMdomain.VerbalMemorySubtest
-	ExecutiveFunctionSubTest  []EFdomain.ExecutiveFunctionsSubtest
-	LanguageFluencySubTest    LFdomain.LanguageFluency
-	VisualSpatialSubTest      VPdomain.VisualSpatialSubtest
+	PK                         string                  `json:"pk"`
+	PatientName                string                  `json:"patientName"`
+	PatientAge                 int                     `json:"patientAge"`
+	SpecialistMail             string                  `json:"specialistMail"`
+	SpecialistID               string                  `json:"specialistId"`
+	AssistantAnalysis          string                  `json:"assistantAnalysis"`
+	AnalysisUsage              LLMUsage                `json:"analysisUsage"`
+	StructuredAnalysis         SAdomain.Analysis       `json:"structuredAnalysis"`
+	StorageURL                 string                  `json:"storage_url"`
+	StorageKey                 string                  `json:"storage_key"`
+	CreatedAt                  time.Time               `json:"createdAt"`
+	CurrentStatus              EvaluationCurrentStatus `json:"currentStatus"`
+	LetterCancellationSubTest  LCdomain.LettersCancellationSubtest
+	VisualMemorySubTest        VIMdomain.VisualMemorySubtest
+	VerbalmemorySubTest        []VEMdomain.VerbalMemorySubtest
+	ExecutiveFunctionSubTest   []EFdomain.ExecutiveFunctionsSubtest
+	LanguageFluencySubTest     LFdomain.LanguageFluency
+	VisualSpatialSubTest       VPdomain.VisualSpatialSubtest
+	DigitSpanSubTest           DSdomain.DigitSpanSubtest
+	StroopSubTest              STRdomain.StroopSubtest
+	SDMTSubTest                SDMTdomain.SDMTSubtest
+	ConfrontationNamingSubTest CNdomain.ConfrontationNamingSubtest
+	ReactionTimeSubTest        RTdomain.ReactionTimeSubtest
+	FingerTappingSubTest       FTdomain.FingerTappingSubtest
+	ArchimedesSpiralSubTest    ASdomain.ArchimedesSpiralSubtest
+	JLOSubTest                 JLOdomain.JLOSubtest
+	GoNoGoSubTest              GNGdomain.GoNoGoSubtest
+	CardSortingSubTest         WCSTdomain.CardSortingSubtest
+	Questionnaires             []QNdomain.QuestionnaireResponse
+	MoCASubTest                MOCAdomain.MoCASubtest
+	ClinicalContext            CCdomain.ClinicalContext
+	Anamnesis                  ANdomain.Anamnesis
+	ExaminerObservations       []EOdomain.ExaminerObservation
+	ScoringSelection           SCPdomain.ScoringSelection
+	CognitiveProfile           COMPdomain.CognitiveProfile
+	PDMCICriteria              PDMCIdomain.Assessment
+	NeuroProfile               NPdomain.Classification
+	NeuroProfileCheck          NPdomain.CrossCheck
+	DataQuality                DQdomain.Report
+	SpeechProfile              SPdomain.SpeechProfile
 }
 
 func newPatientName(name string) (string, error) {
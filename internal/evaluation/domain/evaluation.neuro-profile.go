@@ -0,0 +1,115 @@
+package domain
+
+import (
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+// NeuroProfileInput reúne las métricas que usan las reglas del clasificador offline
+func (e Evaluation) NeuroProfileInput() NPdomain.Input {
+	f := func(v float64) *float64 { return &v }
+	var in NPdomain.Input
+
+	// Varios ensayos del mismo tipo se promedian, como en los compuestos
+	recall := map[VEMdomain.VerbalMemorySubtype][]float64{}
+	var intrusions, perseverations []float64
+	for _, vm := range e.VerbalmemorySubTest {
+		recall[vm.Type] = append(recall[vm.Type], float64(vm.Score.Score))
+		intrusions = append(intrusions, vm.Score.IntrusionRate)
+		perseverations = append(perseverations, vm.Score.PerseverationRate)
+	}
+	if xs := recall[VEMdomain.VerbalMemorySubtypeImmediate]; len(xs) > 0 {
+		in.VerbalImmediate = f(mean(xs))
+	}
+	if xs := recall[VEMdomain.VerbalMemorySubtypeDelayed]; len(xs) > 0 {
+		in.VerbalDelayed = f(mean(xs))
+	}
+	if len(intrusions) > 0 {
+		in.VerbalIntrusionRate = f(mean(intrusions))
+		in.VerbalPerseverationRate = f(mean(perseverations))
+	}
+
+	for _, ef := range e.ExecutiveFunctionSubTest {
+		sec := ef.Score.DurationSec
+		if sec <= 0 {
+			sec = ef.TotalTime.Seconds()
+		}
+		if sec <= 0 {
+			continue
+		}
+		switch ef.Type {
+		case EFdomain.A:
+			in.TMTASec = f(sec)
+		case EFdomain.AB:
+			in.TMTABSec = f(sec)
+		}
+	}
+
+	if ds := e.DigitSpanSubTest; ds.PK != "" {
+		if ds.Score.Forward.Present {
+			in.DigitForwardZ = f(ds.Score.Forward.ZScore)
+		}
+		if ds.Score.Backward.Present {
+			in.DigitBackwardZ = f(ds.Score.Backward.ZScore)
+		}
+		if ds.Score.Sequencing.Present {
+			in.DigitSequencingZ = f(ds.Score.Sequencing.ZScore)
+		}
+	}
+	if st := e.StroopSubTest; st.PK != "" {
+		in.StroopInterferenceT = f(st.Score.InterferenceT)
+	}
+	if sd := e.SDMTSubTest; sd.PK != "" {
+		in.SDMTZ = f(sd.Score.ZScore)
+	}
+	if lc := e.LetterCancellationSubTest; lc.PK != "" {
+		in.LettersScore = f(float64(lc.CancellationScore.Score))
+	}
+	if lf := e.LanguageFluencySubTest; lf.PK != "" {
+		in.FluencyScore = f(float64(lf.Score.Score))
+	}
+	if cn := e.ConfrontationNamingSubTest; cn.PK != "" {
+		semantic, phonemic := cn.Score.Errors[CNdomain.NamingErrorSemantic], cn.Score.PhonemicCued
+		in.NamingScore = f(float64(cn.Score.Score))
+		in.NamingSemanticErrors, in.NamingPhonemicCued = &semantic, &phonemic
+	}
+	if gng := e.GoNoGoSubTest; gng.PK != "" {
+		in.GoNoGoCommissionRate = f(gng.Score.CommissionRate)
+	}
+	if cs := e.CardSortingSubTest; cs.PK != "" && cs.Score.TrialsAdministered > 0 {
+		in.PerseverativeErrorsPct = f(cs.Score.PerseverativeErrorsPct)
+	}
+	if vim := e.VisualMemorySubTest; vim.PK != "" {
+		in.VisualMemoryNorm = f(float64(vim.Score.Val) / 2 * 100)
+	}
+	if vp := e.VisualSpatialSubTest; vp.Id != "" {
+		in.ClockScore = f(float64(vp.Score.Val))
+	}
+	for _, q := range e.Questionnaires {
+		if !q.Score.Valid {
+			continue
+		}
+		abnormal := q.Score.Abnormal
+		switch q.Code {
+		case "gds-15":
+			in.DepressionAbnormal = &abnormal
+		case "apathy-scale":
+			in.ApathyAbnormal = &abnormal
+		}
+	}
+	if rt := e.ReactionTimeSubTest; rt.PK != "" && rt.Score.MotorSpeed.Available {
+		in.MotorSlowed = rt.Score.MotorSpeed.Slowed
+	}
+	return in
+}
+
+// AnalysisProfileCheck contrasta el perfil del análisis con el de las reglas: el campo estructurado
+// si existe y, en análisis anteriores en texto libre, el que se reconoce en el Markdown
+func (e Evaluation) AnalysisProfileCheck() NPdomain.CrossCheck {
+	if !e.StructuredAnalysis.IsZero() {
+		return NPdomain.CompareProfile(e.StructuredAnalysis.Profile, e.NeuroProfile)
+	}
+	return NPdomain.CompareWithLLM(e.AssistantAnalysis, e.NeuroProfile)
+}
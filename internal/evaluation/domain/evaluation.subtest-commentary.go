@@ -0,0 +1,90 @@
+package domain
+
+import (
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+)
+
+// CommentaryTargets lista las filas de subtest administradas con su comentario actual
+func (e Evaluation) CommentaryTargets() []STCdomain.Target {
+	var out []STCdomain.Target
+	add := func(subtest, id, part string, index int, commentary string) {
+		if id != "" {
+			out = append(out, STCdomain.Target{Subtest: subtest, SubtestID: id, Part: part, Index: index, Commentary: commentary})
+		}
+	}
+	add(STCdomain.LettersCancellation, e.LetterCancellationSubTest.PK, "", 0, e.LetterCancellationSubTest.AssistantAnalysis)
+	for i, vm := range e.VerbalmemorySubTest {
+		add(STCdomain.VerbalMemory, vm.Pk, string(vm.Type), i, vm.AssistanAnalysis)
+	}
+	for i, ef := range e.ExecutiveFunctionSubTest {
+		add(STCdomain.ExecutiveFunctions, ef.PK, string(ef.Type), i, ef.AssistanAnalys)
+	}
+	add(STCdomain.LanguageFluency, e.LanguageFluencySubTest.PK, "", 0, e.LanguageFluencySubTest.AssistantAnalysis)
+	add(STCdomain.DigitSpan, e.DigitSpanSubTest.PK, "", 0, e.DigitSpanSubTest.AssistantAnalysis)
+	add(STCdomain.Stroop, e.StroopSubTest.PK, "", 0, e.StroopSubTest.AssistantAnalysis)
+	add(STCdomain.SDMT, e.SDMTSubTest.PK, "", 0, e.SDMTSubTest.AssistantAnalysis)
+	add(STCdomain.ConfrontationNaming, e.ConfrontationNamingSubTest.PK, "", 0, e.ConfrontationNamingSubTest.AssistantAnalysis)
+	add(STCdomain.MotorSpeed, e.ReactionTimeSubTest.PK, "", 0, e.ReactionTimeSubTest.AssistantAnalysis)
+	add(STCdomain.FingerTapping, e.FingerTappingSubTest.PK, "", 0, e.FingerTappingSubTest.AssistantAnalysis)
+	add(STCdomain.ArchimedesSpiral, e.ArchimedesSpiralSubTest.PK, "", 0, e.ArchimedesSpiralSubTest.AssistantAnalysis)
+	add(STCdomain.JLO, e.JLOSubTest.PK, "", 0, e.JLOSubTest.AssistantAnalysis)
+	add(STCdomain.GoNoGo, e.GoNoGoSubTest.PK, "", 0, e.GoNoGoSubTest.AssistantAnalysis)
+	add(STCdomain.CardSorting, e.CardSortingSubTest.PK, "", 0, e.CardSortingSubTest.AssistantAnalysis)
+	add(STCdomain.MoCA, e.MoCASubTest.PK, "", 0, e.MoCASubTest.AssistantAnalysis)
+	return out
+}
+
+// SubtestCommentaries devuelve solo las filas que ya tienen comentario
+func (e Evaluation) SubtestCommentaries() []STCdomain.Target {
+	var out []STCdomain.Target
+	for _, t := range e.CommentaryTargets() {
+		if t.Commentary != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// SetSubtestCommentary copia el comentario a la fila correspondiente de la evaluación
+func (e *Evaluation) SetSubtestCommentary(t STCdomain.Target, commentary string) {
+	switch t.Subtest {
+	case STCdomain.LettersCancellation:
+		e.LetterCancellationSubTest.AssistantAnalysis = commentary
+	case STCdomain.VerbalMemory:
+		for i := range e.VerbalmemorySubTest {
+			if e.VerbalmemorySubTest[i].Pk == t.SubtestID {
+				e.VerbalmemorySubTest[i].AssistanAnalysis = commentary
+			}
+		}
+	case STCdomain.ExecutiveFunctions:
+		for i := range e.ExecutiveFunctionSubTest {
+			if e.ExecutiveFunctionSubTest[i].PK == t.SubtestID {
+				e.ExecutiveFunctionSubTest[i].AssistanAnalys = commentary
+			}
+		}
+	case STCdomain.LanguageFluency:
+		e.LanguageFluencySubTest.AssistantAnalysis = commentary
+	case STCdomain.DigitSpan:
+		e.DigitSpanSubTest.AssistantAnalysis = commentary
+	case STCdomain.Stroop:
+		e.StroopSubTest.AssistantAnalysis = commentary
+	case STCdomain.SDMT:
+		e.SDMTSubTest.AssistantAnalysis = commentary
+	case STCdomain.ConfrontationNaming:
+		e.ConfrontationNamingSubTest.AssistantAnalysis = commentary
+	case STCdomain.MotorSpeed:
+		e.ReactionTimeSubTest.AssistantAnalysis = commentary
+	case STCdomain.FingerTapping:
+		e.FingerTappingSubTest.AssistantAnalysis = commentary
+	case STCdomain.ArchimedesSpiral:
+		e.ArchimedesSpiralSubTest.AssistantAnalysis = commentary
+	case STCdomain.JLO:
+		e.JLOSubTest.AssistantAnalysis = commentary
+	case STCdomain.GoNoGo:
+		e.GoNoGoSubTest.AssistantAnalysis = commentary
+	case STCdomain.CardSorting:
+		e.CardSortingSubTest.AssistantAnalysis = commentary
+	case STCdomain.MoCA:
+		e.MoCASubTest.AssistantAnalysis = commentary
+	}
+}
@@ -0,0 +1,163 @@
+package EOdomain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Fatigue string
+
+const (
+	FatigueNone   Fatigue = "none"
+	FatigueMild   Fatigue = "mild"
+	FatigueMarked Fatigue = "marked"
+)
+
+type Cooperation string
+
+const (
+	CooperationGood Cooperation = "good"
+	CooperationFair Cooperation = "fair"
+	CooperationPoor Cooperation = "poor"
+)
+
+const MaxNotesLength = 500
+
+// Alertas de calidad derivadas de la observación del evaluador
+const (
+	AlertMarkedFatigue       = "marked_fatigue"
+	AlertPoorCooperation     = "poor_cooperation"
+	AlertComprehensionIssues = "comprehension_issues"
+	AlertInterrupted         = "interrupted"
+)
+
+var (
+	ErrInvalidObservation = errors.New("invalid examiner observation")
+	ErrUnknownSubtest     = errors.New("unknown subtest")
+)
+
+// Subtests admite los mismos códigos que el resumen enviado al LLM
+var Subtests = map[string]bool{
+	"letters_cancellation":         true,
+	"visual_memory":                true,
+	"verbal_memory":                true,
+	"executive_functions":          true,
+	"language_fluency":             true,
+	"visual_spatial":               true,
+	"digit_span":                   true,
+	"stroop":                       true,
+	"sdmt":                         true,
+	"confrontation_naming":         true,
+	"motor_speed":                  true,
+	"finger_tapping":               true,
+	"archimedes_spiral":            true,
+	"speech":                       true,
+	"judgment_of_line_orientation": true,
+	"go_no_go":                     true,
+	"card_sorting":                 true,
+	"questionnaires":               true,
+	"moca":                         true,
+}
+
+type ExaminerObservation struct {
+	EvaluationID        string      `json:"evaluationId"`
+	Subtest             string      `json:"subtest"`
+	Fatigue             Fatigue     `json:"fatigue"`
+	Cooperation         Cooperation `json:"cooperation"`
+	ComprehensionIssues bool        `json:"comprehensionIssues"`
+	Interruptions       int         `json:"interruptions"`
+	Notes               string      `json:"notes,omitempty"`
+	CreatedAt           time.Time   `json:"createdAt"`
+	UpdatedAt           time.Time   `json:"updatedAt"`
+}
+
+type ExaminerObservationInput struct {
+	Fatigue             Fatigue
+	Cooperation         Cooperation
+	ComprehensionIssues bool
+	Interruptions       int
+	Notes               string
+}
+
+func NewExaminerObservation(evaluationID, subtest string, in ExaminerObservationInput) (*ExaminerObservation, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidObservation, fmt.Sprintf(format, args...))
+	}
+	if evaluationID == "" {
+		return nil, invalid("evaluation id is required")
+	}
+	if !Subtests[subtest] {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSubtest, subtest)
+	}
+
+	fatigue := in.Fatigue
+	if fatigue == "" {
+		fatigue = FatigueNone
+	}
+	switch fatigue {
+	case FatigueNone, FatigueMild, FatigueMarked:
+	default:
+		return nil, invalid("unknown fatigue level %q", in.Fatigue)
+	}
+
+	cooperation := in.Cooperation
+	if cooperation == "" {
+		cooperation = CooperationGood
+	}
+	switch cooperation {
+	case CooperationGood, CooperationFair, CooperationPoor:
+	default:
+		return nil, invalid("unknown cooperation level %q", in.Cooperation)
+	}
+
+	if in.Interruptions < 0 || in.Interruptions > 50 {
+		return nil, invalid("interruptions out of range")
+	}
+	notes := strings.TrimSpace(in.Notes)
+	if len(notes) > MaxNotesLength {
+		return nil, invalid("notes too long")
+	}
+
+	now := time.Now()
+	return &ExaminerObservation{
+		EvaluationID:        evaluationID,
+		Subtest:             subtest,
+		Fatigue:             fatigue,
+		Cooperation:         cooperation,
+		ComprehensionIssues: in.ComprehensionIssues,
+		Interruptions:       in.Interruptions,
+		Notes:               notes,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}, nil
+}
+
+// Alerts devuelve las incidencias que comprometen la validez del subtest
+func (o ExaminerObservation) Alerts() []string {
+	alerts := []string{}
+	if o.Fatigue == FatigueMarked {
+		alerts = append(alerts, AlertMarkedFatigue)
+	}
+	if o.Cooperation == CooperationPoor {
+		alerts = append(alerts, AlertPoorCooperation)
+	}
+	if o.ComprehensionIssues {
+		alerts = append(alerts, AlertComprehensionIssues)
+	}
+	if o.Interruptions > 0 {
+		alerts = append(alerts, AlertInterrupted)
+	}
+	return alerts
+}
+
+// ForSubtest localiza la observación de un subtest; ok == false si no se registró
+func ForSubtest(observations []ExaminerObservation, subtest string) (ExaminerObservation, bool) {
+	for _, o := range observations {
+		if o.Subtest == subtest {
+			return o, true
+		}
+	}
+	return ExaminerObservation{}, false
+}
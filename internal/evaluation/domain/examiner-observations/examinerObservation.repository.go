@@ -0,0 +1,9 @@
+package EOdomain
+
+import "context"
+
+type ExaminerObservationRepository interface {
+	// Save crea o sustituye la observación del subtest en la evaluación
+	Save(ctx context.Context, o *ExaminerObservation) error
+	GetByEvaluationID(ctx context.Context, evaluationID string) ([]ExaminerObservation, error)
+}
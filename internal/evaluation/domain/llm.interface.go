@@ -1,20 +1,73 @@
 package domain
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	SAdomain "neuro.app.jordi/internal/evaluation/domain/structured-analysis"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
 )
 
+// LLMOptions ajusta una llamada concreta; los valores cero usan los del proveedor
+type LLMOptions struct {
+	Model       string
+	Locale      string // idioma del prompt y del informe; vacío = español
+	Temperature float64
+	MaxTokens   int
+	JSON        bool // pide al proveedor una respuesta en modo JSON
+}
+
+// LLMUsage registra con qué proveedor y modelo se generó el análisis y cuánto tardó
+type LLMUsage struct {
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+	LatencyMs int64  `json:"latencyMs"`
+	// PromptVersion es el id/idioma@versión de la plantilla; vacío si no hubo prompt (reglas)
+	PromptVersion string `json:"promptVersion,omitempty"`
+	// Attempts cuenta las llamadas hasta obtener un JSON válido; 0 si no hubo llamada (reglas)
+	Attempts int `json:"attempts,omitempty"`
+}
+
+func NewLLMUsage(provider, model string, started time.Time) LLMUsage {
+	return LLMUsage{Provider: provider, Model: model, LatencyMs: time.Since(started).Milliseconds()}
+}
+
+// LLMResult: Text es el Markdown del informe, generado desde Analysis cuando hay estructura
+type LLMResult struct {
+	Text     string
+	Analysis SAdomain.Analysis
+	Usage    LLMUsage
+}
+
+// ErrModelNotAllowed: la petición pide un modelo que la configuración del servidor no admite
+var ErrModelNotAllowed = errors.New("llm model not allowed")
+
+// ModelChecker lo implementan los servicios que llaman a un modelo: valida el que pide la
+// petición antes de empezar, para rechazarla sin haber hecho ninguna llamada
+type ModelChecker interface {
+	CheckModel(model string) error
+}
+
+// LLMService recibe el contexto de la petición: la cancelación y los plazos llegan al proveedor
 type LLMService interface {
-	GenerateAnalysis(evaluation Evaluation) (string, error)
+	GenerateAnalysis(ctx context.Context, evaluation Evaluation, opts LLMOptions) (LLMResult, error)
+}
+
+// SubtestCommentator genera el comentario breve de una fila de subtest con sus métricas y
+// normas; lo implementan los servicios que pueden interpretar subtests por separado
+type SubtestCommentator interface {
+	CommentSubtest(ctx context.Context, evaluation Evaluation, target STCdomain.Target, opts LLMOptions) (LLMResult, error)
 }
 
 type MockInterface struct{}
 
-func (mi MockInterface) GenerateAnalysis(evaluation Evaluation) (string, error) {
-	return "test analysis by chatgpt broder", nil
+func (mi MockInterface) GenerateAnalysis(ctx context.Context, evaluation Evaluation, opts LLMOptions) (LLMResult, error) {
+	return LLMResult{Text: "test analysis by chatgpt broder", Usage: LLMUsage{Provider: "mock", Model: "mock"}}, nil
 }
 
 func (mi MockInterface) LettersCancellationAnalysis(subtest *LCdomain.LettersCancellationSubtest, patientAge int) (string, error) {
@@ -0,0 +1,107 @@
+package NPdomain
+
+// Profile son los perfiles que también se piden al LLM, más normal e indeterminado
+type Profile string
+
+const (
+	ProfileAmnesic        Profile = "amnesic"
+	ProfileFrontoTemporal Profile = "fronto_temporal"
+	ProfileAttentional    Profile = "attentional"
+	ProfileDepressive     Profile = "depressive"
+	ProfileDysexecutive   Profile = "dysexecutive"
+	ProfileNormal         Profile = "normal"
+	ProfileInconclusive   Profile = "inconclusive"
+)
+
+// Profiles fija el orden de presentación; un empate en peso deja el perfil como no concluyente
+var Profiles = []Profile{ProfileAmnesic, ProfileFrontoTemporal, ProfileAttentional, ProfileDepressive, ProfileDysexecutive}
+
+// Labels son los nombres con que el prompt pide el perfil
+var Labels = map[Profile]string{
+	ProfileAmnesic:        "Amnésico",
+	ProfileFrontoTemporal: "Fronto-temporal",
+	ProfileAttentional:    "Atencional",
+	ProfileDepressive:     "Depresivo",
+	ProfileDysexecutive:   "Disejecutivo (vascular)",
+	ProfileNormal:         "Funcionamiento normal",
+	ProfileInconclusive:   "No concluyente",
+}
+
+// RulesVersion identifica el conjunto de reglas; cambia si se toca un umbral
+const RulesVersion = "np-rules@1"
+
+// Umbrales del prompt (guía clínica no diagnóstica)
+const (
+	scoreLowCutoff         = 60.0  // escalas 0–100: <60 leve–moderado o peor
+	tmtANormalMaxSec       = 100.0 // A < 100 s normal
+	tmtABNormalMaxSec      = 350.0 // A+B < 350 s normal
+	zLowCutoff             = -1.5
+	stroopLowT             = 40.0
+	verbalErrorRateHigh    = 0.2  // intrusiones/perseveraciones sobre objetivos
+	goNoGoCommissionHigh   = 0.3  // respuestas a no-go
+	perseverativePctHigh   = 20.0 // % de errores perseverativos (WCST)
+	visualMemoryLowNorm    = 66.0 // VM_norm ≤ 66 compromiso leve–moderado
+	clockLowScore          = 3.0  // Shulman 0–5
+	similarRecallMaxGapPts = 15.0 // inmediata y diferida "en proporción similar"
+	minWeightForProfile    = 2
+)
+
+// Input son las métricas ya puntuadas; nil = subtest no administrado
+type Input struct {
+	VerbalImmediate         *float64 // 0–100
+	VerbalDelayed           *float64 // 0–100
+	VerbalIntrusionRate     *float64
+	VerbalPerseverationRate *float64
+	TMTASec                 *float64
+	TMTABSec                *float64
+	DigitForwardZ           *float64
+	DigitBackwardZ          *float64
+	DigitSequencingZ        *float64
+	StroopInterferenceT     *float64
+	SDMTZ                   *float64
+	LettersScore            *float64 // 0–100
+	FluencyScore            *float64 // 0–100
+	NamingScore             *float64 // 0–100
+	NamingSemanticErrors    *int
+	NamingPhonemicCued      *int
+	GoNoGoCommissionRate    *float64
+	PerseverativeErrorsPct  *float64
+	VisualMemoryNorm        *float64 // 0–100
+	ClockScore              *float64 // 0–5
+	DepressionAbnormal      *bool    // GDS-15
+	ApathyAbnormal          *bool
+	MotorSlowed             bool // TR simple enlentecido
+}
+
+// Evidence es una métrica citada por una regla
+type Evidence struct {
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Threshold string  `json:"threshold"`
+}
+
+// Finding es una regla que se ha cumplido; Weight suma a favor de Profile
+type Finding struct {
+	Rule        string     `json:"rule"`
+	Mechanism   string     `json:"mechanism"`
+	Profile     Profile    `json:"profile,omitempty"`
+	Weight      int        `json:"weight"`
+	Explanation string     `json:"explanation"`
+	Evidence    []Evidence `json:"evidence"`
+}
+
+type Classification struct {
+	RulesVersion string          `json:"rulesVersion"`
+	Profile      Profile         `json:"profile"`
+	Label        string          `json:"label"`
+	Scores       map[Profile]int `json:"scores"`
+	Findings     []Finding       `json:"findings"`
+	Limitations  []string        `json:"limitations"`
+}
+
+// CrossCheck compara el perfil elegido por el LLM con el de las reglas
+type CrossCheck struct {
+	LLMProfile  Profile `json:"llmProfile,omitempty"` // vacío si no se reconoce en el texto
+	RuleProfile Profile `json:"ruleProfile"`
+	Agrees      bool    `json:"agrees"`
+}
@@ -0,0 +1,286 @@
+package NPdomain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Classify aplica las reglas interpretativas del prompt y devuelve el perfil con sus hallazgos.
+// Gana el perfil con más peso si llega a minWeightForProfile y no empata; sin hallazgos es normal.
+func Classify(in Input) Classification {
+	c := Classification{RulesVersion: RulesVersion, Scores: map[Profile]int{}, Findings: []Finding{}, Limitations: []string{}}
+	add := func(f Finding) {
+		c.Findings = append(c.Findings, f)
+		if f.Profile != "" && f.Weight > 0 {
+			c.Scores[f.Profile] += f.Weight
+		}
+	}
+	limit := func(l string) {
+		c.Limitations = append(c.Limitations, l)
+	}
+	// En pruebas cronometradas el enlentecimiento motor resta peso a la lectura cognitiva
+	timed := func(w int) int {
+		if in.MotorSlowed {
+			return w - 1
+		}
+		return w
+	}
+	depressed := in.DepressionAbnormal != nil && *in.DepressionAbnormal
+	administered := false
+	seen := func(vs ...bool) {
+		for _, v := range vs {
+			administered = administered || v
+		}
+	}
+
+	// Memoria verbal: codificación vs consolidación
+	imm, del := in.VerbalImmediate, in.VerbalDelayed
+	seen(imm != nil, del != nil)
+	switch {
+	case imm != nil && del != nil && *imm >= scoreLowCutoff && *del < scoreLowCutoff:
+		add(Finding{Rule: "verbal_consolidation", Mechanism: "consolidation", Profile: ProfileAmnesic, Weight: 3,
+			Explanation: "Recuerdo inmediato preservado con diferido bajo: déficit de consolidación/recuperación.",
+			Evidence:    []Evidence{ev("verbal_memory.immediate", *imm, "≥ 60"), ev("verbal_memory.delayed", *del, "< 60")}})
+	case imm != nil && del != nil && *imm < scoreLowCutoff && *del < scoreLowCutoff && math.Abs(*imm-*del) <= similarRecallMaxGapPts:
+		add(Finding{Rule: "verbal_encoding", Mechanism: "encoding", Profile: ProfileAttentional, Weight: 2,
+			Explanation: "Recuerdo inmediato y diferido bajos en proporción similar: fallo de codificación/atención.",
+			Evidence:    []Evidence{ev("verbal_memory.immediate", *imm, "< 60"), ev("verbal_memory.delayed", *del, "< 60, diferencia ≤ 15")}})
+	case imm != nil && del != nil && *imm < scoreLowCutoff && *del < *imm-similarRecallMaxGapPts:
+		add(Finding{Rule: "verbal_encoding_consolidation", Mechanism: "encoding+consolidation", Profile: ProfileAmnesic, Weight: 2,
+			Explanation: "Codificación baja con pérdida adicional en el diferido: componente de consolidación.",
+			Evidence:    []Evidence{ev("verbal_memory.immediate", *imm, "< 60"), ev("verbal_memory.delayed", *del, "> 15 puntos por debajo")}})
+	case (imm == nil) != (del == nil):
+		limit("verbal_memory_partial")
+	}
+	for _, r := range []struct {
+		metric string
+		v      *float64
+	}{{"verbal_memory.intrusion_rate", in.VerbalIntrusionRate}, {"verbal_memory.perseveration_rate", in.VerbalPerseverationRate}} {
+		if r.v != nil && *r.v >= verbalErrorRateHigh {
+			add(Finding{Rule: "verbal_monitoring", Mechanism: "monitoring", Profile: ProfileDysexecutive, Weight: 1,
+				Explanation: "Intrusiones/perseveraciones elevadas: fallo de monitorización y control ejecutivo.",
+				Evidence:    []Evidence{ev(r.metric, *r.v, "≥ 0.2")}})
+			break
+		}
+	}
+
+	// TMT: A normal y A+B lento = set-shifting; A lento = velocidad/atención
+	a, ab := in.TMTASec, in.TMTABSec
+	seen(a != nil, ab != nil)
+	if a != nil && ab != nil && *a < tmtANormalMaxSec && *ab >= tmtABNormalMaxSec {
+		add(Finding{Rule: "tmt_set_shifting", Mechanism: "set_shifting", Profile: ProfileDysexecutive, Weight: 2,
+			Explanation: "TMT A normal con A+B lento: déficit de cambio de set (componente ejecutivo).",
+			Evidence:    []Evidence{ev("tmt_a.duration_sec", *a, "< 100 s"), ev("tmt_a_plus_b.duration_sec", *ab, "≥ 350 s")}})
+	}
+	if a != nil && *a >= tmtANormalMaxSec {
+		add(Finding{Rule: "tmt_a_slow", Mechanism: "processing_speed", Profile: ProfileAttentional, Weight: timed(1),
+			Explanation: "TMT A lento: velocidad de procesamiento/atención comprometida.",
+			Evidence:    []Evidence{ev("tmt_a.duration_sec", *a, "≥ 100 s")}})
+	}
+
+	// Dígitos: directo = span atencional; inverso/secuenciación = memoria de trabajo
+	seen(in.DigitForwardZ != nil, in.DigitBackwardZ != nil, in.DigitSequencingZ != nil)
+	if f := in.DigitForwardZ; f != nil && *f <= zLowCutoff {
+		add(Finding{Rule: "digit_span_forward", Mechanism: "attention_span", Profile: ProfileAttentional, Weight: 1,
+			Explanation: "Span directo bajo: amplitud atencional reducida.",
+			Evidence:    []Evidence{ev("digit_span.forward_z", *f, "≤ -1.5")}})
+	} else {
+		for _, r := range []struct {
+			metric string
+			v      *float64
+		}{{"digit_span.backward_z", in.DigitBackwardZ}, {"digit_span.sequencing_z", in.DigitSequencingZ}} {
+			if r.v != nil && *r.v <= zLowCutoff {
+				add(Finding{Rule: "digit_span_working_memory", Mechanism: "working_memory", Profile: ProfileDysexecutive, Weight: 1,
+					Explanation: "Directo preservado con inverso/secuenciación bajos: fallo de manipulación en memoria de trabajo.",
+					Evidence:    []Evidence{ev(r.metric, *r.v, "≤ -1.5")}})
+				break
+			}
+		}
+	}
+
+	seen(in.StroopInterferenceT != nil)
+	if t := in.StroopInterferenceT; t != nil && *t < stroopLowT {
+		add(Finding{Rule: "stroop_interference", Mechanism: "inhibition", Profile: ProfileDysexecutive, Weight: 2,
+			Explanation: "Interferencia Stroop baja: dificultad para inhibir la respuesta automática.",
+			Evidence:    []Evidence{ev("stroop.interference_t", *t, "< 40")}})
+	}
+
+	seen(in.SDMTZ != nil)
+	if z := in.SDMTZ; z != nil && *z <= zLowCutoff {
+		add(Finding{Rule: "sdmt_slow", Mechanism: "processing_speed", Profile: ProfileAttentional, Weight: timed(2),
+			Explanation: "SDMT bajo: enlentecimiento de la velocidad de procesamiento.",
+			Evidence:    []Evidence{ev("sdmt.z", *z, "≤ -1.5")}})
+	}
+
+	seen(in.LettersScore != nil)
+	if s := in.LettersScore; s != nil && *s < scoreLowCutoff {
+		add(Finding{Rule: "letters_cancellation_low", Mechanism: "sustained_attention", Profile: ProfileAttentional, Weight: timed(2),
+			Explanation: "Cancelación de letras baja: atención sostenida comprometida.",
+			Evidence:    []Evidence{ev("letter_cancellation.score", *s, "< 60")}})
+	}
+
+	// Lenguaje: fluencia y denominación con errores semánticos apuntan a perfil cortical
+	seen(in.FluencyScore != nil, in.NamingScore != nil)
+	semanticNaming := in.NamingScore != nil && *in.NamingScore < scoreLowCutoff &&
+		in.NamingSemanticErrors != nil && *in.NamingSemanticErrors > 0 &&
+		(in.NamingPhonemicCued == nil || *in.NamingSemanticErrors > *in.NamingPhonemicCued)
+	if semanticNaming {
+		add(Finding{Rule: "naming_semantic", Mechanism: "semantic_degradation", Profile: ProfileFrontoTemporal, Weight: 2,
+			Explanation: "Denominación baja con errores semánticos y poco beneficio de claves: posible degradación semántica.",
+			Evidence:    []Evidence{ev("confrontation_naming.score", *in.NamingScore, "< 60"), ev("confrontation_naming.semantic_errors", float64(*in.NamingSemanticErrors), "> phonemic_cued")}})
+	} else if in.NamingScore != nil && *in.NamingScore < scoreLowCutoff && in.NamingPhonemicCued != nil && *in.NamingPhonemicCued > 0 {
+		add(Finding{Rule: "naming_lexical_access", Mechanism: "lexical_access",
+			Explanation: "Denominación baja que mejora con clave fonémica: fallo de acceso léxico (frecuente en Parkinson).",
+			Evidence:    []Evidence{ev("confrontation_naming.score", *in.NamingScore, "< 60"), ev("confrontation_naming.phonemic_cued", float64(*in.NamingPhonemicCued), "> 0")}})
+	}
+	if s := in.FluencyScore; s != nil && *s < scoreLowCutoff {
+		f := Finding{Rule: "fluency_low", Mechanism: "lexical_executive", Profile: ProfileDysexecutive, Weight: 1,
+			Explanation: "Fluencia verbal reducida: déficit léxico/ejecutivo.",
+			Evidence:    []Evidence{ev("language_fluency.score", *s, "< 60")}}
+		if semanticNaming {
+			f.Profile, f.Mechanism = ProfileFrontoTemporal, "semantic_degradation"
+			f.Explanation = "Fluencia reducida junto a denominación con errores semánticos: apoya afectación semántica."
+		}
+		add(f)
+	}
+
+	seen(in.GoNoGoCommissionRate != nil, in.PerseverativeErrorsPct != nil)
+	if r := in.GoNoGoCommissionRate; r != nil && *r >= goNoGoCommissionHigh {
+		add(Finding{Rule: "go_no_go_commissions", Mechanism: "disinhibition", Profile: ProfileFrontoTemporal, Weight: 2,
+			Explanation: "Comisiones altas en Go/No-Go: impulsividad/desinhibición.",
+			Evidence:    []Evidence{ev("go_no_go.commission_rate", *r, "≥ 0.3")}})
+	}
+	if p := in.PerseverativeErrorsPct; p != nil && *p >= perseverativePctHigh {
+		add(Finding{Rule: "card_sorting_perseveration", Mechanism: "set_shifting", Profile: ProfileDysexecutive, Weight: 2,
+			Explanation: "Errores perseverativos altos: rigidez / fallo de cambio de set.",
+			Evidence:    []Evidence{ev("card_sorting.perseverative_errors_pct", *p, "≥ 20%")}})
+	}
+
+	seen(in.VisualMemoryNorm != nil, in.ClockScore != nil)
+	if v := in.VisualMemoryNorm; v != nil && *v <= visualMemoryLowNorm {
+		add(Finding{Rule: "visual_memory_low", Mechanism: "visual_memory", Profile: ProfileAmnesic, Weight: 1,
+			Explanation: "Reproducción visual comprometida (BVMT).",
+			Evidence:    []Evidence{ev("visual_memory.vm_norm", *v, "≤ 66")}})
+	}
+	if s := in.ClockScore; s != nil && *s <= clockLowScore {
+		add(Finding{Rule: "clock_low", Mechanism: "visuoconstruction", Profile: ProfileDysexecutive, Weight: 1,
+			Explanation: "Reloj bajo: alteración visuoespacial/ejecutiva.",
+			Evidence:    []Evidence{ev("clock_drawing.score", *s, "≤ 3")}})
+	}
+
+	// Ánimo: la GDS alterada es requisito del perfil depresivo; entonces el enlentecimiento también lo apoya
+	seen(in.DepressionAbnormal != nil)
+	if depressed {
+		add(Finding{Rule: "gds_abnormal", Mechanism: "mood", Profile: ProfileDepressive, Weight: 3,
+			Explanation: "GDS-15 alterada: sintomatología depresiva.",
+			Evidence:    []Evidence{ev("questionnaires.gds-15.abnormal", 1, "= true")}})
+		for _, f := range c.Findings {
+			if f.Mechanism == "processing_speed" {
+				c.Scores[ProfileDepressive]++
+			}
+		}
+	} else if in.ApathyAbnormal != nil && *in.ApathyAbnormal {
+		add(Finding{Rule: "apathy_without_depression", Mechanism: "apathy",
+			Explanation: "Apatía sin depresión: puede explicar baja iniciativa sin déficit ejecutivo primario.",
+			Evidence:    []Evidence{ev("questionnaires.apathy-scale.abnormal", 1, "= true")}})
+	}
+	if in.MotorSlowed {
+		limit("motor_slowing")
+	}
+
+	c.Profile = decide(c.Scores, administered)
+	if !administered {
+		limit("no_data")
+	}
+	c.Label = Labels[c.Profile]
+	return c
+}
+
+func decide(scores map[Profile]int, administered bool) Profile {
+	if !administered {
+		return ProfileInconclusive
+	}
+	best, bestScore, tie := ProfileNormal, 0, false
+	for _, p := range Profiles {
+		switch s := scores[p]; {
+		case s > bestScore:
+			best, bestScore, tie = p, s, false
+		case s == bestScore && s > 0:
+			tie = true
+		}
+	}
+	switch {
+	case bestScore == 0:
+		return ProfileNormal
+	case bestScore < minWeightForProfile || tie:
+		return ProfileInconclusive
+	}
+	return best
+}
+
+func ev(metric string, v float64, threshold string) Evidence {
+	return Evidence{Metric: metric, Value: math.Round(v*100) / 100, Threshold: threshold}
+}
+
+// Raíces con que se reconoce cada perfil en el texto libre del LLM (español, catalán e inglés)
+var llmProfilePatterns = []struct {
+	profile Profile
+	re      *regexp.Regexp
+}{
+	{ProfileAmnesic, regexp.MustCompile(`amn[eéè]s?t?ic`)},
+	{ProfileFrontoTemporal, regexp.MustCompile(`fronto[- ]?temporal`)},
+	{ProfileAttentional, regexp.MustCompile(`atencional|attentional`)},
+	{ProfileDepressive, regexp.MustCompile(`depresiv|depressi[uv]`)},
+	{ProfileDysexecutive, regexp.MustCompile(`dis[eé]?jecutiv|disexecuti[uv]|dysexecutive`)},
+	{ProfileNormal, regexp.MustCompile(`funcionamiento normal|dentro de la normalidad|funcionament normal|dins de la normalitat|normal functioning|within normal limits`)},
+	{ProfileInconclusive, regexp.MustCompile(`no concluyente|no es concluyente|no concloent|no és concloent|inconclusive|not conclusive`)},
+}
+
+var profileSectionRe = regexp.MustCompile(`perfil (?:neurol[oóò]gic[o]? )?predominant|predominant (?:neurological )?profile`)
+
+// CompareWithLLM busca el perfil elegido en la sección "Perfil predominante" (o su traducción) del análisis
+// y lo contrasta con el de las reglas
+func CompareWithLLM(analysis string, c Classification) CrossCheck {
+	out := CrossCheck{RuleProfile: c.Profile}
+	text := strings.ToLower(analysis)
+	if loc := profileSectionRe.FindStringIndex(text); loc != nil {
+		text = text[loc[1]:]
+		if len(text) > 400 {
+			text = text[:400]
+		}
+	}
+	first := -1
+	for _, p := range llmProfilePatterns {
+		if loc := p.re.FindStringIndex(text); loc != nil && (first == -1 || loc[0] < first) {
+			first, out.LLMProfile = loc[0], p.profile
+		}
+	}
+	out.Agrees = out.LLMProfile != "" && out.LLMProfile == c.Profile
+	return out
+}
+
+// Summary es una línea legible con el perfil y los hallazgos que lo sostienen
+func (c Classification) Summary() string {
+	return c.SummaryAs(c.Label)
+}
+
+// SummaryAs es Summary con otro nombre del perfil, p. ej. el del idioma del informe
+func (c Classification) SummaryAs(label string) string {
+	var why []string
+	for _, f := range c.Findings {
+		if f.Profile == c.Profile && f.Weight > 0 {
+			why = append(why, f.Rule)
+		}
+	}
+	if len(why) == 0 {
+		return label
+	}
+	return fmt.Sprintf("%s (%s)", label, strings.Join(why, ", "))
+}
+
+// CompareProfile contrasta un perfil ya estructurado (respuesta JSON del LLM) con el de las reglas
+func CompareProfile(p Profile, c Classification) CrossCheck {
+	return CrossCheck{LLMProfile: p, RuleProfile: c.Profile, Agrees: p != "" && p == c.Profile}
+}
@@ -0,0 +1,205 @@
+package NPdomain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func f(v float64) *float64 { return &v }
+func b(v bool) *bool       { return &v }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name            string
+		in              Input
+		wantProfile     Profile
+		wantRules       []string
+		wantMechanism   string
+		wantLimitations []string
+	}{
+		{
+			name:          "Low immediate and low delayed recall - encoding",
+			in:            Input{VerbalImmediate: f(45), VerbalDelayed: f(40)},
+			wantProfile:   ProfileAttentional,
+			wantRules:     []string{"verbal_encoding"},
+			wantMechanism: "encoding",
+		},
+		{
+			name:          "Preserved immediate with low delayed recall - consolidation",
+			in:            Input{VerbalImmediate: f(80), VerbalDelayed: f(30)},
+			wantProfile:   ProfileAmnesic,
+			wantRules:     []string{"verbal_consolidation"},
+			wantMechanism: "consolidation",
+		},
+		{
+			name:          "Low immediate with further loss in delayed recall - encoding and consolidation",
+			in:            Input{VerbalImmediate: f(50), VerbalDelayed: f(20)},
+			wantProfile:   ProfileAmnesic,
+			wantRules:     []string{"verbal_encoding_consolidation"},
+			wantMechanism: "encoding+consolidation",
+		},
+		{
+			name:          "TMT-A normal with slow A+B - set-shifting",
+			in:            Input{TMTASec: f(60), TMTABSec: f(400)},
+			wantProfile:   ProfileDysexecutive,
+			wantRules:     []string{"tmt_set_shifting"},
+			wantMechanism: "set_shifting",
+		},
+		{
+			name:          "Slow TMT-A alone is below the profile weight",
+			in:            Input{TMTASec: f(120), TMTABSec: f(400)},
+			wantProfile:   ProfileInconclusive,
+			wantRules:     []string{"tmt_a_slow"},
+			wantMechanism: "processing_speed",
+		},
+		{
+			name:            "Motor slowing lowers the weight of timed tests",
+			in:              Input{SDMTZ: f(-2), MotorSlowed: true},
+			wantProfile:     ProfileInconclusive,
+			wantRules:       []string{"sdmt_slow"},
+			wantMechanism:   "processing_speed",
+			wantLimitations: []string{"motor_slowing"},
+		},
+		{
+			name:        "Abnormal GDS with slowing - depressive",
+			in:          Input{SDMTZ: f(-2), DepressionAbnormal: b(true)},
+			wantProfile: ProfileDepressive,
+			wantRules:   []string{"sdmt_slow", "gds_abnormal"},
+		},
+		{
+			name:            "Only immediate recall administered",
+			in:              Input{VerbalImmediate: f(40)},
+			wantProfile:     ProfileNormal,
+			wantRules:       []string{},
+			wantLimitations: []string{"verbal_memory_partial"},
+		},
+		{
+			name:        "Administered within norms - normal",
+			in:          Input{VerbalImmediate: f(80), VerbalDelayed: f(75), TMTASec: f(60), TMTABSec: f(200), StroopInterferenceT: f(50)},
+			wantProfile: ProfileNormal,
+			wantRules:   []string{},
+		},
+		{
+			name:            "Nothing administered",
+			in:              Input{},
+			wantProfile:     ProfileInconclusive,
+			wantRules:       []string{},
+			wantLimitations: []string{"no_data"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Classify(tt.in)
+
+			if c.RulesVersion != RulesVersion {
+				t.Errorf("expected rules version %s, got %s", RulesVersion, c.RulesVersion)
+			}
+			if c.Profile != tt.wantProfile || c.Label != Labels[tt.wantProfile] {
+				t.Errorf("expected profile %s, got %s (%s) with scores %v", tt.wantProfile, c.Profile, c.Label, c.Scores)
+			}
+			rules := []string{}
+			for _, finding := range c.Findings {
+				rules = append(rules, finding.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Fatalf("expected rules %v, got %v", tt.wantRules, rules)
+			}
+			if tt.wantMechanism != "" && c.Findings[0].Mechanism != tt.wantMechanism {
+				t.Errorf("expected mechanism %s, got %s", tt.wantMechanism, c.Findings[0].Mechanism)
+			}
+			if tt.wantLimitations == nil {
+				tt.wantLimitations = []string{}
+			}
+			if !reflect.DeepEqual(c.Limitations, tt.wantLimitations) {
+				t.Errorf("expected limitations %v, got %v", tt.wantLimitations, c.Limitations)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name         string
+		scores       map[Profile]int
+		administered bool
+		want         Profile
+	}{
+		{name: "No data", scores: map[Profile]int{ProfileAmnesic: 5}, administered: false, want: ProfileInconclusive},
+		{name: "No findings", scores: map[Profile]int{}, administered: true, want: ProfileNormal},
+		{name: "Clear winner", scores: map[Profile]int{ProfileAmnesic: 3, ProfileAttentional: 2}, administered: true, want: ProfileAmnesic},
+		{name: "At the minimum weight", scores: map[Profile]int{ProfileDysexecutive: minWeightForProfile}, administered: true, want: ProfileDysexecutive},
+		{name: "Below the minimum weight", scores: map[Profile]int{ProfileAttentional: minWeightForProfile - 1}, administered: true, want: ProfileInconclusive},
+		{name: "Tie at the top", scores: map[Profile]int{ProfileAmnesic: 3, ProfileDysexecutive: 3}, administered: true, want: ProfileInconclusive},
+		{name: "Tie below the top", scores: map[Profile]int{ProfileFrontoTemporal: 2, ProfileAttentional: 2, ProfileDysexecutive: 4}, administered: true, want: ProfileDysexecutive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decide(tt.scores, tt.administered); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompareWithLLM(t *testing.T) {
+	tests := []struct {
+		name        string
+		analysis    string
+		ruleProfile Profile
+		wantLLM     Profile
+		wantAgrees  bool
+	}{
+		{
+			name:        "Spanish section agrees",
+			analysis:    "## Análisis\n\n**Perfil predominante**: Amnésico\n\nRecuerdo diferido bajo.",
+			ruleProfile: ProfileAmnesic,
+			wantLLM:     ProfileAmnesic,
+			wantAgrees:  true,
+		},
+		{
+			name:        "Only the profile section is read",
+			analysis:    "Sin rasgos depresivos ni amnésicos.\n\n**Perfil predominante**\nAtencional, con enlentecimiento.",
+			ruleProfile: ProfileAttentional,
+			wantLLM:     ProfileAttentional,
+			wantAgrees:  true,
+		},
+		{
+			name:        "English section disagrees",
+			analysis:    "**Predominant profile**: Dysexecutive (vascular)",
+			ruleProfile: ProfileAttentional,
+			wantLLM:     ProfileDysexecutive,
+		},
+		{
+			name:        "Catalan inconclusive",
+			analysis:    "**Perfil predominant**: No concloent",
+			ruleProfile: ProfileInconclusive,
+			wantLLM:     ProfileInconclusive,
+			wantAgrees:  true,
+		},
+		{
+			name:        "Normal functioning",
+			analysis:    "**Perfil predominante**: Funcionamiento normal",
+			ruleProfile: ProfileNormal,
+			wantLLM:     ProfileNormal,
+			wantAgrees:  true,
+		},
+		{
+			name:        "No recognizable profile",
+			analysis:    "El paciente colaboró durante toda la sesión.",
+			ruleProfile: ProfileNormal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareWithLLM(tt.analysis, Classification{Profile: tt.ruleProfile})
+
+			want := CrossCheck{LLMProfile: tt.wantLLM, RuleProfile: tt.ruleProfile, Agrees: tt.wantAgrees}
+			if got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package PDMCIdomain
+
+import "fmt"
+
+// Domain son los cinco dominios de los criterios MDS (Litvan et al., 2012)
+type Domain string
+
+const (
+	AttentionWorkingMemory Domain = "attention_working_memory"
+	Executive              Domain = "executive"
+	Language               Domain = "language"
+	Memory                 Domain = "memory"
+	Visuospatial           Domain = "visuospatial"
+)
+
+var Domains = []Domain{AttentionWorkingMemory, Executive, Language, Memory, Visuospatial}
+
+type Status string
+
+const (
+	StatusMet                 Status = "met"
+	StatusNotMet              Status = "not_met"
+	StatusInsufficientBattery Status = "insufficient_battery" // faltan tests para aplicar el nivel II
+)
+
+type Subtype string
+
+const (
+	SubtypeSingleDomain   Subtype = "single_domain"
+	SubtypeMultipleDomain Subtype = "multiple_domain"
+)
+
+const (
+	NoteFunctionalImpairment = "functional_impairment" // FAQ alterado: PD-MCI exige autonomía preservada, valorar demencia
+	NoteAbbreviatedBattery   = "abbreviated_battery"   // hay ≥2 tests alterados pero solo admite nivel I (sin subtipo)
+)
+
+// TestRule asigna un test a un dominio MDS; Indicators son claves de COMPdomain en orden de preferencia
+// (se usa la primera administrada, p.ej. recuerdo diferido antes que inmediato)
+type TestRule struct {
+	Test       string   `json:"test"`
+	Domain     Domain   `json:"domain"`
+	Indicators []string `json:"indicators"`
+}
+
+type Criteria struct {
+	ID             string     `json:"id"`
+	Version        int        `json:"version"`
+	ImpairmentSD   float64    `json:"impairmentSd"`   // deterioro si z ≤ −ImpairmentSD (MDS: entre 1 y 2)
+	TestsPerDomain int        `json:"testsPerDomain"` // tests por dominio exigidos para el nivel II
+	Tests          []TestRule `json:"tests"`
+}
+
+// MDSLevelII es la operacionalización por defecto sobre la batería de la app
+var MDSLevelII = Criteria{
+	ID:             "mds-pd-mci-level2",
+	Version:        1,
+	ImpairmentSD:   1.5,
+	TestsPerDomain: 2,
+	Tests: []TestRule{
+		{Test: "digit_span", Domain: AttentionWorkingMemory, Indicators: []string{"digit_span.backward_z", "digit_span.sequencing_z", "digit_span.forward_z"}},
+		{Test: "letter_cancellation", Domain: AttentionWorkingMemory, Indicators: []string{"letter_cancellation.score"}},
+		{Test: "sdmt", Domain: AttentionWorkingMemory, Indicators: []string{"sdmt.z"}},
+		{Test: "trail_making_a", Domain: AttentionWorkingMemory, Indicators: []string{"trail_making.a_score"}},
+		{Test: "trail_making_b", Domain: Executive, Indicators: []string{"trail_making.ab_score"}},
+		{Test: "stroop", Domain: Executive, Indicators: []string{"stroop.interference_t"}},
+		{Test: "go_no_go", Domain: Executive, Indicators: []string{"go_no_go.d_prime"}},
+		{Test: "card_sorting", Domain: Executive, Indicators: []string{"card_sorting.categories"}},
+		{Test: "language_fluency", Domain: Language, Indicators: []string{"language_fluency.unique_valid"}},
+		{Test: "confrontation_naming", Domain: Language, Indicators: []string{"confrontation_naming.score"}},
+		{Test: "verbal_memory", Domain: Memory, Indicators: []string{"verbal_memory.delayed_score", "verbal_memory.immediate_score"}},
+		{Test: "visual_memory", Domain: Memory, Indicators: []string{"visual_memory.score"}},
+		{Test: "jlo", Domain: Visuospatial, Indicators: []string{"jlo.score"}},
+		{Test: "visual_spatial", Domain: Visuospatial, Indicators: []string{"visual_spatial.score"}},
+	},
+}
+
+func (c Criteria) Ref() string {
+	return fmt.Sprintf("%s@%d", c.ID, c.Version)
+}
+
+type TestResult struct {
+	Test      string  `json:"test"`
+	Domain    Domain  `json:"domain"`
+	Indicator string  `json:"indicator"`
+	Z         float64 `json:"z"`
+	Impaired  bool    `json:"impaired"`
+}
+
+type DomainResult struct {
+	Domain        Domain       `json:"domain"`
+	Tests         []TestResult `json:"tests"`
+	Sufficient    bool         `json:"sufficient"` // al menos TestsPerDomain tests administrados
+	ImpairedTests int          `json:"impairedTests"`
+}
+
+// Assessment es la sección estructurada de criterios, independiente del análisis del LLM
+type Assessment struct {
+	CriteriaRef    string         `json:"criteriaRef"`
+	ImpairmentSD   float64        `json:"impairmentSd"`
+	Status         Status         `json:"status"`
+	Subtype        Subtype        `json:"subtype,omitempty"` // solo si Status == met
+	Domains        []DomainResult `json:"domains"`
+	DrivingTests   []TestResult   `json:"drivingTests"`   // tests alterados que sostienen la clasificación
+	MissingDomains []Domain       `json:"missingDomains"` // dominios sin tests suficientes para el nivel II
+	Notes          []string       `json:"notes"`
+}
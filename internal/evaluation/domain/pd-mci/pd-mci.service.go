@@ -0,0 +1,83 @@
+package PDMCIdomain
+
+import (
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+)
+
+// Evaluate aplica los criterios de nivel II: deterioro en ≥2 tests, ya sea dos en un mismo dominio
+// o uno en dos dominios distintos. Los z salen de los indicadores del perfil cognitivo ya normalizados.
+// functionalImpairment no cambia el estado pero se anota, porque PD-MCI exige autonomía preservada.
+func Evaluate(c Criteria, profile COMPdomain.CognitiveProfile, functionalImpairment bool) Assessment {
+	zByKey := map[string]float64{}
+	for _, d := range profile.Domains {
+		for _, ind := range d.Indicators {
+			zByKey[ind.Key] = ind.Z
+		}
+	}
+
+	out := Assessment{
+		CriteriaRef:    c.Ref(),
+		ImpairmentSD:   c.ImpairmentSD,
+		Domains:        []DomainResult{},
+		DrivingTests:   []TestResult{},
+		MissingDomains: []Domain{},
+		Notes:          []string{},
+	}
+	results := map[Domain]*DomainResult{}
+	for _, d := range Domains {
+		results[d] = &DomainResult{Domain: d, Tests: []TestResult{}}
+	}
+	for _, rule := range c.Tests {
+		for _, key := range rule.Indicators {
+			z, ok := zByKey[key]
+			if !ok {
+				continue
+			}
+			r := TestResult{Test: rule.Test, Domain: rule.Domain, Indicator: key, Z: z, Impaired: z <= -c.ImpairmentSD}
+			dr := results[rule.Domain]
+			dr.Tests = append(dr.Tests, r)
+			if r.Impaired {
+				dr.ImpairedTests++
+				out.DrivingTests = append(out.DrivingTests, r)
+			}
+			break
+		}
+	}
+
+	impairedDomains, twoInOneDomain := 0, false
+	for _, d := range Domains {
+		dr := results[d]
+		dr.Sufficient = len(dr.Tests) >= c.TestsPerDomain
+		if !dr.Sufficient {
+			out.MissingDomains = append(out.MissingDomains, d)
+		}
+		if dr.ImpairedTests > 0 {
+			impairedDomains++
+		}
+		if dr.ImpairedTests >= 2 {
+			twoInOneDomain = true
+		}
+		out.Domains = append(out.Domains, *dr)
+	}
+
+	met := impairedDomains >= 2 || twoInOneDomain
+	switch {
+	case len(out.MissingDomains) > 0:
+		out.Status = StatusInsufficientBattery
+		if met {
+			out.Notes = append(out.Notes, NoteAbbreviatedBattery)
+		}
+	case met:
+		out.Status = StatusMet
+		out.Subtype = SubtypeSingleDomain
+		if impairedDomains >= 2 {
+			out.Subtype = SubtypeMultipleDomain
+		}
+	default:
+		out.Status = StatusNotMet
+	}
+	if functionalImpairment {
+		out.Notes = append(out.Notes, NoteFunctionalImpairment)
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package PDMCIdomain
+
+import (
+	"reflect"
+	"testing"
+
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+)
+
+// battery devuelve el perfil con la batería completa (primer indicador de cada test) en z = 0,
+// sin los indicadores de drop y con los z de set (que también puede añadir indicadores)
+func battery(set map[string]float64, drop ...string) COMPdomain.CognitiveProfile {
+	z := map[string]float64{}
+	for _, rule := range MDSLevelII.Tests {
+		z[rule.Indicators[0]] = 0
+	}
+	for _, key := range drop {
+		delete(z, key)
+	}
+	for key, v := range set {
+		z[key] = v
+	}
+	var indicators []COMPdomain.IndicatorScore
+	for key, v := range z {
+		indicators = append(indicators, COMPdomain.IndicatorScore{Key: key, Z: v})
+	}
+	return COMPdomain.CognitiveProfile{Domains: []COMPdomain.DomainComposite{{Indicators: indicators}}}
+}
+
+func TestEvaluate(t *testing.T) {
+	visuospatial := []string{"jlo.score", "visual_spatial.score"}
+
+	tests := []struct {
+		name                 string
+		profile              COMPdomain.CognitiveProfile
+		functionalImpairment bool
+		wantStatus           Status
+		wantSubtype          Subtype
+		wantDriving          []string
+		wantMissing          []Domain
+		wantNotes            []string
+	}{
+		{
+			name:        "Not met - full battery within norms",
+			profile:     battery(nil),
+			wantStatus:  StatusNotMet,
+			wantDriving: []string{},
+		},
+		{
+			name:        "Not met - a single impaired test",
+			profile:     battery(map[string]float64{"stroop.interference_t": -2.5, "jlo.score": -1.49}),
+			wantStatus:  StatusNotMet,
+			wantDriving: []string{"stroop.interference_t"},
+		},
+		{
+			name:        "Met single-domain - two impaired memory tests",
+			profile:     battery(map[string]float64{"verbal_memory.delayed_score": -2, "visual_memory.score": -1.6}),
+			wantStatus:  StatusMet,
+			wantSubtype: SubtypeSingleDomain,
+			wantDriving: []string{"verbal_memory.delayed_score", "visual_memory.score"},
+		},
+		{
+			name:        "Met multiple-domain - impairment at the cutoff in two domains",
+			profile:     battery(map[string]float64{"stroop.interference_t": -1.5, "jlo.score": -2}),
+			wantStatus:  StatusMet,
+			wantSubtype: SubtypeMultipleDomain,
+			wantDriving: []string{"stroop.interference_t", "jlo.score"},
+		},
+		{
+			name:        "Delayed recall is preferred over immediate recall",
+			profile:     battery(map[string]float64{"verbal_memory.immediate_score": -3, "jlo.score": -2}),
+			wantStatus:  StatusNotMet,
+			wantDriving: []string{"jlo.score"},
+		},
+		{
+			name:        "Immediate recall is used when delayed recall is missing",
+			profile:     battery(map[string]float64{"verbal_memory.immediate_score": -3, "jlo.score": -2}, "verbal_memory.delayed_score"),
+			wantStatus:  StatusMet,
+			wantSubtype: SubtypeMultipleDomain,
+			wantDriving: []string{"verbal_memory.immediate_score", "jlo.score"},
+		},
+		{
+			name:        "Insufficient battery - no impairment",
+			profile:     battery(nil, visuospatial...),
+			wantStatus:  StatusInsufficientBattery,
+			wantDriving: []string{},
+			wantMissing: []Domain{Visuospatial},
+		},
+		{
+			name:        "Insufficient battery - criteria met at level I only",
+			profile:     battery(map[string]float64{"verbal_memory.delayed_score": -2, "visual_memory.score": -2}, visuospatial...),
+			wantStatus:  StatusInsufficientBattery,
+			wantDriving: []string{"verbal_memory.delayed_score", "visual_memory.score"},
+			wantMissing: []Domain{Visuospatial},
+			wantNotes:   []string{NoteAbbreviatedBattery},
+		},
+		{
+			name:                 "FAQ impairment is noted without changing the status",
+			profile:              battery(nil),
+			functionalImpairment: true,
+			wantStatus:           StatusNotMet,
+			wantDriving:          []string{},
+			wantNotes:            []string{NoteFunctionalImpairment},
+		},
+		{
+			name:                 "FAQ impairment with criteria met",
+			profile:              battery(map[string]float64{"stroop.interference_t": -2, "jlo.score": -2}),
+			functionalImpairment: true,
+			wantStatus:           StatusMet,
+			wantSubtype:          SubtypeMultipleDomain,
+			wantDriving:          []string{"stroop.interference_t", "jlo.score"},
+			wantNotes:            []string{NoteFunctionalImpairment},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(MDSLevelII, tt.profile, tt.functionalImpairment)
+
+			if got.CriteriaRef != "mds-pd-mci-level2@1" || got.ImpairmentSD != 1.5 {
+				t.Errorf("unexpected criteria: %s / %v", got.CriteriaRef, got.ImpairmentSD)
+			}
+			if got.Status != tt.wantStatus || got.Subtype != tt.wantSubtype {
+				t.Errorf("expected %s/%q, got %s/%q", tt.wantStatus, tt.wantSubtype, got.Status, got.Subtype)
+			}
+			driving := []string{}
+			for _, r := range got.DrivingTests {
+				driving = append(driving, r.Indicator)
+			}
+			if !reflect.DeepEqual(driving, tt.wantDriving) {
+				t.Errorf("expected driving tests %v, got %v", tt.wantDriving, driving)
+			}
+			if tt.wantMissing == nil {
+				tt.wantMissing = []Domain{}
+			}
+			if !reflect.DeepEqual(got.MissingDomains, tt.wantMissing) {
+				t.Errorf("expected missing domains %v, got %v", tt.wantMissing, got.MissingDomains)
+			}
+			if tt.wantNotes == nil {
+				tt.wantNotes = []string{}
+			}
+			if !reflect.DeepEqual(got.Notes, tt.wantNotes) {
+				t.Errorf("expected notes %v, got %v", tt.wantNotes, got.Notes)
+			}
+			if len(got.Domains) != len(Domains) {
+				t.Errorf("expected all %d MDS domains in the result, got %d", len(Domains), len(got.Domains))
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package PTdomain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTemplate   = errors.New("invalid prompt template")
+	ErrTemplateNotFound  = errors.New("prompt template not found")
+	ErrUnsupportedLocale = errors.New("unsupported prompt locale")
+)
+
+type Locale string
+
+const (
+	LocaleES Locale = "es"
+	LocaleCA Locale = "ca"
+	LocaleEN Locale = "en"
+)
+
+// DefaultLocale es el idioma de los informes si la petición no indica otro
+const DefaultLocale = LocaleES
+
+var Locales = []Locale{LocaleES, LocaleCA, LocaleEN}
+
+var templateIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,39}$`)
+
+// PromptTemplate es una versión publicada (inmutable) de un prompt en un idioma. Body es
+// un text/template que se ejecuta contra el payload tipado del análisis; el bloque
+// opcional {{define "system"}} aporta el mensaje de sistema.
+type PromptTemplate struct {
+	ID        string    `json:"id"`
+	Locale    Locale    `json:"locale"`
+	Version   int       `json:"version"`
+	Body      string    `json:"body"`
+	Notes     string    `json:"notes,omitempty"`
+	Checksum  string    `json:"checksum"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Ref identifica la versión exacta y es lo que se guarda junto a cada análisis
+func (t PromptTemplate) Ref() string {
+	return FormatRef(t.ID, t.Locale, t.Version)
+}
+
+func FormatRef(id string, locale Locale, version int) string {
+	return fmt.Sprintf("%s/%s@%d", id, locale, version)
+}
+
+func ParseLocale(s string) (Locale, error) {
+	if s == "" {
+		return DefaultLocale, nil
+	}
+	for _, l := range Locales {
+		if string(l) == strings.ToLower(s) {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, s)
+}
+
+func Checksum(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewPromptTemplate prepara una versión para publicar; la versión la asigna el repositorio
+func NewPromptTemplate(id, locale, body, notes string) (PromptTemplate, error) {
+	if !templateIDPattern.MatchString(id) {
+		return PromptTemplate{}, fmt.Errorf("%w: id %q", ErrInvalidTemplate, id)
+	}
+	l, err := ParseLocale(locale)
+	if err != nil {
+		return PromptTemplate{}, err
+	}
+	if strings.TrimSpace(body) == "" {
+		return PromptTemplate{}, fmt.Errorf("%w: empty body", ErrInvalidTemplate)
+	}
+	return PromptTemplate{ID: id, Locale: l, Body: body, Notes: notes, Checksum: Checksum(body), CreatedAt: time.Now()}, nil
+}
@@ -0,0 +1,16 @@
+package PTdomain
+
+import "context"
+
+type PromptTemplateRepository interface {
+	List(ctx context.Context) ([]PromptTemplate, error)
+	// Latest devuelve la versión vigente (la más alta) de un prompt en un idioma
+	Latest(ctx context.Context, id string, locale Locale) (PromptTemplate, error)
+	// Publish asigna la siguiente versión y la hace vigente
+	Publish(ctx context.Context, t *PromptTemplate) error
+}
+
+// Validator comprueba una plantilla contra el payload tipado antes de publicarla
+type Validator interface {
+	Validate(t PromptTemplate) error
+}
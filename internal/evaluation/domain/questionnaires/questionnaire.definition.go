@@ -0,0 +1,190 @@
+package QNdomain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Definición declarativa de un cuestionario / escala de valoración. Las definiciones
+// se versionan: una respuesta guardada siempre se puntúa con la versión con la que se administró.
+
+type ScoringMethod string
+
+const (
+	MethodSum             ScoringMethod = "sum"               // suma de ítems (tras invertir los reversos)
+	MethodPercentOfMax    ScoringMethod = "percent_of_max"    // 0..100 sobre el máximo de los ítems respondidos
+	MethodMeanOfSubscales ScoringMethod = "mean_of_subscales" // sólo para el total (p.ej. PDQ-39 SI)
+)
+
+type Imputation string
+
+const (
+	ImputationNone    Imputation = "none"    // con ítems perdidos dentro de la tolerancia se puntúa lo respondido
+	ImputationProrate Imputation = "prorate" // suma prorrateada a la longitud completa
+)
+
+var (
+	ErrInvalidDefinition  = errors.New("invalid questionnaire definition")
+	ErrDefinitionNotFound = errors.New("questionnaire definition not found")
+	ErrUnknownItem        = errors.New("unknown questionnaire item")
+	ErrInvalidAnswer      = errors.New("invalid questionnaire answer")
+)
+
+type ResponseOption struct {
+	Value int    `json:"value"`
+	Label string `json:"label"`
+}
+
+type Item struct {
+	ID      string           `json:"id"`
+	Text    string           `json:"text"`
+	Options []ResponseOption `json:"options,omitempty"` // si está vacío se usan las opciones de la definición
+	Reverse bool             `json:"reverse,omitempty"`
+}
+
+type Cutoff struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Code     string  `json:"code"`
+	Label    string  `json:"label"`
+	Abnormal bool    `json:"abnormal"`
+}
+
+type MissingRule struct {
+	MaxMissing int        `json:"maxMissing"`
+	Imputation Imputation `json:"imputation"`
+}
+
+type Subscale struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Items   []string      `json:"items"`
+	Method  ScoringMethod `json:"method"`
+	Missing MissingRule   `json:"missing"`
+	Cutoffs []Cutoff      `json:"cutoffs,omitempty"`
+}
+
+type TotalScoring struct {
+	Method  ScoringMethod `json:"method"`
+	Missing MissingRule   `json:"missing"`
+	Cutoffs []Cutoff      `json:"cutoffs,omitempty"`
+}
+
+type Definition struct {
+	Code         string           `json:"code"`
+	Version      int              `json:"version"`
+	Name         string           `json:"name"`
+	Construct    string           `json:"construct"` // depression | apathy | quality_of_life | functional
+	Language     string           `json:"language"`
+	Instructions string           `json:"instructions"`
+	Options      []ResponseOption `json:"options"`
+	Items        []Item           `json:"items"`
+	Subscales    []Subscale       `json:"subscales,omitempty"`
+	Total        TotalScoring     `json:"total"`
+}
+
+func (d Definition) itemOptions(it Item) []ResponseOption {
+	if len(it.Options) > 0 {
+		return it.Options
+	}
+	return d.Options
+}
+
+func (d Definition) item(id string) (Item, bool) {
+	for _, it := range d.Items {
+		if it.ID == id {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+func (d Definition) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w %s v%d: %s", ErrInvalidDefinition, d.Code, d.Version, fmt.Sprintf(format, args...))
+	}
+	if d.Code == "" || d.Version < 1 || d.Name == "" {
+		return invalid("code, version and name are required")
+	}
+	if len(d.Items) == 0 {
+		return invalid("no items")
+	}
+	seen := map[string]bool{}
+	for _, it := range d.Items {
+		if it.ID == "" || seen[it.ID] {
+			return invalid("empty or duplicated item id %q", it.ID)
+		}
+		seen[it.ID] = true
+		opts := d.itemOptions(it)
+		if len(opts) < 2 {
+			return invalid("item %s needs at least two options", it.ID)
+		}
+		values := map[int]bool{}
+		for _, o := range opts {
+			if values[o.Value] {
+				return invalid("item %s has duplicated option value %d", it.ID, o.Value)
+			}
+			values[o.Value] = true
+		}
+	}
+	subscales := map[string]bool{}
+	for _, sc := range d.Subscales {
+		if sc.ID == "" || subscales[sc.ID] || len(sc.Items) == 0 {
+			return invalid("subscale %q is empty or duplicated", sc.ID)
+		}
+		subscales[sc.ID] = true
+		for _, id := range sc.Items {
+			if !seen[id] {
+				return invalid("subscale %s references unknown item %s", sc.ID, id)
+			}
+		}
+		if sc.Method != MethodSum && sc.Method != MethodPercentOfMax {
+			return invalid("subscale %s has unsupported method %q", sc.ID, sc.Method)
+		}
+		if err := validateRule(sc.Missing, len(sc.Items)); err != nil {
+			return invalid("subscale %s: %v", sc.ID, err)
+		}
+		if err := validateCutoffs(sc.Cutoffs); err != nil {
+			return invalid("subscale %s: %v", sc.ID, err)
+		}
+	}
+	switch d.Total.Method {
+	case MethodSum, MethodPercentOfMax:
+	case MethodMeanOfSubscales:
+		if len(d.Subscales) == 0 {
+			return invalid("mean_of_subscales requires subscales")
+		}
+	default:
+		return invalid("unsupported total method %q", d.Total.Method)
+	}
+	if err := validateRule(d.Total.Missing, len(d.Items)); err != nil {
+		return invalid("total: %v", err)
+	}
+	if err := validateCutoffs(d.Total.Cutoffs); err != nil {
+		return invalid("total: %v", err)
+	}
+	return nil
+}
+
+func validateRule(r MissingRule, items int) error {
+	if r.MaxMissing < 0 || r.MaxMissing >= items {
+		return fmt.Errorf("maxMissing %d out of range", r.MaxMissing)
+	}
+	if r.Imputation != "" && r.Imputation != ImputationNone && r.Imputation != ImputationProrate {
+		return fmt.Errorf("unsupported imputation %q", r.Imputation)
+	}
+	return nil
+}
+
+// validateCutoffs exige rangos ordenados y sin solapamiento
+func validateCutoffs(cs []Cutoff) error {
+	for i, c := range cs {
+		if c.Code == "" || c.Min > c.Max {
+			return fmt.Errorf("invalid cutoff %q", c.Code)
+		}
+		if i > 0 && c.Min <= cs[i-1].Max {
+			return fmt.Errorf("cutoff %q overlaps %q", c.Code, cs[i-1].Code)
+		}
+	}
+	return nil
+}
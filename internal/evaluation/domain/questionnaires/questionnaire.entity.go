@@ -0,0 +1,46 @@
+package QNdomain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuestionnaireResponse es una administración de un cuestionario dentro de una evaluación
+type QuestionnaireResponse struct {
+	PK                string             `json:"pk"`
+	EvaluationID      string             `json:"evaluationId"`
+	Code              string             `json:"code"`
+	Version           int                `json:"version"`
+	Name              string             `json:"name"`
+	Construct         string             `json:"construct"`
+	Answers           map[string]int     `json:"answers"`
+	Score             QuestionnaireScore `json:"score"`
+	AssistantAnalysis string             `json:"assistantAnalysis"`
+	CreatedAt         time.Time          `json:"createdAt"`
+}
+
+func NewQuestionnaireResponse(evaluationID string, def Definition, answers map[string]int) (*QuestionnaireResponse, error) {
+	if evaluationID == "" {
+		return nil, errors.New("invalid input for creation of QuestionnaireResponse")
+	}
+	if answers == nil {
+		answers = map[string]int{}
+	}
+	score, err := def.Score(answers)
+	if err != nil {
+		return nil, err
+	}
+	return &QuestionnaireResponse{
+		PK:           uuid.NewString(),
+		EvaluationID: evaluationID,
+		Code:         def.Code,
+		Version:      def.Version,
+		Name:         def.Name,
+		Construct:    def.Construct,
+		Answers:      answers,
+		Score:        score,
+		CreatedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package QNdomain
+
+import "context"
+
+type QuestionnaireRepository interface {
+	Save(ctx context.Context, response *QuestionnaireResponse) error
+	// GetByEvaluationID devuelve la última administración de cada cuestionario de la evaluación
+	GetByEvaluationID(ctx context.Context, evaluationID string) ([]QuestionnaireResponse, error)
+}
+
+// QuestionnaireCatalog da acceso a las definiciones versionadas
+type QuestionnaireCatalog interface {
+	// List devuelve la última versión de cada cuestionario
+	List(ctx context.Context) ([]Definition, error)
+	// Get devuelve la versión pedida; version 0 = la más reciente
+	Get(ctx context.Context, code string, version int) (Definition, error)
+}
@@ -0,0 +1,170 @@
+package QNdomain
+
+import (
+	"fmt"
+	"math"
+)
+
+type SubscaleScore struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Valid          bool    `json:"valid"` // false si se supera el máximo de ítems perdidos
+	Score          float64 `json:"score"`
+	Missing        int     `json:"missing"`
+	Classification string  `json:"classification,omitempty"`
+	Label          string  `json:"label,omitempty"`
+	Abnormal       bool    `json:"abnormal"`
+}
+
+type QuestionnaireScore struct {
+	Valid          bool            `json:"valid"`
+	Total          float64         `json:"total"`
+	MaxTotal       float64         `json:"maxTotal"`
+	Classification string          `json:"classification,omitempty"`
+	Label          string          `json:"label,omitempty"`
+	Abnormal       bool            `json:"abnormal"`
+	MissingItems   []string        `json:"missingItems,omitempty"`
+	Imputed        bool            `json:"imputed"`
+	Subscales      []SubscaleScore `json:"subscales,omitempty"`
+}
+
+// itemValue devuelve la puntuación del ítem ya invertida y su rango
+func (d Definition) itemValue(it Item, answer int) (value, lo, hi float64) {
+	opts := d.itemOptions(it)
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, o := range opts {
+		lo = math.Min(lo, float64(o.Value))
+		hi = math.Max(hi, float64(o.Value))
+	}
+	value = float64(answer)
+	if it.Reverse {
+		value = lo + hi - value
+	}
+	return value, lo, hi
+}
+
+// ValidateAnswers comprueba que cada respuesta corresponde a un ítem y a una opción existente
+func (d Definition) ValidateAnswers(answers map[string]int) error {
+	for id, v := range answers {
+		it, ok := d.item(id)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownItem, id)
+		}
+		valid := false
+		for _, o := range d.itemOptions(it) {
+			valid = valid || o.Value == v
+		}
+		if !valid {
+			return fmt.Errorf("%w: item %s value %d", ErrInvalidAnswer, id, v)
+		}
+	}
+	return nil
+}
+
+// scoreItems puntúa un conjunto de ítems aplicando la regla de ítems perdidos
+func (d Definition) scoreItems(ids []string, method ScoringMethod, rule MissingRule, answers map[string]int) (score, maxScore float64, missing int, imputed, valid bool) {
+	var sum, min, answeredMax, fullMax float64
+	answered := 0
+	for _, id := range ids {
+		it, _ := d.item(id)
+		_, lo, hi := d.itemValue(it, 0)
+		fullMax += hi
+		a, ok := answers[id]
+		if !ok {
+			missing++
+			continue
+		}
+		v, _, _ := d.itemValue(it, a)
+		sum += v - lo
+		min += lo
+		answeredMax += hi - lo
+		answered++
+	}
+	if missing > rule.MaxMissing || answered == 0 {
+		return 0, 0, missing, false, false
+	}
+
+	switch method {
+	case MethodPercentOfMax:
+		// equivale a imputar la media de los ítems respondidos
+		return round1(100 * sum / answeredMax), 100, missing, missing > 0, true
+	default:
+		total := sum + min
+		if missing > 0 && rule.Imputation == ImputationProrate {
+			total = total * float64(len(ids)) / float64(answered)
+			imputed = true
+		}
+		return round1(total), fullMax, missing, imputed, true
+	}
+}
+
+// classify devuelve el último punto de corte cuyo mínimo alcanza el valor (tolera
+// puntuaciones prorrateadas no enteras que caen entre dos rangos)
+func classify(cutoffs []Cutoff, value float64) (Cutoff, bool) {
+	var found Cutoff
+	ok := false
+	for _, c := range cutoffs {
+		if value >= c.Min {
+			found, ok = c, true
+		}
+	}
+	return found, ok
+}
+
+// Score puntúa un conjunto de respuestas (id de ítem → valor de la opción elegida)
+func (d Definition) Score(answers map[string]int) (QuestionnaireScore, error) {
+	if err := d.ValidateAnswers(answers); err != nil {
+		return QuestionnaireScore{}, err
+	}
+
+	out := QuestionnaireScore{}
+	allIDs := make([]string, 0, len(d.Items))
+	for _, it := range d.Items {
+		allIDs = append(allIDs, it.ID)
+		if _, ok := answers[it.ID]; !ok {
+			out.MissingItems = append(out.MissingItems, it.ID)
+		}
+	}
+
+	subscalesValid := true
+	var subscaleSum float64
+	for _, sc := range d.Subscales {
+		s, _, missing, imputed, valid := d.scoreItems(sc.Items, sc.Method, sc.Missing, answers)
+		res := SubscaleScore{ID: sc.ID, Name: sc.Name, Valid: valid, Missing: missing}
+		if valid {
+			res.Score = s
+			if c, ok := classify(sc.Cutoffs, s); ok {
+				res.Classification, res.Label, res.Abnormal = c.Code, c.Label, c.Abnormal
+			}
+			subscaleSum += s
+		}
+		subscalesValid = subscalesValid && valid
+		out.Imputed = out.Imputed || imputed
+		out.Subscales = append(out.Subscales, res)
+	}
+
+	switch d.Total.Method {
+	case MethodMeanOfSubscales:
+		out.Valid = subscalesValid && len(out.MissingItems) <= d.Total.Missing.MaxMissing
+		if out.Valid {
+			out.Total = round1(subscaleSum / float64(len(d.Subscales)))
+		}
+		out.MaxTotal = 100
+	default:
+		total, maxTotal, _, imputed, valid := d.scoreItems(allIDs, d.Total.Method, d.Total.Missing, answers)
+		out.Valid, out.Total, out.MaxTotal = valid, total, maxTotal
+		out.Imputed = out.Imputed || imputed
+	}
+	if !out.Valid {
+		out.Total = 0
+		return out, nil
+	}
+	if c, ok := classify(d.Total.Cutoffs, out.Total); ok {
+		out.Classification, out.Label, out.Abnormal = c.Code, c.Label, c.Abnormal
+	}
+	return out, nil
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
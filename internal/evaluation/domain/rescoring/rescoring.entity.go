@@ -0,0 +1,127 @@
+package RSdomain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var ErrUnknownSubtest = errors.New("unknown rescorable subtest")
+
+// Subtest identifica las tablas con score recalculable a partir de los datos brutos guardados
+type Subtest string
+
+const (
+	LetterCancellation Subtest = "letter_cancellation"
+	VerbalMemory       Subtest = "verbal_memory"
+	ExecutiveFunctions Subtest = "executive_functions"
+	LanguageFluency    Subtest = "language_fluency"
+)
+
+var AllSubtests = []Subtest{LetterCancellation, VerbalMemory, ExecutiveFunctions, LanguageFluency}
+
+func ParseSubtest(s string) (Subtest, error) {
+	for _, st := range AllSubtests {
+		if string(st) == s {
+			return st, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownSubtest, s)
+}
+
+// ScoreRevision es una versión del score de un subtest. La revisión 1 es el score original,
+// archivado la primera vez que se aplica un recálculo; la fila del subtest guarda la última.
+type ScoreRevision struct {
+	Subtest        Subtest         `json:"subtest"`
+	SubtestID      string          `json:"subtestId"`
+	EvaluationID   string          `json:"evaluationId"`
+	Revision       int             `json:"revision"`
+	ScoringProfile string          `json:"scoringProfile"`
+	Score          json.RawMessage `json:"score"`
+	RunID          string          `json:"runId,omitempty"` // vacío en la revisión original
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// Diff describe el cambio de score de una fila; Fields lista las métricas que cambian
+type Diff struct {
+	Subtest      Subtest  `json:"subtest"`
+	SubtestID    string   `json:"subtestId"`
+	EvaluationID string   `json:"evaluationId"`
+	FromProfile  string   `json:"fromProfile"`
+	ToProfile    string   `json:"toProfile"`
+	OldScore     int      `json:"oldScore"`
+	NewScore     int      `json:"newScore"`
+	Fields       []string `json:"fields"`
+	Old          any      `json:"old"`
+	New          any      `json:"new"`
+	Applied      bool     `json:"applied"`
+}
+
+type Failure struct {
+	Subtest   Subtest `json:"subtest"`
+	SubtestID string  `json:"subtestId"`
+	Error     string  `json:"error"`
+}
+
+type Report struct {
+	RunID         string    `json:"runId"`
+	TargetProfile string    `json:"targetProfile"` // vacío: cada fila con el perfil que ya tenía
+	DryRun        bool      `json:"dryRun"`
+	Scanned       int       `json:"scanned"`
+	Changed       int       `json:"changed"`
+	Unchanged     int       `json:"unchanged"`
+	Diffs         []Diff    `json:"diffs"`
+	Failures      []Failure `json:"failures"`
+	StartedAt     time.Time `json:"startedAt"`
+	FinishedAt    time.Time `json:"finishedAt"`
+}
+
+// ChangedFields compara dos scores del mismo tipo métrica a métrica (por su nombre JSON),
+// con tolerancia para el ruido de coma flotante del recálculo
+func ChangedFields(old, new any) ([]string, error) {
+	a, err := toFields(old)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toFields(new)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	for k, v := range b {
+		if !sameValue(a[k], v) {
+			fields = append(fields, k)
+		}
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func toFields(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func sameValue(a, b any) bool {
+	fa, okA := a.(float64)
+	fb, okB := b.(float64)
+	if okA && okB {
+		return math.Abs(fa-fb) <= 1e-9*math.Max(1, math.Abs(fa))
+	}
+	return a == b
+}
@@ -0,0 +1,29 @@
+package RSdomain
+
+import (
+	"context"
+
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+// RescoringRepository lee los subtests guardados y aplica los recálculos. Los List* con
+// evaluationID vacío devuelven todas las filas. Los Apply* guardan el score nuevo como
+// revisión (archivando antes el original si aún no hay historial) y actualizan la fila, todo
+// en una transacción.
+type RescoringRepository interface {
+	ListLetterCancellation(ctx context.Context, evaluationID string) ([]LCdomain.LettersCancellationSubtest, error)
+	ListVerbalMemory(ctx context.Context, evaluationID string) ([]VEMdomain.VerbalMemorySubtest, error)
+	ListExecutiveFunctions(ctx context.Context, evaluationID string) ([]EFdomain.ExecutiveFunctionsSubtest, error)
+	ListLanguageFluency(ctx context.Context, evaluationID string) ([]LFdomain.LanguageFluency, error)
+
+	ApplyLetterCancellation(ctx context.Context, sub LCdomain.LettersCancellationSubtest, score LCdomain.CancellationScore, runID string) error
+	ApplyVerbalMemory(ctx context.Context, sub VEMdomain.VerbalMemorySubtest, score VEMdomain.VerbalMemoryScore, runID string) error
+	// ApplyExecutiveFunctions también corrige total_time_sec con el tiempo reconstruido
+	ApplyExecutiveFunctions(ctx context.Context, sub EFdomain.ExecutiveFunctionsSubtest, score EFdomain.ExecutiveFunctionsScore, runID string) error
+	ApplyLanguageFluency(ctx context.Context, sub LFdomain.LanguageFluency, score LFdomain.LanguageFluencyScore, runID string) error
+
+	GetRevisions(ctx context.Context, subtest Subtest, subtestID string) ([]ScoreRevision, error)
+}
@@ -0,0 +1,88 @@
+package RSdomain
+
+import (
+	"context"
+
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+// Rescorer recalcula scores desde los datos brutos. Con Target nil cada fila se recalcula con
+// el perfil que tiene registrado (p. ej. tras corregir un bug en la fórmula).
+type Rescorer struct {
+	Catalog SCPdomain.ScoringProfileCatalog
+	Target  *SCPdomain.ScoringProfile
+}
+
+func (r Rescorer) profile(ctx context.Context, stored string) (SCPdomain.ScoringProfile, error) {
+	if r.Target != nil {
+		return *r.Target, nil
+	}
+	return r.Catalog.Get(ctx, stored)
+}
+
+func (r Rescorer) LetterCancellation(ctx context.Context, sub LCdomain.LettersCancellationSubtest) (LCdomain.CancellationScore, error) {
+	p, err := r.profile(ctx, sub.CancellationScore.ScoringProfile)
+	if err != nil {
+		return LCdomain.CancellationScore{}, err
+	}
+	score, err := LCdomain.ScoreLettersCancellation(sub, &p.LetterCancellation)
+	if err != nil {
+		return LCdomain.CancellationScore{}, err
+	}
+	score.ScoringProfile = p.Ref()
+	return score, nil
+}
+
+func (r Rescorer) VerbalMemory(ctx context.Context, sub VEMdomain.VerbalMemorySubtest) (VEMdomain.VerbalMemoryScore, error) {
+	p, err := r.profile(ctx, sub.Score.ScoringProfile)
+	if err != nil {
+		return VEMdomain.VerbalMemoryScore{}, err
+	}
+	score, err := VEMdomain.ScoreVerbalMemory(sub, &p.VerbalMemory)
+	if err != nil {
+		return VEMdomain.VerbalMemoryScore{}, err
+	}
+	score.ScoringProfile = p.Ref()
+	return score, nil
+}
+
+// ExecutiveFunctions devuelve también el subtest con el tiempo bruto. Solo se reconstruye a partir
+// de la duración y la penalización del perfil original en las filas antiguas; las posteriores al
+// fix ya lo tienen y reconstruirlo solo añadiría redondeo.
+func (r Rescorer) ExecutiveFunctions(ctx context.Context, sub EFdomain.ExecutiveFunctionsSubtest) (EFdomain.ExecutiveFunctionsSubtest, EFdomain.ExecutiveFunctionsScore, error) {
+	stored, err := r.Catalog.Get(ctx, sub.Score.ScoringProfile)
+	if err != nil {
+		return sub, EFdomain.ExecutiveFunctionsScore{}, err
+	}
+	if !sub.HasRawTotalTime(stored.ExecutiveFunctions.ErrorPenaltySec) {
+		sub.TotalTime = EFdomain.TotalTimeFromScore(sub.Score, sub.TotalErrors, stored.ExecutiveFunctions.ErrorPenaltySec)
+	}
+
+	p, err := r.profile(ctx, sub.Score.ScoringProfile)
+	if err != nil {
+		return sub, EFdomain.ExecutiveFunctionsScore{}, err
+	}
+	score, err := EFdomain.ScoreExecutiveFunctions(sub, &p.ExecutiveFunctions)
+	if err != nil {
+		return sub, EFdomain.ExecutiveFunctionsScore{}, err
+	}
+	score.ScoringProfile = p.Ref()
+	return sub, score, nil
+}
+
+func (r Rescorer) LanguageFluency(ctx context.Context, sub LFdomain.LanguageFluency) (LFdomain.LanguageFluencyScore, error) {
+	p, err := r.profile(ctx, sub.Score.ScoringProfile)
+	if err != nil {
+		return LFdomain.LanguageFluencyScore{}, err
+	}
+	score, err := LFdomain.ScoreLanguageFluency(sub, &p.LanguageFluency)
+	if err != nil {
+		return LFdomain.LanguageFluencyScore{}, err
+	}
+	score.ScoringProfile = p.Ref()
+	return score, nil
+}
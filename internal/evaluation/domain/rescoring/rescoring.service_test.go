@@ -0,0 +1,82 @@
+package RSdomain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	SCPinfra "neuro.app.jordi/internal/evaluation/infra/scoring-profiles"
+)
+
+func TestRescorerExecutiveFunctions(t *testing.T) {
+	catalog := SCPinfra.NewEmbeddedScoringProfileCatalog()
+	target, err := catalog.Get(context.TODO(), "pd-motor@1")
+	if err != nil {
+		t.Fatalf("cannot load target profile: %v", err)
+	}
+
+	scored := func(totalTime time.Duration) EFdomain.ExecutiveFunctionsSubtest {
+		sub := EFdomain.ExecutiveFunctionsSubtest{PK: "ef-1", EvauluationId: "eval-1", NumberOfItems: 25, TotalClicks: 27, TotalErrors: 2, TotalCorrect: 25, TotalTime: totalTime, Type: EFdomain.A}
+		sub.Score, _ = EFdomain.ScoreExecutiveFunctions(sub, nil)
+		sub.Score.ScoringProfile = "standard@1"
+		return sub
+	}
+	// fila antigua: total_time_sec guardaba el score en lugar del tiempo
+	legacy := scored(40 * time.Second)
+	legacy.TotalTime = time.Duration(legacy.Score.Score) * time.Second
+
+	tests := []struct {
+		name          string
+		sub           EFdomain.ExecutiveFunctionsSubtest
+		target        *SCPdomain.ScoringProfile
+		wantTotalTime time.Duration
+	}{
+		{
+			name:          "Legacy row - time rebuilt from duration",
+			sub:           legacy,
+			wantTotalTime: 40 * time.Second,
+		},
+		{
+			name:          "Post-fix row - stored time kept without rounding",
+			sub:           scored(40*time.Second + 123456*time.Microsecond),
+			wantTotalTime: 40*time.Second + 123456*time.Microsecond,
+		},
+		{
+			name:          "Post-fix row - zero time is not replaced by the 1s floor",
+			sub:           scored(0),
+			wantTotalTime: 0,
+		},
+		{
+			name:          "Post-fix row - rescored with a new profile version",
+			sub:           scored(52*time.Second + 700*time.Millisecond),
+			target:        &target,
+			wantTotalTime: 52*time.Second + 700*time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RSdomain.Rescorer{Catalog: catalog, Target: tt.target}
+			rebuilt, score, err := r.ExecutiveFunctions(context.TODO(), tt.sub)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rebuilt.TotalTime != tt.wantTotalTime {
+				t.Errorf("expected total time %v, got %v", tt.wantTotalTime, rebuilt.TotalTime)
+			}
+
+			// un segundo recálculo sobre lo aplicado no cambia nada
+			rebuilt.Score = score
+			again, scoreAgain, err := r.ExecutiveFunctions(context.TODO(), rebuilt)
+			if err != nil {
+				t.Fatalf("unexpected error on second rescore: %v", err)
+			}
+			if again.TotalTime != rebuilt.TotalTime || scoreAgain != score {
+				t.Errorf("expected idempotent rescore, got %v/%+v after %v/%+v", again.TotalTime, scoreAgain, rebuilt.TotalTime, score)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package SCPdomain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+var (
+	ErrInvalidProfile     = errors.New("invalid scoring profile")
+	ErrProfileNotFound    = errors.New("scoring profile not found")
+	ErrProtocolNotFound   = errors.New("scoring protocol not found")
+	ErrProtocolAlreadySet = errors.New("scoring protocol already set for this evaluation")
+)
+
+// DefaultProtocol es el protocolo aplicado a las evaluaciones sin protocolo elegido
+const DefaultProtocol = "default"
+
+var profileIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,39}$`)
+
+// ScoringProfile agrupa los parámetros de las fórmulas configurables. Un perfil publicado
+// (id + versión) es inmutable: cambiar una fórmula exige una versión nueva, de modo que el
+// id@versión guardado en cada resultado sigue describiendo cómo se calculó.
+// Los subtests sin parámetros aquí usan fórmulas fijas que también forman parte de la versión.
+type ScoringProfile struct {
+	ID                 string                                 `json:"id"`
+	Version            int                                    `json:"version"`
+	Description        string                                 `json:"description"`
+	LetterCancellation LCdomain.CancellationScoreConfig       `json:"letterCancellation"`
+	LanguageFluency    LFdomain.LanguageFluencyScoreConfig    `json:"languageFluency"`
+	ExecutiveFunctions EFdomain.ExecutiveFunctionsScoreConfig `json:"executiveFunctions"`
+	VerbalMemory       VEMdomain.VerbalMemoryScoreConfig      `json:"verbalMemory"`
+	// Huella de los parámetros declarada en la configuración; se comprueba al cargar
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ScoringSelection fija el perfil (id@versión exacto) con el que se puntúa una evaluación
+type ScoringSelection struct {
+	EvaluationID string    `json:"evaluationId"`
+	Protocol     string    `json:"protocol"`
+	ProfileRef   string    `json:"profileRef"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (p ScoringProfile) Ref() string {
+	return FormatRef(p.ID, p.Version)
+}
+
+func FormatRef(id string, version int) string {
+	return fmt.Sprintf("%s@%d", id, version)
+}
+
+// ParseRef separa "id@versión"
+func ParseRef(ref string) (string, int, error) {
+	id, v, ok := strings.Cut(ref, "@")
+	if !ok || !profileIDPattern.MatchString(id) {
+		return "", 0, fmt.Errorf("%w: malformed reference %q", ErrInvalidProfile, ref)
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil || version <= 0 {
+		return "", 0, fmt.Errorf("%w: malformed reference %q", ErrInvalidProfile, ref)
+	}
+	return id, version, nil
+}
+
+// ComputeFingerprint resume los parámetros de las fórmulas (no el id ni la descripción)
+func (p ScoringProfile) ComputeFingerprint() string {
+	raw, _ := json.Marshal(struct {
+		LC  LCdomain.CancellationScoreConfig
+		LF  LFdomain.LanguageFluencyScoreConfig
+		EF  EFdomain.ExecutiveFunctionsScoreConfig
+		VEM VEMdomain.VerbalMemoryScoreConfig
+	}{p.LetterCancellation, p.LanguageFluency, p.ExecutiveFunctions, p.VerbalMemory})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Validate exige un perfil completo: no hay valores por defecto implícitos
+func (p ScoringProfile) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidProfile, p.Ref(), fmt.Sprintf(format, args...))
+	}
+	if !profileIDPattern.MatchString(p.ID) || p.Version <= 0 {
+		return fmt.Errorf("%w: id %q / version %d", ErrInvalidProfile, p.ID, p.Version)
+	}
+	if p.LetterCancellation.CapErrorFactor <= 0 {
+		return invalid("letterCancellation.capErrorFactor must be > 0")
+	}
+	lf := p.LanguageFluency
+	if lf.DurationSec <= 0 || lf.MaxExpectedPerMinute <= 0 || lf.IntrusionPenalty < 0 || lf.PersevPenalty < 0 {
+		return invalid("languageFluency parameters out of range")
+	}
+	ef := p.ExecutiveFunctions
+	if ef.AccuracyWeight < 0 || ef.SpeedWeight < 0 || ef.CommissionWeight < 0 || ef.AccuracyWeight+ef.SpeedWeight <= 0 {
+		return invalid("executiveFunctions weights out of range")
+	}
+	if ef.ErrorPenaltySec < 0 || ef.IdealSecPerItemA <= 0 || ef.IdealSecPerItemAB <= 0 {
+		return invalid("executiveFunctions timing parameters out of range")
+	}
+	vm := p.VerbalMemory
+	if vm.IntrusionPenalty < 0 || vm.PerseverationPenalty < 0 {
+		return invalid("verbalMemory penalties out of range")
+	}
+	if p.Fingerprint == "" {
+		return invalid("fingerprint is required (expected %s)", p.ComputeFingerprint())
+	}
+	if got := p.ComputeFingerprint(); got != p.Fingerprint {
+		return invalid("parameters changed without a new version (fingerprint %s, declared %s)", got, p.Fingerprint)
+	}
+	return nil
+}
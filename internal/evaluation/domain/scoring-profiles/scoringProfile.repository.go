@@ -0,0 +1,18 @@
+package SCPdomain
+
+import "context"
+
+type ScoringProfileCatalog interface {
+	List(ctx context.Context) ([]ScoringProfile, error)
+	// Get resuelve una referencia id@versión exacta
+	Get(ctx context.Context, ref string) (ScoringProfile, error)
+	// ForProtocol devuelve el perfil asignado al protocolo ("" = DefaultProtocol)
+	ForProtocol(ctx context.Context, protocol string) (ScoringProfile, error)
+	Protocols(ctx context.Context) (map[string]string, error)
+}
+
+type ScoringSelectionRepository interface {
+	Save(ctx context.Context, s *ScoringSelection) error
+	// GetByEvaluationID devuelve la selección vacía (EvaluationID == "") si no se ha elegido protocolo
+	GetByEvaluationID(ctx context.Context, evaluationID string) (ScoringSelection, error)
+}
@@ -0,0 +1,24 @@
+package SCPdomain
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResolveForEvaluation devuelve el perfil fijado en la evaluación o, si no se eligió
+// protocolo, el del protocolo por defecto
+func ResolveForEvaluation(ctx context.Context, catalog ScoringProfileCatalog, selections ScoringSelectionRepository, evaluationID string) (ScoringProfile, error) {
+	sel, err := selections.GetByEvaluationID(ctx, evaluationID)
+	if err != nil {
+		return ScoringProfile{}, err
+	}
+	if sel.ProfileRef == "" {
+		return catalog.ForProtocol(ctx, DefaultProtocol)
+	}
+	p, err := catalog.Get(ctx, sel.ProfileRef)
+	if err != nil {
+		// Un perfil ya usado nunca debe retirarse de la configuración
+		return ScoringProfile{}, fmt.Errorf("evaluation %s pinned to %s: %w", evaluationID, sel.ProfileRef, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,278 @@
+package SPdomain
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+const (
+	analysisRate  = 8000 // se decima a ~8 kHz: suficiente para F0 e intensidad
+	hopSec        = 0.010
+	windowSec     = 0.040
+	minPitchHz    = 75.0
+	maxPitchHz    = 400.0
+	voicingThresh = 0.6
+	minPauseSec   = 0.250
+	// Separación mínima entre núcleos silábicos y prominencia exigida
+	nucleusHalfWindow = 5 // tramas (±50 ms)
+	nucleusDipDB      = 2.0
+	silenceFloorDB    = -100.0
+)
+
+var (
+	ErrInsufficientAudio  = errors.New("audio too short or sample rate too low for speech analysis")
+	ErrInsufficientSpeech = errors.New("not enough speech detected in audio")
+)
+
+type frame struct {
+	energy float64 // media de x² en la ventana
+	db     float64
+	speech bool
+	f0     float64 // 0 si sorda
+}
+
+// AnalyzeSpeech calcula intensidad, F0, pausas y velocidad articulatoria de una grabación mono.
+// Todo el análisis es en memoria: el llamador es responsable de descartar las muestras.
+func AnalyzeSpeech(samples []float32, sampleRate int) (Metrics, error) {
+	if sampleRate < analysisRate || len(samples) < sampleRate {
+		return Metrics{}, ErrInsufficientAudio
+	}
+	x, rate := decimate(samples, sampleRate)
+
+	hop := int(hopSec * float64(rate))
+	win := int(windowSec * float64(rate))
+	if len(x) < win {
+		return Metrics{}, ErrInsufficientAudio
+	}
+	var frames []frame
+	for start := 0; start+win <= len(x); start += hop {
+		w := x[start : start+win]
+		var e float64
+		for _, v := range w {
+			e += v * v
+		}
+		e /= float64(win)
+		db := silenceFloorDB
+		if e > 0 {
+			db = math.Max(10*math.Log10(e), silenceFloorDB)
+		}
+		frames = append(frames, frame{energy: e, db: db})
+	}
+
+	// Umbral adaptativo: ruido de fondo + 12 dB, sin bajar de 30 dB bajo los picos
+	dbs := make([]float64, len(frames))
+	for i, f := range frames {
+		dbs[i] = f.db
+	}
+	sort.Float64s(dbs)
+	threshold := math.Max(percentile(dbs, 0.10)+12, percentile(dbs, 0.95)-30)
+
+	first, last := -1, -1
+	var speechEnergy float64
+	var speechDB []float64
+	for i := range frames {
+		if frames[i].db < threshold {
+			continue
+		}
+		frames[i].speech = true
+		if first < 0 {
+			first = i
+		}
+		last = i
+		speechEnergy += frames[i].energy
+		speechDB = append(speechDB, frames[i].db)
+		start := i * hop
+		frames[i].f0 = pitch(x[start:start+win], rate)
+	}
+	if len(speechDB) < int(0.5/hopSec) {
+		return Metrics{}, ErrInsufficientSpeech
+	}
+
+	var m Metrics
+	m.DurationSec = round2(float64(len(x)) / float64(rate))
+	m.LoudnessDBFS = round2(10 * math.Log10(speechEnergy/float64(len(speechDB))))
+	_, m.LoudnessSDDB = meanSD(speechDB)
+	m.LoudnessSDDB = round2(m.LoudnessSDDB)
+
+	// F0: media en Hz y variabilidad en semitonos respecto a la mediana
+	var f0s []float64
+	for _, f := range frames {
+		if f.f0 > 0 {
+			f0s = append(f0s, f.f0)
+		}
+	}
+	m.VoicedFraction = round2(float64(len(f0s)) / float64(len(speechDB)))
+	if len(f0s) > 0 {
+		meanHz, _ := meanSD(f0s)
+		sorted := append([]float64{}, f0s...)
+		sort.Float64s(sorted)
+		median := percentile(sorted, 0.5)
+		st := make([]float64, len(f0s))
+		for i, f := range f0s {
+			st[i] = 12 * math.Log2(f/median)
+		}
+		_, sd := meanSD(st)
+		sort.Float64s(st)
+		m.PitchMeanHz = round2(meanHz)
+		m.PitchSDSemitones = round2(sd)
+		m.PitchRangeSemitones = round2(percentile(st, 0.90) - percentile(st, 0.10))
+	}
+
+	// Pausas: silencios ≥ 250 ms entre el primer y el último tramo de habla
+	minPause := int(minPauseSec / hopSec)
+	pauseFrames, run := 0, 0
+	for i := first; i <= last; i++ {
+		if !frames[i].speech {
+			run++
+			continue
+		}
+		if run >= minPause {
+			m.Pauses++
+			pauseFrames += run
+		}
+		run = 0
+	}
+	span := last - first + 1
+	m.PauseRatio = round2(float64(pauseFrames) / float64(span))
+	m.SpeakingTimeSec = round2(float64(span-pauseFrames) * hopSec)
+
+	// Núcleos silábicos: máximos locales sonoros de la intensidad suavizada con caída previa ≥ 2 dB
+	smooth := make([]float64, len(frames))
+	for i := range frames {
+		lo, hi := max(0, i-2), min(len(frames)-1, i+2)
+		var s float64
+		for j := lo; j <= hi; j++ {
+			s += frames[j].db
+		}
+		smooth[i] = s / float64(hi-lo+1)
+	}
+	lastPeak := -1
+	for i := range frames {
+		if !frames[i].speech || frames[i].f0 == 0 {
+			continue
+		}
+		isMax := true
+		for j := max(0, i-nucleusHalfWindow); j <= min(len(frames)-1, i+nucleusHalfWindow); j++ {
+			if smooth[j] > smooth[i] || (smooth[j] == smooth[i] && j < i) {
+				isMax = false
+				break
+			}
+		}
+		if !isMax {
+			continue
+		}
+		if lastPeak >= 0 {
+			dip := smooth[i]
+			for j := lastPeak; j <= i; j++ {
+				dip = math.Min(dip, smooth[j])
+			}
+			if math.Min(smooth[lastPeak], smooth[i])-dip < nucleusDipDB {
+				continue
+			}
+		}
+		m.SyllableNuclei++
+		lastPeak = i
+	}
+	if m.SpeakingTimeSec > 0 {
+		m.ArticulationRate = round2(float64(m.SyllableNuclei) / m.SpeakingTimeSec)
+	}
+
+	m.Hypophonia = m.LoudnessDBFS < HypophoniaDBFS
+	m.Monotone = len(f0s) > 0 && m.PitchSDSemitones < MonotoneSemitones
+	return m, nil
+}
+
+// decimate promedia bloques hasta ~8 kHz (filtro paso bajo rudimentario) y elimina la continua
+func decimate(samples []float32, sampleRate int) ([]float64, int) {
+	factor := max(1, sampleRate/analysisRate)
+	out := make([]float64, 0, len(samples)/factor)
+	var mean float64
+	for i := 0; i+factor <= len(samples); i += factor {
+		var s float64
+		for _, v := range samples[i : i+factor] {
+			s += float64(v)
+		}
+		out = append(out, s/float64(factor))
+		mean += s / float64(factor)
+	}
+	mean /= float64(len(out))
+	for i := range out {
+		out[i] -= mean
+	}
+	return out, sampleRate / factor
+}
+
+// pitch estima F0 por autocorrelación normalizada; devuelve 0 si la trama es sorda
+func pitch(w []float64, rate int) float64 {
+	minLag := int(float64(rate) / maxPitchHz)
+	maxLag := int(float64(rate) / minPitchHz)
+	if maxLag >= len(w)/2 {
+		maxLag = len(w)/2 - 1
+	}
+	if minLag < 1 || maxLag <= minLag {
+		return 0
+	}
+	nccf := make([]float64, maxLag+2)
+	best := 0.0
+	for lag := minLag; lag <= maxLag+1 && lag < len(w); lag++ {
+		var num, e0, e1 float64
+		for i := 0; i+lag < len(w); i++ {
+			num += w[i] * w[i+lag]
+			e0 += w[i] * w[i]
+			e1 += w[i+lag] * w[i+lag]
+		}
+		if e0 > 0 && e1 > 0 {
+			nccf[lag] = num / math.Sqrt(e0*e1)
+		}
+		if lag <= maxLag && nccf[lag] > best {
+			best = nccf[lag]
+		}
+	}
+	if best < voicingThresh {
+		return 0
+	}
+	// Primer pico cercano al máximo: evita errores de octava grave
+	for lag := minLag; lag <= maxLag; lag++ {
+		if nccf[lag] < 0.9*best || nccf[lag] < nccf[lag+1] {
+			continue
+		}
+		// Interpolación parabólica alrededor del pico
+		a, b, c := nccf[lag-1], nccf[lag], nccf[lag+1]
+		shift := 0.0
+		if den := a - 2*b + c; den != 0 {
+			shift = 0.5 * (a - c) / den
+		}
+		return float64(rate) / (float64(lag) + shift)
+	}
+	return 0
+}
+
+func meanSD(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	mean := s / float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)))
+}
+
+// percentile sobre un slice ya ordenado
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Round(p * float64(len(sorted)-1)))
+	return sorted[idx]
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
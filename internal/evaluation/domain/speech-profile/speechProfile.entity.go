@@ -0,0 +1,59 @@
+package SPdomain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Source string
+
+const (
+	SourceLanguageFluency Source = "language_fluency"
+)
+
+const (
+	// Umbral orientativo: el micrófono no está calibrado, el nivel es relativo a escala completa
+	HypophoniaDBFS = -35.0
+	// Variabilidad de F0 por debajo de la cual el habla se considera monótona
+	MonotoneSemitones = 2.0
+)
+
+type SpeechProfile struct {
+	PK           string    `json:"pk"`
+	EvaluationID string    `json:"evaluationId"`
+	Source       Source    `json:"source"`
+	Metrics      Metrics   `json:"metrics"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type Metrics struct {
+	DurationSec         float64 `json:"durationSec"`
+	SpeakingTimeSec     float64 `json:"speakingTimeSec"`
+	LoudnessDBFS        float64 `json:"loudnessDBFS"`        // nivel medio en tramos de habla
+	LoudnessSDDB        float64 `json:"loudnessSDDB"`        // variabilidad de intensidad
+	PitchMeanHz         float64 `json:"pitchMeanHz"`         // F0 media en tramos sonoros
+	PitchSDSemitones    float64 `json:"pitchSDSemitones"`    // variabilidad de F0 (prosodia)
+	PitchRangeSemitones float64 `json:"pitchRangeSemitones"` // p90 − p10
+	VoicedFraction      float64 `json:"voicedFraction"`      // tramas sonoras / tramas de habla
+	Pauses              int     `json:"pauses"`              // silencios ≥ 250 ms
+	PauseRatio          float64 `json:"pauseRatio"`          // tiempo en pausa / tiempo entre primer y último tramo de habla
+	SyllableNuclei      int     `json:"syllableNuclei"`      // picos de intensidad sonoros
+	ArticulationRate    float64 `json:"articulationRate"`    // sílabas / s de habla (sin pausas)
+	Hypophonia          bool    `json:"hypophonia"`
+	Monotone            bool    `json:"monotone"`
+}
+
+func NewSpeechProfile(evaluationID string, source Source, metrics Metrics) (*SpeechProfile, error) {
+	if evaluationID == "" || source == "" {
+		return nil, errors.New("invalid input for creation of SpeechProfile")
+	}
+	return &SpeechProfile{
+		PK:           uuid.NewString(),
+		EvaluationID: evaluationID,
+		Source:       source,
+		Metrics:      metrics,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
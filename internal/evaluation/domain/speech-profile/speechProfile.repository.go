@@ -0,0 +1,9 @@
+package SPdomain
+
+import "context"
+
+type SpeechProfileRepository interface {
+	Save(ctx context.Context, profile *SpeechProfile) error
+	// GetByEvaluationID devuelve el perfil vacío (PK == "") si no hay audio analizado
+	GetByEvaluationID(ctx context.Context, evaluationID string) (SpeechProfile, error)
+}
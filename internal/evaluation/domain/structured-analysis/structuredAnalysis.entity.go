@@ -0,0 +1,221 @@
+package SAdomain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+)
+
+// ErrInvalidAnalysis: la respuesta del modelo no es JSON o no cumple el esquema
+var ErrInvalidAnalysis = errors.New("invalid structured analysis")
+
+type Severity string
+
+const (
+	SeverityPreserved Severity = "preserved"
+	SeverityMild      Severity = "mild"
+	SeverityModerate  Severity = "moderate"
+	SeveritySevere    Severity = "severe"
+)
+
+var Severities = []Severity{SeverityPreserved, SeverityMild, SeverityModerate, SeveritySevere}
+
+type Uncertainty string
+
+const (
+	UncertaintyLow    Uncertainty = "low"
+	UncertaintyMedium Uncertainty = "medium"
+	UncertaintyHigh   Uncertainty = "high"
+)
+
+var Uncertainties = []Uncertainty{UncertaintyLow, UncertaintyMedium, UncertaintyHigh}
+
+// Domain usa los códigos de los índices compuestos más motor y ánimo
+type Domain string
+
+const (
+	DomainAttention       Domain = "attention"
+	DomainProcessingSpeed Domain = "processing_speed"
+	DomainMemory          Domain = "memory"
+	DomainExecutive       Domain = "executive"
+	DomainLanguage        Domain = "language"
+	DomainVisuospatial    Domain = "visuospatial"
+	DomainMotor           Domain = "motor"
+	DomainMood            Domain = "mood"
+)
+
+var Domains = []Domain{DomainAttention, DomainProcessingSpeed, DomainMemory, DomainExecutive, DomainLanguage, DomainVisuospatial, DomainMotor, DomainMood}
+
+// Profiles admitidos en la respuesta: los del clasificador de reglas, normal y no concluyente
+var Profiles = append(append([]NPdomain.Profile{}, NPdomain.Profiles...), NPdomain.ProfileNormal, NPdomain.ProfileInconclusive)
+
+// DomainFinding es el hallazgo de un dominio; Subtests usa los códigos del resumen enviado al LLM
+type DomainFinding struct {
+	Domain   Domain   `json:"domain"`
+	Severity Severity `json:"severity"`
+	Subtests []string `json:"subtests"`
+	Finding  string   `json:"finding"`
+}
+
+// Analysis es la respuesta estructurada del análisis; el texto del informe se genera a partir de ella
+type Analysis struct {
+	Title           string           `json:"title"`
+	Profile         NPdomain.Profile `json:"profile"`
+	Justification   string           `json:"justification"`
+	Findings        []DomainFinding  `json:"findings"`
+	Summary         string           `json:"summary"`
+	Recommendations []string         `json:"recommendations"`
+	Uncertainty     Uncertainty      `json:"uncertainty"`
+}
+
+// IsZero: evaluaciones anteriores al análisis estructurado o sin análisis
+func (a Analysis) IsZero() bool {
+	return a.Profile == ""
+}
+
+// Flagged devuelve los dominios con alteración (severidad distinta de preserved)
+func (a Analysis) Flagged() []DomainFinding {
+	var out []DomainFinding
+	for _, f := range a.Findings {
+		if f.Severity != SeverityPreserved {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Parse decodifica la respuesta del modelo (tolera un bloque ```json) y la valida contra el esquema
+func Parse(raw string) (Analysis, error) {
+	body := strings.TrimSpace(raw)
+	if strings.HasPrefix(body, "```") {
+		body = strings.TrimPrefix(body, "```json")
+		body = strings.TrimPrefix(body, "```")
+		body = strings.TrimSuffix(strings.TrimSpace(body), "```")
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(body)))
+	dec.DisallowUnknownFields()
+	var a Analysis
+	if err := dec.Decode(&a); err != nil {
+		return Analysis{}, fmt.Errorf("%w: %v", ErrInvalidAnalysis, err)
+	}
+	if dec.More() {
+		return Analysis{}, fmt.Errorf("%w: trailing data after the JSON object", ErrInvalidAnalysis)
+	}
+	if err := a.Validate(); err != nil {
+		return Analysis{}, err
+	}
+	return a, nil
+}
+
+// Validate reúne todos los incumplimientos para que el reintento los corrija a la vez
+func (a Analysis) Validate() error {
+	var problems []string
+	bad := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	if !oneOf(a.Profile, Profiles) {
+		bad("profile %q is not one of %v", a.Profile, Profiles)
+	}
+	if strings.TrimSpace(a.Justification) == "" {
+		bad("justification is required")
+	}
+	if strings.TrimSpace(a.Summary) == "" {
+		bad("summary is required")
+	}
+	if !oneOf(a.Uncertainty, Uncertainties) {
+		bad("uncertainty %q is not one of %v", a.Uncertainty, Uncertainties)
+	}
+	if a.Findings == nil {
+		bad("findings is required (use [] if there are none)")
+	}
+	seen := map[Domain]bool{}
+	for i, f := range a.Findings {
+		if !oneOf(f.Domain, Domains) {
+			bad("findings[%d].domain %q is not one of %v", i, f.Domain, Domains)
+		} else if seen[f.Domain] {
+			bad("findings[%d].domain %q is repeated: one finding per domain", i, f.Domain)
+		}
+		seen[f.Domain] = true
+		if !oneOf(f.Severity, Severities) {
+			bad("findings[%d].severity %q is not one of %v", i, f.Severity, Severities)
+		}
+		if strings.TrimSpace(f.Finding) == "" {
+			bad("findings[%d].finding is required", i)
+		}
+		for _, s := range f.Subtests {
+			if !EOdomain.Subtests[s] {
+				bad("findings[%d].subtests: unknown subtest %q", i, s)
+			}
+		}
+	}
+	if a.Recommendations == nil {
+		bad("recommendations is required (use [] if there are none)")
+	}
+	for i, r := range a.Recommendations {
+		if strings.TrimSpace(r) == "" {
+			bad("recommendations[%d] is empty", i)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAnalysis, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func oneOf[T comparable](v T, allowed []T) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema es el JSON Schema que se envía al modelo junto al prompt
+var Schema = mustSchema()
+
+func mustSchema() string {
+	subtests := make([]string, 0, len(EOdomain.Subtests))
+	for s := range EOdomain.Subtests {
+		subtests = append(subtests, s)
+	}
+	sort.Strings(subtests)
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"title", "profile", "justification", "findings", "summary", "recommendations", "uncertainty"},
+		"properties": map[string]any{
+			"title":         map[string]any{"type": "string"},
+			"profile":       map[string]any{"enum": Profiles},
+			"justification": map[string]any{"type": "string", "minLength": 1},
+			"findings": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"domain", "severity", "subtests", "finding"},
+					"properties": map[string]any{
+						"domain":   map[string]any{"enum": Domains},
+						"severity": map[string]any{"enum": Severities},
+						"subtests": map[string]any{"type": "array", "items": map[string]any{"enum": subtests}},
+						"finding":  map[string]any{"type": "string", "minLength": 1},
+					},
+				},
+			},
+			"summary":         map[string]any{"type": "string", "minLength": 1},
+			"recommendations": map[string]any{"type": "array", "items": map[string]any{"type": "string", "minLength": 1}},
+			"uncertainty":     map[string]any{"enum": Uncertainties},
+		},
+	}
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		panic("structured analysis schema: " + err.Error())
+	}
+	return string(raw)
+}
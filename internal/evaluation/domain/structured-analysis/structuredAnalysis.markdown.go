@@ -0,0 +1,115 @@
+package SAdomain
+
+import (
+	"fmt"
+	"strings"
+
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+)
+
+// markdownLabels son los textos fijos del informe; el encabezado del perfil conserva la forma
+// que reconoce NPdomain.CompareWithLLM
+type markdownLabels struct {
+	title, profile, justification, findings, summary, recommendations, uncertainty string
+	profiles                                                                       map[NPdomain.Profile]string
+	domains                                                                        map[Domain]string
+	severities                                                                     map[Severity]string
+	uncertainties                                                                  map[Uncertainty]string
+}
+
+var labelsES = markdownLabels{
+	title: "Análisis neuropsicológico", profile: "Perfil predominante", justification: "Justificación",
+	findings: "Hallazgos por dominio", summary: "Resumen", recommendations: "Recomendaciones", uncertainty: "Grado de incertidumbre",
+	profiles: NPdomain.Labels,
+	domains: map[Domain]string{
+		DomainAttention: "Atención", DomainProcessingSpeed: "Velocidad de procesamiento", DomainMemory: "Memoria",
+		DomainExecutive: "Funciones ejecutivas", DomainLanguage: "Lenguaje", DomainVisuospatial: "Visuoespacial",
+		DomainMotor: "Motor", DomainMood: "Estado de ánimo",
+	},
+	severities:    map[Severity]string{SeverityPreserved: "preservado", SeverityMild: "leve", SeverityModerate: "moderado", SeveritySevere: "grave"},
+	uncertainties: map[Uncertainty]string{UncertaintyLow: "baja", UncertaintyMedium: "media", UncertaintyHigh: "alta"},
+}
+
+var labelsCA = markdownLabels{
+	title: "Anàlisi neuropsicològica", profile: "Perfil predominant", justification: "Justificació",
+	findings: "Troballes per domini", summary: "Resum", recommendations: "Recomanacions", uncertainty: "Grau d'incertesa",
+	profiles: map[NPdomain.Profile]string{
+		NPdomain.ProfileAmnesic: "Amnèsic", NPdomain.ProfileFrontoTemporal: "Fronto-temporal", NPdomain.ProfileAttentional: "Atencional",
+		NPdomain.ProfileDepressive: "Depressiu", NPdomain.ProfileDysexecutive: "Disexecutiu (vascular)",
+		NPdomain.ProfileNormal: "Funcionament normal", NPdomain.ProfileInconclusive: "No concloent",
+	},
+	domains: map[Domain]string{
+		DomainAttention: "Atenció", DomainProcessingSpeed: "Velocitat de processament", DomainMemory: "Memòria",
+		DomainExecutive: "Funcions executives", DomainLanguage: "Llenguatge", DomainVisuospatial: "Visuoespacial",
+		DomainMotor: "Motor", DomainMood: "Estat d'ànim",
+	},
+	severities:    map[Severity]string{SeverityPreserved: "preservat", SeverityMild: "lleu", SeverityModerate: "moderat", SeveritySevere: "greu"},
+	uncertainties: map[Uncertainty]string{UncertaintyLow: "baixa", UncertaintyMedium: "mitjana", UncertaintyHigh: "alta"},
+}
+
+var labelsEN = markdownLabels{
+	title: "Neuropsychological analysis", profile: "Predominant profile", justification: "Rationale",
+	findings: "Findings by domain", summary: "Summary", recommendations: "Recommendations", uncertainty: "Uncertainty",
+	profiles: map[NPdomain.Profile]string{
+		NPdomain.ProfileAmnesic: "Amnestic", NPdomain.ProfileFrontoTemporal: "Fronto-temporal", NPdomain.ProfileAttentional: "Attentional",
+		NPdomain.ProfileDepressive: "Depressive", NPdomain.ProfileDysexecutive: "Dysexecutive (vascular)",
+		NPdomain.ProfileNormal: "Normal functioning", NPdomain.ProfileInconclusive: "Inconclusive",
+	},
+	domains: map[Domain]string{
+		DomainAttention: "Attention", DomainProcessingSpeed: "Processing speed", DomainMemory: "Memory",
+		DomainExecutive: "Executive functions", DomainLanguage: "Language", DomainVisuospatial: "Visuospatial",
+		DomainMotor: "Motor", DomainMood: "Mood",
+	},
+	severities:    map[Severity]string{SeverityPreserved: "preserved", SeverityMild: "mild", SeverityModerate: "moderate", SeveritySevere: "severe"},
+	uncertainties: map[Uncertainty]string{UncertaintyLow: "low", UncertaintyMedium: "medium", UncertaintyHigh: "high"},
+}
+
+func labelsFor(locale string) markdownLabels {
+	switch locale {
+	case "ca":
+		return labelsCA
+	case "en":
+		return labelsEN
+	default:
+		return labelsES
+	}
+}
+
+// ProfileLabel es el nombre del perfil en el idioma del informe
+func ProfileLabel(p NPdomain.Profile, locale string) string {
+	return labelsFor(locale).profiles[p]
+}
+
+// Markdown genera el texto narrativo del informe a partir de la estructura; locale es es, ca o en
+func (a Analysis) Markdown(locale string) string {
+	l := labelsFor(locale)
+	var b strings.Builder
+	title := strings.TrimSpace(a.Title)
+	if title == "" {
+		title = l.title
+	}
+	fmt.Fprintf(&b, "## %s\n\n", title)
+	fmt.Fprintf(&b, "**%s**: %s\n\n", l.profile, l.profiles[a.Profile])
+	fmt.Fprintf(&b, "**%s**: %s\n\n", l.justification, strings.TrimSpace(a.Justification))
+	if len(a.Findings) > 0 {
+		fmt.Fprintf(&b, "### %s\n\n", l.findings)
+		for _, f := range a.Findings {
+			fmt.Fprintf(&b, "- **%s** (%s): %s", l.domains[f.Domain], l.severities[f.Severity], strings.TrimSpace(f.Finding))
+			if len(f.Subtests) > 0 {
+				fmt.Fprintf(&b, " _[%s]_", strings.Join(f.Subtests, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "### %s\n\n%s\n\n", l.summary, strings.TrimSpace(a.Summary))
+	if len(a.Recommendations) > 0 {
+		fmt.Fprintf(&b, "### %s\n\n", l.recommendations)
+		for _, r := range a.Recommendations {
+			fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(r))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "**%s**: %s\n", l.uncertainty, l.uncertainties[a.Uncertainty])
+	return b.String()
+}
@@ -0,0 +1,374 @@
+package ASdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type Hand string
+
+const (
+	HandLeft  Hand = "left"
+	HandRight Hand = "right"
+)
+
+const (
+	MinSpiralPoints = 50
+	MaxSpiralPoints = 20_000
+	// Una espiral clínica dura segundos; por encima de 2 min el trazo no es válido
+	MaxSpiralDurationMs = 120_000
+	// Tope de muestras remuestreadas: 120 s a 100 Hz caben con margen
+	maxResampledSamples = 1 << 14
+	// Frecuencia de remuestreo del residuo radial para el análisis espectral
+	resampleHz = 100.0
+	// Banda de temblor parkinsoniano / esencial
+	tremorBandLowHz  = 3.0
+	tremorBandHighHz = 12.0
+	// Banda de referencia para la fracción de potencia
+	spectrumLowHz  = 1.0
+	spectrumHighHz = 20.0
+	// Fracción de potencia en banda a partir de la cual se considera temblor
+	tremorPowerFraction = 0.5
+	// Paso angular para las derivadas de suavidad
+	thetaStep = math.Pi / 90
+)
+
+var (
+	ErrInvalidSpiral       = errors.New("invalid Archimedes spiral input")
+	ErrInvalidHand         = fmt.Errorf("%w: hand must be left or right", ErrInvalidSpiral)
+	ErrInvalidSpiralPoints = fmt.Errorf("%w: spiral needs between 50 and 20000 points ordered in time", ErrInvalidSpiral)
+	ErrSpiralTooLong       = fmt.Errorf("%w: spiral drawing must last at most 120 s", ErrInvalidSpiral)
+)
+
+type SpiralPoint struct {
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	AtMs int64   `json:"atMs"`
+}
+
+type SpiralDrawing struct {
+	Hand Hand `json:"hand"`
+	// Centro de la plantilla; si no llega se usa el primer punto (la espiral se dibuja desde dentro)
+	CenterX *float64      `json:"centerX,omitempty"`
+	CenterY *float64      `json:"centerY,omitempty"`
+	Points  []SpiralPoint `json:"points"`
+}
+
+type ArchimedesSpiralSubtest struct {
+	PK                string          `json:"pk"`
+	EvaluationID      string          `json:"evaluationId"`
+	Drawings          []SpiralDrawing `json:"drawings"`
+	Score             SpiralScore     `json:"score"`
+	AssistantAnalysis string          `json:"assistantAnalysis"`
+	CreatedAt         time.Time       `json:"createdAt"`
+}
+
+type HandSpiralScore struct {
+	Present               bool      `json:"present"`
+	Loops                 float64   `json:"loops"`
+	TremorFrequencyHz     float64   `json:"tremorFrequencyHz"`   // pico espectral del residuo radial en 3–12 Hz
+	TremorPowerFraction   float64   `json:"tremorPowerFraction"` // potencia 3–12 Hz / potencia 1–20 Hz
+	TremorDetected        bool      `json:"tremorDetected"`
+	ResidualRMS           float64   `json:"residualRMS"`           // desviación radial frente a r = a + bθ
+	FirstOrderSmoothness  float64   `json:"firstOrderSmoothness"`  // media |dr/dθ − b|
+	SecondOrderSmoothness float64   `json:"secondOrderSmoothness"` // media |d²r/dθ²|
+	SpeedPxPerSec         float64   `json:"speedPxPerSec"`
+	LoopWidths            []float64 `json:"loopWidths,omitempty"`
+	SizeDecrementPct      float64   `json:"sizeDecrementPct"` // primera vs última separación entre vueltas
+}
+
+type SpiralScore struct {
+	Score          int             `json:"score"` // 0..100 (peor mano; desviación radial frente a la separación entre vueltas)
+	Left           HandSpiralScore `json:"left"`
+	Right          HandSpiralScore `json:"right"`
+	ScoringProfile string          `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewArchimedesSpiralSubtest(evaluationID string, drawings []SpiralDrawing) (*ArchimedesSpiralSubtest, error) {
+	if evaluationID == "" || len(drawings) == 0 || len(drawings) > 2 {
+		return nil, ErrInvalidSpiral
+	}
+	seen := map[Hand]bool{}
+	for _, d := range drawings {
+		if (d.Hand != HandLeft && d.Hand != HandRight) || seen[d.Hand] {
+			return nil, ErrInvalidHand
+		}
+		seen[d.Hand] = true
+		if len(d.Points) < MinSpiralPoints || len(d.Points) > MaxSpiralPoints {
+			return nil, ErrInvalidSpiralPoints
+		}
+		for i := 1; i < len(d.Points); i++ {
+			if d.Points[i].AtMs < d.Points[i-1].AtMs {
+				return nil, ErrInvalidSpiralPoints
+			}
+		}
+		duration := d.Points[len(d.Points)-1].AtMs - d.Points[0].AtMs
+		if duration == 0 {
+			return nil, ErrInvalidSpiralPoints
+		}
+		// Negativa solo si la resta desborda (marcas de tiempo extremas)
+		if duration < 0 || duration > MaxSpiralDurationMs {
+			return nil, ErrSpiralTooLong
+		}
+	}
+	return &ArchimedesSpiralSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Drawings:          drawings,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func ScoreArchimedesSpiral(sub ArchimedesSpiralSubtest) (SpiralScore, error) {
+	if len(sub.Drawings) == 0 {
+		return SpiralScore{}, fmt.Errorf("%w: drawings vacío", ErrInvalidSpiral)
+	}
+	var out SpiralScore
+	worst := 100
+	for _, d := range sub.Drawings {
+		hs, handScore := scoreDrawing(d)
+		if d.Hand == HandLeft {
+			out.Left = hs
+		} else {
+			out.Right = hs
+		}
+		worst = min(worst, handScore)
+	}
+	out.Score = worst
+	return out, nil
+}
+
+// polar convierte a (r, θ) con θ desenrollado y creciente en el sentido del trazo
+func polar(d SpiralDrawing) (r, theta []float64) {
+	cx, cy := d.Points[0].X, d.Points[0].Y
+	if d.CenterX != nil && d.CenterY != nil {
+		cx, cy = *d.CenterX, *d.CenterY
+	}
+	r = make([]float64, len(d.Points))
+	theta = make([]float64, len(d.Points))
+	prev, acc := 0.0, 0.0
+	for i, p := range d.Points {
+		dx, dy := p.X-cx, p.Y-cy
+		r[i] = math.Hypot(dx, dy)
+		a := math.Atan2(dy, dx)
+		if i > 0 {
+			delta := a - prev
+			for delta > math.Pi {
+				delta -= 2 * math.Pi
+			}
+			for delta < -math.Pi {
+				delta += 2 * math.Pi
+			}
+			acc += delta
+		}
+		prev = a
+		theta[i] = acc
+	}
+	if acc < 0 {
+		for i := range theta {
+			theta[i] = -theta[i]
+		}
+	}
+	return r, theta
+}
+
+func scoreDrawing(d SpiralDrawing) (HandSpiralScore, int) {
+	out := HandSpiralScore{Present: true}
+	r, theta := polar(d)
+
+	// Espiral ideal r = a + bθ por mínimos cuadrados
+	a, b := linearFit(theta, r)
+	residual := make([]float64, len(r))
+	var ss float64
+	for i := range r {
+		residual[i] = r[i] - (a + b*theta[i])
+		ss += residual[i] * residual[i]
+	}
+	out.ResidualRMS = round2(math.Sqrt(ss / float64(len(r))))
+	out.Loops = round2(theta[len(theta)-1] / (2 * math.Pi))
+
+	// Velocidad: longitud del trazo / duración
+	var length float64
+	for i := 1; i < len(d.Points); i++ {
+		length += math.Hypot(d.Points[i].X-d.Points[i-1].X, d.Points[i].Y-d.Points[i-1].Y)
+	}
+	durationSec := float64(d.Points[len(d.Points)-1].AtMs-d.Points[0].AtMs) / 1000
+	out.SpeedPxPerSec = round2(length / durationSec)
+
+	// Temblor: espectro del residuo remuestreado en el tiempo
+	times := make([]float64, len(d.Points))
+	for i, p := range d.Points {
+		times[i] = float64(p.AtMs-d.Points[0].AtMs) / 1000
+	}
+	freq, fraction := tremorPeak(resample(times, residual, resampleHz), resampleHz)
+	out.TremorFrequencyHz = round2(freq)
+	out.TremorPowerFraction = round2(fraction)
+	out.TremorDetected = fraction >= tremorPowerFraction
+
+	// Suavidad sobre r(θ) remuestreado en θ
+	rTheta := resample(theta, r, 1/thetaStep)
+	if len(rTheta) >= 3 {
+		var first, second float64
+		for i := 1; i < len(rTheta); i++ {
+			first += math.Abs((rTheta[i]-rTheta[i-1])/thetaStep - b)
+		}
+		for i := 2; i < len(rTheta); i++ {
+			second += math.Abs((rTheta[i] - 2*rTheta[i-1] + rTheta[i-2]) / (thetaStep * thetaStep))
+		}
+		out.FirstOrderSmoothness = round2(first / float64(len(rTheta)-1))
+		out.SecondOrderSmoothness = round2(second / float64(len(rTheta)-2))
+	}
+
+	// Separación entre vueltas consecutivas (micrografía: disminuye hacia fuera)
+	perLoop := int(math.Round(2 * math.Pi / thetaStep))
+	var loopMeans []float64
+	for start := 0; start+perLoop <= len(rTheta); start += perLoop {
+		m, _ := meanOf(rTheta[start : start+perLoop])
+		loopMeans = append(loopMeans, m)
+	}
+	for i := 1; i < len(loopMeans); i++ {
+		out.LoopWidths = append(out.LoopWidths, round2(loopMeans[i]-loopMeans[i-1]))
+	}
+	if n := len(out.LoopWidths); n >= 2 && out.LoopWidths[0] > 0 {
+		out.SizeDecrementPct = round2((out.LoopWidths[0] - out.LoopWidths[n-1]) / out.LoopWidths[0] * 100)
+	}
+
+	// Puntuación: desviación radial relativa a la separación ideal entre vueltas (2πb)
+	score := 0
+	if width := 2 * math.Pi * b; width > 0 {
+		score = int(math.Round(100 * (1 - utils.Clamp01(out.ResidualRMS/width))))
+	}
+	return out, score
+}
+
+// resample interpola linealmente ys(xs) en una rejilla uniforme de paso 1/rate; como mucho
+// maxResampledSamples muestras, para que un trazo anómalo no dispare memoria ni tiempo
+func resample(xs, ys []float64, rate float64) []float64 {
+	if len(xs) < 2 {
+		return nil
+	}
+	step := 1 / rate
+	n := maxResampledSamples
+	if span := (xs[len(xs)-1]-xs[0])/step + 1; span < float64(n) {
+		n = int(span)
+	}
+	out := make([]float64, 0, n)
+	j := 0
+	for i := 0; i < n; i++ {
+		x := xs[0] + float64(i)*step
+		for j < len(xs)-2 && xs[j+1] < x {
+			j++
+		}
+		x0, x1 := xs[j], xs[j+1]
+		if x1 == x0 {
+			out = append(out, ys[j])
+			continue
+		}
+		t := (x - x0) / (x1 - x0)
+		out = append(out, ys[j]+t*(ys[j+1]-ys[j]))
+	}
+	return out
+}
+
+// tremorPeak devuelve el pico espectral en la banda de temblor y la fracción de
+// potencia que concentra esa banda (FFT con ventana de Hann y relleno de ceros).
+func tremorPeak(signal []float64, fs float64) (float64, float64) {
+	n := len(signal)
+	if n < int(fs) { // menos de 1 s de trazo
+		return 0, 0
+	}
+	mean, _ := meanOf(signal)
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	spectrum := make([]complex128, size)
+	for i, v := range signal {
+		w := 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(n-1)))
+		spectrum[i] = complex((v-mean)*w, 0)
+	}
+	fft(spectrum)
+	var bandPower, totalPower, peakPower, peakFreq float64
+	for k := 1; float64(k)*fs/float64(size) <= spectrumHighHz && k < size/2; k++ {
+		f := float64(k) * fs / float64(size)
+		if f < spectrumLowHz {
+			continue
+		}
+		re, im := real(spectrum[k]), imag(spectrum[k])
+		p := re*re + im*im
+		totalPower += p
+		if f >= tremorBandLowHz && f <= tremorBandHighHz {
+			bandPower += p
+			if p > peakPower {
+				peakPower, peakFreq = p, f
+			}
+		}
+	}
+	if totalPower == 0 {
+		return 0, 0
+	}
+	return peakFreq, bandPower / totalPower
+}
+
+// fft transforma x en sitio (Cooley-Tukey iterativa); len(x) debe ser potencia de 2
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := -2 * math.Pi / float64(size)
+		for start := 0; start < n; start += size {
+			for k := 0; k < size/2; k++ {
+				w := complex(math.Cos(step*float64(k)), math.Sin(step*float64(k)))
+				a, b := x[start+k], w*x[start+k+size/2]
+				x[start+k], x[start+k+size/2] = a+b, a-b
+			}
+		}
+	}
+}
+
+func linearFit(xs, ys []float64) (intercept, slope float64) {
+	n := float64(len(xs))
+	var sx, sy, sxy, sxx float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxy += xs[i] * ys[i]
+		sxx += xs[i] * xs[i]
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return sy / n, 0
+	}
+	slope = (n*sxy - sx*sy) / den
+	return (sy - slope*sx) / n, slope
+}
+
+func meanOf(xs []float64) (float64, bool) {
+	if len(xs) == 0 {
+		return 0, false
+	}
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	return s / float64(len(xs)), true
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,78 @@
+package ASdomain
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestNewArchimedesSpiralSubtestDuration(t *testing.T) {
+	points := func(n int, stepMs int64) []SpiralPoint {
+		pts := make([]SpiralPoint, n)
+		for i := range pts {
+			theta := 8 * math.Pi * float64(i) / float64(n)
+			r := 10 + 5*theta
+			pts[i] = SpiralPoint{X: r * math.Cos(theta), Y: r * math.Sin(theta), AtMs: int64(i) * stepMs}
+		}
+		return pts
+	}
+
+	tests := []struct {
+		name    string
+		points  []SpiralPoint
+		wantErr error
+	}{
+		{name: "Valid - 8 s drawing", points: points(800, 10)},
+		{name: "Valid - exactly at the clinical limit", points: points(61, 2_000)},
+		{name: "Invalid - 60 points spread over ~16 h", points: points(60, 1_000_000), wantErr: ErrSpiralTooLong},
+		{name: "Invalid - just over the clinical limit", points: points(51, 2_401), wantErr: ErrSpiralTooLong},
+		{name: "Invalid - timestamps far in the future", points: append(points(50, 10), SpiralPoint{AtMs: math.MaxInt64}), wantErr: ErrSpiralTooLong},
+		{name: "Invalid - duration overflows int64", points: append([]SpiralPoint{{AtMs: math.MinInt64}}, points(50, 10)[1:]...), wantErr: ErrSpiralTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := NewArchimedesSpiralSubtest("eval-123", []SpiralDrawing{{Hand: HandRight, Points: tt.points}})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || sub != nil {
+					t.Fatalf("expected %v, got %v (%+v)", tt.wantErr, err, sub)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// El trazo admitido se puntúa con muestras acotadas
+			if _, err := ScoreArchimedesSpiral(*sub); err != nil {
+				t.Fatalf("unexpected scoring error: %v", err)
+			}
+		})
+	}
+}
+
+func TestResampleIsCapped(t *testing.T) {
+	got := resample([]float64{0, 1e12}, []float64{0, 1}, resampleHz)
+	if len(got) != maxResampledSamples {
+		t.Fatalf("expected %d samples, got %d", maxResampledSamples, len(got))
+	}
+}
+
+func TestFFTMatchesDirectDFT(t *testing.T) {
+	x := make([]complex128, 64)
+	for i := range x {
+		x[i] = complex(math.Sin(0.3*float64(i))+0.5*math.Cos(1.7*float64(i)), 0)
+	}
+	want := make([]complex128, len(x))
+	for k := range want {
+		for i, v := range x {
+			want[k] += v * cmplx.Exp(complex(0, -2*math.Pi*float64(k*i)/float64(len(x))))
+		}
+	}
+	fft(x)
+	for k := range x {
+		if cmplx.Abs(x[k]-want[k]) > 1e-9 {
+			t.Fatalf("bin %d: expected %v, got %v", k, want[k], x[k])
+		}
+	}
+}
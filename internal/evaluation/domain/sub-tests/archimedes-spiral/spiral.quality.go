@@ -0,0 +1,19 @@
+package ASdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: menos de una vuelta no permite estimar temblor ni micrografía
+func (s ArchimedesSpiralSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("archimedes_spiral", s.PK)
+	for _, h := range []struct {
+		name  string
+		score HandSpiralScore
+	}{{"left", s.Score.Left}, {"right", s.Score.Right}} {
+		if h.score.Present && h.score.Loops < 1 {
+			c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, h.name, "%s hand: spiral with %.1f loops is incomplete", h.name, h.score.Loops)
+		}
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package ASdomain
+
+import "context"
+
+type ArchimedesSpiralRepository interface {
+	Save(ctx context.Context, subtest *ArchimedesSpiralSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (ArchimedesSpiralSubtest, error)
+}
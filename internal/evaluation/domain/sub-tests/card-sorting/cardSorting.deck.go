@@ -0,0 +1,84 @@
+package WCSTdomain
+
+type Dimension string
+
+const (
+	DimensionColor  Dimension = "color"
+	DimensionShape  Dimension = "shape"
+	DimensionNumber Dimension = "number"
+)
+
+type Card struct {
+	Color  string `json:"color"`
+	Shape  string `json:"shape"`
+	Number int    `json:"number"`
+}
+
+var (
+	colors = []string{"red", "green", "yellow", "blue"}
+	shapes = []string{"triangle", "star", "cross", "circle"}
+)
+
+// KeyCards son las cuatro cartas estímulo (1 triángulo rojo, 2 estrellas verdes,
+// 3 cruces amarillas, 4 círculos azules); las respuestas se dan como 1..4.
+var KeyCards = [4]Card{
+	{Color: "red", Shape: "triangle", Number: 1},
+	{Color: "green", Shape: "star", Number: 2},
+	{Color: "yellow", Shape: "cross", Number: 3},
+	{Color: "blue", Shape: "circle", Number: 4},
+}
+
+// ruleSequence: color, forma, número y se repite (Heaton)
+var ruleSequence = []Dimension{DimensionColor, DimensionShape, DimensionNumber, DimensionColor, DimensionShape, DimensionNumber}
+
+// deck son las 64 combinaciones en un orden fijo; el cliente nunca lo conoce
+var deck = buildDeck()
+
+func buildDeck() []Card {
+	cards := make([]Card, 0, 64)
+	for _, c := range colors {
+		for _, s := range shapes {
+			for n := 1; n <= 4; n++ {
+				cards = append(cards, Card{Color: c, Shape: s, Number: n})
+			}
+		}
+	}
+	// Barajado determinista (LCG) para que todas las sesiones vean la misma secuencia
+	seed := uint32(20261019)
+	for i := len(cards) - 1; i > 0; i-- {
+		seed = seed*1664525 + 1013904223
+		j := int(seed % uint32(i+1))
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	return cards
+}
+
+// DeckCard devuelve la carta i; con 128 cartas el mazo se presenta dos veces
+func DeckCard(i int) Card {
+	return deck[i%len(deck)]
+}
+
+// matches devuelve las dimensiones en las que la carta coincide con la carta estímulo
+func matches(card Card, keyCard int) []Dimension {
+	k := KeyCards[keyCard-1]
+	var out []Dimension
+	if card.Color == k.Color {
+		out = append(out, DimensionColor)
+	}
+	if card.Shape == k.Shape {
+		out = append(out, DimensionShape)
+	}
+	if card.Number == k.Number {
+		out = append(out, DimensionNumber)
+	}
+	return out
+}
+
+func contains(ds []Dimension, d Dimension) bool {
+	for _, x := range ds {
+		if x == d {
+			return true
+		}
+	}
+	return false
+}
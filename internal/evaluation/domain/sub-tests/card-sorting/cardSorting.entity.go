@@ -0,0 +1,235 @@
+package WCSTdomain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionStatus string
+
+const (
+	SessionInProgress SessionStatus = "in_progress"
+	SessionCompleted  SessionStatus = "completed"
+)
+
+const (
+	CategoriesToComplete = 6
+	CorrectRunToShift    = 10 // aciertos consecutivos para cambiar de regla
+	FailureToMaintainRun = 5  // error tras ≥5 aciertos seguidos = pérdida de set
+	DefaultMaxCards      = 64
+	FullMaxCards         = 128
+)
+
+var (
+	ErrSessionNotFound     = errors.New("card sorting session not found")
+	ErrSessionCompleted    = errors.New("card sorting session already completed")
+	ErrUnexpectedCard      = errors.New("response does not match the card currently presented")
+	ErrInvalidKeyCard      = errors.New("key card must be between 1 and 4")
+	ErrInvalidMaxCards     = errors.New("max cards must be 64 or 128")
+	ErrConcurrentResponses = errors.New("card sorting session was updated concurrently")
+)
+
+// SortResponse es una respuesta validada por el servidor (regla y acierto no vienen del cliente)
+type SortResponse struct {
+	CardIndex     int         `json:"cardIndex"`
+	KeyCard       int         `json:"keyCard"`
+	RespondedAtMs int64       `json:"respondedAtMs"`
+	Rule          Dimension   `json:"rule"`
+	Category      int         `json:"category"` // índice de la categoría en curso (0..5)
+	Matches       []Dimension `json:"matches"`
+	Correct       bool        `json:"correct"`
+}
+
+type CardSortingSubtest struct {
+	PK                string           `json:"pk"`
+	EvaluationID      string           `json:"evaluationId"`
+	Status            SessionStatus    `json:"status"`
+	MaxCards          int              `json:"maxCards"`
+	Responses         []SortResponse   `json:"responses"`
+	Score             CardSortingScore `json:"score"`
+	AssistantAnalysis string           `json:"assistantAnalysis"`
+	CreatedAt         time.Time        `json:"createdAt"`
+}
+
+// PresentedCard es lo único que ve el cliente de la siguiente carta
+type PresentedCard struct {
+	Index int  `json:"index"`
+	Card  Card `json:"card"`
+}
+
+// Feedback es la respuesta al paciente: solo acierto/error, nunca la regla
+type Feedback struct {
+	CardIndex int  `json:"cardIndex"`
+	Correct   bool `json:"correct"`
+}
+
+type CardSortingScore struct {
+	Score                  int     `json:"score"` // 0..100 (categorías / 6)
+	TrialsAdministered     int     `json:"trialsAdministered"`
+	CategoriesCompleted    int     `json:"categoriesCompleted"`
+	TrialsToFirstCategory  int     `json:"trialsToFirstCategory"` // 0 = no completó ninguna
+	TotalCorrect           int     `json:"totalCorrect"`
+	TotalErrors            int     `json:"totalErrors"`
+	PerseverativeResponses int     `json:"perseverativeResponses"`
+	PerseverativeErrors    int     `json:"perseverativeErrors"`
+	NonPerseverativeErrors int     `json:"nonPerseverativeErrors"`
+	PerseverativeErrorsPct float64 `json:"perseverativeErrorsPct"`
+	FailuresToMaintainSet  int     `json:"failuresToMaintainSet"`
+	ConceptualLevelPct     float64 `json:"conceptualLevelPct"` // aciertos en rachas ≥3
+	ScoringProfile         string  `json:"scoringProfile"`     // id@versión del perfil que produjo el score
+}
+
+func NewCardSortingSubtest(evaluationID string, maxCards int) (*CardSortingSubtest, error) {
+	if evaluationID == "" {
+		return nil, errors.New("invalid input for creation of CardSortingSubtest")
+	}
+	if maxCards == 0 {
+		maxCards = DefaultMaxCards
+	}
+	if maxCards != DefaultMaxCards && maxCards != FullMaxCards {
+		return nil, ErrInvalidMaxCards
+	}
+	return &CardSortingSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Status:            SessionInProgress,
+		MaxCards:          maxCards,
+		Responses:         []SortResponse{},
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+// progress reconstruye la regla vigente a partir de las respuestas
+func (s *CardSortingSubtest) progress() (category, run int) {
+	for _, r := range s.Responses {
+		if !r.Correct {
+			run = 0
+			continue
+		}
+		run++
+		if run == CorrectRunToShift {
+			category++
+			run = 0
+		}
+	}
+	return category, run
+}
+
+// NextCard devuelve la carta a presentar, o nil si la sesión terminó
+func (s *CardSortingSubtest) NextCard() *PresentedCard {
+	if s.Status == SessionCompleted {
+		return nil
+	}
+	i := len(s.Responses)
+	return &PresentedCard{Index: i, Card: DeckCard(i)}
+}
+
+// LastFeedback devuelve el resultado de la última respuesta, si la hay
+func (s *CardSortingSubtest) LastFeedback() *Feedback {
+	if len(s.Responses) == 0 {
+		return nil
+	}
+	r := s.Responses[len(s.Responses)-1]
+	return &Feedback{CardIndex: r.CardIndex, Correct: r.Correct}
+}
+
+// Respond valida la respuesta contra la carta presentada y la regla vigente.
+// La sesión se completa al lograr 6 categorías o agotar las cartas.
+func (s *CardSortingSubtest) Respond(cardIndex, keyCard int, respondedAtMs int64) (Feedback, error) {
+	if s.Status == SessionCompleted {
+		return Feedback{}, ErrSessionCompleted
+	}
+	if cardIndex != len(s.Responses) {
+		return Feedback{}, ErrUnexpectedCard
+	}
+	if keyCard < 1 || keyCard > len(KeyCards) {
+		return Feedback{}, ErrInvalidKeyCard
+	}
+	category, _ := s.progress()
+	rule := ruleSequence[category]
+	m := matches(DeckCard(cardIndex), keyCard)
+	resp := SortResponse{
+		CardIndex:     cardIndex,
+		KeyCard:       keyCard,
+		RespondedAtMs: respondedAtMs,
+		Rule:          rule,
+		Category:      category,
+		Matches:       m,
+		Correct:       contains(m, rule),
+	}
+	s.Responses = append(s.Responses, resp)
+
+	if category, _ = s.progress(); category >= CategoriesToComplete || len(s.Responses) >= s.MaxCards {
+		s.Status = SessionCompleted
+	}
+	return Feedback{CardIndex: cardIndex, Correct: resp.Correct}, nil
+}
+
+// ScoreCardSorting aplica una versión simplificada de los criterios de Heaton:
+// el principio perseverativo es la regla de la categoría anterior; en la primera
+// categoría lo fija el primer error no ambiguo.
+func ScoreCardSorting(sub CardSortingSubtest) (CardSortingScore, error) {
+	if len(sub.Responses) == 0 {
+		return CardSortingScore{}, errors.New("responses vacío")
+	}
+	var out CardSortingScore
+	out.TrialsAdministered = len(sub.Responses)
+
+	var perseverative Dimension
+	category, run := 0, 0
+	var conceptual, correctRun int
+	for i, r := range sub.Responses {
+		if r.Category != category {
+			perseverative = ruleSequence[category]
+			category = r.Category
+		}
+		isPerseverative := perseverative != "" && contains(r.Matches, perseverative)
+		if isPerseverative {
+			out.PerseverativeResponses++
+		}
+
+		if r.Correct {
+			out.TotalCorrect++
+			run++
+			correctRun++
+			if run == CorrectRunToShift {
+				out.CategoriesCompleted++
+				if out.CategoriesCompleted == 1 {
+					out.TrialsToFirstCategory = i + 1
+				}
+				run = 0
+			}
+			continue
+		}
+
+		out.TotalErrors++
+		if isPerseverative {
+			out.PerseverativeErrors++
+		} else {
+			out.NonPerseverativeErrors++
+		}
+		if run >= FailureToMaintainRun {
+			out.FailuresToMaintainSet++
+		}
+		if correctRun >= 3 {
+			conceptual += correctRun
+		}
+		run, correctRun = 0, 0
+		if perseverative == "" && len(r.Matches) == 1 {
+			perseverative = r.Matches[0]
+		}
+	}
+	if correctRun >= 3 {
+		conceptual += correctRun
+	}
+
+	n := float64(out.TrialsAdministered)
+	out.PerseverativeErrorsPct = math.Round(float64(out.PerseverativeErrors)/n*1000) / 10
+	out.ConceptualLevelPct = math.Round(float64(conceptual)/n*1000) / 10
+	out.Score = int(math.Round(100 * float64(out.CategoriesCompleted) / CategoriesToComplete))
+	return out, nil
+}
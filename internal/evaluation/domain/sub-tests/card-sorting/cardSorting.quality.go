@@ -0,0 +1,20 @@
+package WCSTdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags solo aplica a sesiones terminadas
+func (s CardSortingSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("card_sorting", s.PK)
+	if s.Status != SessionCompleted {
+		return c.Flags
+	}
+	switch s.Score.CategoriesCompleted {
+	case 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "categoriesCompleted", "no categories completed in %d trials", s.Score.TrialsAdministered)
+	case 6:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "categoriesCompleted", "all six categories completed")
+	}
+	return c.Flags
+}
@@ -0,0 +1,13 @@
+package WCSTdomain
+
+import "context"
+
+type CardSortingRepository interface {
+	Save(ctx context.Context, subtest *CardSortingSubtest) error
+	// Update persiste la sesión si nadie respondió entre medias (previousTrials = respuestas antes de responder)
+	Update(ctx context.Context, subtest *CardSortingSubtest, previousTrials int) error
+	// GetByID devuelve ErrSessionNotFound si la sesión no existe
+	GetByID(ctx context.Context, sessionID string) (CardSortingSubtest, error)
+	// GetByEvaluationID devuelve la última sesión completada o el subtest vacío (PK == "")
+	GetByEvaluationID(ctx context.Context, evaluationID string) (CardSortingSubtest, error)
+}
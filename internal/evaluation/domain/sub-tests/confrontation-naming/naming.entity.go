@@ -0,0 +1,280 @@
+package CNdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+// NamingOutcome clasifica cómo se obtuvo (o no) la denominación.
+type NamingOutcome string
+
+const (
+	NamingSpontaneous    NamingOutcome = "spontaneous"     // correcta sin clave
+	NamingSemanticCued   NamingOutcome = "semantic_cued"   // correcta tras clave semántica
+	NamingPhonemicCued   NamingOutcome = "phonemic_cued"   // correcta tras clave fonémica
+	NamingIncorrectFinal NamingOutcome = "incorrect_final" // no denominada
+)
+
+// NamingErrorType es el tipo de error de la respuesta no correcta.
+type NamingErrorType string
+
+const (
+	NamingErrorNone           NamingErrorType = ""
+	NamingErrorOmission       NamingErrorType = "omission"       // sin respuesta
+	NamingErrorPhonemic       NamingErrorType = "phonemic"       // parafasia fonémica (forma cercana al objetivo)
+	NamingErrorCircumlocution NamingErrorType = "circumlocution" // describe en vez de nombrar
+	NamingErrorSemantic       NamingErrorType = "semantic"       // sustitución por otra palabra (solo por el evaluador)
+	NamingErrorVisual         NamingErrorType = "visual"         // error perceptivo (solo por el evaluador)
+	NamingErrorSubstitution   NamingErrorType = "substitution"   // otra palabra, sin precisar
+)
+
+const (
+	// Similitud mínima (1 − Levenshtein normalizado) para considerar parafasia fonémica
+	phonemicSimilarity = 0.6
+	// A partir de este número de palabras la respuesta se trata como circunloquio
+	circumlocutionWords = 3
+)
+
+var (
+	ErrInvalidNaming        = errors.New("invalid confrontation naming input")
+	ErrInvalidNamingVersion = fmt.Errorf("%w: version must be 15, 30 or 60 items", ErrInvalidNaming)
+	ErrInvalidNamingItem    = fmt.Errorf("%w: item does not belong to the administered version or is repeated", ErrInvalidNaming)
+	ErrInvalidNamingError   = fmt.Errorf("%w: unknown naming error type", ErrInvalidNaming)
+)
+
+// NamingOverride permite al evaluador corregir la clasificación automática.
+type NamingOverride struct {
+	Correct   bool            `json:"correct"`
+	ErrorType NamingErrorType `json:"errorType,omitempty"`
+}
+
+type NamingResponse struct {
+	Item        int             `json:"item"` // número de lámina (1..60)
+	Transcript  string          `json:"transcript"`
+	SemanticCue bool            `json:"semanticCue"`
+	PhonemicCue bool            `json:"phonemicCue"`
+	Override    *NamingOverride `json:"override,omitempty"`
+	// Calculados en servidor
+	Target     string          `json:"target"`
+	Matched    bool            `json:"matched"` // coincidencia automática con respuestas aceptadas
+	Outcome    NamingOutcome   `json:"outcome"`
+	ErrorType  NamingErrorType `json:"errorType,omitempty"`
+	Overridden bool            `json:"overridden"`
+}
+
+type ConfrontationNamingSubtest struct {
+	PK                string           `json:"pk"`
+	EvaluationID      string           `json:"evaluationId"`
+	Version           NamingVersion    `json:"version"`
+	Responses         []NamingResponse `json:"responses"`
+	Score             NamingScore      `json:"score"`
+	AssistantAnalysis string           `json:"assistantAnalysis"`
+	CreatedAt         time.Time        `json:"createdAt"`
+}
+
+type NamingScore struct {
+	Score              int                     `json:"score"` // 0..100 (total / láminas de la versión)
+	ItemsAdministered  int                     `json:"itemsAdministered"`
+	SpontaneousCorrect int                     `json:"spontaneousCorrect"`
+	SemanticCued       int                     `json:"semanticCued"`
+	PhonemicCued       int                     `json:"phonemicCued"`
+	Total              int                     `json:"total"` // espontáneas + tras clave semántica (criterio Boston)
+	Errors             map[NamingErrorType]int `json:"errors"`
+	Overrides          int                     `json:"overrides"`
+	ScoringProfile     string                  `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewConfrontationNamingSubtest(evaluationID string, version NamingVersion, responses []NamingResponse) (*ConfrontationNamingSubtest, error) {
+	if evaluationID == "" || len(responses) == 0 {
+		return nil, ErrInvalidNaming
+	}
+	items, err := ItemsForVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	byNumber := make(map[int]NamingItem, len(items))
+	for _, it := range items {
+		byNumber[it.Number] = it
+	}
+
+	seen := map[int]bool{}
+	out := make([]NamingResponse, 0, len(responses))
+	for _, r := range responses {
+		item, ok := byNumber[r.Item]
+		if !ok || seen[r.Item] {
+			return nil, ErrInvalidNamingItem
+		}
+		seen[r.Item] = true
+		if r.Override != nil && !validErrorType(r.Override.ErrorType) {
+			return nil, ErrInvalidNamingError
+		}
+		out = append(out, ClassifyResponse(item, r))
+	}
+	return &ConfrontationNamingSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Version:           version,
+		Responses:         out,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func validErrorType(t NamingErrorType) bool {
+	switch t {
+	case NamingErrorNone, NamingErrorOmission, NamingErrorPhonemic, NamingErrorCircumlocution,
+		NamingErrorSemantic, NamingErrorVisual, NamingErrorSubstitution:
+		return true
+	}
+	return false
+}
+
+// ClassifyResponse compara la transcripción con las respuestas aceptadas y asigna
+// resultado y tipo de error; la corrección del evaluador prevalece.
+func ClassifyResponse(item NamingItem, r NamingResponse) NamingResponse {
+	r.Target = item.Target
+	r.Matched = matchesItem(item, r.Transcript)
+
+	correct := r.Matched
+	errType := NamingErrorNone
+	if !r.Matched {
+		errType = classifyError(item, r.Transcript)
+	}
+	if r.Override != nil {
+		r.Overridden = true
+		correct = r.Override.Correct
+		errType = r.Override.ErrorType
+		if !correct && errType == NamingErrorNone {
+			errType = NamingErrorSubstitution
+		}
+	}
+
+	switch {
+	case !correct:
+		r.Outcome = NamingIncorrectFinal
+		r.ErrorType = errType
+	case r.PhonemicCue:
+		r.Outcome = NamingPhonemicCued
+	case r.SemanticCue:
+		r.Outcome = NamingSemanticCued
+	default:
+		r.Outcome = NamingSpontaneous
+	}
+	if correct {
+		r.ErrorType = NamingErrorNone
+	}
+	return r
+}
+
+func matchesItem(item NamingItem, transcript string) bool {
+	words := normalizeWords(transcript)
+	if len(words) == 0 {
+		return false
+	}
+	text := " " + strings.Join(words, " ") + " "
+	for _, answer := range append([]string{item.Target}, item.Accepted...) {
+		if strings.Contains(text, " "+strings.Join(normalizeWords(answer), " ")+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func classifyError(item NamingItem, transcript string) NamingErrorType {
+	words := normalizeWords(transcript)
+	if len(words) == 0 {
+		return NamingErrorOmission
+	}
+	target := strings.Join(normalizeWords(item.Target), " ")
+	for _, w := range words {
+		if similarity(w, target) >= phonemicSimilarity {
+			return NamingErrorPhonemic
+		}
+	}
+	if similarity(strings.Join(words, " "), target) >= phonemicSimilarity {
+		return NamingErrorPhonemic
+	}
+	if len(words) >= circumlocutionWords {
+		return NamingErrorCircumlocution
+	}
+	return NamingErrorSubstitution
+}
+
+var namingStopwords = map[string]bool{
+	"el": true, "la": true, "los": true, "las": true, "un": true, "una": true,
+	"unos": true, "unas": true, "es": true, "eso": true, "esto": true,
+}
+
+func normalizeWords(s string) []string {
+	s = strings.ToLower(utils.ReplaceAccentsES(s))
+	fields := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !namingStopwords[f] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// similarity = 1 − distancia de Levenshtein / longitud máxima
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
+
+func ScoreConfrontationNaming(sub ConfrontationNamingSubtest) (NamingScore, error) {
+	if len(sub.Responses) == 0 {
+		return NamingScore{}, fmt.Errorf("%w: responses vacío", ErrInvalidNaming)
+	}
+	score := NamingScore{
+		ItemsAdministered: len(sub.Responses),
+		Errors:            map[NamingErrorType]int{},
+	}
+	for _, r := range sub.Responses {
+		if r.Overridden {
+			score.Overrides++
+		}
+		switch r.Outcome {
+		case NamingSpontaneous:
+			score.SpontaneousCorrect++
+		case NamingSemanticCued:
+			score.SemanticCued++
+		case NamingPhonemicCued:
+			score.PhonemicCued++
+		default:
+			score.Errors[r.ErrorType]++
+		}
+	}
+	score.Total = score.SpontaneousCorrect + score.SemanticCued
+	if sub.Version > 0 {
+		score.Score = int(math.Round(100 * utils.Clamp01(float64(score.Total)/float64(sub.Version))))
+	}
+	return score, nil
+}
@@ -0,0 +1,102 @@
+package CNdomain
+
+// NamingItem es una lámina del test con su respuesta objetivo y sinónimos aceptados.
+type NamingItem struct {
+	Number   int      `json:"number"` // posición en la versión de 60 láminas (1..60)
+	Target   string   `json:"target"`
+	Accepted []string `json:"accepted"`
+}
+
+// Catálogo de 60 láminas en orden creciente de dificultad (estilo Boston)
+var namingCatalog = []NamingItem{
+	{1, "cama", nil},
+	{2, "árbol", nil},
+	{3, "lápiz", nil},
+	{4, "casa", nil},
+	{5, "silbato", []string{"pito"}},
+	{6, "tijeras", []string{"tijera"}},
+	{7, "peine", nil},
+	{8, "flor", nil},
+	{9, "serrucho", []string{"sierra"}},
+	{10, "cepillo de dientes", []string{"cepillo"}},
+	{11, "helicóptero", nil},
+	{12, "escoba", nil},
+	{13, "pulpo", nil},
+	{14, "seta", []string{"champiñón", "hongo"}},
+	{15, "percha", []string{"gancho", "colgador"}},
+	{16, "silla de ruedas", nil},
+	{17, "camello", nil},
+	{18, "máscara", []string{"careta", "antifaz"}},
+	{19, "galleta salada", []string{"pretzel", "rosquilla"}},
+	{20, "banco", nil},
+	{21, "raqueta", nil},
+	{22, "caracol", nil},
+	{23, "volcán", nil},
+	{24, "caballito de mar", []string{"hipocampo"}},
+	{25, "dardo", nil},
+	{26, "canoa", []string{"piragua"}},
+	{27, "globo terráqueo", []string{"bola del mundo", "mundo"}},
+	{28, "corona de flores", []string{"corona"}},
+	{29, "castor", nil},
+	{30, "armónica", nil},
+	{31, "rinoceronte", nil},
+	{32, "bellota", nil},
+	{33, "iglú", nil},
+	{34, "zancos", nil},
+	{35, "dominó", nil},
+	{36, "cactus", nil},
+	{37, "escalera mecánica", []string{"escaleras mecánicas"}},
+	{38, "arpa", nil},
+	{39, "hamaca", nil},
+	{40, "aldaba", []string{"llamador", "picaporte"}},
+	{41, "pelícano", nil},
+	{42, "estetoscopio", []string{"fonendoscopio", "fonendo"}},
+	{43, "pirámide", nil},
+	{44, "bozal", nil},
+	{45, "unicornio", nil},
+	{46, "embudo", nil},
+	{47, "acordeón", nil},
+	{48, "horca", []string{"soga", "lazo"}},
+	{49, "espárrago", []string{"espárragos"}},
+	{50, "compás", nil},
+	{51, "pestillo", []string{"cerrojo"}},
+	{52, "trípode", nil},
+	{53, "pergamino", nil},
+	{54, "pinzas", []string{"tenazas"}},
+	{55, "esfinge", nil},
+	{56, "yugo", nil},
+	{57, "enrejado", []string{"celosía", "espaldera"}},
+	{58, "paleta", []string{"paleta de pintor"}},
+	{59, "transportador", []string{"semicírculo"}},
+	{60, "ábaco", nil},
+}
+
+// NamingVersion es el número de láminas administradas.
+type NamingVersion int
+
+const (
+	NamingVersion15 NamingVersion = 15
+	NamingVersion30 NamingVersion = 30
+	NamingVersion60 NamingVersion = 60
+)
+
+// ItemsForVersion devuelve las láminas de la versión: la de 30 toma las impares
+// y la de 15 una de cada cuatro, conservando el gradiente de dificultad.
+func ItemsForVersion(v NamingVersion) ([]NamingItem, error) {
+	step := 0
+	switch v {
+	case NamingVersion60:
+		step = 1
+	case NamingVersion30:
+		step = 2
+	case NamingVersion15:
+		step = 4
+	default:
+		return nil, ErrInvalidNamingVersion
+	}
+	out := make([]NamingItem, 0, int(v))
+	for i := 0; i < len(namingCatalog); i += step {
+		out = append(out, namingCatalog[i])
+	}
+	return out, nil
+}
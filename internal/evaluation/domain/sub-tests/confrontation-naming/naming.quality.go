@@ -0,0 +1,24 @@
+package CNdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// Más de un 30 % de correcciones del evaluador indica que la clasificación automática no es fiable
+const maxOverrideRatio = 0.3
+
+// QualityFlags revisa techo/suelo y el desacuerdo evaluador–clasificación automática
+func (s ConfrontationNamingSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("confrontation_naming", s.PK)
+	sc := s.Score
+	if sc.ItemsAdministered > 0 && float64(sc.Overrides)/float64(sc.ItemsAdministered) > maxOverrideRatio {
+		c.Add(DQdomain.CodeExaminerDeviceMismatch, DQdomain.SeverityWarning, "overrides", "examiner overrode %d of %d automatic classifications", sc.Overrides, sc.ItemsAdministered)
+	}
+	switch {
+	case sc.ItemsAdministered > 0 && sc.Total == 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "total", "no items named")
+	case sc.Score >= 100:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "total", "all items named")
+	}
+	return c.Flags
+}
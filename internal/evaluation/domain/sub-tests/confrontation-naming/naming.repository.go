@@ -0,0 +1,9 @@
+package CNdomain
+
+import "context"
+
+type ConfrontationNamingRepository interface {
+	Save(ctx context.Context, subtest *ConfrontationNamingSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (ConfrontationNamingSubtest, error)
+}
@@ -0,0 +1,280 @@
+package DSdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type DigitSpanCondition string
+
+const (
+	DigitSpanForward    DigitSpanCondition = "forward"
+	DigitSpanBackward   DigitSpanCondition = "backward"
+	DigitSpanSequencing DigitSpanCondition = "sequencing"
+)
+
+const (
+	MinSpanLength = 2
+	MaxSpanLength = 9
+	// Regla de discontinuación: se detiene la condición al fallar dos ensayos de la misma longitud
+	MaxFailuresPerLength = 2
+	MaxDigitSpanTrials   = 60
+)
+
+var (
+	ErrInvalidDigitSpan          = errors.New("invalid digit span input")
+	ErrInvalidDigitSpanCondition = fmt.Errorf("%w: condition must be forward, backward or sequencing", ErrInvalidDigitSpan)
+	ErrInvalidDigitSpanLength    = fmt.Errorf("%w: span length must be between 2 and 9", ErrInvalidDigitSpan)
+	ErrInvalidDigitSpanTrial     = fmt.Errorf("%w: presented digits must match span length and be 0-9", ErrInvalidDigitSpan)
+)
+
+type DigitSpanTrial struct {
+	Condition  DigitSpanCondition `json:"condition"`
+	SpanLength int                `json:"spanLength"`
+	Presented  []int              `json:"presented"`
+	Response   []int              `json:"response"`
+	Correct    bool               `json:"correct"` // calculado en servidor
+	Scored     bool               `json:"scored"`  // false si se administró tras la discontinuación
+}
+
+type DigitSpanSubtest struct {
+	PK                string           `json:"pk"`
+	EvaluationID      string           `json:"evaluationId"`
+	Trials            []DigitSpanTrial `json:"trials"`
+	Score             DigitSpanScore   `json:"score"`
+	AssistantAnalysis string           `json:"assistantAnalysis"`
+	CreatedAt         time.Time        `json:"createdAt"`
+}
+
+type DigitSpanConditionScore struct {
+	Present            bool    `json:"present"`
+	LongestSpan        int     `json:"longestSpan"`        // mayor longitud con al menos un ensayo correcto
+	TotalCorrect       int     `json:"totalCorrect"`       // ensayos correctos puntuados
+	TrialsAdministered int     `json:"trialsAdministered"` // ensayos puntuados (antes de discontinuar)
+	Discontinued       bool    `json:"discontinued"`
+	ZScore             float64 `json:"zScore"`     // TotalCorrect frente a normas por edad
+	Percentile         float64 `json:"percentile"` // 0..100
+}
+
+type DigitSpanScore struct {
+	Score          int                     `json:"score"` // 0..100 (aciertos / máximo posible de las condiciones aplicadas)
+	Forward        DigitSpanConditionScore `json:"forward"`
+	Backward       DigitSpanConditionScore `json:"backward"`
+	Sequencing     DigitSpanConditionScore `json:"sequencing"`
+	TotalRaw       int                     `json:"totalRaw"`
+	ScoringProfile string                  `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewDigitSpanSubtest(evaluationID string, trials []DigitSpanTrial) (*DigitSpanSubtest, error) {
+	if evaluationID == "" || len(trials) == 0 || len(trials) > MaxDigitSpanTrials {
+		return nil, ErrInvalidDigitSpan
+	}
+	for _, t := range trials {
+		if err := validateTrial(t); err != nil {
+			return nil, err
+		}
+	}
+	return &DigitSpanSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Trials:            applyDiscontinueRule(trials),
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func validateTrial(t DigitSpanTrial) error {
+	switch t.Condition {
+	case DigitSpanForward, DigitSpanBackward, DigitSpanSequencing:
+	default:
+		return ErrInvalidDigitSpanCondition
+	}
+	if t.SpanLength < MinSpanLength || t.SpanLength > MaxSpanLength {
+		return ErrInvalidDigitSpanLength
+	}
+	if len(t.Presented) != t.SpanLength {
+		return ErrInvalidDigitSpanTrial
+	}
+	for _, d := range append(append([]int{}, t.Presented...), t.Response...) {
+		if d < 0 || d > 9 {
+			return ErrInvalidDigitSpanTrial
+		}
+	}
+	return nil
+}
+
+// ExpectedResponse devuelve la secuencia correcta para la condición del ensayo.
+func ExpectedResponse(t DigitSpanTrial) []int {
+	out := append([]int{}, t.Presented...)
+	switch t.Condition {
+	case DigitSpanBackward:
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	case DigitSpanSequencing:
+		sort.Ints(out)
+	}
+	return out
+}
+
+// applyDiscontinueRule corrige cada ensayo y marca como no puntuados los que
+// llegan después de dos fallos en la misma longitud dentro de una condición.
+func applyDiscontinueRule(trials []DigitSpanTrial) []DigitSpanTrial {
+	out := make([]DigitSpanTrial, 0, len(trials))
+	failures := map[DigitSpanCondition]map[int]int{}
+	stopped := map[DigitSpanCondition]bool{}
+	for _, t := range trials {
+		t.Correct = equalDigits(t.Response, ExpectedResponse(t))
+		t.Scored = !stopped[t.Condition]
+		if t.Scored && !t.Correct {
+			if failures[t.Condition] == nil {
+				failures[t.Condition] = map[int]int{}
+			}
+			failures[t.Condition][t.SpanLength]++
+			if failures[t.Condition][t.SpanLength] >= MaxFailuresPerLength {
+				stopped[t.Condition] = true
+			}
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// IsDiscontinued indica si la condición ya alcanzó el criterio de parada; útil
+// para que la tableta sepa si debe seguir presentando ensayos.
+func IsDiscontinued(trials []DigitSpanTrial, condition DigitSpanCondition) bool {
+	failures := map[int]int{}
+	for _, t := range applyDiscontinueRule(trials) {
+		if t.Condition != condition || !t.Scored || t.Correct {
+			continue
+		}
+		failures[t.SpanLength]++
+		if failures[t.SpanLength] >= MaxFailuresPerLength {
+			return true
+		}
+	}
+	return false
+}
+
+func ScoreDigitSpan(sub DigitSpanSubtest, patientAge int) (DigitSpanScore, error) {
+	if len(sub.Trials) == 0 {
+		return DigitSpanScore{}, fmt.Errorf("%w: trials vacío", ErrInvalidDigitSpan)
+	}
+	trials := applyDiscontinueRule(sub.Trials)
+	norms := normsForAge(patientAge)
+
+	forward := scoreCondition(trials, DigitSpanForward, norms.Forward)
+	backward := scoreCondition(trials, DigitSpanBackward, norms.Backward)
+	sequencing := scoreCondition(trials, DigitSpanSequencing, norms.Sequencing)
+
+	totalRaw := forward.TotalCorrect + backward.TotalCorrect + sequencing.TotalCorrect
+	maxRaw := 0
+	for _, c := range []struct {
+		score DigitSpanConditionScore
+		max   int
+	}{
+		{forward, maxTrials[DigitSpanForward]},
+		{backward, maxTrials[DigitSpanBackward]},
+		{sequencing, maxTrials[DigitSpanSequencing]},
+	} {
+		if c.score.Present {
+			maxRaw += c.max
+		}
+	}
+	score := 0
+	if maxRaw > 0 {
+		score = int(math.Round(100 * utils.Clamp01(float64(totalRaw)/float64(maxRaw))))
+	}
+
+	return DigitSpanScore{
+		Score:      score,
+		Forward:    forward,
+		Backward:   backward,
+		Sequencing: sequencing,
+		TotalRaw:   totalRaw,
+	}, nil
+}
+
+func scoreCondition(trials []DigitSpanTrial, condition DigitSpanCondition, norm normStat) DigitSpanConditionScore {
+	var out DigitSpanConditionScore
+	for _, t := range trials {
+		if t.Condition != condition {
+			continue
+		}
+		out.Present = true
+		if !t.Scored {
+			continue
+		}
+		out.TrialsAdministered++
+		if t.Correct {
+			out.TotalCorrect++
+			if t.SpanLength > out.LongestSpan {
+				out.LongestSpan = t.SpanLength
+			}
+		}
+	}
+	if !out.Present {
+		return out
+	}
+	out.Discontinued = IsDiscontinued(trials, condition)
+	out.ZScore = (float64(out.TotalCorrect) - norm.Mean) / norm.SD
+	out.Percentile = utils.ZToPercentile(out.ZScore)
+	return out
+}
+
+func equalDigits(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/* ====== Normas orientativas (puntuación directa por condición) ====== */
+
+// Ensayos máximos por condición (2 ensayos por longitud, formato WAIS-IV)
+var maxTrials = map[DigitSpanCondition]int{
+	DigitSpanForward:    16,
+	DigitSpanBackward:   14,
+	DigitSpanSequencing: 16,
+}
+
+type normStat struct {
+	Mean float64
+	SD   float64
+}
+
+type digitSpanNorm struct {
+	MaxAge     int
+	Forward    normStat
+	Backward   normStat
+	Sequencing normStat
+}
+
+// Valores de referencia por franja de edad (guía clínica no diagnóstica)
+var digitSpanNorms = []digitSpanNorm{
+	{MaxAge: 29, Forward: normStat{10.5, 2.2}, Backward: normStat{9.5, 2.4}, Sequencing: normStat{9.5, 2.2}},
+	{MaxAge: 49, Forward: normStat{10.0, 2.2}, Backward: normStat{8.8, 2.4}, Sequencing: normStat{9.0, 2.3}},
+	{MaxAge: 64, Forward: normStat{9.5, 2.1}, Backward: normStat{8.0, 2.3}, Sequencing: normStat{8.3, 2.3}},
+	{MaxAge: 74, Forward: normStat{9.0, 2.0}, Backward: normStat{7.3, 2.2}, Sequencing: normStat{7.5, 2.3}},
+	{MaxAge: math.MaxInt, Forward: normStat{8.5, 2.0}, Backward: normStat{6.8, 2.1}, Sequencing: normStat{6.8, 2.3}},
+}
+
+func normsForAge(age int) digitSpanNorm {
+	for _, n := range digitSpanNorms {
+		if age <= n.MaxAge {
+			return n
+		}
+	}
+	return digitSpanNorms[len(digitSpanNorms)-1]
+}
@@ -0,0 +1,23 @@
+package DSdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags marca techo/suelo por condición administrada
+func (s DigitSpanSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("digit_span", s.PK)
+	for _, cond := range []struct {
+		name  string
+		score DigitSpanConditionScore
+	}{{"forward", s.Score.Forward}, {"backward", s.Score.Backward}, {"sequencing", s.Score.Sequencing}} {
+		switch sc := cond.score; {
+		case !sc.Present:
+		case sc.TotalCorrect == 0:
+			c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, cond.name, "%s: no correct trials", cond.name)
+		case !sc.Discontinued && sc.TotalCorrect == sc.TrialsAdministered:
+			c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, cond.name, "%s: all trials correct up to span %d", cond.name, sc.LongestSpan)
+		}
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package DSdomain
+
+import "context"
+
+type DigitSpanRepository interface {
+	Save(ctx context.Context, subtest *DigitSpanSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (DigitSpanSubtest, error)
+}
@@ -2,6 +2,7 @@ package EFdomain
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	"neuro.app.jordi/internal/evaluation/utils"
 )
 
+var ErrInvalidExecutiveFunctions = errors.New("invalid executive functions input")
+
 type ExuctiveFunctionSubtestType string
 
 const (
@@ -36,6 +39,28 @@ type ExecutiveFunctionsScore struct {
 	SpeedIndex     float64 `json:"speedIndex" bson:"speedIndex"`         // tIdeal / durationSec (cap 0..1)
 	CommissionRate float64 `json:"commissionRate" bson:"commissionRate"` // TotalErrors / TotalClicks (0..1)
 	DurationSec    float64 `json:"durationSec" bson:"durationSec"`       // duración total en segundos
+	ScoringProfile string  `json:"scoringProfile" bson:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+// Pesos y penalizaciones del score 0..100
+type ExecutiveFunctionsScoreConfig struct {
+	AccuracyWeight    float64 `json:"accuracyWeight"`    // default 0.7
+	SpeedWeight       float64 `json:"speedWeight"`       // default 0.3
+	CommissionWeight  float64 `json:"commissionWeight"`  // default 0.2 (resta)
+	ErrorPenaltySec   float64 `json:"errorPenaltySec"`   // segundos añadidos por error, default 10
+	IdealSecPerItemA  float64 `json:"idealSecPerItemA"`  // default 1.0
+	IdealSecPerItemAB float64 `json:"idealSecPerItemAB"` // default 1.5
+}
+
+func DefaultExecutiveFunctionsScoreConfig() ExecutiveFunctionsScoreConfig {
+	return ExecutiveFunctionsScoreConfig{
+		AccuracyWeight:    0.7,
+		SpeedWeight:       0.3,
+		CommissionWeight:  0.2,
+		ErrorPenaltySec:   10,
+		IdealSecPerItemA:  1.0,
+		IdealSecPerItemAB: 1.5,
+	}
 }
 
 func NewExecutiveFunctionsSubtest(
@@ -49,7 +74,7 @@ func NewExecutiveFunctionsSubtest(
 	createdAt time.Time,
 ) (*ExecutiveFunctionsSubtest, error) {
 	if numberOfItems <= 0 || totalErrors < 0 || totalCorrect < 0 || totalClicks < 0 || (subtestType != A && subtestType != AB) || evaluationId == "" {
-		return nil, errors.New("error creating ExecutiveFunctionsSubtest")
+		return nil, ErrInvalidExecutiveFunctions
 	}
 	return &ExecutiveFunctionsSubtest{
 		PK:             uuid.NewString(),
@@ -66,21 +91,48 @@ func NewExecutiveFunctionsSubtest(
 }
 
 func (s ExecutiveFunctionsSubtest) DurationSeconds() float64 {
+	return s.durationSeconds(DefaultExecutiveFunctionsScoreConfig().ErrorPenaltySec)
+}
+
+func (s ExecutiveFunctionsSubtest) durationSeconds(errorPenaltySec float64) float64 {
 	sec := s.TotalTime.Seconds()
 	if sec <= 0 {
 		sec = 1
 	}
-	// Penalización: cada error añade +10 segundos
-	penalty := float64(s.TotalErrors * 10)
+	// Penalización: cada error añade errorPenaltySec segundos
+	penalty := float64(s.TotalErrors) * errorPenaltySec
 	return sec + penalty
 }
 
-func ScoreExecutiveFunctions(sub ExecutiveFunctionsSubtest) (ExecutiveFunctionsScore, error) {
+// HasRawTotalTime indica si TotalTime es el tiempo bruto: con él y la penalización del perfil
+// con que se puntuó sale la duración guardada. Las filas anteriores al fix de total_time_sec no
+// lo cumplen porque guardaban el score.
+func (s ExecutiveFunctionsSubtest) HasRawTotalTime(errorPenaltySec float64) bool {
+	return math.Abs(s.durationSeconds(errorPenaltySec)-s.Score.DurationSec) < 1e-3
+}
+
+// TotalTimeFromScore reconstruye el tiempo bruto a partir de la duración puntuada, que incluye
+// la penalización por errores del perfil con que se calculó. Las filas antiguas guardaban el score
+// en total_time_sec, así que durationSec es la única fuente fiable del tiempo.
+func TotalTimeFromScore(score ExecutiveFunctionsScore, totalErrors int, errorPenaltySec float64) time.Duration {
+	sec := score.DurationSec - float64(totalErrors)*errorPenaltySec
+	if sec <= 0 {
+		sec = 1
+	}
+	return time.Duration(sec * float64(time.Second)).Round(time.Millisecond)
+}
+
+// ScoreExecutiveFunctions puntúa con cfg; nil usa la configuración por defecto
+func ScoreExecutiveFunctions(sub ExecutiveFunctionsSubtest, cfg *ExecutiveFunctionsScoreConfig) (ExecutiveFunctionsScore, error) {
+	c := DefaultExecutiveFunctionsScoreConfig()
+	if cfg != nil {
+		c = *cfg
+	}
 	if sub.NumberOfItems <= 0 {
-		return ExecutiveFunctionsScore{}, errors.New("numberOfItems must be > 0")
+		return ExecutiveFunctionsScore{}, fmt.Errorf("%w: numberOfItems must be > 0", ErrInvalidExecutiveFunctions)
 	}
 	if sub.TotalCorrect < 0 || sub.TotalErrors < 0 || sub.TotalClicks < 0 {
-		return ExecutiveFunctionsScore{}, errors.New("counts must be >= 0")
+		return ExecutiveFunctionsScore{}, fmt.Errorf("%w: counts must be >= 0", ErrInvalidExecutiveFunctions)
 	}
 
 	clicks := sub.TotalClicks
@@ -91,15 +143,15 @@ func ScoreExecutiveFunctions(sub ExecutiveFunctionsSubtest) (ExecutiveFunctionsS
 		}
 	}
 
-	durationSec := sub.DurationSeconds()
+	durationSec := sub.durationSeconds(c.ErrorPenaltySec)
 	edges := sub.NumberOfItems - 1
 	if edges < 1 {
 		edges = 1
 	}
 
-	idealPerItem := 1.0
+	idealPerItem := c.IdealSecPerItemA
 	if sub.Type == AB {
-		idealPerItem = 1.5
+		idealPerItem = c.IdealSecPerItemAB
 	}
 	tIdeal := float64(edges) * idealPerItem
 
@@ -107,7 +159,7 @@ func ScoreExecutiveFunctions(sub ExecutiveFunctionsSubtest) (ExecutiveFunctionsS
 	speedIdx := utils.Clamp01(tIdeal / durationSec)
 	commissionRate := utils.Clamp01(float64(sub.TotalErrors) / float64(clicks))
 
-	score01 := utils.Clamp01(0.7*accuracy + 0.3*speedIdx - 0.2*commissionRate)
+	score01 := utils.Clamp01(c.AccuracyWeight*accuracy + c.SpeedWeight*speedIdx - c.CommissionWeight*commissionRate)
 	return ExecutiveFunctionsScore{
 		Score:          int(math.Round(100 * score01)),
 		Accuracy:       accuracy,
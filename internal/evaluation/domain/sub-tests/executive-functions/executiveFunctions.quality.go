@@ -0,0 +1,25 @@
+package EFdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags comprueba la coherencia de los recuentos y del tiempo del TMT
+func (s ExecutiveFunctionsSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("executive_functions", s.PK)
+	if s.TotalTime <= 0 {
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "totalTime", "TMT %s: totalTime must be > 0", s.Type)
+	} else if s.NumberOfItems > 0 && s.TotalTime.Seconds()/float64(s.NumberOfItems) < 0.4 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "totalTime", "TMT %s: %.1f s for %d items is implausibly fast", s.Type, s.TotalTime.Seconds(), s.NumberOfItems)
+	}
+	if s.TotalClicks > 0 && s.TotalClicks < s.TotalCorrect+s.TotalErrors {
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "totalClicks", "TMT %s: totalClicks (%d) is smaller than correct + errors (%d)", s.Type, s.TotalClicks, s.TotalCorrect+s.TotalErrors)
+	}
+	if s.TotalCorrect > s.NumberOfItems {
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "totalCorrect", "TMT %s: totalCorrect (%d) exceeds numberOfItems (%d)", s.Type, s.TotalCorrect, s.NumberOfItems)
+	}
+	if s.TotalCorrect == 0 {
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "totalCorrect", "TMT %s: no correct items", s.Type)
+	}
+	return c.Flags
+}
@@ -0,0 +1,244 @@
+package FTdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type Hand string
+
+const (
+	HandLeft  Hand = "left"
+	HandRight Hand = "right"
+)
+
+const (
+	MinTappingWindowMs = 5_000
+	MaxTappingWindowMs = 60_000
+	MaxTapsPerHand     = 1_000
+	// Una pausa es un intervalo entre toques mayor que el doble de la mediana
+	hesitationFactor = 2.0
+	// Asimetría (%) a partir de la cual se señala el lado más afectado
+	asymmetryThresholdPct = 10.0
+	// Frecuencia de referencia (toques/s) para la puntuación 0..100
+	referenceRateHz = 5.0
+)
+
+var (
+	ErrInvalidFingerTapping = errors.New("invalid finger tapping input")
+	ErrInvalidHand          = fmt.Errorf("%w: hand must be left or right", ErrInvalidFingerTapping)
+	ErrInvalidTappingWindow = fmt.Errorf("%w: window must be between 5 and 60 seconds", ErrInvalidFingerTapping)
+	ErrInvalidTaps          = fmt.Errorf("%w: taps must be ordered in time and inside the window", ErrInvalidFingerTapping)
+)
+
+// Tap es un toque en pantalla; la amplitud se mide como distancia entre toques
+// consecutivos (tapping alternante entre dos dianas).
+type Tap struct {
+	AtMs int64   `json:"atMs"` // desde el inicio de la ventana
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+type HandTrial struct {
+	Hand     Hand  `json:"hand"`
+	WindowMs int64 `json:"windowMs"`
+	Taps     []Tap `json:"taps"`
+}
+
+type FingerTappingSubtest struct {
+	PK                string             `json:"pk"`
+	EvaluationID      string             `json:"evaluationId"`
+	Trials            []HandTrial        `json:"trials"`
+	Score             FingerTappingScore `json:"score"`
+	AssistantAnalysis string             `json:"assistantAnalysis"`
+	CreatedAt         time.Time          `json:"createdAt"`
+}
+
+type HandScore struct {
+	Present              bool    `json:"present"`
+	Taps                 int     `json:"taps"`
+	RateHz               float64 `json:"rateHz"`
+	MeanIntervalMs       float64 `json:"meanIntervalMs"`
+	IntervalCV           float64 `json:"intervalCV"` // variabilidad del ritmo
+	Hesitations          int     `json:"hesitations"`
+	MeanAmplitude        float64 `json:"meanAmplitude"`
+	AmplitudeDecrement   float64 `json:"amplitudeDecrementPct"` // tercio inicial vs final (efecto secuencia)
+	AmplitudeSlopePerTap float64 `json:"amplitudeSlopePerTap"`
+}
+
+type FingerTappingScore struct {
+	Score            int       `json:"score"` // 0..100 (frecuencia de la mano más lenta frente a 5 Hz)
+	Left             HandScore `json:"left"`
+	Right            HandScore `json:"right"`
+	RateAsymmetryPct float64   `json:"rateAsymmetryPct"` // (D − I) / media × 100
+	MoreAffectedSide Hand      `json:"moreAffectedSide,omitempty"`
+	ScoringProfile   string    `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewFingerTappingSubtest(evaluationID string, trials []HandTrial) (*FingerTappingSubtest, error) {
+	if evaluationID == "" || len(trials) == 0 || len(trials) > 2 {
+		return nil, ErrInvalidFingerTapping
+	}
+	seen := map[Hand]bool{}
+	for _, t := range trials {
+		if (t.Hand != HandLeft && t.Hand != HandRight) || seen[t.Hand] {
+			return nil, ErrInvalidHand
+		}
+		seen[t.Hand] = true
+		if t.WindowMs < MinTappingWindowMs || t.WindowMs > MaxTappingWindowMs {
+			return nil, ErrInvalidTappingWindow
+		}
+		if len(t.Taps) > MaxTapsPerHand {
+			return nil, ErrInvalidTaps
+		}
+		var last int64
+		for _, tap := range t.Taps {
+			if tap.AtMs < last || tap.AtMs > t.WindowMs {
+				return nil, ErrInvalidTaps
+			}
+			last = tap.AtMs
+		}
+	}
+	return &FingerTappingSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Trials:            trials,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func ScoreFingerTapping(sub FingerTappingSubtest) (FingerTappingScore, error) {
+	if len(sub.Trials) == 0 {
+		return FingerTappingScore{}, fmt.Errorf("%w: trials vacío", ErrInvalidFingerTapping)
+	}
+	var out FingerTappingScore
+	for _, t := range sub.Trials {
+		if t.Hand == HandLeft {
+			out.Left = scoreHand(t)
+		} else {
+			out.Right = scoreHand(t)
+		}
+	}
+
+	slowest := math.Inf(1)
+	for _, h := range []HandScore{out.Left, out.Right} {
+		if h.Present {
+			slowest = math.Min(slowest, h.RateHz)
+		}
+	}
+	out.Score = int(math.Round(100 * utils.Clamp01(slowest/referenceRateHz)))
+
+	if out.Left.Present && out.Right.Present {
+		mean := (out.Left.RateHz + out.Right.RateHz) / 2
+		if mean > 0 {
+			out.RateAsymmetryPct = round2((out.Right.RateHz - out.Left.RateHz) / mean * 100)
+		}
+		switch {
+		case out.RateAsymmetryPct <= -asymmetryThresholdPct:
+			out.MoreAffectedSide = HandRight
+		case out.RateAsymmetryPct >= asymmetryThresholdPct:
+			out.MoreAffectedSide = HandLeft
+		}
+	}
+	return out, nil
+}
+
+func scoreHand(t HandTrial) HandScore {
+	out := HandScore{Present: true, Taps: len(t.Taps)}
+	out.RateHz = round2(float64(len(t.Taps)) / (float64(t.WindowMs) / 1000))
+	if len(t.Taps) < 2 {
+		return out
+	}
+
+	intervals := make([]float64, 0, len(t.Taps)-1)
+	amplitudes := make([]float64, 0, len(t.Taps)-1)
+	for i := 1; i < len(t.Taps); i++ {
+		intervals = append(intervals, float64(t.Taps[i].AtMs-t.Taps[i-1].AtMs))
+		amplitudes = append(amplitudes, math.Hypot(t.Taps[i].X-t.Taps[i-1].X, t.Taps[i].Y-t.Taps[i-1].Y))
+	}
+
+	mean, sd := meanSD(intervals)
+	out.MeanIntervalMs = round2(mean)
+	if mean > 0 {
+		out.IntervalCV = round2(sd / mean)
+	}
+	limit := hesitationFactor * median(intervals)
+	for _, iti := range intervals {
+		if iti > limit {
+			out.Hesitations++
+		}
+	}
+
+	ampMean, _ := meanSD(amplitudes)
+	out.MeanAmplitude = round2(ampMean)
+	if third := len(amplitudes) / 3; third > 0 {
+		first, _ := meanSD(amplitudes[:third])
+		last, _ := meanSD(amplitudes[len(amplitudes)-third:])
+		if first > 0 {
+			out.AmplitudeDecrement = round2((first - last) / first * 100)
+		}
+	}
+	out.AmplitudeSlopePerTap = round2(slope(amplitudes))
+	return out
+}
+
+func meanSD(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)-1))
+}
+
+func median(xs []float64) float64 {
+	sorted := append([]float64{}, xs...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// slope es la pendiente por mínimos cuadrados de la serie frente a su índice
+func slope(ys []float64) float64 {
+	n := float64(len(ys))
+	if n < 2 {
+		return 0
+	}
+	var sx, sy, sxy, sxx float64
+	for i, y := range ys {
+		x := float64(i)
+		sx += x
+		sy += y
+		sxy += x * y
+		sxx += x * x
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return 0
+	}
+	return (n*sxy - sx*sy) / den
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,23 @@
+package FTdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: por encima de ~9 Hz sostenidos suele ser rebote del sensor, no golpeteo
+func (s FingerTappingSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("finger_tapping", s.PK)
+	for _, h := range []struct {
+		name  string
+		score HandScore
+	}{{"left", s.Score.Left}, {"right", s.Score.Right}} {
+		switch sc := h.score; {
+		case !sc.Present:
+		case sc.Taps == 0:
+			c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, h.name, "%s hand: no taps recorded", h.name)
+		case sc.RateHz > 9:
+			c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, h.name, "%s hand: %.1f Hz is implausibly fast", h.name, sc.RateHz)
+		}
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package FTdomain
+
+import "context"
+
+type FingerTappingRepository interface {
+	Save(ctx context.Context, subtest *FingerTappingSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (FingerTappingSubtest, error)
+}
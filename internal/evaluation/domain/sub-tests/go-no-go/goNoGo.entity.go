@@ -0,0 +1,257 @@
+package GNGdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type Stimulus string
+
+const (
+	StimulusGo   Stimulus = "go"
+	StimulusNoGo Stimulus = "nogo"
+)
+
+const (
+	MinGoNoGoTrials = 20
+	MaxGoNoGoTrials = 1000
+	DefaultBlocks   = 4
+	// Respuestas más rápidas que esto son anticipaciones (no reacción al estímulo)
+	AnticipationMs = 150
+)
+
+var (
+	ErrInvalidGoNoGo       = errors.New("invalid go/no-go input")
+	ErrInvalidGoNoGoTrials = fmt.Errorf("%w: go/no-go needs between 20 and 1000 trials with both go and nogo stimuli", ErrInvalidGoNoGo)
+	ErrInvalidGoNoGoTrial  = fmt.Errorf("%w: invalid go/no-go trial", ErrInvalidGoNoGo)
+)
+
+type GoNoGoTrial struct {
+	Stimulus Stimulus `json:"stimulus"`
+	OnsetMs  int64    `json:"onsetMs"`
+	// nil = sin respuesta dentro de la ventana del ensayo
+	RespondedAtMs *int64 `json:"respondedAtMs,omitempty"`
+}
+
+type GoNoGoSubtest struct {
+	PK                string        `json:"pk"`
+	EvaluationID      string        `json:"evaluationId"`
+	Blocks            int           `json:"blocks"`
+	Trials            []GoNoGoTrial `json:"trials"`
+	Score             GoNoGoScore   `json:"score"`
+	AssistantAnalysis string        `json:"assistantAnalysis"`
+	CreatedAt         time.Time     `json:"createdAt"`
+}
+
+type GoNoGoBlock struct {
+	Block          int     `json:"block"`
+	GoTrials       int     `json:"goTrials"`
+	NoGoTrials     int     `json:"noGoTrials"`
+	CommissionRate float64 `json:"commissionRate"`
+	OmissionRate   float64 `json:"omissionRate"`
+	MedianRTMs     float64 `json:"medianRTMs"`
+}
+
+type GoNoGoScore struct {
+	Score          int           `json:"score"` // 0..100 (precisión media go/no-go)
+	GoTrials       int           `json:"goTrials"`
+	NoGoTrials     int           `json:"noGoTrials"`
+	Commissions    int           `json:"commissions"` // respuestas a no-go
+	Omissions      int           `json:"omissions"`   // go sin respuesta
+	Anticipations  int           `json:"anticipations"`
+	CommissionRate float64       `json:"commissionRate"`
+	OmissionRate   float64       `json:"omissionRate"`
+	HitRate        float64       `json:"hitRate"`
+	FalseAlarmRate float64       `json:"falseAlarmRate"`
+	DPrime         float64       `json:"dPrime"`    // z(aciertos) − z(falsas alarmas), corrección log-lineal
+	Criterion      float64       `json:"criterion"` // c < 0 = sesgo a responder (impulsivo)
+	MeanRTMs       float64       `json:"meanRTMs"`
+	MedianRTMs     float64       `json:"medianRTMs"`
+	RTCV           float64       `json:"rtCV"`
+	CommissionRTMs float64       `json:"commissionRTMs"` // mediana del TR de las comisiones
+	Blocks         []GoNoGoBlock `json:"blocks"`
+	// Último bloque − primero: positivo = empeora con el tiempo (fatiga / vigilancia)
+	CommissionRateChange float64 `json:"commissionRateChange"`
+	MedianRTChangeMs     float64 `json:"medianRTChangeMs"`
+	ScoringProfile       string  `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewGoNoGoSubtest(evaluationID string, blocks int, trials []GoNoGoTrial) (*GoNoGoSubtest, error) {
+	if evaluationID == "" {
+		return nil, ErrInvalidGoNoGo
+	}
+	if len(trials) < MinGoNoGoTrials || len(trials) > MaxGoNoGoTrials {
+		return nil, ErrInvalidGoNoGoTrials
+	}
+	if blocks <= 0 {
+		blocks = DefaultBlocks
+	}
+	if blocks > len(trials)/5 {
+		return nil, fmt.Errorf("%w: too many blocks for the number of trials", ErrInvalidGoNoGo)
+	}
+	var goN, nogoN int
+	for i, t := range trials {
+		switch t.Stimulus {
+		case StimulusGo:
+			goN++
+		case StimulusNoGo:
+			nogoN++
+		default:
+			return nil, ErrInvalidGoNoGoTrial
+		}
+		if i > 0 && t.OnsetMs < trials[i-1].OnsetMs {
+			return nil, ErrInvalidGoNoGoTrial
+		}
+		if t.RespondedAtMs != nil && *t.RespondedAtMs < t.OnsetMs {
+			return nil, ErrInvalidGoNoGoTrial
+		}
+	}
+	if goN == 0 || nogoN == 0 {
+		return nil, ErrInvalidGoNoGoTrials
+	}
+	return &GoNoGoSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Blocks:            blocks,
+		Trials:            trials,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+type tally struct {
+	goN, nogoN, commissions, omissions int
+	goRTs, commissionRTs               []float64
+	anticipations                      int
+}
+
+func (t *tally) add(trial GoNoGoTrial) {
+	responded := trial.RespondedAtMs != nil
+	var rt float64
+	if responded {
+		rt = float64(*trial.RespondedAtMs - trial.OnsetMs)
+		if rt < AnticipationMs {
+			t.anticipations++
+		}
+	}
+	switch trial.Stimulus {
+	case StimulusGo:
+		t.goN++
+		if !responded {
+			t.omissions++
+		} else if rt >= AnticipationMs {
+			t.goRTs = append(t.goRTs, rt)
+		}
+	case StimulusNoGo:
+		t.nogoN++
+		if responded {
+			t.commissions++
+			t.commissionRTs = append(t.commissionRTs, rt)
+		}
+	}
+}
+
+func ScoreGoNoGo(sub GoNoGoSubtest) (GoNoGoScore, error) {
+	if len(sub.Trials) == 0 {
+		return GoNoGoScore{}, fmt.Errorf("%w: trials vacío", ErrInvalidGoNoGo)
+	}
+	var all tally
+	for _, t := range sub.Trials {
+		all.add(t)
+	}
+
+	out := GoNoGoScore{
+		GoTrials:      all.goN,
+		NoGoTrials:    all.nogoN,
+		Commissions:   all.commissions,
+		Omissions:     all.omissions,
+		Anticipations: all.anticipations,
+	}
+	out.CommissionRate = round3(rate(all.commissions, all.nogoN))
+	out.OmissionRate = round3(rate(all.omissions, all.goN))
+	out.HitRate = round3(1 - rate(all.omissions, all.goN))
+	out.FalseAlarmRate = out.CommissionRate
+
+	// Corrección log-lineal (Hautus, 1995) para tasas de 0 o 1
+	zHit := utils.ProbabilityToZ((float64(all.goN-all.omissions) + 0.5) / (float64(all.goN) + 1))
+	zFA := utils.ProbabilityToZ((float64(all.commissions) + 0.5) / (float64(all.nogoN) + 1))
+	out.DPrime = round3(zHit - zFA)
+	out.Criterion = round3(-(zHit + zFA) / 2)
+
+	if len(all.goRTs) > 0 {
+		mean, sd := meanSD(all.goRTs)
+		out.MeanRTMs = round1(mean)
+		out.MedianRTMs = round1(median(all.goRTs))
+		if mean > 0 {
+			out.RTCV = round3(sd / mean)
+		}
+	}
+	out.CommissionRTMs = round1(median(all.commissionRTs))
+	out.Score = int(math.Round(100 * (1 - (out.CommissionRate+out.OmissionRate)/2)))
+
+	// Bloques consecutivos de tamaño similar en orden de presentación
+	n := len(sub.Trials)
+	for b := 0; b < sub.Blocks; b++ {
+		var t tally
+		for _, trial := range sub.Trials[b*n/sub.Blocks : (b+1)*n/sub.Blocks] {
+			t.add(trial)
+		}
+		out.Blocks = append(out.Blocks, GoNoGoBlock{
+			Block:          b + 1,
+			GoTrials:       t.goN,
+			NoGoTrials:     t.nogoN,
+			CommissionRate: round3(rate(t.commissions, t.nogoN)),
+			OmissionRate:   round3(rate(t.omissions, t.goN)),
+			MedianRTMs:     round1(median(t.goRTs)),
+		})
+	}
+	if len(out.Blocks) >= 2 {
+		first, last := out.Blocks[0], out.Blocks[len(out.Blocks)-1]
+		out.CommissionRateChange = round3(last.CommissionRate - first.CommissionRate)
+		if first.MedianRTMs > 0 && last.MedianRTMs > 0 {
+			out.MedianRTChangeMs = round1(last.MedianRTMs - first.MedianRTMs)
+		}
+	}
+	return out, nil
+}
+
+func rate(k, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(k) / float64(n)
+}
+
+func meanSD(xs []float64) (float64, float64) {
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	mean := s / float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)))
+}
+
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	s := append([]float64{}, xs...)
+	sort.Float64s(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+func round1(x float64) float64 { return math.Round(x*10) / 10 }
+func round3(x float64) float64 { return math.Round(x*1000) / 1000 }
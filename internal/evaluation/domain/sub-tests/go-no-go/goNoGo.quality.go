@@ -0,0 +1,24 @@
+package GNGdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags revisa TR inverosímiles, anticipaciones y techo
+func (s GoNoGoSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("go_no_go", s.PK)
+	sc := s.Score
+	if sc.MedianRTMs > 0 && sc.MedianRTMs < 150 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "medianRTMs", "median go RT %.0f ms is implausibly fast", sc.MedianRTMs)
+	}
+	if sc.GoTrials > 0 && float64(sc.Anticipations)/float64(sc.GoTrials) > 0.2 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "anticipations", "%d anticipations in %d go trials", sc.Anticipations, sc.GoTrials)
+	}
+	switch {
+	case sc.GoTrials > 0 && sc.Omissions == sc.GoTrials:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "omissions", "no responses to go trials")
+	case sc.Commissions == 0 && sc.Omissions == 0:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "score", "no commissions or omissions")
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package GNGdomain
+
+import "context"
+
+type GoNoGoRepository interface {
+	Save(ctx context.Context, subtest *GoNoGoSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (GoNoGoSubtest, error)
+}
@@ -10,6 +10,8 @@ import (
 	"neuro.app.jordi/internal/evaluation/utils"
 )
 
+var ErrInvalidLanguageFluency = errors.New("invalid language fluency input")
+
 /* ====== Tu modelo (tal cual) ====== */
 
 type LanguageFluency struct {
@@ -26,7 +28,7 @@ type LanguageFluency struct {
 
 func NewLanguageFluency(language, proficiency, category string, answerWords []string, evaluationID string) (*LanguageFluency, error) {
 	if language == "" || proficiency == "" || category == "" || len(answerWords) == 0 || evaluationID == "" {
-		return nil, errors.New("invalid input for creating LanguageFluency")
+		return nil, ErrInvalidLanguageFluency
 	}
 	return &LanguageFluency{
 		PK:                uuid.New().String(),
@@ -51,21 +53,21 @@ type LanguageFluencyScore struct {
 	WordsPerMinute float64 `json:"wordsPerMinute"` // uniqueValid / (duration/60)
 	IntrusionRate  float64 `json:"intrusionRate"`  // intrusions / max(1,totalProduced)
 	PersevRate     float64 `json:"persevRate"`     // perseverations / max(1,totalProduced)
+	ScoringProfile string  `json:"scoringProfile"` // id@versión del perfil que produjo el score
 }
 
 type LanguageFluencyScoreConfig struct {
-	DurationSec          int     // por defecto 60
-	MaxExpectedPerMinute int     // cap superior esperado (default 30)
-	NormalizeWords       bool    // minúsculas + quitar tildes/diéresis, mapear ñ→n (default true)
-	IntrusionPenalty     float64 // default 0.5
-	PersevPenalty        float64 // default 0.25
+	DurationSec          int     `json:"durationSec"`          // por defecto 60
+	MaxExpectedPerMinute int     `json:"maxExpectedPerMinute"` // cap superior esperado (default 30)
+	NormalizeWords       bool    `json:"normalizeWords"`       // minúsculas + quitar tildes/diéresis, mapear ñ→n (default true)
+	IntrusionPenalty     float64 `json:"intrusionPenalty"`     // default 0.5
+	PersevPenalty        float64 `json:"persevPenalty"`        // default 0.25
 	// Conjunto de términos válidos para la categoría (opcional).
-	ValidSet map[string]struct{}
+	ValidSet map[string]struct{} `json:"-"`
 }
 
-func ScoreLanguageFluency(sub LanguageFluency) (LanguageFluencyScore, error) {
-	// Defaults
-	c := LanguageFluencyScoreConfig{
+func DefaultLanguageFluencyScoreConfig() LanguageFluencyScoreConfig {
+	return LanguageFluencyScoreConfig{
 		DurationSec:          60,
 		MaxExpectedPerMinute: 30,
 		NormalizeWords:       true,
@@ -73,6 +75,17 @@ func ScoreLanguageFluency(sub LanguageFluency) (LanguageFluencyScore, error) {
 		PersevPenalty:        0.25,
 		ValidSet:             nil,
 	}
+}
+
+// ScoreLanguageFluency puntúa con cfg; nil usa la configuración por defecto
+func ScoreLanguageFluency(sub LanguageFluency, cfg *LanguageFluencyScoreConfig) (LanguageFluencyScore, error) {
+	c := DefaultLanguageFluencyScoreConfig()
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.DurationSec <= 0 || c.MaxExpectedPerMinute <= 0 {
+		return LanguageFluencyScore{}, errors.New("invalid language fluency score config")
+	}
 
 	words := sanitizeList(sub.AnswerWords, c.NormalizeWords)
 	totalProduced := len(words)
@@ -0,0 +1,17 @@
+package LFdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags detecta producciones imposibles en un minuto y el efecto suelo
+func (s LanguageFluency) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("language_fluency", s.PK)
+	if n := len(s.AnswerWords); n > 60 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "answerWords", "%d words in one minute is implausibly fast", n)
+	}
+	if s.Score.UniqueValid == 0 {
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "uniqueValid", "no valid words for category %q", s.Category)
+	}
+	return c.Flags
+}
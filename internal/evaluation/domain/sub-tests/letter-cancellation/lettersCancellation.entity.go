@@ -2,6 +2,7 @@ package LCdomain
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -10,10 +11,11 @@ import (
 )
 
 var (
-	ErrInvalidTotalTargets = errors.New("totalTargets must be > 0")
-	ErrInvalidCorrect      = errors.New("correct must be >= 0 and <= totalTargets")
-	ErrInvalidErrors       = errors.New("errors must be >= 0")
-	ErrInvalidTimeInSecs   = errors.New("timeInSecs must be > 0")
+	ErrInvalidLettersCancellation = errors.New("invalid letters cancellation input")
+	ErrInvalidTotalTargets        = fmt.Errorf("%w: totalTargets must be > 0", ErrInvalidLettersCancellation)
+	ErrInvalidCorrect             = fmt.Errorf("%w: correct must be >= 0 and <= totalTargets", ErrInvalidLettersCancellation)
+	ErrInvalidErrors              = fmt.Errorf("%w: errors must be >= 0", ErrInvalidLettersCancellation)
+	ErrInvalidTimeInSecs          = fmt.Errorf("%w: timeInSecs must be > 0", ErrInvalidLettersCancellation)
 )
 
 type LettersCancellationSubtest struct {
@@ -37,16 +39,21 @@ type CancellationScore struct {
 	CommissionRate float64 `json:"commissionRate"` // C / (H + C) si H+C>0
 	HitsPerMin     float64 `json:"hitsPerMin"`
 	ErrorsPerMin   float64 `json:"errorsPerMin"`
+	ScoringProfile string  `json:"scoringProfile"` // id@versión del perfil que produjo el score
 }
 
 // Configuración de scoring (k: factor de capado de errores; p.ej. 2 => hasta 2× objetivos)
 type CancellationScoreConfig struct {
-	CapErrorFactor float64 // default 2.0
+	CapErrorFactor float64 `json:"capErrorFactor"` // default 2.0
+}
+
+func DefaultCancellationScoreConfig() CancellationScoreConfig {
+	return CancellationScoreConfig{CapErrorFactor: 2.0}
 }
 
 func NewLettersCancellationSubtest(totalTargets, correct, errs, timeInSecs int, evaluationID string, cfg *CancellationScoreConfig) (*LettersCancellationSubtest, error) {
 	if evaluationID == "" || totalTargets <= 0 || correct < 0 || correct > totalTargets || errs < 0 || timeInSecs <= 0 {
-		return nil, errors.New("invalid input for creation of LettersCancellationSubtest")
+		return nil, ErrInvalidLettersCancellation
 	}
 	score, err := calculateCancellationScore(totalTargets, correct, errs, timeInSecs, cfg)
 	if err != nil {
@@ -65,6 +72,11 @@ func NewLettersCancellationSubtest(totalTargets, correct, errs, timeInSecs int,
 	}, nil
 }
 
+// ScoreLettersCancellation vuelve a puntuar un subtest guardado con cfg; nil usa la configuración por defecto
+func ScoreLettersCancellation(sub LettersCancellationSubtest, cfg *CancellationScoreConfig) (CancellationScore, error) {
+	return calculateCancellationScore(sub.TotalTargets, sub.Correct, sub.Errors, sub.TimeInSecs, cfg)
+}
+
 func calculateCancellationScore(totalTargets, correct, errors, timeInSecs int, cfg *CancellationScoreConfig) (CancellationScore, error) {
 	if totalTargets <= 0 {
 		return CancellationScore{}, ErrInvalidTotalTargets
@@ -0,0 +1,23 @@
+package LCdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags marca tiempos imposibles de barrido y efectos techo/suelo
+func (s LettersCancellationSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("letters_cancellation", s.PK)
+	if s.TotalTargets > 0 && s.TimeInSecs > 0 && float64(s.TimeInSecs)/float64(s.TotalTargets) < 0.2 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "timeInSecs", "%d s for %d targets is implausibly fast", s.TimeInSecs, s.TotalTargets)
+	}
+	if s.TotalTargets > 0 && s.Errors > 3*s.TotalTargets {
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "errors", "errors (%d) exceed three times the targets (%d)", s.Errors, s.TotalTargets)
+	}
+	switch {
+	case s.Correct == 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "correct", "no targets cancelled")
+	case s.Correct == s.TotalTargets && s.Errors == 0:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "correct", "all targets cancelled without errors")
+	}
+	return c.Flags
+}
@@ -0,0 +1,224 @@
+package JLOdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JLOForm string
+
+const (
+	JLOFormFull JLOForm = "full" // 30 ítems
+	JLOFormOdd  JLOForm = "odd"  // 15 ítems impares, se prorratea ×2
+	JLOFormEven JLOForm = "even" // 15 ítems pares, se prorratea ×2
+)
+
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+type JLOClassification string
+
+const (
+	JLOSuperior            JLOClassification = "superior"             // 29–30
+	JLOHighAverage         JLOClassification = "high_average"         // 27–28
+	JLOAverage             JLOClassification = "average"              // 25–26
+	JLOLowAverage          JLOClassification = "low_average"          // 23–24
+	JLOBorderline          JLOClassification = "borderline"           // 21–22
+	JLOModeratelyDefective JLOClassification = "moderately_defective" // 18–20
+	JLOSeverelyDefective   JLOClassification = "severely_defective"   // < 18
+)
+
+var (
+	ErrInvalidJLO         = errors.New("invalid JLO input")
+	ErrInvalidJLOForm     = fmt.Errorf("%w: form must be full, odd or even", ErrInvalidJLO)
+	ErrInvalidSex         = fmt.Errorf("%w: sex must be male or female", ErrInvalidJLO)
+	ErrInvalidJLOResponse = fmt.Errorf("%w: invalid JLO response", ErrInvalidJLO)
+)
+
+type JLOResponse struct {
+	Item          int    `json:"item"`
+	PresentedAtMs int64  `json:"presentedAtMs"`
+	RespondedAtMs int64  `json:"respondedAtMs"`
+	Selected      [2]int `json:"selected"` // líneas elegidas (1–11); 0 = sin respuesta
+}
+
+type JLOItemResult struct {
+	Item           int   `json:"item"`
+	Correct        bool  `json:"correct"`
+	Partial        bool  `json:"partial"` // solo una de las dos líneas correcta
+	ResponseTimeMs int64 `json:"responseTimeMs"`
+}
+
+type JLOSubtest struct {
+	PK                string        `json:"pk"`
+	EvaluationID      string        `json:"evaluationId"`
+	Form              JLOForm       `json:"form"`
+	Sex               Sex           `json:"sex"`
+	Responses         []JLOResponse `json:"responses"`
+	Score             JLOScore      `json:"score"`
+	AssistantAnalysis string        `json:"assistantAnalysis"`
+	CreatedAt         time.Time     `json:"createdAt"`
+}
+
+type JLOScore struct {
+	Score            int               `json:"score"`      // puntuación corregida (0..30)
+	RawCorrect       int               `json:"rawCorrect"` // aciertos sobre los ítems de la forma
+	Prorated         int               `json:"prorated"`   // equivalente en 30 ítems
+	AgeCorrection    int               `json:"ageCorrection"`
+	SexCorrection    int               `json:"sexCorrection"`
+	PartialResponses int               `json:"partialResponses"`
+	Omissions        int               `json:"omissions"`
+	MedianResponseMs int64             `json:"medianResponseMs"`
+	Classification   JLOClassification `json:"classification"`
+	Items            []JLOItemResult   `json:"items"`
+	ScoringProfile   string            `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewJLOSubtest(evaluationID string, form JLOForm, sex Sex, responses []JLOResponse) (*JLOSubtest, error) {
+	if evaluationID == "" || len(responses) == 0 {
+		return nil, ErrInvalidJLO
+	}
+	if form != JLOFormFull && form != JLOFormOdd && form != JLOFormEven {
+		return nil, ErrInvalidJLOForm
+	}
+	if sex != SexMale && sex != SexFemale {
+		return nil, ErrInvalidSex
+	}
+	allowed := map[int]bool{}
+	for _, i := range formItems(form) {
+		allowed[i] = true
+	}
+	seen := map[int]bool{}
+	for _, r := range responses {
+		if !allowed[r.Item] || seen[r.Item] || r.RespondedAtMs < r.PresentedAtMs {
+			return nil, ErrInvalidJLOResponse
+		}
+		seen[r.Item] = true
+		for _, l := range r.Selected {
+			if l != 0 && (l < JLOMinLine || l > JLOMaxLine) {
+				return nil, ErrInvalidJLOResponse
+			}
+		}
+	}
+	return &JLOSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Form:              form,
+		Sex:               sex,
+		Responses:         responses,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+// ScoreJLO puntúa contra la clave y aplica las correcciones de Benton por edad y sexo.
+// Los ítems de la forma no presentados cuentan como omisión.
+func ScoreJLO(sub JLOSubtest, patientAge int) (JLOScore, error) {
+	if len(sub.Responses) == 0 {
+		return JLOScore{}, fmt.Errorf("%w: responses vacío", ErrInvalidJLO)
+	}
+	byItem := map[int]JLOResponse{}
+	for _, r := range sub.Responses {
+		byItem[r.Item] = r
+	}
+
+	var out JLOScore
+	var rts []int64
+	items := formItems(sub.Form)
+	for _, item := range items {
+		r, ok := byItem[item]
+		res := JLOItemResult{Item: item}
+		if !ok || (r.Selected[0] == 0 && r.Selected[1] == 0) {
+			out.Omissions++
+			out.Items = append(out.Items, res)
+			continue
+		}
+		key := jloAnswerKey[item]
+		hits := 0
+		for _, l := range uniqueLines(r.Selected) {
+			if l == key[0] || l == key[1] {
+				hits++
+			}
+		}
+		res.Correct = hits == 2
+		res.Partial = hits == 1
+		res.ResponseTimeMs = r.RespondedAtMs - r.PresentedAtMs
+		rts = append(rts, res.ResponseTimeMs)
+		if res.Correct {
+			out.RawCorrect++
+		}
+		if res.Partial {
+			out.PartialResponses++
+		}
+		out.Items = append(out.Items, res)
+	}
+
+	out.Prorated = int(math.Round(float64(out.RawCorrect) * JLOItemsFull / float64(len(items))))
+	out.AgeCorrection = jloAgeCorrection(patientAge)
+	if sub.Sex == SexFemale {
+		out.SexCorrection = 2
+	}
+	out.Score = min(JLOItemsFull, out.Prorated+out.AgeCorrection+out.SexCorrection)
+	out.Classification = classifyJLO(out.Score)
+	out.MedianResponseMs = median(rts)
+	return out, nil
+}
+
+// Benton (1983): +1 a los 50–64 y +3 a los 65–74; ampliación a ≥75 con +4
+func jloAgeCorrection(age int) int {
+	switch {
+	case age >= 75:
+		return 4
+	case age >= 65:
+		return 3
+	case age >= 50:
+		return 1
+	}
+	return 0
+}
+
+func classifyJLO(score int) JLOClassification {
+	switch {
+	case score >= 29:
+		return JLOSuperior
+	case score >= 27:
+		return JLOHighAverage
+	case score >= 25:
+		return JLOAverage
+	case score >= 23:
+		return JLOLowAverage
+	case score >= 21:
+		return JLOBorderline
+	case score >= 18:
+		return JLOModeratelyDefective
+	}
+	return JLOSeverelyDefective
+}
+
+func uniqueLines(sel [2]int) []int {
+	if sel[0] == sel[1] {
+		return []int{sel[0]}
+	}
+	return sel[:]
+}
+
+func median(xs []int64) int64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	s := append([]int64{}, xs...)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
@@ -0,0 +1,34 @@
+package JLOdomain
+
+// Clave de respuestas de las 30 láminas que presenta el frontend (Forma V).
+// Cada ítem muestra dos segmentos y el paciente elige las dos líneas (1–11)
+// del abanico de respuesta con la misma orientación. El orden no importa.
+var jloAnswerKey = map[int][2]int{
+	1: {1, 6}, 2: {4, 11}, 3: {3, 9}, 4: {2, 7}, 5: {5, 10},
+	6: {6, 8}, 7: {1, 11}, 8: {4, 9}, 9: {2, 5}, 10: {7, 10},
+	11: {3, 8}, 12: {6, 11}, 13: {1, 4}, 14: {5, 9}, 15: {2, 10},
+	16: {8, 11}, 17: {3, 6}, 18: {1, 9}, 19: {4, 7}, 20: {5, 11},
+	21: {2, 8}, 22: {6, 9}, 23: {3, 10}, 24: {1, 7}, 25: {4, 5},
+	26: {9, 11}, 27: {2, 6}, 28: {7, 8}, 29: {3, 11}, 30: {1, 10},
+}
+
+const (
+	JLOItemsFull  = 30
+	JLOItemsShort = 15
+	JLOMinLine    = 1
+	JLOMaxLine    = 11
+)
+
+// formItems devuelve los ítems puntuables de cada forma (formas cortas par/impar de Woodard)
+func formItems(form JLOForm) []int {
+	var items []int
+	for i := 1; i <= JLOItemsFull; i++ {
+		switch {
+		case form == JLOFormFull,
+			form == JLOFormOdd && i%2 == 1,
+			form == JLOFormEven && i%2 == 0:
+			items = append(items, i)
+		}
+	}
+	return items
+}
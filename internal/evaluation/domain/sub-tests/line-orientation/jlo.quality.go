@@ -0,0 +1,20 @@
+package JLOdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: juzgar la orientación de dos líneas exige al menos ~1 s por ítem
+func (s JLOSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("judgment_of_line_orientation", s.PK)
+	if s.Score.MedianResponseMs > 0 && s.Score.MedianResponseMs < 1000 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "responses", "median response %d ms is implausibly fast", s.Score.MedianResponseMs)
+	}
+	switch {
+	case s.Score.RawCorrect == 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "score", "no correct items")
+	case s.Score.Prorated >= 30:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "score", "all items correct")
+	}
+	return c.Flags
+}
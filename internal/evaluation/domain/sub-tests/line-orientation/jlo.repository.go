@@ -0,0 +1,9 @@
+package JLOdomain
+
+import "context"
+
+type JLORepository interface {
+	Save(ctx context.Context, subtest *JLOSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (JLOSubtest, error)
+}
@@ -0,0 +1,180 @@
+package MOCAdomain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Versiones alternativas: mismos ítems y puntuación, cambian los estímulos
+// (palabras de memoria, dígitos, letra de fluencia...) para evitar el efecto de aprendizaje.
+type MoCAVersion string
+
+const (
+	MoCAVersion71 MoCAVersion = "7.1"
+	MoCAVersion72 MoCAVersion = "7.2"
+	MoCAVersion73 MoCAVersion = "7.3"
+)
+
+var FluencyLetter = map[MoCAVersion]string{
+	MoCAVersion71: "F",
+	MoCAVersion72: "B",
+	MoCAVersion73: "S",
+}
+
+// Origen de los ítems reutilizables
+type ItemSource string
+
+const (
+	SourceEntered         ItemSource = "entered"
+	SourceClockDrawing    ItemSource = "clock_drawing_test"
+	SourceLanguageFluency ItemSource = "language_fluency"
+)
+
+var (
+	ErrInvalidMoCA        = errors.New("invalid MoCA input")
+	ErrInvalidVersion     = fmt.Errorf("%w: invalid MoCA version", ErrInvalidMoCA)
+	ErrInvalidItem        = fmt.Errorf("%w: invalid MoCA item", ErrInvalidMoCA)
+	ErrMissingClockItem   = fmt.Errorf("%w: clock item not entered and no clock drawing test available", ErrInvalidMoCA)
+	ErrMissingFluencyItem = fmt.Errorf("%w: fluency item not entered and no phonemic fluency subtest available", ErrInvalidMoCA)
+)
+
+type MoCAClock struct {
+	Contour bool `json:"contour"`
+	Numbers bool `json:"numbers"`
+	Hands   bool `json:"hands"`
+}
+
+func (c MoCAClock) points() int {
+	return b2i(c.Contour) + b2i(c.Numbers) + b2i(c.Hands)
+}
+
+// ClockFromCDT aproxima los tres puntos del reloj MoCA desde el CDT de Shulman (0–5, 5 = perfecto):
+// 5 → 3; 3–4 (errores de espaciado u hora) → contorno y números; 1–2 (desorganización) → contorno; 0 → 0.
+func ClockFromCDT(score int) MoCAClock {
+	switch {
+	case score >= 5:
+		return MoCAClock{Contour: true, Numbers: true, Hands: true}
+	case score >= 3:
+		return MoCAClock{Contour: true, Numbers: true}
+	case score >= 1:
+		return MoCAClock{Contour: true}
+	}
+	return MoCAClock{}
+}
+
+type MoCADelayedRecall struct {
+	Free           int `json:"free"`           // palabras sin clave (puntúan)
+	CategoryCued   int `json:"categoryCued"`   // con clave de categoría (sólo índice de memoria)
+	MultipleChoice int `json:"multipleChoice"` // con elección múltiple (sólo índice de memoria)
+}
+
+type MoCAItems struct {
+	Trails              int               `json:"trails"` // 0..1
+	Cube                int               `json:"cube"`   // 0..1
+	Clock               *MoCAClock        `json:"clock,omitempty"`
+	Naming              int               `json:"naming"`          // 0..3
+	ImmediateRecall     [2]int            `json:"immediateRecall"` // palabras en cada ensayo (no puntúa)
+	DigitsForward       bool              `json:"digitsForward"`
+	DigitsBackward      bool              `json:"digitsBackward"`
+	LetterTappingErrors int               `json:"letterTappingErrors"`
+	Serial7Correct      int               `json:"serial7Correct"`   // 0..5 restas correctas
+	SentencesCorrect    int               `json:"sentencesCorrect"` // 0..2
+	FluencyWords        *int              `json:"fluencyWords,omitempty"`
+	Abstraction         int               `json:"abstraction"` // 0..2
+	DelayedRecall       MoCADelayedRecall `json:"delayedRecall"`
+	Orientation         int               `json:"orientation"` // 0..6
+}
+
+type MoCASubtest struct {
+	PK                string      `json:"pk"`
+	EvaluationID      string      `json:"evaluationId"`
+	Version           MoCAVersion `json:"version"`
+	EducationYears    int         `json:"educationYears"`
+	Items             MoCAItems   `json:"items"`
+	ClockSource       ItemSource  `json:"clockSource"`
+	FluencySource     ItemSource  `json:"fluencySource"`
+	Score             MoCAScore   `json:"score"`
+	AssistantAnalysis string      `json:"assistantAnalysis"`
+	CreatedAt         time.Time   `json:"createdAt"`
+}
+
+func NewMoCASubtest(evaluationID string, version MoCAVersion, educationYears int, items MoCAItems) (*MoCASubtest, error) {
+	if evaluationID == "" || educationYears < 0 {
+		return nil, ErrInvalidMoCA
+	}
+	if _, ok := FluencyLetter[version]; !ok {
+		return nil, ErrInvalidVersion
+	}
+	if err := items.validate(); err != nil {
+		return nil, err
+	}
+	s := &MoCASubtest{
+		PK:             uuid.NewString(),
+		EvaluationID:   evaluationID,
+		Version:        version,
+		EducationYears: educationYears,
+		Items:          items,
+		CreatedAt:      time.Now(),
+	}
+	if items.Clock != nil {
+		s.ClockSource = SourceEntered
+	}
+	if items.FluencyWords != nil {
+		s.FluencySource = SourceEntered
+	}
+	return s, nil
+}
+
+// UseClock / UseFluency completan los ítems reutilizados de otros subtests
+func (s *MoCASubtest) UseClock(clock MoCAClock, source ItemSource) {
+	s.Items.Clock = &clock
+	s.ClockSource = source
+}
+
+func (s *MoCASubtest) UseFluency(words int, source ItemSource) {
+	s.Items.FluencyWords = &words
+	s.FluencySource = source
+}
+
+func (it MoCAItems) validate() error {
+	check := func(name string, v, max int) error {
+		if v < 0 || v > max {
+			return fmt.Errorf("%w: %s must be between 0 and %d", ErrInvalidItem, name, max)
+		}
+		return nil
+	}
+	dr := it.DelayedRecall
+	for _, err := range []error{
+		check("trails", it.Trails, 1),
+		check("cube", it.Cube, 1),
+		check("naming", it.Naming, 3),
+		check("immediate recall trial 1", it.ImmediateRecall[0], 5),
+		check("immediate recall trial 2", it.ImmediateRecall[1], 5),
+		check("serial 7", it.Serial7Correct, 5),
+		check("sentences", it.SentencesCorrect, 2),
+		check("abstraction", it.Abstraction, 2),
+		check("orientation", it.Orientation, 6),
+		check("delayed recall", dr.Free+dr.CategoryCued+dr.MultipleChoice, 5),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	if it.LetterTappingErrors < 0 || dr.Free < 0 || dr.CategoryCued < 0 || dr.MultipleChoice < 0 {
+		return fmt.Errorf("%w: negative count", ErrInvalidItem)
+	}
+	if it.FluencyWords != nil && *it.FluencyWords < 0 {
+		return fmt.Errorf("%w: fluency words", ErrInvalidItem)
+	}
+	return nil
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
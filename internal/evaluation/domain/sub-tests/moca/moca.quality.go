@@ -0,0 +1,35 @@
+package MOCAdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags revisa techo/suelo del cribado
+func (s MoCASubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("moca", s.PK)
+	switch {
+	case s.Score.Total >= 30:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "total", "MoCA at maximum: screening may miss mild deficits")
+	case s.Score.Total == 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "total", "MoCA total 0")
+	}
+	return c.Flags
+}
+
+// MismatchFlags contrasta los ítems anotados por el evaluador con los subtests de la tableta
+// (CDT de Shulman y span directo); nil si no hay con qué comparar
+func (s MoCASubtest) MismatchFlags(cdtScore, forwardSpan *int) []DQdomain.Flag {
+	c := DQdomain.NewChecker("moca", s.PK)
+	if cdtScore != nil && s.ClockSource == SourceEntered && s.Items.Clock != nil {
+		entered, device := s.Items.Clock.points(), ClockFromCDT(*cdtScore).points()
+		if diff := entered - device; diff >= 2 || diff <= -2 {
+			c.Add(DQdomain.CodeExaminerDeviceMismatch, DQdomain.SeverityWarning, "clock", "clock entered as %d/3 but clock drawing test scores %d/5", entered, *cdtScore)
+		}
+	}
+	if forwardSpan != nil {
+		if (s.Items.DigitsForward && *forwardSpan < 5) || (!s.Items.DigitsForward && *forwardSpan >= 6) {
+			c.Add(DQdomain.CodeExaminerDeviceMismatch, DQdomain.SeverityWarning, "digitsForward", "digits forward entered as %t but digit span forward reached %d", s.Items.DigitsForward, *forwardSpan)
+		}
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package MOCAdomain
+
+import "context"
+
+type MoCARepository interface {
+	Save(ctx context.Context, subtest *MoCASubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (MoCASubtest, error)
+}
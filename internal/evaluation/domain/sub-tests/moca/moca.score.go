@@ -0,0 +1,113 @@
+package MOCAdomain
+
+const (
+	MoCAMaxScore    = 30
+	MoCANormalScore = 26
+)
+
+// Puntuación por secciones de la hoja MoCA
+type MoCASections struct {
+	VisuospatialExecutive int `json:"visuospatialExecutive"` // 0..5
+	Naming                int `json:"naming"`                // 0..3
+	Attention             int `json:"attention"`             // 0..6
+	Language              int `json:"language"`              // 0..3
+	Abstraction           int `json:"abstraction"`           // 0..2
+	DelayedRecall         int `json:"delayedRecall"`         // 0..5
+	Orientation           int `json:"orientation"`           // 0..6
+}
+
+// Índices por dominio (Julayanont et al., 2014)
+type MoCAIndexes struct {
+	Memory       int `json:"memory"`       // MIS 0..15: libre×3 + clave×2 + elección×1
+	Executive    int `json:"executive"`    // EIS 0..13
+	Attention    int `json:"attention"`    // AIS 0..18
+	Language     int `json:"language"`     // LIS 0..6
+	Visuospatial int `json:"visuospatial"` // VIS 0..7
+	Orientation  int `json:"orientation"`  // OIS 0..6
+}
+
+type MoCAScore struct {
+	Total          int          `json:"total"` // 0..30 con el punto de escolaridad
+	RawTotal       int          `json:"rawTotal"`
+	EducationPoint int          `json:"educationPoint"` // +1 con ≤12 años de escolaridad
+	Classification string       `json:"classification"` // normal | mild | moderate | severe
+	Sections       MoCASections `json:"sections"`
+	Indexes        MoCAIndexes  `json:"indexes"`
+	ScoringProfile string       `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func serial7Points(correct int) int {
+	switch {
+	case correct >= 4:
+		return 3
+	case correct >= 2:
+		return 2
+	case correct == 1:
+		return 1
+	}
+	return 0
+}
+
+// FluencyPoint: ≥11 palabras con la letra en 60 s
+func FluencyPoint(words int) int {
+	return b2i(words >= 11)
+}
+
+func classifyMoCA(total int) string {
+	switch {
+	case total >= MoCANormalScore:
+		return "normal"
+	case total >= 18:
+		return "mild"
+	case total >= 10:
+		return "moderate"
+	}
+	return "severe"
+}
+
+// ScoreMoCA exige que el reloj y la fluencia estén ya completados (introducidos o reutilizados)
+func ScoreMoCA(s MoCASubtest) (MoCAScore, error) {
+	it := s.Items
+	if it.Clock == nil {
+		return MoCAScore{}, ErrMissingClockItem
+	}
+	if it.FluencyWords == nil {
+		return MoCAScore{}, ErrMissingFluencyItem
+	}
+	clock := it.Clock.points()
+	digits := b2i(it.DigitsForward) + b2i(it.DigitsBackward)
+	letters := b2i(it.LetterTappingErrors < 2)
+	serial7 := serial7Points(it.Serial7Correct)
+	fluency := FluencyPoint(*it.FluencyWords)
+	dr := it.DelayedRecall
+
+	sec := MoCASections{
+		VisuospatialExecutive: it.Trails + it.Cube + clock,
+		Naming:                it.Naming,
+		Attention:             digits + letters + serial7,
+		Language:              it.SentencesCorrect + fluency,
+		Abstraction:           it.Abstraction,
+		DelayedRecall:         dr.Free,
+		Orientation:           it.Orientation,
+	}
+	raw := sec.VisuospatialExecutive + sec.Naming + sec.Attention + sec.Language + sec.Abstraction + sec.DelayedRecall + sec.Orientation
+
+	out := MoCAScore{
+		RawTotal: raw,
+		Sections: sec,
+		Indexes: MoCAIndexes{
+			Memory:       dr.Free*3 + dr.CategoryCued*2 + dr.MultipleChoice,
+			Executive:    it.Trails + clock + digits + letters + serial7 + fluency + it.Abstraction,
+			Attention:    digits + letters + serial7 + it.SentencesCorrect + it.ImmediateRecall[0] + it.ImmediateRecall[1],
+			Language:     it.Naming + it.SentencesCorrect + fluency,
+			Visuospatial: it.Cube + clock + it.Naming,
+			Orientation:  it.Orientation,
+		},
+	}
+	if s.EducationYears <= 12 && raw < MoCAMaxScore {
+		out.EducationPoint = 1
+	}
+	out.Total = raw + out.EducationPoint
+	out.Classification = classifyMoCA(out.Total)
+	return out, nil
+}
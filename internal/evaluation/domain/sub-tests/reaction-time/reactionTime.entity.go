@@ -0,0 +1,224 @@
+package RTdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type RTCondition string
+
+const (
+	RTSimple RTCondition = "simple"
+	RTChoice RTCondition = "choice" // dos alternativas (izquierda / derecha)
+)
+
+const (
+	MaxRTTrials = 200
+	// Respuestas más rápidas que esto no pueden ser reacción al estímulo
+	AnticipationMs = 100
+	// Umbral de lapso por condición (o ausencia de respuesta)
+	SimpleLapseMs = 1000
+	ChoiceLapseMs = 1500
+	// z ≥ 1.5 en TR simple se considera enlentecimiento motor
+	MotorSlowingZ = 1.5
+)
+
+var (
+	ErrInvalidReactionTime = errors.New("invalid reaction time input")
+	ErrInvalidRTCondition  = fmt.Errorf("%w: condition must be simple or choice", ErrInvalidReactionTime)
+	ErrInvalidRTTrial      = fmt.Errorf("%w: response must come after stimulus; choice trials need stimulus side", ErrInvalidReactionTime)
+)
+
+type RTTrial struct {
+	Condition    RTCondition `json:"condition"`
+	Stimulus     string      `json:"stimulus,omitempty"` // lado en elección: left | right
+	Response     string      `json:"response,omitempty"`
+	StimulusAtMs int64       `json:"stimulusAtMs"` // reloj de la tableta
+	ResponseAtMs int64       `json:"responseAtMs"` // 0 = sin respuesta
+}
+
+type ReactionTimeSubtest struct {
+	PK                string            `json:"pk"`
+	EvaluationID      string            `json:"evaluationId"`
+	Trials            []RTTrial         `json:"trials"`
+	Score             ReactionTimeScore `json:"score"`
+	AssistantAnalysis string            `json:"assistantAnalysis"`
+	CreatedAt         time.Time         `json:"createdAt"`
+}
+
+type RTConditionScore struct {
+	Present       bool    `json:"present"`
+	Trials        int     `json:"trials"`
+	Valid         int     `json:"valid"` // sin anticipación, sin lapso y correctos
+	MedianMs      float64 `json:"medianMs"`
+	MeanMs        float64 `json:"meanMs"`
+	SDMs          float64 `json:"sdMs"`
+	CV            float64 `json:"cv"` // variabilidad intraindividual (SD / media)
+	Anticipations int     `json:"anticipations"`
+	Lapses        int     `json:"lapses"`
+	Errors        int     `json:"errors"` // solo elección: lado equivocado
+}
+
+// MotorSpeedCovariate resume la velocidad motora (TR simple frente a normas por edad)
+// para interpretar las pruebas cronometradas.
+type MotorSpeedCovariate struct {
+	Available      bool    `json:"available"`
+	SimpleMedianMs float64 `json:"simpleMedianMs"`
+	ZScore         float64 `json:"zScore"` // positivo = más lento que lo esperado
+	Slowed         bool    `json:"slowed"`
+	DecisionTimeMs float64 `json:"decisionTimeMs"` // mediana elección − mediana simple
+}
+
+type ReactionTimeScore struct {
+	Score          int                 `json:"score"` // 0..100 (100 − percentil de lentitud del TR simple)
+	Simple         RTConditionScore    `json:"simple"`
+	Choice         RTConditionScore    `json:"choice"`
+	MotorSpeed     MotorSpeedCovariate `json:"motorSpeed"`
+	ScoringProfile string              `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewReactionTimeSubtest(evaluationID string, trials []RTTrial) (*ReactionTimeSubtest, error) {
+	if evaluationID == "" || len(trials) == 0 || len(trials) > MaxRTTrials {
+		return nil, ErrInvalidReactionTime
+	}
+	for _, t := range trials {
+		switch t.Condition {
+		case RTSimple:
+		case RTChoice:
+			if t.Stimulus == "" {
+				return nil, ErrInvalidRTTrial
+			}
+		default:
+			return nil, ErrInvalidRTCondition
+		}
+		if t.StimulusAtMs < 0 || (t.ResponseAtMs != 0 && t.ResponseAtMs < t.StimulusAtMs) {
+			return nil, ErrInvalidRTTrial
+		}
+	}
+	return &ReactionTimeSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Trials:            trials,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func ScoreReactionTime(sub ReactionTimeSubtest, patientAge int) (ReactionTimeScore, error) {
+	if len(sub.Trials) == 0 {
+		return ReactionTimeScore{}, fmt.Errorf("%w: trials vacío", ErrInvalidReactionTime)
+	}
+	simple := scoreCondition(sub.Trials, RTSimple, SimpleLapseMs)
+	choice := scoreCondition(sub.Trials, RTChoice, ChoiceLapseMs)
+
+	out := ReactionTimeScore{Simple: simple, Choice: choice}
+	if simple.Valid == 0 {
+		return out, nil
+	}
+	norm := simpleRTNormForAge(patientAge)
+	z := round2((simple.MedianMs - norm.Mean) / norm.SD)
+	out.MotorSpeed = MotorSpeedCovariate{
+		Available:      true,
+		SimpleMedianMs: simple.MedianMs,
+		ZScore:         z,
+		Slowed:         z >= MotorSlowingZ,
+	}
+	if choice.Valid > 0 {
+		out.MotorSpeed.DecisionTimeMs = round2(choice.MedianMs - simple.MedianMs)
+	}
+	out.Score = int(math.Round(100 - utils.ZToPercentile(z)))
+	return out, nil
+}
+
+func scoreCondition(trials []RTTrial, condition RTCondition, lapseMs int64) RTConditionScore {
+	var out RTConditionScore
+	var rts []float64
+	for _, t := range trials {
+		if t.Condition != condition {
+			continue
+		}
+		out.Present = true
+		out.Trials++
+		if t.ResponseAtMs == 0 {
+			out.Lapses++
+			continue
+		}
+		rt := t.ResponseAtMs - t.StimulusAtMs
+		switch {
+		case rt < AnticipationMs:
+			out.Anticipations++
+		case rt > lapseMs:
+			out.Lapses++
+		case condition == RTChoice && t.Response != t.Stimulus:
+			out.Errors++
+		default:
+			rts = append(rts, float64(rt))
+		}
+	}
+	out.Valid = len(rts)
+	if out.Valid == 0 {
+		return out
+	}
+	sort.Float64s(rts)
+	mid := len(rts) / 2
+	if len(rts)%2 == 0 {
+		out.MedianMs = (rts[mid-1] + rts[mid]) / 2
+	} else {
+		out.MedianMs = rts[mid]
+	}
+	var sum float64
+	for _, v := range rts {
+		sum += v
+	}
+	mean := sum / float64(len(rts))
+	var ss float64
+	for _, v := range rts {
+		ss += (v - mean) * (v - mean)
+	}
+	if len(rts) > 1 {
+		out.SDMs = round2(math.Sqrt(ss / float64(len(rts)-1)))
+	}
+	out.MeanMs = round2(mean)
+	out.CV = round2(out.SDMs / mean)
+	return out
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+/* ====== Normas orientativas (mediana de TR simple, ms) ====== */
+
+type normStat struct {
+	Mean float64
+	SD   float64
+}
+
+type rtNorm struct {
+	MaxAge int
+	Simple normStat
+}
+
+// Valores de referencia por franja de edad (guía clínica no diagnóstica)
+var simpleRTNorms = []rtNorm{
+	{MaxAge: 39, Simple: normStat{260, 40}},
+	{MaxAge: 59, Simple: normStat{290, 45}},
+	{MaxAge: 69, Simple: normStat{320, 55}},
+	{MaxAge: 79, Simple: normStat{350, 65}},
+	{MaxAge: math.MaxInt, Simple: normStat{390, 75}},
+}
+
+func simpleRTNormForAge(age int) normStat {
+	for _, n := range simpleRTNorms {
+		if age <= n.MaxAge {
+			return n.Simple
+		}
+	}
+	return simpleRTNorms[len(simpleRTNorms)-1].Simple
+}
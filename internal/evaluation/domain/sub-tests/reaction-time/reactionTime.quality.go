@@ -0,0 +1,30 @@
+package RTdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: medianas por debajo de ~150 ms no son reacciones a estímulo sino anticipaciones
+func (s ReactionTimeSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("motor_speed", s.PK)
+	for _, cond := range []struct {
+		name  string
+		score RTConditionScore
+	}{{"simple", s.Score.Simple}, {"choice", s.Score.Choice}} {
+		sc := cond.score
+		if !sc.Present {
+			continue
+		}
+		if sc.Valid == 0 {
+			c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, cond.name, "%s: no valid trials", cond.name)
+			continue
+		}
+		if sc.MedianMs < 150 {
+			c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, cond.name, "%s: median RT %.0f ms is implausibly fast", cond.name, sc.MedianMs)
+		}
+		if sc.Trials > 0 && float64(sc.Anticipations)/float64(sc.Trials) > 0.3 {
+			c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, cond.name, "%s: %d of %d trials are anticipations", cond.name, sc.Anticipations, sc.Trials)
+		}
+	}
+	return c.Flags
+}
@@ -0,0 +1,9 @@
+package RTdomain
+
+import "context"
+
+type ReactionTimeRepository interface {
+	Save(ctx context.Context, subtest *ReactionTimeSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (ReactionTimeSubtest, error)
+}
@@ -0,0 +1,217 @@
+package SDMTdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type SDMTMode string
+
+const (
+	SDMTWritten SDMTMode = "written"
+	SDMTOral    SDMTMode = "oral"
+)
+
+const (
+	SDMTTimeLimitMs  = 90_000
+	SDMTIntervalMs   = 30_000
+	MaxSDMTItems     = 110 // ítems de la hoja estándar
+	sdmtIntervalsNum = SDMTTimeLimitMs / SDMTIntervalMs
+)
+
+var (
+	ErrInvalidSDMT         = errors.New("invalid SDMT input")
+	ErrInvalidSDMTMode     = fmt.Errorf("%w: mode must be written or oral", ErrInvalidSDMT)
+	ErrInvalidSDMTResponse = fmt.Errorf("%w: expected and given digits must be between 1 and 9 with non-decreasing timestamps", ErrInvalidSDMT)
+	ErrNoSDMTDigits        = fmt.Errorf("%w: no digits could be recognised in the oral response", ErrInvalidSDMT)
+)
+
+type SDMTResponse struct {
+	Item      int  `json:"item"`      // posición en la hoja (0-based)
+	Expected  int  `json:"expected"`  // dígito correcto según la clave
+	Given     int  `json:"given"`     // dígito respondido
+	ElapsedMs int  `json:"elapsedMs"` // desde el inicio de la prueba; 0 si no hay marca temporal
+	Correct   bool `json:"correct"`   // calculado en servidor
+}
+
+type SDMTSubtest struct {
+	PK                string         `json:"pk"`
+	EvaluationID      string         `json:"evaluationId"`
+	Mode              SDMTMode       `json:"mode"`
+	Responses         []SDMTResponse `json:"responses"`
+	Score             SDMTScore      `json:"score"`
+	AssistantAnalysis string         `json:"assistantAnalysis"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+type SDMTInterval struct {
+	StartSec  int `json:"startSec"`
+	EndSec    int `json:"endSec"`
+	Correct   int `json:"correct"`
+	Attempted int `json:"attempted"`
+}
+
+type SDMTScore struct {
+	Score          int            `json:"score"`     // 0..100 (percentil normativo redondeado)
+	Correct        int            `json:"correct"`   // respuestas correctas en 90 s
+	Errors         int            `json:"errors"`    // respuestas incorrectas en 90 s
+	Attempted      int            `json:"attempted"` // correct + errors
+	Intervals      []SDMTInterval `json:"intervals,omitempty"`
+	ZScore         float64        `json:"zScore"`
+	Percentile     float64        `json:"percentile"`
+	ScoringProfile string         `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewSDMTSubtest(evaluationID string, mode SDMTMode, responses []SDMTResponse) (*SDMTSubtest, error) {
+	if evaluationID == "" || len(responses) == 0 || len(responses) > MaxSDMTItems {
+		return nil, ErrInvalidSDMT
+	}
+	if mode != SDMTWritten && mode != SDMTOral {
+		return nil, ErrInvalidSDMTMode
+	}
+	out := make([]SDMTResponse, 0, len(responses))
+	lastMs := 0
+	for i, r := range responses {
+		if r.Expected < 1 || r.Expected > 9 || r.Given < 1 || r.Given > 9 || r.ElapsedMs < lastMs {
+			return nil, ErrInvalidSDMTResponse
+		}
+		lastMs = r.ElapsedMs
+		r.Item = i
+		r.Correct = r.Given == r.Expected
+		out = append(out, r)
+	}
+	return &SDMTSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Mode:              mode,
+		Responses:         out,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+// ResponsesFromSpokenDigits alinea en orden los dígitos transcritos con la clave
+// esperada. La transcripción no trae marcas temporales, así que ElapsedMs queda a 0.
+func ResponsesFromSpokenDigits(expected []int, spoken []int) ([]SDMTResponse, error) {
+	if len(spoken) == 0 {
+		return nil, ErrNoSDMTDigits
+	}
+	n := min(len(spoken), len(expected))
+	out := make([]SDMTResponse, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, SDMTResponse{Item: i, Expected: expected[i], Given: spoken[i]})
+	}
+	return out, nil
+}
+
+var spokenDigits = map[string]int{
+	"uno": 1, "un": 1, "una": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5,
+	"seis": 6, "siete": 7, "ocho": 8, "nueve": 9,
+}
+
+// ParseSpokenDigits extrae los dígitos 1..9 de una transcripción ("tres, 7 ocho" → 3 7 8).
+func ParseSpokenDigits(transcript string) []int {
+	text := strings.ToLower(utils.ReplaceAccentsES(transcript))
+	tokens := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var out []int
+	for _, tok := range tokens {
+		if d, ok := spokenDigits[tok]; ok {
+			out = append(out, d)
+			continue
+		}
+		for _, r := range tok {
+			if r >= '1' && r <= '9' {
+				out = append(out, int(r-'0'))
+			}
+		}
+	}
+	return out
+}
+
+func ScoreSDMT(sub SDMTSubtest, patientAge int) (SDMTScore, error) {
+	if len(sub.Responses) == 0 {
+		return SDMTScore{}, fmt.Errorf("%w: responses vacío", ErrInvalidSDMT)
+	}
+	var score SDMTScore
+	timed := false
+	intervals := make([]SDMTInterval, sdmtIntervalsNum)
+	for i := range intervals {
+		intervals[i] = SDMTInterval{StartSec: i * SDMTIntervalMs / 1000, EndSec: (i + 1) * SDMTIntervalMs / 1000}
+	}
+	for _, r := range sub.Responses {
+		if r.ElapsedMs > SDMTTimeLimitMs {
+			continue
+		}
+		if r.ElapsedMs > 0 {
+			timed = true
+		}
+		score.Attempted++
+		if r.Correct {
+			score.Correct++
+		} else {
+			score.Errors++
+		}
+		idx := min(r.ElapsedMs/SDMTIntervalMs, sdmtIntervalsNum-1)
+		intervals[idx].Attempted++
+		if r.Correct {
+			intervals[idx].Correct++
+		}
+	}
+	// Sin marcas temporales (p. ej. modo oral transcrito) no hay reparto por intervalos
+	if timed {
+		score.Intervals = intervals
+	}
+
+	norm := normsFor(sub.Mode, patientAge)
+	score.ZScore = math.Round((float64(score.Correct)-norm.Mean)/norm.SD*100) / 100
+	score.Percentile = math.Round(utils.ZToPercentile(score.ZScore)*10) / 10
+	score.Score = int(math.Round(score.Percentile))
+	return score, nil
+}
+
+/* ====== Normas orientativas (correctas en 90 s) ====== */
+
+type normStat struct {
+	Mean float64
+	SD   float64
+}
+
+type sdmtNorm struct {
+	MaxAge  int
+	Written normStat
+	Oral    normStat
+}
+
+// Valores de referencia por franja de edad (guía clínica no diagnóstica)
+var sdmtNorms = []sdmtNorm{
+	{MaxAge: 29, Written: normStat{55.2, 7.6}, Oral: normStat{62.5, 9.0}},
+	{MaxAge: 39, Written: normStat{53.6, 6.7}, Oral: normStat{61.2, 7.7}},
+	{MaxAge: 49, Written: normStat{51.1, 7.3}, Oral: normStat{57.3, 9.0}},
+	{MaxAge: 59, Written: normStat{46.8, 6.9}, Oral: normStat{51.6, 8.4}},
+	{MaxAge: 69, Written: normStat{41.0, 7.0}, Oral: normStat{46.6, 8.6}},
+	{MaxAge: 79, Written: normStat{35.7, 8.0}, Oral: normStat{40.1, 9.5}},
+	{MaxAge: math.MaxInt, Written: normStat{30.0, 8.0}, Oral: normStat{34.0, 9.5}},
+}
+
+func normsFor(mode SDMTMode, age int) normStat {
+	n := sdmtNorms[len(sdmtNorms)-1]
+	for _, candidate := range sdmtNorms {
+		if age <= candidate.MaxAge {
+			n = candidate
+			break
+		}
+	}
+	if mode == SDMTOral {
+		return n.Oral
+	}
+	return n.Written
+}
@@ -0,0 +1,17 @@
+package SDMTdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: más de 110 intentos en 90 s excede la hoja y el ritmo humano
+func (s SDMTSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("sdmt", s.PK)
+	if s.Score.Attempted > 110 {
+		c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, "responses", "%d responses in 90 s is implausibly fast", s.Score.Attempted)
+	}
+	if s.Score.Correct == 0 {
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "correct", "no correct responses")
+	}
+	return c.Flags
+}
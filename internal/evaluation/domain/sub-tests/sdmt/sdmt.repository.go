@@ -0,0 +1,9 @@
+package SDMTdomain
+
+import "context"
+
+type SDMTRepository interface {
+	Save(ctx context.Context, subtest *SDMTSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (SDMTSubtest, error)
+}
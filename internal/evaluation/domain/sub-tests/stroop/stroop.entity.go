@@ -0,0 +1,172 @@
+package STRdomain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"neuro.app.jordi/internal/evaluation/utils"
+)
+
+type StroopCondition string
+
+const (
+	StroopWord      StroopCondition = "word"
+	StroopColor     StroopCondition = "color"
+	StroopColorWord StroopCondition = "color_word"
+)
+
+const (
+	StroopTimeLimitSecs = 45
+	MaxStroopItems      = 100 // láminas de 100 estímulos (Golden)
+	// Desviación típica de referencia de la interferencia de Golden para la puntuación T
+	stroopInterferenceSD = 7.5
+)
+
+var (
+	ErrInvalidStroop          = errors.New("invalid stroop input")
+	ErrInvalidStroopCondition = fmt.Errorf("%w: stroop condition must be word, color or color_word", ErrInvalidStroop)
+	ErrInvalidStroopItems     = fmt.Errorf("%w: items completed must be between 0 and 100", ErrInvalidStroop)
+	ErrInvalidStroopErrors    = fmt.Errorf("%w: errors and self-corrections must be non-negative and not exceed items completed", ErrInvalidStroop)
+	ErrInvalidStroopRT        = fmt.Errorf("%w: response times must be positive and no more than items completed", ErrInvalidStroop)
+)
+
+type StroopConditionResult struct {
+	Condition       StroopCondition `json:"condition"`
+	ItemsCompleted  int             `json:"itemsCompleted"` // ítems en 45 s
+	Errors          int             `json:"errors"`
+	SelfCorrections int             `json:"selfCorrections"`
+	ItemTimesMs     []int           `json:"itemTimesMs,omitempty"` // opcional, tiempo de respuesta por ítem
+}
+
+type StroopSubtest struct {
+	PK                string                `json:"pk"`
+	EvaluationID      string                `json:"evaluationId"`
+	Word              StroopConditionResult `json:"word"`
+	Color             StroopConditionResult `json:"color"`
+	ColorWord         StroopConditionResult `json:"colorWord"`
+	Score             StroopScore           `json:"score"`
+	AssistantAnalysis string                `json:"assistantAnalysis"`
+	CreatedAt         time.Time             `json:"createdAt"`
+}
+
+type StroopScore struct {
+	Score                  int     `json:"score"`              // 0..100 derivado de la T de interferencia
+	PredictedColorWord     float64 `json:"predictedColorWord"` // PC' = (P × C) / (P + C)
+	Interference           float64 `json:"interference"`       // PC − PC' (negativo = más interferencia)
+	InterferenceT          float64 `json:"interferenceT"`
+	WordErrorRatio         float64 `json:"wordErrorRatio"` // errores / ítems completados
+	ColorErrorRatio        float64 `json:"colorErrorRatio"`
+	ColorWordErrorRatio    float64 `json:"colorWordErrorRatio"`
+	InterferenceErrorRatio float64 `json:"interferenceErrorRatio"` // ratio PC − ratio C
+	SelfCorrections        int     `json:"selfCorrections"`
+	WordMedianMs           int     `json:"wordMedianMs,omitempty"`
+	ColorMedianMs          int     `json:"colorMedianMs,omitempty"`
+	ColorWordMedianMs      int     `json:"colorWordMedianMs,omitempty"`
+	ScoringProfile         string  `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+func NewStroopSubtest(evaluationID string, word, color, colorWord StroopConditionResult) (*StroopSubtest, error) {
+	if evaluationID == "" {
+		return nil, ErrInvalidStroop
+	}
+	word.Condition, color.Condition, colorWord.Condition = StroopWord, StroopColor, StroopColorWord
+	for _, c := range []StroopConditionResult{word, color, colorWord} {
+		if err := validateCondition(c); err != nil {
+			return nil, err
+		}
+	}
+	if word.ItemsCompleted+color.ItemsCompleted == 0 {
+		return nil, fmt.Errorf("%w: word and color conditions cannot both be empty", ErrInvalidStroop)
+	}
+	return &StroopSubtest{
+		PK:                uuid.NewString(),
+		EvaluationID:      evaluationID,
+		Word:              word,
+		Color:             color,
+		ColorWord:         colorWord,
+		AssistantAnalysis: "",
+		CreatedAt:         time.Now().UTC(),
+	}, nil
+}
+
+func validateCondition(c StroopConditionResult) error {
+	switch c.Condition {
+	case StroopWord, StroopColor, StroopColorWord:
+	default:
+		return ErrInvalidStroopCondition
+	}
+	if c.ItemsCompleted < 0 || c.ItemsCompleted > MaxStroopItems {
+		return ErrInvalidStroopItems
+	}
+	if c.Errors < 0 || c.SelfCorrections < 0 || c.Errors > c.ItemsCompleted || c.SelfCorrections > c.ItemsCompleted {
+		return ErrInvalidStroopErrors
+	}
+	if len(c.ItemTimesMs) > c.ItemsCompleted {
+		return ErrInvalidStroopRT
+	}
+	for _, ms := range c.ItemTimesMs {
+		if ms <= 0 {
+			return ErrInvalidStroopRT
+		}
+	}
+	return nil
+}
+
+// ScoreStroop calcula la interferencia de Golden: PC' = (P × C) / (P + C), I = PC − PC'.
+func ScoreStroop(sub StroopSubtest) (StroopScore, error) {
+	w := float64(sub.Word.ItemsCompleted)
+	c := float64(sub.Color.ItemsCompleted)
+	if w+c == 0 {
+		return StroopScore{}, fmt.Errorf("%w: word and color conditions cannot both be empty", ErrInvalidStroop)
+	}
+	predicted := (w * c) / (w + c)
+	interference := float64(sub.ColorWord.ItemsCompleted) - predicted
+	t := 50 + 10*interference/stroopInterferenceSD
+
+	colorRatio := errorRatio(sub.Color)
+	colorWordRatio := errorRatio(sub.ColorWord)
+
+	return StroopScore{
+		// T 20 → 0, T 80 → 100
+		Score:                  int(math.Round(100 * utils.Clamp01((t-20)/60))),
+		PredictedColorWord:     round2(predicted),
+		Interference:           round2(interference),
+		InterferenceT:          round2(t),
+		WordErrorRatio:         errorRatio(sub.Word),
+		ColorErrorRatio:        colorRatio,
+		ColorWordErrorRatio:    colorWordRatio,
+		InterferenceErrorRatio: round2(colorWordRatio - colorRatio),
+		SelfCorrections:        sub.Word.SelfCorrections + sub.Color.SelfCorrections + sub.ColorWord.SelfCorrections,
+		WordMedianMs:           medianMs(sub.Word.ItemTimesMs),
+		ColorMedianMs:          medianMs(sub.Color.ItemTimesMs),
+		ColorWordMedianMs:      medianMs(sub.ColorWord.ItemTimesMs),
+	}, nil
+}
+
+func errorRatio(c StroopConditionResult) float64 {
+	if c.ItemsCompleted == 0 {
+		return 0
+	}
+	return round2(float64(c.Errors) / float64(c.ItemsCompleted))
+}
+
+func medianMs(times []int) int {
+	if len(times) == 0 {
+		return 0
+	}
+	sorted := append([]int{}, times...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,24 @@
+package STRdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// Más de ~3 ítems por segundo en 45 s no es compatible con lectura/denominación real
+const maxPlausibleItems = 140
+
+// QualityFlags revisa cada lámina del Stroop
+func (s StroopSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("stroop", s.PK)
+	for _, cond := range []StroopConditionResult{s.Word, s.Color, s.ColorWord} {
+		switch {
+		case cond.Errors > cond.ItemsCompleted:
+			c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, string(cond.Condition), "%s: errors (%d) exceed items completed (%d)", cond.Condition, cond.Errors, cond.ItemsCompleted)
+		case cond.ItemsCompleted > maxPlausibleItems:
+			c.Add(DQdomain.CodeImplausiblyFast, DQdomain.SeverityWarning, string(cond.Condition), "%s: %d items in 45 s is implausibly fast", cond.Condition, cond.ItemsCompleted)
+		case cond.ItemsCompleted == 0:
+			c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, string(cond.Condition), "%s: no items completed", cond.Condition)
+		}
+	}
+	return c.Flags
+}
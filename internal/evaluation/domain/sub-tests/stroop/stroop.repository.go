@@ -0,0 +1,9 @@
+package STRdomain
+
+import "context"
+
+type StroopRepository interface {
+	Save(ctx context.Context, subtest *StroopSubtest) error
+	// GetByEvaluationID devuelve el subtest vacío (PK == "") si no se administró
+	GetByEvaluationID(ctx context.Context, evaluationID string) (StroopSubtest, error)
+}
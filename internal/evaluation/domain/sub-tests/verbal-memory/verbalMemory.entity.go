@@ -2,6 +2,7 @@ package VEMdomain
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strings"
 	"time"
@@ -10,6 +11,8 @@ import (
 	"neuro.app.jordi/internal/evaluation/utils"
 )
 
+var ErrInvalidVerbalMemory = errors.New("invalid verbal memory input")
+
 const MaxVerbalMemoryWords = 100
 const MaxTimeSinceStart = 3600 // segundos (1 hora)
 const ImmediateThreshold = 300 // segundos (5 minutos)
@@ -42,18 +45,29 @@ type VerbalMemoryScore struct {
 	Accuracy          float64 `json:"accuracy"`       // hits / objetivos
 	IntrusionRate     float64 `json:"intrusionRate"`  // intrusions / max(1, len(recalled))
 	PerseverationRate float64 `json:"perseverationRate"`
+	ScoringProfile    string  `json:"scoringProfile"` // id@versión del perfil que produjo el score
+}
+
+type VerbalMemoryScoreConfig struct {
+	IntrusionPenalty     float64 `json:"intrusionPenalty"`     // default 0.5
+	PerseverationPenalty float64 `json:"perseverationPenalty"` // default 0.25
+	NormalizeWords       bool    `json:"normalizeWords"`       // default true
+}
+
+func DefaultVerbalMemoryScoreConfig() VerbalMemoryScoreConfig {
+	return VerbalMemoryScoreConfig{IntrusionPenalty: 0.5, PerseverationPenalty: 0.25, NormalizeWords: true}
 }
 
 func NewVerbalMemorySubtest(evaluationID string, startAt time.Time, givenWords, recalledWords []string, subTypeStr string) (VerbalMemorySubtest, error) {
 	if evaluationID == "" {
-		return VerbalMemorySubtest{}, errors.New("evaluationID es obligatorio")
+		return VerbalMemorySubtest{}, fmt.Errorf("%w: evaluationID es obligatorio", ErrInvalidVerbalMemory)
 	}
 	timeSinceStart := time.Since(startAt).Seconds()
 	if timeSinceStart < 0 || timeSinceStart > MaxTimeSinceStart {
-		return VerbalMemorySubtest{}, errors.New("startAt no puede ser en el futuro o más de 1 hora en el pasado")
+		return VerbalMemorySubtest{}, fmt.Errorf("%w: startAt no puede ser en el futuro o más de 1 hora en el pasado", ErrInvalidVerbalMemory)
 	}
 	if len(givenWords) == 0 || len(recalledWords) == 0 || len(givenWords) > MaxVerbalMemoryWords || len(recalledWords) > MaxVerbalMemoryWords {
-		return VerbalMemorySubtest{}, errors.New("givenWords y recalledWords no pueden estar vacíos y como maximo 100 palabras")
+		return VerbalMemorySubtest{}, fmt.Errorf("%w: givenWords y recalledWords no pueden estar vacíos y como maximo 100 palabras", ErrInvalidVerbalMemory)
 	}
 	var subType VerbalMemorySubtype
 	switch subTypeStr {
@@ -75,14 +89,18 @@ func NewVerbalMemorySubtest(evaluationID string, startAt time.Time, givenWords,
 	}, nil
 }
 
-func ScoreVerbalMemory(sub VerbalMemorySubtest) (VerbalMemoryScore, error) {
+// ScoreVerbalMemory puntúa con cfg; nil usa la configuración por defecto
+func ScoreVerbalMemory(sub VerbalMemorySubtest, cfg *VerbalMemoryScoreConfig) (VerbalMemoryScore, error) {
 	if len(sub.GivenWords) == 0 {
-		return VerbalMemoryScore{}, errors.New("given_words vacío")
+		return VerbalMemoryScore{}, fmt.Errorf("%w: given_words vacío", ErrInvalidVerbalMemory)
+	}
+	c := DefaultVerbalMemoryScoreConfig()
+	if cfg != nil {
+		c = *cfg
 	}
-	// cfg por defecto
-	ip := 0.5
-	pp := 0.25
-	norm := true
+	ip := c.IntrusionPenalty
+	pp := c.PerseverationPenalty
+	norm := c.NormalizeWords
 
 	// Normaliza
 	given := normalizeList(sub.GivenWords, norm)
@@ -0,0 +1,35 @@
+package VEMdomain
+
+import (
+	"strings"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags revisa las listas de palabras y, ya puntuado, el techo/suelo de aciertos
+func (s VerbalMemorySubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("verbal_memory", s.Pk)
+	given := len(s.GivenWords)
+	seen := map[string]bool{}
+	for _, w := range s.GivenWords {
+		k := strings.ToLower(strings.TrimSpace(w))
+		if seen[k] {
+			c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "givenWords", "%s trial: given word %q is repeated", s.Type, w)
+			break
+		}
+		seen[k] = true
+	}
+	switch recalled := len(s.RecalledWords); {
+	case given > 0 && recalled > 3*given:
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityError, "recalledWords", "%s trial: %d recalled words for %d given", s.Type, recalled, given)
+	case recalled > given:
+		c.Add(DQdomain.CodeImpossibleValue, DQdomain.SeverityWarning, "recalledWords", "%s trial: more recalled words (%d) than given (%d)", s.Type, recalled, given)
+	}
+	switch {
+	case s.Score.Hits == 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityWarning, "hits", "%s trial: no target words recalled", s.Type)
+	case s.Score.Hits == given:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "hits", "%s trial: all target words recalled", s.Type)
+	}
+	return c.Flags
+}
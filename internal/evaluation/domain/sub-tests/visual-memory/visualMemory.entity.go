@@ -2,6 +2,7 @@ package VIMdomain
 
 import (
 	"errors"
+	"fmt"
 	_ "image/jpeg"
 	_ "image/png"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/google/uuid"
 )
 
+var ErrInvalidVisualMemory = errors.New("invalid visual memory input")
+
 type VisualMemorySubtest struct {
 	PK           string            `json:"pk"`
 	EvaluationID string            `json:"evaluation_id"`
@@ -20,7 +23,8 @@ type VisualMemorySubtest struct {
 }
 
 type VisualMemoryScore struct {
-	Val int
+	Val            int
+	ScoringProfile string `json:"scoringProfile"` // id@versión del perfil que produjo el score
 }
 type VisualMemoryNote struct {
 	Val string
@@ -28,7 +32,7 @@ type VisualMemoryNote struct {
 
 func newVisualMemoryScore(score int) (*VisualMemoryScore, error) {
 	if score < 0 || score > 2 {
-		return nil, errors.New("invalid score, must be between 0-2")
+		return nil, fmt.Errorf("%w: invalid score, must be between 0-2", ErrInvalidVisualMemory)
 	}
 	return &VisualMemoryScore{
 		Val: score,
@@ -37,7 +41,7 @@ func newVisualMemoryScore(score int) (*VisualMemoryScore, error) {
 
 func newVisualMemoryNote(note string) (*VisualMemoryNote, error) {
 	if len(note) >= 2500 {
-		return nil, errors.New("note exceeds limit")
+		return nil, fmt.Errorf("%w: note exceeds limit", ErrInvalidVisualMemory)
 	}
 	return &VisualMemoryNote{
 		Val: note,
@@ -45,7 +49,7 @@ func newVisualMemoryNote(note string) (*VisualMemoryNote, error) {
 }
 func NewVisualMemorySubtest(evaluationId string, imageSrc *string, scoreIn int, noteIn string) (VisualMemorySubtest, error) {
 	if evaluationId == "" {
-		return VisualMemorySubtest{}, errors.New("evaluationId is required")
+		return VisualMemorySubtest{}, fmt.Errorf("%w: evaluationId is required", ErrInvalidVisualMemory)
 	}
 	score, err := newVisualMemoryScore(scoreIn)
 	if err != nil {
@@ -0,0 +1,17 @@
+package VIMdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: la escala 0–2 solo admite techo/suelo
+func (s VisualMemorySubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("visual_memory", s.PK)
+	switch s.Score.Val {
+	case 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityInfo, "score", "BVMT score 0: very low performance or copy failure")
+	case 2:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "score", "BVMT score at maximum")
+	}
+	return c.Flags
+}
@@ -2,13 +2,17 @@ package VPdomain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrInvalidVisualSpatial = errors.New("invalid visual spatial input")
+
 type VisualSpatialScore struct {
-	Val int
+	Val            int
+	ScoringProfile string `json:"scoringProfile"` // id@versión del perfil que produjo el score
 }
 type VisualSpatialNote struct {
 	Val string
@@ -24,7 +28,7 @@ type VisualSpatialSubtest struct {
 
 func newScore(score int) (VisualSpatialScore, error) {
 	if score < 0 || score > 5 {
-		return VisualSpatialScore{}, errors.New("test score must be between 0-5")
+		return VisualSpatialScore{}, fmt.Errorf("%w: test score must be between 0-5", ErrInvalidVisualSpatial)
 	}
 	return VisualSpatialScore{
 		Val: score,
@@ -33,7 +37,7 @@ func newScore(score int) (VisualSpatialScore, error) {
 
 func newNote(note string) (VisualSpatialNote, error) {
 	if len(note) >= 2500 {
-		return VisualSpatialNote{}, errors.New("max of 2500 words")
+		return VisualSpatialNote{}, fmt.Errorf("%w: max of 2500 words", ErrInvalidVisualSpatial)
 	}
 
 	return VisualSpatialNote{
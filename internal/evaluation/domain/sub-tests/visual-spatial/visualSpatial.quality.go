@@ -0,0 +1,17 @@
+package VPdomain
+
+import (
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+// QualityFlags: el CDT (Shulman 0–5) solo admite techo/suelo
+func (s VisualSpatialSubtest) QualityFlags() []DQdomain.Flag {
+	c := DQdomain.NewChecker("visual_spatial", s.Id)
+	switch s.Score.Val {
+	case 0:
+		c.Add(DQdomain.CodeFloorEffect, DQdomain.SeverityInfo, "score", "clock drawing score 0")
+	case 5:
+		c.Add(DQdomain.CodeCeilingEffect, DQdomain.SeverityInfo, "score", "clock drawing score at maximum")
+	}
+	return c.Flags
+}
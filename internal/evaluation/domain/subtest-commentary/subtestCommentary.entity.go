@@ -0,0 +1,63 @@
+package STCdomain
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrCommentaryUnavailable: el servicio configurado no genera comentarios por subtest
+var ErrCommentaryUnavailable = errors.New("subtest commentary unavailable")
+
+// MaxCommentaryRunes limita lo que se guarda en la columna del subtest: es un comentario breve,
+// no un informe
+const MaxCommentaryRunes = 1200
+
+// Subtests con columna de análisis propia; usan los códigos del resumen enviado al LLM
+const (
+	LettersCancellation = "letters_cancellation"
+	VerbalMemory        = "verbal_memory"
+	ExecutiveFunctions  = "executive_functions"
+	LanguageFluency     = "language_fluency"
+	DigitSpan           = "digit_span"
+	Stroop              = "stroop"
+	SDMT                = "sdmt"
+	ConfrontationNaming = "confrontation_naming"
+	MotorSpeed          = "motor_speed" // tiempo de reacción
+	FingerTapping       = "finger_tapping"
+	ArchimedesSpiral    = "archimedes_spiral"
+	JLO                 = "judgment_of_line_orientation"
+	GoNoGo              = "go_no_go"
+	CardSorting         = "card_sorting"
+	MoCA                = "moca"
+)
+
+// Target es una fila de subtest administrada. Part distingue las filas de un mismo subtest
+// (inmediata/diferida en memoria verbal, a/a+b en funciones ejecutivas) e Index es su
+// posición dentro del subtest
+type Target struct {
+	Subtest    string `json:"subtest"`
+	SubtestID  string `json:"subtestId"`
+	Part       string `json:"part,omitempty"`
+	Index      int    `json:"-"`
+	Commentary string `json:"commentary"`
+}
+
+// Normalize recorta el comentario a MaxCommentaryRunes sin partir palabras
+func Normalize(text string) string {
+	text = strings.TrimSpace(text)
+	r := []rune(text)
+	if len(r) <= MaxCommentaryRunes {
+		return text
+	}
+	cut := string(r[:MaxCommentaryRunes])
+	if i := strings.LastIndexAny(cut, " \n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimSpace(cut) + "…"
+}
+
+// CommentaryRepository guarda el comentario en la columna de análisis de la fila del subtest
+type CommentaryRepository interface {
+	Save(ctx context.Context, subtest, subtestID, commentary string) error
+}
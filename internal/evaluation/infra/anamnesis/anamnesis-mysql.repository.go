@@ -0,0 +1,176 @@
+package ANinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+)
+
+type AnamnesisMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockAnamnesisRepository struct{}
+
+var mockSleepHours = 6.5
+
+var MockAnamneses []*ANdomain.Anamnesis = []*ANdomain.Anamnesis{
+	{
+		EvaluationID:       "eval1",
+		ReferralReason:     "Quejas de memoria y lentitud en los últimos meses",
+		Comorbidities:      []string{"hipertensión arterial"},
+		Sensory:            ANdomain.Sensory{Vision: ANdomain.SensoryCorrected, Hearing: ANdomain.SensoryNone},
+		Sleep:              ANdomain.Sleep{Quality: ANdomain.SleepFair, HoursPerNight: &mockSleepHours, RBDSuspected: true},
+		MoodComplaints:     ANdomain.MoodComplaints{Apathy: true},
+		CurrentMedications: []string{"enalapril"},
+	},
+}
+
+func NewAnamnesisMYSQLRepository(db *sql.DB) *AnamnesisMYSQLRepository {
+	return &AnamnesisMYSQLRepository{DB: db}
+}
+
+func NewMockAnamnesisRepository() *MockAnamnesisRepository {
+	return &MockAnamnesisRepository{}
+}
+
+type anamnesisRow struct {
+	EvaluationID       string
+	ReferralReason     string
+	Comorbidities      []byte
+	VisionStatus       string
+	HearingStatus      string
+	Sleep              []byte
+	MoodComplaints     []byte
+	CurrentMedications []byte
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func toRow(a *ANdomain.Anamnesis) (anamnesisRow, error) {
+	comorbidities, err := json.Marshal(a.Comorbidities)
+	if err != nil {
+		return anamnesisRow{}, err
+	}
+	sleep, err := json.Marshal(a.Sleep)
+	if err != nil {
+		return anamnesisRow{}, err
+	}
+	mood, err := json.Marshal(a.MoodComplaints)
+	if err != nil {
+		return anamnesisRow{}, err
+	}
+	meds, err := json.Marshal(a.CurrentMedications)
+	if err != nil {
+		return anamnesisRow{}, err
+	}
+	return anamnesisRow{
+		EvaluationID:       a.EvaluationID,
+		ReferralReason:     a.ReferralReason,
+		Comorbidities:      comorbidities,
+		VisionStatus:       string(a.Sensory.Vision),
+		HearingStatus:      string(a.Sensory.Hearing),
+		Sleep:              sleep,
+		MoodComplaints:     mood,
+		CurrentMedications: meds,
+		CreatedAt:          a.CreatedAt.Truncate(time.Millisecond),
+		UpdatedAt:          a.UpdatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r anamnesisRow) toDomain() (ANdomain.Anamnesis, error) {
+	a := ANdomain.Anamnesis{
+		EvaluationID:   r.EvaluationID,
+		ReferralReason: r.ReferralReason,
+		Sensory: ANdomain.Sensory{
+			Vision:  ANdomain.SensoryStatus(r.VisionStatus),
+			Hearing: ANdomain.SensoryStatus(r.HearingStatus),
+		},
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if err := json.Unmarshal(r.Comorbidities, &a.Comorbidities); err != nil {
+		return ANdomain.Anamnesis{}, err
+	}
+	if err := json.Unmarshal(r.Sleep, &a.Sleep); err != nil {
+		return ANdomain.Anamnesis{}, err
+	}
+	if err := json.Unmarshal(r.MoodComplaints, &a.MoodComplaints); err != nil {
+		return ANdomain.Anamnesis{}, err
+	}
+	if err := json.Unmarshal(r.CurrentMedications, &a.CurrentMedications); err != nil {
+		return ANdomain.Anamnesis{}, err
+	}
+	return a, nil
+}
+
+func (r *AnamnesisMYSQLRepository) Save(ctx context.Context, a *ANdomain.Anamnesis) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if a == nil {
+		return errors.New("nil ANdomain.Anamnesis")
+	}
+	row, err := toRow(a)
+	if err != nil {
+		return err
+	}
+	// Una anamnesis por evaluación: se sustituye conservando created_at
+	const upsertSQL = `
+		INSERT INTO evaluation_anamneses
+		    (evaluation_id, referral_reason, comorbidities, vision_status, hearing_status,
+		     sleep, mood_complaints, current_medications, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		    referral_reason = VALUES(referral_reason),
+		    comorbidities = VALUES(comorbidities),
+		    vision_status = VALUES(vision_status),
+		    hearing_status = VALUES(hearing_status),
+		    sleep = VALUES(sleep),
+		    mood_complaints = VALUES(mood_complaints),
+		    current_medications = VALUES(current_medications),
+		    updated_at = VALUES(updated_at)
+	`
+	_, err = r.DB.ExecContext(ctx, upsertSQL,
+		row.EvaluationID, row.ReferralReason, row.Comorbidities, row.VisionStatus, row.HearingStatus,
+		row.Sleep, row.MoodComplaints, row.CurrentMedications, row.CreatedAt, row.UpdatedAt,
+	)
+	return err
+}
+
+func (r *AnamnesisMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (ANdomain.Anamnesis, error) {
+	if r == nil || r.DB == nil {
+		return ANdomain.Anamnesis{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT evaluation_id, referral_reason, comorbidities, vision_status, hearing_status,
+		       sleep, mood_complaints, current_medications, created_at, updated_at
+		  FROM evaluation_anamneses
+		 WHERE evaluation_id = ?
+	`
+	var row anamnesisRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.EvaluationID, &row.ReferralReason, &row.Comorbidities, &row.VisionStatus, &row.HearingStatus,
+		&row.Sleep, &row.MoodComplaints, &row.CurrentMedications, &row.CreatedAt, &row.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Anamnesis opcional: no registrada en esta evaluación
+		return ANdomain.Anamnesis{}, nil
+	}
+	if err != nil {
+		return ANdomain.Anamnesis{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockAnamnesisRepository) Save(ctx context.Context, a *ANdomain.Anamnesis) error {
+	return nil
+}
+
+func (r *MockAnamnesisRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (ANdomain.Anamnesis, error) {
+	return *MockAnamneses[0], nil
+}
@@ -0,0 +1,50 @@
+package audiodecoder
+
+import (
+	"bytes"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+)
+
+type Format string
+
+const (
+	FormatUnknown Format = ""
+	FormatWAV     Format = "wav"
+	FormatWebM    Format = "webm"
+)
+
+// Decoder elige el decodificador según la cabecera del fichero; se pueden
+// registrar decodificadores adicionales o sustituir los de serie.
+type Decoder struct {
+	decoders map[Format]domain.AudioDecoder
+}
+
+func NewAudioDecoder() *Decoder {
+	return &Decoder{decoders: map[Format]domain.AudioDecoder{
+		FormatWAV:  WAVDecoder{},
+		FormatWebM: &WebMDecoder{NewOpus: NewPionOpus},
+	}}
+}
+
+func (d *Decoder) Register(format Format, decoder domain.AudioDecoder) {
+	d.decoders[format] = decoder
+}
+
+func (d *Decoder) Decode(audio []byte) (domain.PCMAudio, error) {
+	dec, ok := d.decoders[Sniff(audio)]
+	if !ok {
+		return domain.PCMAudio{}, domain.ErrUnsupportedAudioFormat
+	}
+	return dec.Decode(audio)
+}
+
+func Sniff(audio []byte) Format {
+	switch {
+	case len(audio) >= 12 && bytes.Equal(audio[0:4], []byte("RIFF")) && bytes.Equal(audio[8:12], []byte("WAVE")):
+		return FormatWAV
+	case len(audio) >= 4 && bytes.Equal(audio[0:4], []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return FormatWebM
+	}
+	return FormatUnknown
+}
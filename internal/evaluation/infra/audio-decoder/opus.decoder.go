@@ -0,0 +1,40 @@
+package audiodecoder
+
+import (
+	"fmt"
+
+	"github.com/pion/opus"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+)
+
+// Un paquete Opus dura como mucho 120 ms: 5760 muestras por canal a 48 kHz
+const maxOpusPacketSamples = 5760
+
+// pionOpus decodifica paquetes Opus (SILK, CELT e híbrido) con github.com/pion/opus, en Go puro
+type pionOpus struct {
+	dec      opus.Decoder
+	channels int
+	buf      []float32
+}
+
+// NewPionOpus es la OpusDecoderFactory por defecto. Solo admite mono y estéreo, que es lo
+// que graban los navegadores; más canales se tratan como formato no soportado.
+func NewPionOpus(sampleRate, channels int) (OpusPacketDecoder, error) {
+	if channels > 2 {
+		return nil, fmt.Errorf("%w: opus with %d channels", domain.ErrUnsupportedAudioFormat, channels)
+	}
+	dec, err := opus.NewDecoderWithOutput(sampleRate, channels)
+	if err != nil {
+		return nil, err
+	}
+	return &pionOpus{dec: dec, channels: channels, buf: make([]float32, maxOpusPacketSamples*channels)}, nil
+}
+
+func (p *pionOpus) Decode(packet []byte) ([]float32, error) {
+	n, err := p.dec.DecodeToFloat32(packet, p.buf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebM, err)
+	}
+	return p.buf[:n*p.channels], nil
+}
@@ -0,0 +1,96 @@
+package audiodecoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+)
+
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+var ErrInvalidWAV = errors.New("invalid wav file")
+
+// WAVDecoder decodifica WAV PCM (8/16/24/32 bits) y float (32/64 bits) a mono
+type WAVDecoder struct{}
+
+func (WAVDecoder) Decode(audio []byte) (domain.PCMAudio, error) {
+	if Sniff(audio) != FormatWAV {
+		return domain.PCMAudio{}, ErrInvalidWAV
+	}
+	var (
+		format, channels, bits int
+		sampleRate             int
+		data                   []byte
+	)
+	for pos := 12; pos+8 <= len(audio); {
+		id := string(audio[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(audio[pos+4 : pos+8]))
+		body := audio[pos+8:]
+		if size > len(body) {
+			// Grabadores en streaming dejan el tamaño sin cerrar
+			size = len(body)
+		}
+		body = body[:size]
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return domain.PCMAudio{}, ErrInvalidWAV
+			}
+			format = int(binary.LittleEndian.Uint16(body[0:2]))
+			channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			bits = int(binary.LittleEndian.Uint16(body[14:16]))
+			if format == wavFormatExtensible && size >= 26 {
+				format = int(binary.LittleEndian.Uint16(body[24:26]))
+			}
+		case "data":
+			data = body
+		}
+		pos += 8 + size + size%2
+	}
+	if channels == 0 || sampleRate == 0 || data == nil {
+		return domain.PCMAudio{}, ErrInvalidWAV
+	}
+
+	read, err := sampleReader(format, bits)
+	if err != nil {
+		return domain.PCMAudio{}, err
+	}
+	frameSize := channels * bits / 8
+	samples := make([]float32, 0, len(data)/frameSize)
+	for off := 0; off+frameSize <= len(data); off += frameSize {
+		var sum float64
+		for ch := 0; ch < channels; ch++ {
+			sum += read(data[off+ch*bits/8:])
+		}
+		samples = append(samples, float32(sum/float64(channels)))
+	}
+	return domain.PCMAudio{SampleRate: sampleRate, Samples: samples}, nil
+}
+
+func sampleReader(format, bits int) (func([]byte) float64, error) {
+	switch {
+	case format == wavFormatPCM && bits == 8:
+		return func(b []byte) float64 { return (float64(b[0]) - 128) / 128 }, nil
+	case format == wavFormatPCM && bits == 16:
+		return func(b []byte) float64 { return float64(int16(binary.LittleEndian.Uint16(b))) / 32768 }, nil
+	case format == wavFormatPCM && bits == 24:
+		return func(b []byte) float64 {
+			v := int32(b[0]) | int32(b[1])<<8 | int32(int8(b[2]))<<16
+			return float64(v) / 8388608
+		}, nil
+	case format == wavFormatPCM && bits == 32:
+		return func(b []byte) float64 { return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }, nil
+	case format == wavFormatFloat && bits == 32:
+		return func(b []byte) float64 { return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) }, nil
+	case format == wavFormatFloat && bits == 64:
+		return func(b []byte) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(b)) }, nil
+	}
+	return nil, domain.ErrUnsupportedAudioFormat
+}
@@ -0,0 +1,234 @@
+package audiodecoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+)
+
+// IDs EBML/Matroska que interesan para extraer la pista de audio
+const (
+	ebmlSegment      = 0x18538067
+	ebmlTracks       = 0x1654AE6B
+	ebmlTrackEntry   = 0xAE
+	ebmlTrackNumber  = 0xD7
+	ebmlCodecID      = 0x86
+	ebmlCodecPrivate = 0x63A2
+	ebmlCluster      = 0x1F43B675
+	ebmlBlockGroup   = 0xA0
+	ebmlBlock        = 0xA1
+	ebmlSimpleBlock  = 0xA3
+)
+
+// Opus siempre decodifica a 48 kHz
+const opusSampleRate = 48000
+
+var ErrInvalidWebM = errors.New("invalid webm file")
+
+// ErrOpusDecoderUnavailable: WebM/Opus (lo que graba MediaRecorder por defecto) sin códec
+// Opus registrado. Envuelve ErrUnsupportedAudioFormat para que el cliente pueda mapearlo.
+var ErrOpusDecoderUnavailable = fmt.Errorf("%w: webm/opus cannot be decoded on this server, record as audio/wav (PCM)", domain.ErrUnsupportedAudioFormat)
+
+// OpusPacketDecoder decodifica un paquete Opus a muestras intercaladas por canal
+type OpusPacketDecoder interface {
+	Decode(packet []byte) ([]float32, error)
+}
+
+type OpusDecoderFactory func(sampleRate, channels int) (OpusPacketDecoder, error)
+
+// WebMDecoder demultiplexa WebM/Matroska en Go puro y delega los paquetes Opus
+// en NewOpus. Sin códec Opus configurado devuelve ErrOpusDecoderUnavailable.
+type WebMDecoder struct {
+	NewOpus OpusDecoderFactory
+}
+
+type webmTrack struct {
+	number  uint64
+	codec   string
+	private []byte
+	packets [][]byte
+}
+
+func (d *WebMDecoder) Decode(audio []byte) (domain.PCMAudio, error) {
+	tracks, err := demuxWebM(audio)
+	if err != nil {
+		return domain.PCMAudio{}, err
+	}
+	var opus *webmTrack
+	for i := range tracks {
+		if tracks[i].codec == "A_OPUS" {
+			opus = &tracks[i]
+			break
+		}
+	}
+	if opus == nil {
+		return domain.PCMAudio{}, domain.ErrUnsupportedAudioFormat
+	}
+	if d.NewOpus == nil {
+		return domain.PCMAudio{}, ErrOpusDecoderUnavailable
+	}
+
+	// OpusHead: "OpusHead", versión, canales, pre-skip (LE)...
+	channels, preSkip := 1, 0
+	if len(opus.private) >= 12 && string(opus.private[:8]) == "OpusHead" {
+		channels = max(1, int(opus.private[9]))
+		preSkip = int(binary.LittleEndian.Uint16(opus.private[10:12]))
+	}
+	dec, err := d.NewOpus(opusSampleRate, channels)
+	if err != nil {
+		return domain.PCMAudio{}, err
+	}
+	var samples []float32
+	for _, p := range opus.packets {
+		pcm, err := dec.Decode(p)
+		if err != nil {
+			return domain.PCMAudio{}, err
+		}
+		for i := 0; i+channels <= len(pcm); i += channels {
+			var sum float32
+			for ch := 0; ch < channels; ch++ {
+				sum += pcm[i+ch]
+			}
+			samples = append(samples, sum/float32(channels))
+		}
+	}
+	if preSkip < len(samples) {
+		samples = samples[preSkip:]
+	}
+	return domain.PCMAudio{SampleRate: opusSampleRate, Samples: samples}, nil
+}
+
+// demuxWebM recorre los elementos de forma lineal: entra en los contenedores
+// relevantes (también con tamaño desconocido, como los de MediaRecorder) y salta el resto.
+func demuxWebM(data []byte) ([]webmTrack, error) {
+	var tracks []webmTrack
+	byNumber := map[uint64]int{}
+	for pos := 0; pos < len(data); {
+		id, n := readElementID(data[pos:])
+		if n == 0 {
+			return nil, ErrInvalidWebM
+		}
+		pos += n
+		size, unknown, n := readVint(data[pos:])
+		if n == 0 {
+			return nil, ErrInvalidWebM
+		}
+		pos += n
+		switch id {
+		case ebmlSegment, ebmlTracks, ebmlCluster, ebmlBlockGroup:
+			continue
+		case ebmlTrackEntry:
+			tracks = append(tracks, webmTrack{})
+			continue
+		}
+		if unknown || size > uint64(len(data)-pos) {
+			return nil, ErrInvalidWebM
+		}
+		body := data[pos : pos+int(size)]
+		pos += int(size)
+
+		switch id {
+		case ebmlTrackNumber:
+			if len(tracks) > 0 {
+				tracks[len(tracks)-1].number = readUint(body)
+				byNumber[tracks[len(tracks)-1].number] = len(tracks) - 1
+			}
+		case ebmlCodecID:
+			if len(tracks) > 0 {
+				tracks[len(tracks)-1].codec = string(body)
+			}
+		case ebmlCodecPrivate:
+			if len(tracks) > 0 {
+				tracks[len(tracks)-1].private = body
+			}
+		case ebmlSimpleBlock, ebmlBlock:
+			track, frames, err := parseBlock(body)
+			if err != nil {
+				return nil, err
+			}
+			if i, ok := byNumber[track]; ok {
+				tracks[i].packets = append(tracks[i].packets, frames...)
+			}
+		}
+	}
+	return tracks, nil
+}
+
+// parseBlock soporta bloques sin lacing y con lacing de tamaño fijo
+func parseBlock(b []byte) (uint64, [][]byte, error) {
+	track, _, n := readVint(b)
+	if n == 0 || len(b) < n+3 {
+		return 0, nil, ErrInvalidWebM
+	}
+	flags := b[n+2]
+	payload := b[n+3:]
+	switch (flags >> 1) & 0x03 {
+	case 0:
+		return track, [][]byte{payload}, nil
+	case 2:
+		if len(payload) < 1 {
+			return 0, nil, ErrInvalidWebM
+		}
+		count := int(payload[0]) + 1
+		payload = payload[1:]
+		if len(payload)%count != 0 {
+			return 0, nil, ErrInvalidWebM
+		}
+		size := len(payload) / count
+		frames := make([][]byte, count)
+		for i := range frames {
+			frames[i] = payload[i*size : (i+1)*size]
+		}
+		return track, frames, nil
+	}
+	return 0, nil, domain.ErrUnsupportedAudioFormat
+}
+
+// readElementID lee un ID EBML conservando el marcador de longitud
+func readElementID(b []byte) (uint32, int) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0
+	}
+	length := 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 4 || len(b) < length {
+		return 0, 0
+	}
+	var id uint32
+	for _, c := range b[:length] {
+		id = id<<8 | uint32(c)
+	}
+	return id, length
+}
+
+// readVint lee un entero de longitud variable; unknown indica tamaño desconocido (todo a 1)
+func readVint(b []byte) (value uint64, unknown bool, n int) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, false, 0
+	}
+	length := 1
+	mask := byte(0x80)
+	for ; b[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if len(b) < length {
+		return 0, false, 0
+	}
+	value = uint64(b[0] & (mask - 1))
+	for _, c := range b[1:length] {
+		value = value<<8 | uint64(c)
+	}
+	return value, value == (uint64(1)<<(7*length))-1, length
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
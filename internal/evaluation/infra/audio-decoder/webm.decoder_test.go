@@ -0,0 +1,180 @@
+package audiodecoder
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+)
+
+// testdata/mediarecorder-opus.webm reproduce la estructura que genera MediaRecorder
+// (audio/webm;codecs=opus): Segment y Cluster de tamaño desconocido, pista A_OPUS mono con
+// OpusHead (pre-skip 312) y 50 SimpleBlocks de 20 ms con el paquete de silencio CELT F8 FF FE.
+func readFixture(t *testing.T) []byte {
+	return readTestdata(t, "mediarecorder-opus.webm")
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("cannot read fixture: %v", err)
+	}
+	return data
+}
+
+type stubOpus struct {
+	packets [][]byte
+}
+
+func (s *stubOpus) Decode(packet []byte) ([]float32, error) {
+	s.packets = append(s.packets, packet)
+	pcm := make([]float32, 960) // 20 ms a 48 kHz
+	for i := range pcm {
+		pcm[i] = 0.25
+	}
+	return pcm, nil
+}
+
+func TestWebMDecoder(t *testing.T) {
+	fixture := readFixture(t)
+	withoutOpus := bytes.Replace(fixture, []byte("A_OPUS"), []byte("A_VORB"), 1)
+
+	tests := []struct {
+		name        string
+		audio       []byte
+		withCodec   bool
+		withoutOpus bool
+		wantErr     error
+		wantSamples int
+	}{
+		{
+			name:        "MediaRecorder WebM/Opus without an Opus codec is reported as such",
+			audio:       fixture,
+			withoutOpus: true,
+			wantErr:     ErrOpusDecoderUnavailable,
+		},
+		{
+			name:        "MediaRecorder WebM/Opus with an Opus codec",
+			audio:       fixture,
+			withCodec:   true,
+			wantSamples: 50*960 - 312,
+		},
+		{
+			name:      "WebM without an Opus track",
+			audio:     withoutOpus,
+			withCodec: true,
+			wantErr:   domain.ErrUnsupportedAudioFormat,
+		},
+		{
+			name:    "Truncated WebM",
+			audio:   fixture[:len(fixture)-4],
+			wantErr: ErrInvalidWebM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubOpus{}
+			var gotRate, gotChannels int
+			dec := NewAudioDecoder()
+			if tt.withoutOpus {
+				dec.Register(FormatWebM, &WebMDecoder{})
+			}
+			if tt.withCodec {
+				dec.Register(FormatWebM, &WebMDecoder{NewOpus: func(sampleRate, channels int) (OpusPacketDecoder, error) {
+					gotRate, gotChannels = sampleRate, channels
+					return stub, nil
+				}})
+			}
+
+			pcm, err := dec.Decode(tt.audio)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected success, got error: %v", err)
+			}
+			if gotRate != 48000 || gotChannels != 1 {
+				t.Errorf("expected a 48 kHz mono Opus decoder, got %d Hz / %d channels", gotRate, gotChannels)
+			}
+			if len(stub.packets) != 50 || !bytes.Equal(stub.packets[0], []byte{0xF8, 0xFF, 0xFE}) {
+				t.Errorf("expected 50 silence packets, got %d (first %x)", len(stub.packets), stub.packets[0])
+			}
+			if pcm.SampleRate != 48000 || len(pcm.Samples) != tt.wantSamples {
+				t.Errorf("expected %d samples at 48 kHz after pre-skip, got %d at %d", tt.wantSamples, len(pcm.Samples), pcm.SampleRate)
+			}
+		})
+	}
+}
+
+func TestErrOpusDecoderUnavailableIsUnsupportedFormat(t *testing.T) {
+	if Sniff(readFixture(t)) != FormatWebM {
+		t.Fatalf("expected the fixture to be sniffed as webm")
+	}
+	if !errors.Is(ErrOpusDecoderUnavailable, domain.ErrUnsupportedAudioFormat) {
+		t.Errorf("callers map ErrUnsupportedAudioFormat; the opus error must wrap it")
+	}
+}
+
+// testdata/libopus-silk.webm lleva en la misma estructura de MediaRecorder un paquete SILK
+// de banda ancha codificado con libopus (tomado de testdata/tiny.ogg de github.com/pion/opus, MIT)
+func TestAudioDecoderDecodesWebMOpus(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		wantSamples int
+		wantSilent  bool
+	}{
+		{
+			name:        "CELT silence packets decode to silence",
+			fixture:     "mediarecorder-opus.webm",
+			wantSamples: 50*960 - 312,
+			wantSilent:  true,
+		},
+		{
+			name:        "libopus SILK packet decodes to audible PCM",
+			fixture:     "libopus-silk.webm",
+			wantSamples: 960 - 312,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm, err := NewAudioDecoder().Decode(readTestdata(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("expected the default decoder to decode webm/opus, got %v", err)
+			}
+			if pcm.SampleRate != 48000 || len(pcm.Samples) != tt.wantSamples {
+				t.Fatalf("expected %d samples at 48 kHz, got %d at %d", tt.wantSamples, len(pcm.Samples), pcm.SampleRate)
+			}
+			var energy float64
+			for _, s := range pcm.Samples {
+				if s < -1 || s > 1 {
+					t.Fatalf("sample %f out of [-1, 1]", s)
+				}
+				energy += float64(s * s)
+			}
+			rms := math.Sqrt(energy / float64(len(pcm.Samples)))
+			if tt.wantSilent && rms > 1e-4 {
+				t.Errorf("expected silence, got rms %f", rms)
+			}
+			if !tt.wantSilent && rms < 0.005 {
+				t.Errorf("expected audible audio, got rms %f", rms)
+			}
+		})
+	}
+}
+
+func TestNewPionOpusRejectsMultichannel(t *testing.T) {
+	if _, err := NewPionOpus(48000, 6); !errors.Is(err, domain.ErrUnsupportedAudioFormat) {
+		t.Errorf("expected ErrUnsupportedAudioFormat for 5.1 opus, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package CCinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+)
+
+type ClinicalContextMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockClinicalContextRepository struct{}
+
+var mockHoehnYahr = 2.5
+var mockDiseaseDuration = 7.0
+var mockMinutesSinceLevodopa = 90
+
+var MockClinicalContexts []*CCdomain.ClinicalContext = []*CCdomain.ClinicalContext{
+	{
+		EvaluationID:             "eval1",
+		MedicationState:          CCdomain.MedicationOn,
+		MinutesSinceLastLevodopa: &mockMinutesSinceLevodopa,
+		Medications: []CCdomain.Medication{
+			{Drug: "levodopa", DailyDoseMg: 400},
+			{Drug: "pramipexole", DailyDoseMg: 1.5},
+		},
+		LEDD:                 CCdomain.LEDD{Total: 550, Levodopa: 400, Agonists: 150},
+		HoehnYahr:            &mockHoehnYahr,
+		DBS:                  CCdomain.DBS{Stimulation: CCdomain.DBSNone},
+		DiseaseDurationYears: &mockDiseaseDuration,
+	},
+}
+
+func NewClinicalContextMYSQLRepository(db *sql.DB) *ClinicalContextMYSQLRepository {
+	return &ClinicalContextMYSQLRepository{DB: db}
+}
+
+func NewMockClinicalContextRepository() *MockClinicalContextRepository {
+	return &MockClinicalContextRepository{}
+}
+
+type clinicalContextRow struct {
+	EvaluationID             string
+	MedicationState          string
+	MinutesSinceLastLevodopa sql.NullInt64
+	Medications              []byte
+	LEDDTotal                float64
+	LEDDLevodopa             float64
+	LEDDAgonists             float64
+	HoehnYahr                sql.NullFloat64
+	DBSStimulation           string
+	DBSTarget                sql.NullString
+	DiseaseDurationYears     sql.NullFloat64
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+}
+
+func toRow(cc *CCdomain.ClinicalContext) (clinicalContextRow, error) {
+	meds, err := json.Marshal(cc.Medications)
+	if err != nil {
+		return clinicalContextRow{}, err
+	}
+	row := clinicalContextRow{
+		EvaluationID:    cc.EvaluationID,
+		MedicationState: string(cc.MedicationState),
+		Medications:     meds,
+		LEDDTotal:       cc.LEDD.Total,
+		LEDDLevodopa:    cc.LEDD.Levodopa,
+		LEDDAgonists:    cc.LEDD.Agonists,
+		DBSStimulation:  string(cc.DBS.Stimulation),
+		DBSTarget:       sql.NullString{String: cc.DBS.Target, Valid: cc.DBS.Target != ""},
+		CreatedAt:       cc.CreatedAt.Truncate(time.Millisecond),
+		UpdatedAt:       cc.UpdatedAt.Truncate(time.Millisecond),
+	}
+	if cc.MinutesSinceLastLevodopa != nil {
+		row.MinutesSinceLastLevodopa = sql.NullInt64{Int64: int64(*cc.MinutesSinceLastLevodopa), Valid: true}
+	}
+	if cc.HoehnYahr != nil {
+		row.HoehnYahr = sql.NullFloat64{Float64: *cc.HoehnYahr, Valid: true}
+	}
+	if cc.DiseaseDurationYears != nil {
+		row.DiseaseDurationYears = sql.NullFloat64{Float64: *cc.DiseaseDurationYears, Valid: true}
+	}
+	return row, nil
+}
+
+func (r clinicalContextRow) toDomain() (CCdomain.ClinicalContext, error) {
+	var meds []CCdomain.Medication
+	if err := json.Unmarshal(r.Medications, &meds); err != nil {
+		return CCdomain.ClinicalContext{}, err
+	}
+	cc := CCdomain.ClinicalContext{
+		EvaluationID:    r.EvaluationID,
+		MedicationState: CCdomain.MedicationState(r.MedicationState),
+		Medications:     meds,
+		LEDD:            CCdomain.LEDD{Total: r.LEDDTotal, Levodopa: r.LEDDLevodopa, Agonists: r.LEDDAgonists},
+		DBS:             CCdomain.DBS{Stimulation: CCdomain.DBSStimulation(r.DBSStimulation), Target: r.DBSTarget.String},
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+	if r.MinutesSinceLastLevodopa.Valid {
+		m := int(r.MinutesSinceLastLevodopa.Int64)
+		cc.MinutesSinceLastLevodopa = &m
+	}
+	if r.HoehnYahr.Valid {
+		cc.HoehnYahr = &r.HoehnYahr.Float64
+	}
+	if r.DiseaseDurationYears.Valid {
+		cc.DiseaseDurationYears = &r.DiseaseDurationYears.Float64
+	}
+	return cc, nil
+}
+
+func (r *ClinicalContextMYSQLRepository) Save(ctx context.Context, cc *CCdomain.ClinicalContext) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if cc == nil {
+		return errors.New("nil CCdomain.ClinicalContext")
+	}
+	row, err := toRow(cc)
+	if err != nil {
+		return err
+	}
+	// Un contexto por evaluación: se sustituye conservando created_at
+	const upsertSQL = `
+		INSERT INTO evaluation_clinical_contexts
+		    (evaluation_id, medication_state, minutes_since_last_levodopa, medications,
+		     ledd_total, ledd_levodopa, ledd_agonists, hoehn_yahr, dbs_stimulation, dbs_target,
+		     disease_duration_years, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		    medication_state = VALUES(medication_state),
+		    minutes_since_last_levodopa = VALUES(minutes_since_last_levodopa),
+		    medications = VALUES(medications),
+		    ledd_total = VALUES(ledd_total),
+		    ledd_levodopa = VALUES(ledd_levodopa),
+		    ledd_agonists = VALUES(ledd_agonists),
+		    hoehn_yahr = VALUES(hoehn_yahr),
+		    dbs_stimulation = VALUES(dbs_stimulation),
+		    dbs_target = VALUES(dbs_target),
+		    disease_duration_years = VALUES(disease_duration_years),
+		    updated_at = VALUES(updated_at)
+	`
+	_, err = r.DB.ExecContext(ctx, upsertSQL,
+		row.EvaluationID, row.MedicationState, row.MinutesSinceLastLevodopa, row.Medications,
+		row.LEDDTotal, row.LEDDLevodopa, row.LEDDAgonists, row.HoehnYahr, row.DBSStimulation, row.DBSTarget,
+		row.DiseaseDurationYears, row.CreatedAt, row.UpdatedAt,
+	)
+	return err
+}
+
+func (r *ClinicalContextMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (CCdomain.ClinicalContext, error) {
+	if r == nil || r.DB == nil {
+		return CCdomain.ClinicalContext{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT evaluation_id, medication_state, minutes_since_last_levodopa, medications,
+		       ledd_total, ledd_levodopa, ledd_agonists, hoehn_yahr, dbs_stimulation, dbs_target,
+		       disease_duration_years, created_at, updated_at
+		  FROM evaluation_clinical_contexts
+		 WHERE evaluation_id = ?
+	`
+	var row clinicalContextRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.EvaluationID, &row.MedicationState, &row.MinutesSinceLastLevodopa, &row.Medications,
+		&row.LEDDTotal, &row.LEDDLevodopa, &row.LEDDAgonists, &row.HoehnYahr, &row.DBSStimulation, &row.DBSTarget,
+		&row.DiseaseDurationYears, &row.CreatedAt, &row.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Contexto opcional: no registrado en esta evaluación
+		return CCdomain.ClinicalContext{}, nil
+	}
+	if err != nil {
+		return CCdomain.ClinicalContext{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockClinicalContextRepository) Save(ctx context.Context, cc *CCdomain.ClinicalContext) error {
+	return nil
+}
+
+func (r *MockClinicalContextRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (CCdomain.ClinicalContext, error) {
+	return *MockClinicalContexts[0], nil
+}
@@ -0,0 +1,63 @@
+package COMPinfra
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+)
+
+//go:embed config/standard.v1.json
+var embeddedConfig []byte
+
+// CompositeConfigFileEnv apunta a un JSON con la misma estructura que sustituye al empaquetado
+const CompositeConfigFileEnv = "COMPOSITE_CONFIG_FILE"
+
+type CompositeConfigProvider struct {
+	cfg COMPdomain.Config
+}
+
+func NewCompositeConfigProvider(raw []byte) (*CompositeConfigProvider, error) {
+	var cfg COMPdomain.Config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("composite config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &CompositeConfigProvider{cfg: cfg}, nil
+}
+
+// NewCompositeConfigProviderFromEnv usa COMPOSITE_CONFIG_FILE si está definido; una
+// configuración inválida impide arrancar.
+func NewCompositeConfigProviderFromEnv() *CompositeConfigProvider {
+	raw := embeddedConfig
+	if path := os.Getenv(CompositeConfigFileEnv); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			panic("composite config: " + err.Error())
+		}
+		raw = b
+	}
+	p, err := NewCompositeConfigProvider(raw)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+// NewEmbeddedCompositeConfigProvider solo con la configuración empaquetada (tests)
+func NewEmbeddedCompositeConfigProvider() *CompositeConfigProvider {
+	p, err := NewCompositeConfigProvider(embeddedConfig)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+func (p *CompositeConfigProvider) Current(ctx context.Context) (COMPdomain.Config, error) {
+	return p.cfg, nil
+}
@@ -0,0 +1,115 @@
+package DQinfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+)
+
+type DataQualityMYSQLRepository struct {
+	DB *sql.DB
+}
+
+// MockDataQualityRepository guarda en memoria los flags por evaluación y fila de subtest
+type MockDataQualityRepository struct {
+	mu    sync.Mutex
+	flags map[string]map[string][]DQdomain.Flag
+}
+
+func NewDataQualityMYSQLRepository(db *sql.DB) *DataQualityMYSQLRepository {
+	return &DataQualityMYSQLRepository{DB: db}
+}
+
+func NewMockDataQualityRepository() *MockDataQualityRepository {
+	return &MockDataQualityRepository{flags: map[string]map[string][]DQdomain.Flag{}}
+}
+
+func (r *DataQualityMYSQLRepository) Replace(ctx context.Context, evaluationID, subtestID string, flags []DQdomain.Flag) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM evaluation_quality_flags WHERE evaluation_id = ? AND subtest_id = ?`, evaluationID, subtestID); err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO evaluation_quality_flags
+		    (evaluation_id, subtest, subtest_id, code, severity, field, message)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, f := range flags {
+		if _, err := tx.ExecContext(ctx, insertSQL,
+			evaluationID, f.Subtest, subtestID, string(f.Code), string(f.Severity),
+			sql.NullString{String: f.Field, Valid: f.Field != ""}, f.Message,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *DataQualityMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]DQdomain.Flag, error) {
+	if r == nil || r.DB == nil {
+		return nil, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT subtest, subtest_id, code, severity, field, message
+		  FROM evaluation_quality_flags
+		 WHERE evaluation_id = ?
+		 ORDER BY id
+	`
+	rows, err := r.DB.QueryContext(ctx, q, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []DQdomain.Flag{}
+	for rows.Next() {
+		var f DQdomain.Flag
+		var code, severity string
+		var field sql.NullString
+		if err := rows.Scan(&f.Subtest, &f.SubtestID, &code, &severity, &field, &f.Message); err != nil {
+			return nil, err
+		}
+		f.Code, f.Severity, f.Field = DQdomain.Code(code), DQdomain.Severity(severity), field.String
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+func (r *MockDataQualityRepository) Replace(ctx context.Context, evaluationID, subtestID string, flags []DQdomain.Flag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.flags[evaluationID] == nil {
+		r.flags[evaluationID] = map[string][]DQdomain.Flag{}
+	}
+	r.flags[evaluationID][subtestID] = append([]DQdomain.Flag{}, flags...)
+	return nil
+}
+
+func (r *MockDataQualityRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]DQdomain.Flag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []DQdomain.Flag{}
+	for _, flags := range r.flags[evaluationID] {
+		out = append(out, flags...)
+	}
+	return out, nil
+}
+
+// Get devuelve los flags guardados de una fila; solo lo usan las pruebas
+func (r *MockDataQualityRepository) Get(evaluationID, subtestID string) ([]DQdomain.Flag, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	flags, ok := r.flags[evaluationID][subtestID]
+	return flags, ok
+}
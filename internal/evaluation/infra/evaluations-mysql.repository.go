@@ -3,6 +3,7 @@ package infra
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/aarondl/null/v8"
@@ -10,6 +11,8 @@ import (
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"neuro.app.jordi/database/dbmodels"
 	"neuro.app.jordi/internal/evaluation/domain"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	SAdomain "neuro.app.jordi/internal/evaluation/domain/structured-analysis"
 	"neuro.app.jordi/internal/pkg/utils"
 )
 
@@ -56,18 +59,61 @@ func NewEvaluationsMYSQLRepository(db *sql.DB) *EvaluationsMYSQLRepository {
 }
 
 func domainEvaluationToDB(evaluation domain.Evaluation) *dbmodels.Evaluation {
-	return &dbmodels.Evaluation{
-		ID:                evaluation.PK,
-		AssistantAnalysis: null.StringFrom(evaluation.AssistantAnalysis),
-		PatientName:       evaluation.PatientName,
-		PatientAge:        evaluation.PatientAge,
-		SpecialistMail:    evaluation.SpecialistMail,
-		SpecialistID:      evaluation.SpecialistID,
-		StorageURL:        null.StringFrom(evaluation.StorageURL),
-		StorageKey:        null.StringFrom(evaluation.StorageKey),
-		CreatedAt:         evaluation.CreatedAt,
-		CurrentStatus:     string(evaluation.CurrentStatus),
+	dbEvaluation := &dbmodels.Evaluation{
+		ID:                    evaluation.PK,
+		AssistantAnalysis:     null.StringFrom(evaluation.AssistantAnalysis),
+		PatientName:           evaluation.PatientName,
+		PatientAge:            evaluation.PatientAge,
+		SpecialistMail:        evaluation.SpecialistMail,
+		SpecialistID:          evaluation.SpecialistID,
+		StorageURL:            null.StringFrom(evaluation.StorageURL),
+		StorageKey:            null.StringFrom(evaluation.StorageKey),
+		CreatedAt:             evaluation.CreatedAt,
+		CurrentStatus:         string(evaluation.CurrentStatus),
+		AnalysisProvider:      evaluation.AnalysisUsage.Provider,
+		AnalysisModel:         evaluation.AnalysisUsage.Model,
+		AnalysisLatencyMS:     int(evaluation.AnalysisUsage.LatencyMs),
+		AnalysisPromptVersion: evaluation.AnalysisUsage.PromptVersion,
+		AnalysisAttempts:      evaluation.AnalysisUsage.Attempts,
+	}
+	setStructuredAnalysis(dbEvaluation, evaluation.StructuredAnalysis)
+	return dbEvaluation
+}
+
+// setStructuredAnalysis guarda cada campo del análisis en su columna; sin análisis quedan vacías
+func setStructuredAnalysis(db *dbmodels.Evaluation, a SAdomain.Analysis) {
+	db.AnalysisTitle = a.Title
+	db.AnalysisProfile = string(a.Profile)
+	db.AnalysisUncertainty = string(a.Uncertainty)
+	db.AnalysisJustification = null.NewString(a.Justification, !a.IsZero())
+	db.AnalysisSummary = null.NewString(a.Summary, !a.IsZero())
+	db.AnalysisFindings, db.AnalysisRecommendations = null.JSON{}, null.JSON{}
+	if a.IsZero() {
+		return
+	}
+	if findings, err := json.Marshal(a.Findings); err == nil {
+		db.AnalysisFindings = null.JSONFrom(findings)
+	}
+	if recommendations, err := json.Marshal(a.Recommendations); err == nil {
+		db.AnalysisRecommendations = null.JSONFrom(recommendations)
+	}
+}
+
+func dbStructuredAnalysisToDomain(db *dbmodels.Evaluation) SAdomain.Analysis {
+	a := SAdomain.Analysis{
+		Title:         db.AnalysisTitle,
+		Profile:       NPdomain.Profile(db.AnalysisProfile),
+		Justification: db.AnalysisJustification.String,
+		Summary:       db.AnalysisSummary.String,
+		Uncertainty:   SAdomain.Uncertainty(db.AnalysisUncertainty),
+	}
+	if db.AnalysisFindings.Valid {
+		_ = json.Unmarshal(db.AnalysisFindings.JSON, &a.Findings)
+	}
+	if db.AnalysisRecommendations.Valid {
+		_ = json.Unmarshal(db.AnalysisRecommendations.JSON, &a.Recommendations)
 	}
+	return a
 }
 
 func dbEvaluationToDomain(evaluation *dbmodels.Evaluation) domain.Evaluation {
@@ -82,6 +128,14 @@ func dbEvaluationToDomain(evaluation *dbmodels.Evaluation) domain.Evaluation {
 		StorageURL:        evaluation.StorageKey.String,
 		StorageKey:        evaluation.StorageKey.String,
 		CreatedAt:         evaluation.CreatedAt,
+		AnalysisUsage: domain.LLMUsage{
+			Provider:      evaluation.AnalysisProvider,
+			Model:         evaluation.AnalysisModel,
+			LatencyMs:     int64(evaluation.AnalysisLatencyMS),
+			PromptVersion: evaluation.AnalysisPromptVersion,
+			Attempts:      evaluation.AnalysisAttempts,
+		},
+		StructuredAnalysis: dbStructuredAnalysisToDomain(evaluation),
 	}
 }
 func (m *EvaluationsMYSQLRepository) CanFinishEvaluation(ctx context.Context, evaluationID, specialistID string) (bool, error) {
@@ -106,6 +160,12 @@ func (m *EvaluationsMYSQLRepository) Update(ctx context.Context, evaluation doma
 	//TODO: here we should add the fields we want to update
 	dbEvaluation.CurrentStatus = string(evaluation.CurrentStatus)
 	dbEvaluation.AssistantAnalysis = null.StringFrom(evaluation.AssistantAnalysis)
+	dbEvaluation.AnalysisProvider = evaluation.AnalysisUsage.Provider
+	dbEvaluation.AnalysisModel = evaluation.AnalysisUsage.Model
+	dbEvaluation.AnalysisLatencyMS = int(evaluation.AnalysisUsage.LatencyMs)
+	dbEvaluation.AnalysisPromptVersion = evaluation.AnalysisUsage.PromptVersion
+	dbEvaluation.AnalysisAttempts = evaluation.AnalysisUsage.Attempts
+	setStructuredAnalysis(dbEvaluation, evaluation.StructuredAnalysis)
 	_, err = dbEvaluation.Update(ctx, m.Exec, boil.Infer())
 	return err
 }
@@ -0,0 +1,147 @@
+package EOinfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+)
+
+type ExaminerObservationMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockExaminerObservationRepository struct{}
+
+var MockExaminerObservations []*EOdomain.ExaminerObservation = []*EOdomain.ExaminerObservation{
+	{
+		EvaluationID:  "eval1",
+		Subtest:       "stroop",
+		Fatigue:       EOdomain.FatigueMild,
+		Cooperation:   EOdomain.CooperationGood,
+		Interruptions: 1,
+		Notes:         "Interrupción breve por llamada telefónica",
+	},
+}
+
+func NewExaminerObservationMYSQLRepository(db *sql.DB) *ExaminerObservationMYSQLRepository {
+	return &ExaminerObservationMYSQLRepository{DB: db}
+}
+
+func NewMockExaminerObservationRepository() *MockExaminerObservationRepository {
+	return &MockExaminerObservationRepository{}
+}
+
+type examinerObservationRow struct {
+	EvaluationID        string
+	Subtest             string
+	Fatigue             string
+	Cooperation         string
+	ComprehensionIssues bool
+	Interruptions       int
+	Notes               sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func toRow(o *EOdomain.ExaminerObservation) examinerObservationRow {
+	return examinerObservationRow{
+		EvaluationID:        o.EvaluationID,
+		Subtest:             o.Subtest,
+		Fatigue:             string(o.Fatigue),
+		Cooperation:         string(o.Cooperation),
+		ComprehensionIssues: o.ComprehensionIssues,
+		Interruptions:       o.Interruptions,
+		Notes:               sql.NullString{String: o.Notes, Valid: o.Notes != ""},
+		CreatedAt:           o.CreatedAt.Truncate(time.Millisecond),
+		UpdatedAt:           o.UpdatedAt.Truncate(time.Millisecond),
+	}
+}
+
+func (r examinerObservationRow) toDomain() EOdomain.ExaminerObservation {
+	return EOdomain.ExaminerObservation{
+		EvaluationID:        r.EvaluationID,
+		Subtest:             r.Subtest,
+		Fatigue:             EOdomain.Fatigue(r.Fatigue),
+		Cooperation:         EOdomain.Cooperation(r.Cooperation),
+		ComprehensionIssues: r.ComprehensionIssues,
+		Interruptions:       r.Interruptions,
+		Notes:               r.Notes.String,
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
+	}
+}
+
+func (r *ExaminerObservationMYSQLRepository) Save(ctx context.Context, o *EOdomain.ExaminerObservation) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if o == nil {
+		return errors.New("nil EOdomain.ExaminerObservation")
+	}
+	row := toRow(o)
+	// Una observación por subtest y evaluación
+	const upsertSQL = `
+		INSERT INTO evaluation_examiner_observations
+		    (evaluation_id, subtest, fatigue, cooperation, comprehension_issues, interruptions,
+		     notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		    fatigue = VALUES(fatigue),
+		    cooperation = VALUES(cooperation),
+		    comprehension_issues = VALUES(comprehension_issues),
+		    interruptions = VALUES(interruptions),
+		    notes = VALUES(notes),
+		    updated_at = VALUES(updated_at)
+	`
+	_, err := r.DB.ExecContext(ctx, upsertSQL,
+		row.EvaluationID, row.Subtest, row.Fatigue, row.Cooperation, row.ComprehensionIssues, row.Interruptions,
+		row.Notes, row.CreatedAt, row.UpdatedAt,
+	)
+	return err
+}
+
+func (r *ExaminerObservationMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]EOdomain.ExaminerObservation, error) {
+	if r == nil || r.DB == nil {
+		return nil, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT evaluation_id, subtest, fatigue, cooperation, comprehension_issues, interruptions,
+		       notes, created_at, updated_at
+		  FROM evaluation_examiner_observations
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at
+	`
+	rows, err := r.DB.QueryContext(ctx, q, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []EOdomain.ExaminerObservation{}
+	for rows.Next() {
+		var row examinerObservationRow
+		if err := rows.Scan(
+			&row.EvaluationID, &row.Subtest, &row.Fatigue, &row.Cooperation, &row.ComprehensionIssues, &row.Interruptions,
+			&row.Notes, &row.CreatedAt, &row.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, row.toDomain())
+	}
+	return out, rows.Err()
+}
+
+func (r *MockExaminerObservationRepository) Save(ctx context.Context, o *EOdomain.ExaminerObservation) error {
+	return nil
+}
+
+func (r *MockExaminerObservationRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]EOdomain.ExaminerObservation, error) {
+	out := []EOdomain.ExaminerObservation{}
+	for _, o := range MockExaminerObservations {
+		out = append(out, *o)
+	}
+	return out, nil
+}
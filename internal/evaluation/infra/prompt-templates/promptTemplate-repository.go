@@ -0,0 +1,219 @@
+package PTinfra
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+)
+
+// Plantillas empaquetadas: templates/<id>/<idioma>.v<versión>.tmpl. Son la base de cada
+// prompt; las versiones publicadas por la API de administración se guardan en la base de
+// datos con números posteriores y pasan a ser las vigentes.
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+var templateFilePattern = regexp.MustCompile(`^([a-z]{2})\.v([0-9]+)\.tmpl$`)
+
+type PromptTemplateMYSQLRepository struct {
+	DB   *sql.DB
+	base []PTdomain.PromptTemplate
+}
+
+// MockPromptTemplateRepository guarda en memoria las versiones publicadas
+type MockPromptTemplateRepository struct {
+	mu        sync.Mutex
+	templates []PTdomain.PromptTemplate
+}
+
+func NewPromptTemplateMYSQLRepository(db *sql.DB) *PromptTemplateMYSQLRepository {
+	return &PromptTemplateMYSQLRepository{DB: db, base: mustLoadEmbedded()}
+}
+
+func NewMockPromptTemplateRepository() *MockPromptTemplateRepository {
+	return &MockPromptTemplateRepository{templates: mustLoadEmbedded()}
+}
+
+func mustLoadEmbedded() []PTdomain.PromptTemplate {
+	ts, err := loadTemplates(embeddedTemplates)
+	if err != nil {
+		panic("prompt templates: " + err.Error())
+	}
+	return ts
+}
+
+func loadTemplates(fsys fs.FS) ([]PTdomain.PromptTemplate, error) {
+	files, err := fs.Glob(fsys, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	var out []PTdomain.PromptTemplate
+	for _, f := range files {
+		m := templateFilePattern.FindStringSubmatch(path.Base(f))
+		if m == nil {
+			return nil, fmt.Errorf("%s: expected <locale>.v<version>.tmpl", f)
+		}
+		raw, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+		t, err := PTdomain.NewPromptTemplate(path.Base(path.Dir(f)), m[1], string(raw), "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		t.Version, _ = strconv.Atoi(m[2])
+		t.CreatedAt = time.Time{} // empaquetada: no tiene fecha de publicación
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// latest busca la versión más alta de un prompt e idioma
+func latest(ts []PTdomain.PromptTemplate, id string, locale PTdomain.Locale) (PTdomain.PromptTemplate, bool) {
+	var best PTdomain.PromptTemplate
+	found := false
+	for _, t := range ts {
+		if t.ID == id && t.Locale == locale && (!found || t.Version > best.Version) {
+			best, found = t, true
+		}
+	}
+	return best, found
+}
+
+func sortTemplates(ts []PTdomain.PromptTemplate) {
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].ID != ts[j].ID {
+			return ts[i].ID < ts[j].ID
+		}
+		if ts[i].Locale != ts[j].Locale {
+			return ts[i].Locale < ts[j].Locale
+		}
+		return ts[i].Version < ts[j].Version
+	})
+}
+
+func (r *PromptTemplateMYSQLRepository) List(ctx context.Context) ([]PTdomain.PromptTemplate, error) {
+	if r == nil || r.DB == nil {
+		return nil, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, locale, version, body, notes, checksum, created_at
+		  FROM prompt_templates
+	`
+	rows, err := r.DB.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := append([]PTdomain.PromptTemplate(nil), r.base...)
+	for rows.Next() {
+		var t PTdomain.PromptTemplate
+		if err := rows.Scan(&t.ID, &t.Locale, &t.Version, &t.Body, &t.Notes, &t.Checksum, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sortTemplates(out)
+	return out, nil
+}
+
+func (r *PromptTemplateMYSQLRepository) Latest(ctx context.Context, id string, locale PTdomain.Locale) (PTdomain.PromptTemplate, error) {
+	if r == nil || r.DB == nil {
+		return PTdomain.PromptTemplate{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, locale, version, body, notes, checksum, created_at
+		  FROM prompt_templates
+		 WHERE id = ? AND locale = ?
+		 ORDER BY version DESC
+		 LIMIT 1
+	`
+	var t PTdomain.PromptTemplate
+	err := r.DB.QueryRowContext(ctx, q, id, locale).Scan(&t.ID, &t.Locale, &t.Version, &t.Body, &t.Notes, &t.Checksum, &t.CreatedAt)
+	if err == nil {
+		return t, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return PTdomain.PromptTemplate{}, err
+	}
+	// Sin versiones publicadas: la empaquetada
+	if t, ok := latest(r.base, id, locale); ok {
+		return t, nil
+	}
+	return PTdomain.PromptTemplate{}, fmt.Errorf("%w: %s/%s", PTdomain.ErrTemplateNotFound, id, locale)
+}
+
+func (r *PromptTemplateMYSQLRepository) Publish(ctx context.Context, t *PTdomain.PromptTemplate) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if t == nil {
+		return errors.New("nil PTdomain.PromptTemplate")
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var maxVersion int
+	const qMax = `SELECT COALESCE(MAX(version), 0) FROM prompt_templates WHERE id = ? AND locale = ? FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, qMax, t.ID, t.Locale).Scan(&maxVersion); err != nil {
+		return err
+	}
+	if b, ok := latest(r.base, t.ID, t.Locale); ok && b.Version > maxVersion {
+		maxVersion = b.Version
+	}
+	t.Version = maxVersion + 1
+
+	const q = `
+		INSERT INTO prompt_templates (id, locale, version, body, notes, checksum, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	if _, err := tx.ExecContext(ctx, q, t.ID, t.Locale, t.Version, t.Body, t.Notes, t.Checksum, t.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *MockPromptTemplateRepository) List(ctx context.Context) ([]PTdomain.PromptTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := append([]PTdomain.PromptTemplate(nil), r.templates...)
+	sortTemplates(out)
+	return out, nil
+}
+
+func (r *MockPromptTemplateRepository) Latest(ctx context.Context, id string, locale PTdomain.Locale) (PTdomain.PromptTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := latest(r.templates, id, locale); ok {
+		return t, nil
+	}
+	return PTdomain.PromptTemplate{}, fmt.Errorf("%w: %s/%s", PTdomain.ErrTemplateNotFound, id, locale)
+}
+
+func (r *MockPromptTemplateRepository) Publish(ctx context.Context, t *PTdomain.PromptTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.Version = 1
+	if prev, ok := latest(r.templates, t.ID, t.Locale); ok {
+		t.Version = prev.Version + 1
+	}
+	r.templates = append(r.templates, *t)
+	return nil
+}
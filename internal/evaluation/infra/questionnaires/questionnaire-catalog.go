@@ -0,0 +1,95 @@
+package QNinfra
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+)
+
+// Definiciones versionadas: definitions/<code>.v<version>.json. Una versión publicada
+// no se modifica; los cambios de ítems o puntos de corte van en un fichero nuevo.
+//
+//go:embed definitions/*.json
+var embeddedDefinitions embed.FS
+
+type QuestionnaireCatalog struct {
+	byCode map[string][]QNdomain.Definition // ordenadas por versión ascendente
+}
+
+// NewQuestionnaireCatalog carga y valida todas las definiciones de fsys
+func NewQuestionnaireCatalog(fsys fs.FS) (*QuestionnaireCatalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	c := &QuestionnaireCatalog{byCode: map[string][]QNdomain.Definition{}}
+	for _, f := range files {
+		raw, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+		var def QNdomain.Definition
+		if err := json.Unmarshal(raw, &def); err != nil {
+			return nil, fmt.Errorf("%s: %w", path.Base(f), err)
+		}
+		if err := def.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path.Base(f), err)
+		}
+		for _, other := range c.byCode[def.Code] {
+			if other.Version == def.Version {
+				return nil, fmt.Errorf("%s: duplicated version %d of %s", path.Base(f), def.Version, def.Code)
+			}
+		}
+		c.byCode[def.Code] = append(c.byCode[def.Code], def)
+	}
+	for code := range c.byCode {
+		defs := c.byCode[code]
+		sort.Slice(defs, func(i, j int) bool { return defs[i].Version < defs[j].Version })
+	}
+	return c, nil
+}
+
+// NewEmbeddedQuestionnaireCatalog usa las definiciones empaquetadas en el binario;
+// una definición inválida es un error de programación, así que entra en pánico al arrancar.
+func NewEmbeddedQuestionnaireCatalog() *QuestionnaireCatalog {
+	sub, err := fs.Sub(embeddedDefinitions, "definitions")
+	if err != nil {
+		panic("questionnaire definitions: " + err.Error())
+	}
+	c, err := NewQuestionnaireCatalog(sub)
+	if err != nil {
+		panic("questionnaire definitions: " + err.Error())
+	}
+	return c
+}
+
+func (c *QuestionnaireCatalog) List(ctx context.Context) ([]QNdomain.Definition, error) {
+	out := make([]QNdomain.Definition, 0, len(c.byCode))
+	for _, defs := range c.byCode {
+		out = append(out, defs[len(defs)-1])
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out, nil
+}
+
+func (c *QuestionnaireCatalog) Get(ctx context.Context, code string, version int) (QNdomain.Definition, error) {
+	defs := c.byCode[code]
+	if len(defs) == 0 {
+		return QNdomain.Definition{}, fmt.Errorf("%w: %s", QNdomain.ErrDefinitionNotFound, code)
+	}
+	if version == 0 {
+		return defs[len(defs)-1], nil
+	}
+	for _, d := range defs {
+		if d.Version == version {
+			return d, nil
+		}
+	}
+	return QNdomain.Definition{}, fmt.Errorf("%w: %s v%d", QNdomain.ErrDefinitionNotFound, code, version)
+}
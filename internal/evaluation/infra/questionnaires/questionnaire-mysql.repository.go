@@ -0,0 +1,183 @@
+package QNinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+)
+
+type QuestionnaireMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockQuestionnaireRepository struct{}
+
+var MockQuestionnaireResponses []*QNdomain.QuestionnaireResponse = []*QNdomain.QuestionnaireResponse{
+	{
+		PK:           "questionnaire1",
+		EvaluationID: "eval1",
+		Code:         "gds-15",
+		Version:      1,
+		Name:         "Escala de Depresión Geriátrica (GDS-15)",
+		Construct:    "depression",
+		Answers:      map[string]int{"q1": 1, "q2": 1, "q3": 0, "q4": 1, "q5": 1, "q6": 0, "q7": 1, "q8": 0, "q9": 1, "q10": 1, "q11": 1, "q12": 0, "q13": 0, "q14": 0, "q15": 0},
+		Score: QNdomain.QuestionnaireScore{
+			Valid:          true,
+			Total:          5,
+			MaxTotal:       15,
+			Classification: "mild",
+			Label:          "Depresión leve",
+			Abnormal:       true,
+		},
+	},
+}
+
+func NewQuestionnaireMYSQLRepository(db *sql.DB) *QuestionnaireMYSQLRepository {
+	return &QuestionnaireMYSQLRepository{DB: db}
+}
+
+func NewMockQuestionnaireRepository() *MockQuestionnaireRepository {
+	return &MockQuestionnaireRepository{}
+}
+
+type questionnaireRow struct {
+	ID                string
+	EvaluationID      string
+	Code              string
+	Version           int
+	Name              string
+	Construct         string
+	Answers           []byte
+	Total             float64
+	Valid             bool
+	ScoreDetail       []byte
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(r *QNdomain.QuestionnaireResponse) (questionnaireRow, error) {
+	answers, err := json.Marshal(r.Answers)
+	if err != nil {
+		return questionnaireRow{}, err
+	}
+	detail, err := json.Marshal(r.Score)
+	if err != nil {
+		return questionnaireRow{}, err
+	}
+	return questionnaireRow{
+		ID:                r.PK,
+		EvaluationID:      r.EvaluationID,
+		Code:              r.Code,
+		Version:           r.Version,
+		Name:              r.Name,
+		Construct:         r.Construct,
+		Answers:           answers,
+		Total:             r.Score.Total,
+		Valid:             r.Score.Valid,
+		ScoreDetail:       detail,
+		AssistantAnalysis: sql.NullString{String: r.AssistantAnalysis, Valid: true},
+		CreatedAt:         r.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r questionnaireRow) toDomain() (QNdomain.QuestionnaireResponse, error) {
+	var answers map[string]int
+	if err := json.Unmarshal(r.Answers, &answers); err != nil {
+		return QNdomain.QuestionnaireResponse{}, err
+	}
+	var score QNdomain.QuestionnaireScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return QNdomain.QuestionnaireResponse{}, err
+	}
+	return QNdomain.QuestionnaireResponse{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Code:              r.Code,
+		Version:           r.Version,
+		Name:              r.Name,
+		Construct:         r.Construct,
+		Answers:           answers,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *QuestionnaireMYSQLRepository) Save(ctx context.Context, response *QNdomain.QuestionnaireResponse) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if response == nil {
+		return errors.New("nil QNdomain.QuestionnaireResponse")
+	}
+	row, err := toRow(response)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO questionnaire_responses
+		    (id, evaluation_id, code, version, name, construct, answers,
+		     total, valid, score_detail, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Code, row.Version, row.Name, row.Construct, row.Answers,
+		row.Total, row.Valid, row.ScoreDetail, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *QuestionnaireMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]QNdomain.QuestionnaireResponse, error) {
+	if r == nil || r.DB == nil {
+		return nil, errors.New("nil repo or DB")
+	}
+	// última administración de cada código
+	const q = `
+		SELECT q.id, q.evaluation_id, q.code, q.version, q.name, q.construct, q.answers,
+		       q.score_detail, q.assistant_analysis, q.created_at
+		  FROM questionnaire_responses q
+		 WHERE q.evaluation_id = ?
+		   AND q.created_at = (
+		       SELECT MAX(l.created_at) FROM questionnaire_responses l
+		        WHERE l.evaluation_id = q.evaluation_id AND l.code = q.code)
+		 ORDER BY q.code
+	`
+	rows, err := r.DB.QueryContext(ctx, q, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []QNdomain.QuestionnaireResponse{}
+	for rows.Next() {
+		var row questionnaireRow
+		if err := rows.Scan(
+			&row.ID, &row.EvaluationID, &row.Code, &row.Version, &row.Name, &row.Construct, &row.Answers,
+			&row.ScoreDetail, &row.AssistantAnalysis, &row.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		resp, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp)
+	}
+	return out, rows.Err()
+}
+
+func (r *MockQuestionnaireRepository) Save(ctx context.Context, response *QNdomain.QuestionnaireResponse) error {
+	return nil
+}
+
+func (r *MockQuestionnaireRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]QNdomain.QuestionnaireResponse, error) {
+	out := make([]QNdomain.QuestionnaireResponse, 0, len(MockQuestionnaireResponses))
+	for _, resp := range MockQuestionnaireResponses {
+		out = append(out, *resp)
+	}
+	return out, nil
+}
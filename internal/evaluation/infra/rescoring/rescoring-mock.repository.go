@@ -0,0 +1,158 @@
+package RSinfra
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+)
+
+// MockRescoringRepository guarda en memoria unas filas puntuadas con standard@1; "lc-stale"
+// conserva un score obsoleto para que el recálculo encuentre diferencias
+type MockRescoringRepository struct {
+	mu        sync.Mutex
+	lc        []LCdomain.LettersCancellationSubtest
+	vem       []VEMdomain.VerbalMemorySubtest
+	ef        []EFdomain.ExecutiveFunctionsSubtest
+	lf        []LFdomain.LanguageFluency
+	revisions map[string][]RSdomain.ScoreRevision
+}
+
+const mockProfile = "standard@1"
+
+func NewMockRescoringRepository() *MockRescoringRepository {
+	created := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	lc := LCdomain.LettersCancellationSubtest{PK: "lc-1", EvaluationID: "eval-1", TotalTargets: 50, Correct: 45, Errors: 5, TimeInSecs: 60, CreatedAt: created}
+	lc.CancellationScore, _ = LCdomain.ScoreLettersCancellation(lc, nil)
+	lc.CancellationScore.ScoringProfile = mockProfile
+	stale := LCdomain.LettersCancellationSubtest{PK: "lc-stale", EvaluationID: "eval-2", TotalTargets: 50, Correct: 40, Errors: 10, TimeInSecs: 70, CreatedAt: created}
+	stale.CancellationScore = LCdomain.CancellationScore{Score: 80, Accuracy: 0.8, Omissions: 10, ScoringProfile: mockProfile}
+
+	vem := VEMdomain.VerbalMemorySubtest{Pk: "vem-1", EvaluationID: "eval-1", GivenWords: []string{"casa", "perro", "sol", "mesa"}, RecalledWords: []string{"casa", "sol", "gato"}, Type: VEMdomain.VerbalMemorySubtypeImmediate, CreatedAt: created}
+	vem.Score, _ = VEMdomain.ScoreVerbalMemory(vem, nil)
+	vem.Score.ScoringProfile = mockProfile
+
+	ef := EFdomain.ExecutiveFunctionsSubtest{PK: "ef-1", EvauluationId: "eval-1", NumberOfItems: 25, TotalClicks: 27, TotalErrors: 2, TotalCorrect: 25, TotalTime: 40 * time.Second, Type: EFdomain.A, CreatedAt: created}
+	ef.Score, _ = EFdomain.ScoreExecutiveFunctions(ef, nil)
+	ef.Score.ScoringProfile = mockProfile
+
+	lf := LFdomain.LanguageFluency{PK: "lf-1", EvaluationID: "eval-1", Language: "es", Proficiency: "native", Category: "animales", AnswerWords: []string{"perro", "gato", "león", "gato"}, CreatedAt: created}
+	lf.Score, _ = LFdomain.ScoreLanguageFluency(lf, nil)
+	lf.Score.ScoringProfile = mockProfile
+
+	return &MockRescoringRepository{
+		lc:        []LCdomain.LettersCancellationSubtest{lc, stale},
+		vem:       []VEMdomain.VerbalMemorySubtest{vem},
+		ef:        []EFdomain.ExecutiveFunctionsSubtest{ef},
+		lf:        []LFdomain.LanguageFluency{lf},
+		revisions: map[string][]RSdomain.ScoreRevision{},
+	}
+}
+
+func filterByEvaluation[T any](rows []T, evaluationID string, idOf func(T) string) []T {
+	out := []T{}
+	for _, r := range rows {
+		if evaluationID == "" || idOf(r) == evaluationID {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (r *MockRescoringRepository) ListLetterCancellation(ctx context.Context, evaluationID string) ([]LCdomain.LettersCancellationSubtest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterByEvaluation(r.lc, evaluationID, func(s LCdomain.LettersCancellationSubtest) string { return s.EvaluationID }), nil
+}
+
+func (r *MockRescoringRepository) ListVerbalMemory(ctx context.Context, evaluationID string) ([]VEMdomain.VerbalMemorySubtest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterByEvaluation(r.vem, evaluationID, func(s VEMdomain.VerbalMemorySubtest) string { return s.EvaluationID }), nil
+}
+
+func (r *MockRescoringRepository) ListExecutiveFunctions(ctx context.Context, evaluationID string) ([]EFdomain.ExecutiveFunctionsSubtest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterByEvaluation(r.ef, evaluationID, func(s EFdomain.ExecutiveFunctionsSubtest) string { return s.EvauluationId }), nil
+}
+
+func (r *MockRescoringRepository) ListLanguageFluency(ctx context.Context, evaluationID string) ([]LFdomain.LanguageFluency, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterByEvaluation(r.lf, evaluationID, func(s LFdomain.LanguageFluency) string { return s.EvaluationID }), nil
+}
+
+func (r *MockRescoringRepository) ApplyLetterCancellation(ctx context.Context, sub LCdomain.LettersCancellationSubtest, score LCdomain.CancellationScore, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.lc {
+		if r.lc[i].PK == sub.PK {
+			r.addRevisions(RSdomain.LetterCancellation, sub.PK, sub.EvaluationID, r.lc[i].CancellationScore.ScoringProfile, r.lc[i].CancellationScore, score.ScoringProfile, score, runID)
+			r.lc[i].CancellationScore = score
+		}
+	}
+	return nil
+}
+
+func (r *MockRescoringRepository) ApplyVerbalMemory(ctx context.Context, sub VEMdomain.VerbalMemorySubtest, score VEMdomain.VerbalMemoryScore, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.vem {
+		if r.vem[i].Pk == sub.Pk {
+			r.addRevisions(RSdomain.VerbalMemory, sub.Pk, sub.EvaluationID, r.vem[i].Score.ScoringProfile, r.vem[i].Score, score.ScoringProfile, score, runID)
+			r.vem[i].Score = score
+		}
+	}
+	return nil
+}
+
+func (r *MockRescoringRepository) ApplyExecutiveFunctions(ctx context.Context, sub EFdomain.ExecutiveFunctionsSubtest, score EFdomain.ExecutiveFunctionsScore, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.ef {
+		if r.ef[i].PK == sub.PK {
+			r.addRevisions(RSdomain.ExecutiveFunctions, sub.PK, sub.EvauluationId, r.ef[i].Score.ScoringProfile, r.ef[i].Score, score.ScoringProfile, score, runID)
+			r.ef[i].TotalTime = sub.TotalTime
+			r.ef[i].Score = score
+		}
+	}
+	return nil
+}
+
+func (r *MockRescoringRepository) ApplyLanguageFluency(ctx context.Context, sub LFdomain.LanguageFluency, score LFdomain.LanguageFluencyScore, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.lf {
+		if r.lf[i].PK == sub.PK {
+			r.addRevisions(RSdomain.LanguageFluency, sub.PK, sub.EvaluationID, r.lf[i].Score.ScoringProfile, r.lf[i].Score, score.ScoringProfile, score, runID)
+			r.lf[i].Score = score
+		}
+	}
+	return nil
+}
+
+func (r *MockRescoringRepository) addRevisions(subtest RSdomain.Subtest, subtestID, evaluationID, oldProfile string, oldScore any, newProfile string, newScore any, runID string) {
+	key := string(subtest) + "/" + subtestID
+	revs := r.revisions[key]
+	if len(revs) == 0 {
+		raw, _ := json.Marshal(oldScore)
+		revs = append(revs, RSdomain.ScoreRevision{Subtest: subtest, SubtestID: subtestID, EvaluationID: evaluationID, Revision: 1, ScoringProfile: oldProfile, Score: raw})
+	}
+	raw, _ := json.Marshal(newScore)
+	revs = append(revs, RSdomain.ScoreRevision{Subtest: subtest, SubtestID: subtestID, EvaluationID: evaluationID, Revision: len(revs) + 1, ScoringProfile: newProfile, Score: raw, RunID: runID, CreatedAt: time.Now()})
+	r.revisions[key] = revs
+}
+
+func (r *MockRescoringRepository) GetRevisions(ctx context.Context, subtest RSdomain.Subtest, subtestID string) ([]RSdomain.ScoreRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RSdomain.ScoreRevision(nil), r.revisions[string(subtest)+"/"+subtestID]...), nil
+}
@@ -0,0 +1,229 @@
+package RSinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"neuro.app.jordi/database/dbmodels"
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
+	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
+	EFinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/executive-functions"
+	LFinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/language-fluency"
+	LCinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/letter-cancellation"
+	VEMinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/verbal-memory"
+)
+
+type RescoringMYSQLRepository struct {
+	DB *sql.DB
+}
+
+func NewRescoringMYSQLRepository(db *sql.DB) *RescoringMYSQLRepository {
+	return &RescoringMYSQLRepository{DB: db}
+}
+
+func byEvaluation(column, evaluationID string) []qm.QueryMod {
+	mods := []qm.QueryMod{qm.OrderBy(column)}
+	if evaluationID != "" {
+		mods = append(mods, qm.Where("evaluation_id = ?", evaluationID))
+	}
+	return mods
+}
+
+func (r *RescoringMYSQLRepository) ListLetterCancellation(ctx context.Context, evaluationID string) ([]LCdomain.LettersCancellationSubtest, error) {
+	rows, err := dbmodels.LettersCancellationSubtests(byEvaluation(dbmodels.LettersCancellationSubtestColumns.CreatedAt, evaluationID)...).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LCdomain.LettersCancellationSubtest, 0, len(rows))
+	for _, m := range rows {
+		out = append(out, LCinfra.DBToDomainLetterCancellation(m))
+	}
+	return out, nil
+}
+
+func (r *RescoringMYSQLRepository) ListVerbalMemory(ctx context.Context, evaluationID string) ([]VEMdomain.VerbalMemorySubtest, error) {
+	rows, err := dbmodels.VerbalMemorySubtests(byEvaluation(dbmodels.VerbalMemorySubtestColumns.CreatedAt, evaluationID)...).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]VEMdomain.VerbalMemorySubtest, 0, len(rows))
+	for _, m := range rows {
+		vm, err := VEMinfra.DBToDomainVerbalMemory(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vm)
+	}
+	return out, nil
+}
+
+func (r *RescoringMYSQLRepository) ListExecutiveFunctions(ctx context.Context, evaluationID string) ([]EFdomain.ExecutiveFunctionsSubtest, error) {
+	rows, err := dbmodels.ExecutiveFunctionsSubtests(byEvaluation(dbmodels.ExecutiveFunctionsSubtestColumns.CreatedAt, evaluationID)...).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]EFdomain.ExecutiveFunctionsSubtest, 0, len(rows))
+	for _, m := range rows {
+		out = append(out, EFinfra.DBToDomainExecutiveFunctions(m))
+	}
+	return out, nil
+}
+
+func (r *RescoringMYSQLRepository) ListLanguageFluency(ctx context.Context, evaluationID string) ([]LFdomain.LanguageFluency, error) {
+	rows, err := dbmodels.LanguageFluencies(byEvaluation(dbmodels.LanguageFluencyColumns.CreatedAt, evaluationID)...).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LFdomain.LanguageFluency, 0, len(rows))
+	for _, m := range rows {
+		out = append(out, LFinfra.DBToDomainLanguageFluency(m))
+	}
+	return out, nil
+}
+
+func (r *RescoringMYSQLRepository) ApplyLetterCancellation(ctx context.Context, sub LCdomain.LettersCancellationSubtest, score LCdomain.CancellationScore, runID string) error {
+	updated := sub
+	updated.CancellationScore = score
+	m := LCinfra.DomainToDBLetterCancellation(updated)
+	c := dbmodels.LettersCancellationSubtestColumns
+	cols := boil.Whitelist(c.Score, c.CPPerMin, c.Accuracy, c.Omissions, c.OmissionsRate, c.CommissionRate, c.HitsPerMin, c.ErrorsPerMin, c.ScoringProfile)
+	return r.apply(ctx, RSdomain.LetterCancellation, sub.PK, sub.EvaluationID, sub.CreatedAt, sub.CancellationScore.ScoringProfile, sub.CancellationScore, score.ScoringProfile, score, runID,
+		func(tx *sql.Tx) error {
+			_, err := m.Update(ctx, tx, cols)
+			return err
+		})
+}
+
+func (r *RescoringMYSQLRepository) ApplyVerbalMemory(ctx context.Context, sub VEMdomain.VerbalMemorySubtest, score VEMdomain.VerbalMemoryScore, runID string) error {
+	updated := sub
+	updated.Score = score
+	m, err := VEMinfra.DomainToDBVerbalMemory(updated)
+	if err != nil {
+		return err
+	}
+	c := dbmodels.VerbalMemorySubtestColumns
+	cols := boil.Whitelist(c.ScoreScore, c.ScoreHits, c.ScoreOmissions, c.ScoreIntrusions, c.ScorePerseverations, c.ScoreAccuracy, c.ScoreIntrusionRate, c.ScorePerseverationRate, c.ScoringProfile)
+	return r.apply(ctx, RSdomain.VerbalMemory, sub.Pk, sub.EvaluationID, sub.CreatedAt, sub.Score.ScoringProfile, sub.Score, score.ScoringProfile, score, runID,
+		func(tx *sql.Tx) error {
+			_, err := m.Update(ctx, tx, cols)
+			return err
+		})
+}
+
+func (r *RescoringMYSQLRepository) ApplyExecutiveFunctions(ctx context.Context, sub EFdomain.ExecutiveFunctionsSubtest, score EFdomain.ExecutiveFunctionsScore, runID string) error {
+	// El score archivado es el de la fila tal como estaba; sub.TotalTime ya viene reconstruido
+	previous := sub.Score
+	updated := sub
+	updated.Score = score
+	m := EFinfra.DomainToDBExecutiveFunctions(updated)
+	c := dbmodels.ExecutiveFunctionsSubtestColumns
+	cols := boil.Whitelist(c.TotalTimeSec, c.Score, c.Accuracy, c.SpeedIndex, c.CommissionRate, c.DurationSec, c.ScoringProfile)
+	return r.apply(ctx, RSdomain.ExecutiveFunctions, sub.PK, sub.EvauluationId, sub.CreatedAt, previous.ScoringProfile, previous, score.ScoringProfile, score, runID,
+		func(tx *sql.Tx) error {
+			_, err := m.Update(ctx, tx, cols)
+			return err
+		})
+}
+
+func (r *RescoringMYSQLRepository) ApplyLanguageFluency(ctx context.Context, sub LFdomain.LanguageFluency, score LFdomain.LanguageFluencyScore, runID string) error {
+	updated := sub
+	updated.Score = score
+	m := LFinfra.DomainToDBLanguageFluency(updated)
+	c := dbmodels.LanguageFluencyColumns
+	cols := boil.Whitelist(c.Score, c.UniqueValid, c.Intrusions, c.Perseverations, c.TotalProduced, c.WordsPerMinute, c.IntrusionRate, c.PersevRate, c.ScoringProfile)
+	return r.apply(ctx, RSdomain.LanguageFluency, sub.PK, sub.EvaluationID, sub.CreatedAt, sub.Score.ScoringProfile, sub.Score, score.ScoringProfile, score, runID,
+		func(tx *sql.Tx) error {
+			_, err := m.Update(ctx, tx, cols)
+			return err
+		})
+}
+
+// apply archiva el score original como revisión 1 si la fila no tiene historial, añade la
+// revisión nueva y ejecuta update, todo en una transacción
+func (r *RescoringMYSQLRepository) apply(ctx context.Context, subtest RSdomain.Subtest, subtestID, evaluationID string, subtestCreatedAt time.Time, oldProfile string, oldScore any, newProfile string, newScore any, runID string, update func(tx *sql.Tx) error) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	oldRaw, err := json.Marshal(oldScore)
+	if err != nil {
+		return err
+	}
+	newRaw, err := json.Marshal(newScore)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var last int
+	const qLast = `
+		SELECT COALESCE(MAX(revision), 0)
+		  FROM subtest_score_revisions
+		 WHERE subtest = ? AND subtest_id = ?
+		   FOR UPDATE
+	`
+	if err := tx.QueryRowContext(ctx, qLast, string(subtest), subtestID).Scan(&last); err != nil {
+		return err
+	}
+
+	const qInsert = `
+		INSERT INTO subtest_score_revisions (subtest, subtest_id, evaluation_id, revision, scoring_profile, score, run_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if last == 0 {
+		last = 1
+		if _, err := tx.ExecContext(ctx, qInsert, string(subtest), subtestID, evaluationID, last, oldProfile, oldRaw, nil, subtestCreatedAt.Truncate(time.Millisecond)); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, qInsert, string(subtest), subtestID, evaluationID, last+1, newProfile, newRaw, runID, time.Now().UTC().Truncate(time.Millisecond)); err != nil {
+		return err
+	}
+	if err := update(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *RescoringMYSQLRepository) GetRevisions(ctx context.Context, subtest RSdomain.Subtest, subtestID string) ([]RSdomain.ScoreRevision, error) {
+	if r == nil || r.DB == nil {
+		return nil, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT subtest, subtest_id, evaluation_id, revision, scoring_profile, score, COALESCE(run_id, ''), created_at
+		  FROM subtest_score_revisions
+		 WHERE subtest = ? AND subtest_id = ?
+		 ORDER BY revision
+	`
+	rows, err := r.DB.QueryContext(ctx, q, string(subtest), subtestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []RSdomain.ScoreRevision
+	for rows.Next() {
+		var rev RSdomain.ScoreRevision
+		var st string
+		var raw []byte
+		if err := rows.Scan(&st, &rev.SubtestID, &rev.EvaluationID, &rev.Revision, &rev.ScoringProfile, &raw, &rev.RunID, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		rev.Subtest = RSdomain.Subtest(st)
+		rev.Score = json.RawMessage(raw)
+		out = append(out, rev)
+	}
+	return out, rows.Err()
+}
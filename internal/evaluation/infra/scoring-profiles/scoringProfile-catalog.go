@@ -0,0 +1,149 @@
+package SCPinfra
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+)
+
+// Perfiles versionados: profiles/<id>.v<version>.json, y protocols.json con la asignación
+// protocolo -> id@versión. Un perfil publicado no se modifica (la huella lo comprueba al
+// arrancar); los cambios de fórmula van en una versión nueva y se asignan al protocolo.
+//
+//go:embed profiles/*.json protocols.json
+var embeddedProfiles embed.FS
+
+// ScoringProfilesDirEnv apunta a un directorio adicional con la misma estructura
+const ScoringProfilesDirEnv = "SCORING_PROFILES_DIR"
+
+type ScoringProfileCatalog struct {
+	byRef     map[string]SCPdomain.ScoringProfile
+	protocols map[string]string
+}
+
+// NewScoringProfileCatalog carga las fuentes en orden: los perfiles se acumulan (una misma
+// referencia solo puede repetirse con idénticos parámetros) y protocols.json de las fuentes
+// posteriores sustituye las asignaciones de las anteriores
+func NewScoringProfileCatalog(sources ...fs.FS) (*ScoringProfileCatalog, error) {
+	c := &ScoringProfileCatalog{byRef: map[string]SCPdomain.ScoringProfile{}, protocols: map[string]string{}}
+	for _, fsys := range sources {
+		files, err := fs.Glob(fsys, "profiles/*.json")
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			raw, err := fs.ReadFile(fsys, f)
+			if err != nil {
+				return nil, err
+			}
+			var p SCPdomain.ScoringProfile
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return nil, fmt.Errorf("%s: %w", path.Base(f), err)
+			}
+			if err := p.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: %w", path.Base(f), err)
+			}
+			if prev, ok := c.byRef[p.Ref()]; ok && prev.Fingerprint != p.Fingerprint {
+				return nil, fmt.Errorf("%s: %s redefined with different parameters", path.Base(f), p.Ref())
+			}
+			c.byRef[p.Ref()] = p
+		}
+
+		raw, err := fs.ReadFile(fsys, "protocols.json")
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var protocols map[string]string
+		if err := json.Unmarshal(raw, &protocols); err != nil {
+			return nil, fmt.Errorf("protocols.json: %w", err)
+		}
+		for name, ref := range protocols {
+			c.protocols[name] = ref
+		}
+	}
+
+	for name, ref := range c.protocols {
+		if _, ok := c.byRef[ref]; !ok {
+			return nil, fmt.Errorf("protocol %s: %w: %s", name, SCPdomain.ErrProfileNotFound, ref)
+		}
+	}
+	if _, ok := c.protocols[SCPdomain.DefaultProtocol]; !ok {
+		return nil, fmt.Errorf("%w: %s", SCPdomain.ErrProtocolNotFound, SCPdomain.DefaultProtocol)
+	}
+	return c, nil
+}
+
+// NewScoringProfileCatalogFromEnv usa los perfiles empaquetados más, si está definido,
+// el directorio SCORING_PROFILES_DIR de la clínica; un perfil inválido impide arrancar.
+func NewScoringProfileCatalogFromEnv() *ScoringProfileCatalog {
+	sources := []fs.FS{embeddedProfiles}
+	if dir := os.Getenv(ScoringProfilesDirEnv); dir != "" {
+		sources = append(sources, os.DirFS(dir))
+	}
+	c, err := NewScoringProfileCatalog(sources...)
+	if err != nil {
+		panic("scoring profiles: " + err.Error())
+	}
+	return c
+}
+
+// NewEmbeddedScoringProfileCatalog solo con los perfiles empaquetados (tests)
+func NewEmbeddedScoringProfileCatalog() *ScoringProfileCatalog {
+	c, err := NewScoringProfileCatalog(embeddedProfiles)
+	if err != nil {
+		panic("scoring profiles: " + err.Error())
+	}
+	return c
+}
+
+func (c *ScoringProfileCatalog) List(ctx context.Context) ([]SCPdomain.ScoringProfile, error) {
+	out := make([]SCPdomain.ScoringProfile, 0, len(c.byRef))
+	for _, p := range c.byRef {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ID != out[j].ID {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].Version < out[j].Version
+	})
+	return out, nil
+}
+
+func (c *ScoringProfileCatalog) Get(ctx context.Context, ref string) (SCPdomain.ScoringProfile, error) {
+	p, ok := c.byRef[ref]
+	if !ok {
+		return SCPdomain.ScoringProfile{}, fmt.Errorf("%w: %s", SCPdomain.ErrProfileNotFound, ref)
+	}
+	return p, nil
+}
+
+func (c *ScoringProfileCatalog) ForProtocol(ctx context.Context, protocol string) (SCPdomain.ScoringProfile, error) {
+	if protocol == "" {
+		protocol = SCPdomain.DefaultProtocol
+	}
+	ref, ok := c.protocols[protocol]
+	if !ok {
+		return SCPdomain.ScoringProfile{}, fmt.Errorf("%w: %s", SCPdomain.ErrProtocolNotFound, protocol)
+	}
+	return c.Get(ctx, ref)
+}
+
+func (c *ScoringProfileCatalog) Protocols(ctx context.Context) (map[string]string, error) {
+	out := make(map[string]string, len(c.protocols))
+	for k, v := range c.protocols {
+		out[k] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package SCPinfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+)
+
+type ScoringSelectionMYSQLRepository struct {
+	DB *sql.DB
+}
+
+// MockScoringSelectionRepository guarda en memoria para poder probar la fijación del protocolo
+type MockScoringSelectionRepository struct {
+	mu         sync.Mutex
+	selections map[string]SCPdomain.ScoringSelection
+}
+
+func NewScoringSelectionMYSQLRepository(db *sql.DB) *ScoringSelectionMYSQLRepository {
+	return &ScoringSelectionMYSQLRepository{DB: db}
+}
+
+func NewMockScoringSelectionRepository() *MockScoringSelectionRepository {
+	return &MockScoringSelectionRepository{selections: map[string]SCPdomain.ScoringSelection{}}
+}
+
+func (r *ScoringSelectionMYSQLRepository) Save(ctx context.Context, s *SCPdomain.ScoringSelection) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if s == nil {
+		return errors.New("nil SCPdomain.ScoringSelection")
+	}
+	const q = `
+		INSERT INTO evaluation_scoring_selections (evaluation_id, protocol, profile_ref, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.DB.ExecContext(ctx, q, s.EvaluationID, s.Protocol, s.ProfileRef, s.CreatedAt.Truncate(time.Millisecond))
+	return err
+}
+
+func (r *ScoringSelectionMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SCPdomain.ScoringSelection, error) {
+	if r == nil || r.DB == nil {
+		return SCPdomain.ScoringSelection{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT evaluation_id, protocol, profile_ref, created_at
+		  FROM evaluation_scoring_selections
+		 WHERE evaluation_id = ?
+	`
+	var s SCPdomain.ScoringSelection
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(&s.EvaluationID, &s.Protocol, &s.ProfileRef, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Sin protocolo elegido: se aplica el protocolo por defecto
+		return SCPdomain.ScoringSelection{}, nil
+	}
+	if err != nil {
+		return SCPdomain.ScoringSelection{}, err
+	}
+	return s, nil
+}
+
+func (r *MockScoringSelectionRepository) Save(ctx context.Context, s *SCPdomain.ScoringSelection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.selections[s.EvaluationID] = *s
+	return nil
+}
+
+func (r *MockScoringSelectionRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SCPdomain.ScoringSelection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.selections[evaluationID], nil
+}
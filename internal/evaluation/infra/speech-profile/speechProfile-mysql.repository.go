@@ -0,0 +1,146 @@
+package SPinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+)
+
+type SpeechProfileMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockSpeechProfileRepository struct{}
+
+var MockSpeechProfiles []*SPdomain.SpeechProfile = []*SPdomain.SpeechProfile{
+	{
+		PK:           "profile1",
+		EvaluationID: "eval1",
+		Source:       SPdomain.SourceLanguageFluency,
+		Metrics: SPdomain.Metrics{
+			DurationSec:      60,
+			SpeakingTimeSec:  42,
+			LoudnessDBFS:     -24.5,
+			PitchMeanHz:      128,
+			PitchSDSemitones: 2.8,
+			PauseRatio:       0.3,
+			SyllableNuclei:   170,
+			ArticulationRate: 4.05,
+		},
+	},
+}
+
+func NewSpeechProfileMYSQLRepository(db *sql.DB) *SpeechProfileMYSQLRepository {
+	return &SpeechProfileMYSQLRepository{DB: db}
+}
+
+func NewMockSpeechProfileRepository() *MockSpeechProfileRepository {
+	return &MockSpeechProfileRepository{}
+}
+
+type speechProfileRow struct {
+	ID               string
+	EvaluationID     string
+	Source           string
+	LoudnessDBFS     float64
+	PitchMeanHz      float64
+	PitchSDSemitones float64
+	PauseRatio       float64
+	ArticulationRate float64
+	Metrics          []byte
+	CreatedAt        time.Time
+}
+
+func toRow(p *SPdomain.SpeechProfile) (speechProfileRow, error) {
+	metrics, err := json.Marshal(p.Metrics)
+	if err != nil {
+		return speechProfileRow{}, err
+	}
+	return speechProfileRow{
+		ID:               p.PK,
+		EvaluationID:     p.EvaluationID,
+		Source:           string(p.Source),
+		LoudnessDBFS:     p.Metrics.LoudnessDBFS,
+		PitchMeanHz:      p.Metrics.PitchMeanHz,
+		PitchSDSemitones: p.Metrics.PitchSDSemitones,
+		PauseRatio:       p.Metrics.PauseRatio,
+		ArticulationRate: p.Metrics.ArticulationRate,
+		Metrics:          metrics,
+		CreatedAt:        p.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r speechProfileRow) toDomain() (SPdomain.SpeechProfile, error) {
+	var metrics SPdomain.Metrics
+	if err := json.Unmarshal(r.Metrics, &metrics); err != nil {
+		return SPdomain.SpeechProfile{}, err
+	}
+	return SPdomain.SpeechProfile{
+		PK:           r.ID,
+		EvaluationID: r.EvaluationID,
+		Source:       SPdomain.Source(r.Source),
+		Metrics:      metrics,
+		CreatedAt:    r.CreatedAt,
+	}, nil
+}
+
+func (r *SpeechProfileMYSQLRepository) Save(ctx context.Context, profile *SPdomain.SpeechProfile) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if profile == nil {
+		return errors.New("nil SPdomain.SpeechProfile")
+	}
+	row, err := toRow(profile)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO speech_profiles
+		    (id, evaluation_id, source, loudness_dbfs, pitch_mean_hz,
+		     pitch_sd_semitones, pause_ratio, articulation_rate, metrics, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Source, row.LoudnessDBFS, row.PitchMeanHz,
+		row.PitchSDSemitones, row.PauseRatio, row.ArticulationRate, row.Metrics, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *SpeechProfileMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SPdomain.SpeechProfile, error) {
+	if r == nil || r.DB == nil {
+		return SPdomain.SpeechProfile{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, source, metrics, created_at
+		  FROM speech_profiles
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row speechProfileRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Source, &row.Metrics, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Sin audio analizado en esta evaluación
+		return SPdomain.SpeechProfile{}, nil
+	}
+	if err != nil {
+		return SPdomain.SpeechProfile{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockSpeechProfileRepository) Save(ctx context.Context, profile *SPdomain.SpeechProfile) error {
+	return nil
+}
+
+func (r *MockSpeechProfileRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SPdomain.SpeechProfile, error) {
+	return *MockSpeechProfiles[0], nil
+}
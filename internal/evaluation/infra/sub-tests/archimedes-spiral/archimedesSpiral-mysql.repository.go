@@ -0,0 +1,153 @@
+package ASinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+)
+
+type ArchimedesSpiralMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockArchimedesSpiralRepository struct{}
+
+var MockArchimedesSpiralSubtests []*ASdomain.ArchimedesSpiralSubtest = []*ASdomain.ArchimedesSpiralSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Drawings: []ASdomain.SpiralDrawing{
+			{Hand: ASdomain.HandRight, Points: []ASdomain.SpiralPoint{{X: 200, Y: 200, AtMs: 0}, {X: 210, Y: 205, AtMs: 10}, {X: 215, Y: 215, AtMs: 20}}},
+		},
+		Score: ASdomain.SpiralScore{
+			Score: 80,
+			Right: ASdomain.HandSpiralScore{Present: true, Loops: 0.3, SpeedPxPerSec: 900},
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewArchimedesSpiralMYSQLRepository(db *sql.DB) *ArchimedesSpiralMYSQLRepository {
+	return &ArchimedesSpiralMYSQLRepository{DB: db}
+}
+
+func NewMockArchimedesSpiralRepository() *MockArchimedesSpiralRepository {
+	return &MockArchimedesSpiralRepository{}
+}
+
+type archimedesSpiralRow struct {
+	ID                string
+	EvaluationID      string
+	Drawings          []byte
+	Score             int
+	LeftTremorHz      float64
+	RightTremorHz     float64
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *ASdomain.ArchimedesSpiralSubtest) (archimedesSpiralRow, error) {
+	drawings, err := json.Marshal(s.Drawings)
+	if err != nil {
+		return archimedesSpiralRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return archimedesSpiralRow{}, err
+	}
+	return archimedesSpiralRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Drawings:          drawings,
+		Score:             s.Score.Score,
+		LeftTremorHz:      s.Score.Left.TremorFrequencyHz,
+		RightTremorHz:     s.Score.Right.TremorFrequencyHz,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r archimedesSpiralRow) toDomain() (ASdomain.ArchimedesSpiralSubtest, error) {
+	var drawings []ASdomain.SpiralDrawing
+	if err := json.Unmarshal(r.Drawings, &drawings); err != nil {
+		return ASdomain.ArchimedesSpiralSubtest{}, err
+	}
+	var score ASdomain.SpiralScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return ASdomain.ArchimedesSpiralSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return ASdomain.ArchimedesSpiralSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Drawings:          drawings,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *ArchimedesSpiralMYSQLRepository) Save(ctx context.Context, subtest *ASdomain.ArchimedesSpiralSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil ASdomain.ArchimedesSpiralSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO archimedes_spiral_subtests
+		    (id, evaluation_id, drawings, score, left_tremor_hz,
+		     right_tremor_hz, score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Drawings, row.Score, row.LeftTremorHz,
+		row.RightTremorHz, row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *ArchimedesSpiralMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (ASdomain.ArchimedesSpiralSubtest, error) {
+	if r == nil || r.DB == nil {
+		return ASdomain.ArchimedesSpiralSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, drawings, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM archimedes_spiral_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row archimedesSpiralRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Drawings, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return ASdomain.ArchimedesSpiralSubtest{}, nil
+	}
+	if err != nil {
+		return ASdomain.ArchimedesSpiralSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockArchimedesSpiralRepository) Save(ctx context.Context, subtest *ASdomain.ArchimedesSpiralSubtest) error {
+	return nil
+}
+
+func (r *MockArchimedesSpiralRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (ASdomain.ArchimedesSpiralSubtest, error) {
+	return *MockArchimedesSpiralSubtests[0], nil
+}
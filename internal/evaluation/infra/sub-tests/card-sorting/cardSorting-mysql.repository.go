@@ -0,0 +1,263 @@
+package WCSTinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+)
+
+type CardSortingMYSQLRepository struct {
+	DB *sql.DB
+}
+
+// MockCardSortingRepository guarda las sesiones en memoria para poder encadenar respuestas
+type MockCardSortingRepository struct {
+	mu       sync.Mutex
+	sessions map[string]WCSTdomain.CardSortingSubtest
+}
+
+var MockCardSortingSubtests []*WCSTdomain.CardSortingSubtest = []*WCSTdomain.CardSortingSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Status:       WCSTdomain.SessionCompleted,
+		MaxCards:     64,
+		Responses: []WCSTdomain.SortResponse{
+			{CardIndex: 0, KeyCard: 1, Rule: WCSTdomain.DimensionColor, Matches: []WCSTdomain.Dimension{WCSTdomain.DimensionColor}, Correct: true},
+		},
+		Score: WCSTdomain.CardSortingScore{
+			Score:                  67,
+			TrialsAdministered:     64,
+			CategoriesCompleted:    4,
+			TrialsToFirstCategory:  12,
+			TotalCorrect:           46,
+			TotalErrors:            18,
+			PerseverativeResponses: 10,
+			PerseverativeErrors:    8,
+			NonPerseverativeErrors: 10,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewCardSortingMYSQLRepository(db *sql.DB) *CardSortingMYSQLRepository {
+	return &CardSortingMYSQLRepository{DB: db}
+}
+
+func NewMockCardSortingRepository() *MockCardSortingRepository {
+	return &MockCardSortingRepository{sessions: map[string]WCSTdomain.CardSortingSubtest{}}
+}
+
+type cardSortingRow struct {
+	ID                  string
+	EvaluationID        string
+	Status              string
+	MaxCards            int
+	TrialsAdministered  int
+	Responses           []byte
+	Score               int
+	CategoriesCompleted int
+	PerseverativeErrors int
+	ScoreDetail         []byte
+	ScoringProfile      string
+	AssistantAnalysis   sql.NullString
+	CreatedAt           time.Time
+}
+
+func toRow(s *WCSTdomain.CardSortingSubtest) (cardSortingRow, error) {
+	responses, err := json.Marshal(s.Responses)
+	if err != nil {
+		return cardSortingRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return cardSortingRow{}, err
+	}
+	return cardSortingRow{
+		ID:                  s.PK,
+		EvaluationID:        s.EvaluationID,
+		Status:              string(s.Status),
+		MaxCards:            s.MaxCards,
+		TrialsAdministered:  len(s.Responses),
+		Responses:           responses,
+		Score:               s.Score.Score,
+		CategoriesCompleted: s.Score.CategoriesCompleted,
+		PerseverativeErrors: s.Score.PerseverativeErrors,
+		ScoreDetail:         detail,
+		ScoringProfile:      s.Score.ScoringProfile,
+		AssistantAnalysis:   sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:           s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r cardSortingRow) toDomain() (WCSTdomain.CardSortingSubtest, error) {
+	var responses []WCSTdomain.SortResponse
+	if err := json.Unmarshal(r.Responses, &responses); err != nil {
+		return WCSTdomain.CardSortingSubtest{}, err
+	}
+	var score WCSTdomain.CardSortingScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return WCSTdomain.CardSortingSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return WCSTdomain.CardSortingSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Status:            WCSTdomain.SessionStatus(r.Status),
+		MaxCards:          r.MaxCards,
+		Responses:         responses,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+const selectCardSorting = `
+		SELECT id, evaluation_id, status, max_cards, responses, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM card_sorting_subtests
+`
+
+func scanCardSorting(row *sql.Row) (cardSortingRow, error) {
+	var r cardSortingRow
+	err := row.Scan(&r.ID, &r.EvaluationID, &r.Status, &r.MaxCards, &r.Responses, &r.ScoreDetail, &r.ScoringProfile, &r.AssistantAnalysis, &r.CreatedAt)
+	return r, err
+}
+
+func (r *CardSortingMYSQLRepository) Save(ctx context.Context, subtest *WCSTdomain.CardSortingSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil WCSTdomain.CardSortingSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO card_sorting_subtests
+		    (id, evaluation_id, status, max_cards, trials_administered, responses, score,
+		     categories_completed, perseverative_errors, score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Status, row.MaxCards, row.TrialsAdministered, row.Responses, row.Score,
+		row.CategoriesCompleted, row.PerseverativeErrors, row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *CardSortingMYSQLRepository) Update(ctx context.Context, subtest *WCSTdomain.CardSortingSubtest, previousTrials int) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	// Bloqueo optimista: solo se aplica si la sesión sigue en el ensayo que leímos
+	const updateSQL = `
+		UPDATE card_sorting_subtests
+		   SET status = ?, trials_administered = ?, responses = ?, score = ?,
+		       categories_completed = ?, perseverative_errors = ?, score_detail = ?, scoring_profile = ?
+		 WHERE id = ? AND trials_administered = ?
+	`
+	res, err := r.DB.ExecContext(ctx, updateSQL,
+		row.Status, row.TrialsAdministered, row.Responses, row.Score,
+		row.CategoriesCompleted, row.PerseverativeErrors, row.ScoreDetail, row.ScoringProfile,
+		row.ID, previousTrials,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return WCSTdomain.ErrConcurrentResponses
+	}
+	return nil
+}
+
+func (r *CardSortingMYSQLRepository) GetByID(ctx context.Context, sessionID string) (WCSTdomain.CardSortingSubtest, error) {
+	if r == nil || r.DB == nil {
+		return WCSTdomain.CardSortingSubtest{}, errors.New("nil repo or DB")
+	}
+	row, err := scanCardSorting(r.DB.QueryRowContext(ctx, selectCardSorting+` WHERE id = ?`, sessionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return WCSTdomain.CardSortingSubtest{}, WCSTdomain.ErrSessionNotFound
+	}
+	if err != nil {
+		return WCSTdomain.CardSortingSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *CardSortingMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (WCSTdomain.CardSortingSubtest, error) {
+	if r == nil || r.DB == nil {
+		return WCSTdomain.CardSortingSubtest{}, errors.New("nil repo or DB")
+	}
+	const where = `
+		 WHERE evaluation_id = ? AND status = 'completed'
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	row, err := scanCardSorting(r.DB.QueryRowContext(ctx, selectCardSorting+where, evaluationID))
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado (o sin completar) en esta evaluación
+		return WCSTdomain.CardSortingSubtest{}, nil
+	}
+	if err != nil {
+		return WCSTdomain.CardSortingSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockCardSortingRepository) Save(ctx context.Context, subtest *WCSTdomain.CardSortingSubtest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[subtest.PK] = clone(*subtest)
+	return nil
+}
+
+func (r *MockCardSortingRepository) Update(ctx context.Context, subtest *WCSTdomain.CardSortingSubtest, previousTrials int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions[subtest.PK]
+	if !ok {
+		return WCSTdomain.ErrSessionNotFound
+	}
+	if len(stored.Responses) != previousTrials {
+		return WCSTdomain.ErrConcurrentResponses
+	}
+	r.sessions[subtest.PK] = clone(*subtest)
+	return nil
+}
+
+func (r *MockCardSortingRepository) GetByID(ctx context.Context, sessionID string) (WCSTdomain.CardSortingSubtest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[sessionID]; ok {
+		return clone(s), nil
+	}
+	if sessionID == MockCardSortingSubtests[0].PK {
+		return *MockCardSortingSubtests[0], nil
+	}
+	return WCSTdomain.CardSortingSubtest{}, WCSTdomain.ErrSessionNotFound
+}
+
+func (r *MockCardSortingRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (WCSTdomain.CardSortingSubtest, error) {
+	return *MockCardSortingSubtests[0], nil
+}
+
+// clone evita que el llamador modifique la sesión guardada a través del slice compartido
+func clone(s WCSTdomain.CardSortingSubtest) WCSTdomain.CardSortingSubtest {
+	s.Responses = append([]WCSTdomain.SortResponse{}, s.Responses...)
+	return s
+}
@@ -0,0 +1,165 @@
+package CNinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+)
+
+type ConfrontationNamingMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockConfrontationNamingRepository struct{}
+
+var MockConfrontationNamingSubtests []*CNdomain.ConfrontationNamingSubtest = []*CNdomain.ConfrontationNamingSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Version:      CNdomain.NamingVersion15,
+		Responses: []CNdomain.NamingResponse{
+			{Item: 1, Transcript: "una cama", Target: "cama", Matched: true, Outcome: CNdomain.NamingSpontaneous},
+			{Item: 5, Transcript: "silbido", Target: "silbato", Outcome: CNdomain.NamingIncorrectFinal, ErrorType: CNdomain.NamingErrorPhonemic},
+		},
+		Score: CNdomain.NamingScore{
+			Score:              7,
+			ItemsAdministered:  2,
+			SpontaneousCorrect: 1,
+			Total:              1,
+			Errors:             map[CNdomain.NamingErrorType]int{CNdomain.NamingErrorPhonemic: 1},
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewConfrontationNamingMYSQLRepository(db *sql.DB) *ConfrontationNamingMYSQLRepository {
+	return &ConfrontationNamingMYSQLRepository{DB: db}
+}
+
+func NewMockConfrontationNamingRepository() *MockConfrontationNamingRepository {
+	return &MockConfrontationNamingRepository{}
+}
+
+type namingRow struct {
+	ID                 string
+	EvaluationID       string
+	Version            int
+	Responses          []byte
+	Score              int
+	SpontaneousCorrect int
+	CuedCorrect        int
+	Total              int
+	ScoreDetail        []byte
+	ScoringProfile     string
+	AssistantAnalysis  sql.NullString
+	CreatedAt          time.Time
+}
+
+func toRow(s *CNdomain.ConfrontationNamingSubtest) (namingRow, error) {
+	responses, err := json.Marshal(s.Responses)
+	if err != nil {
+		return namingRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return namingRow{}, err
+	}
+	return namingRow{
+		ID:                 s.PK,
+		EvaluationID:       s.EvaluationID,
+		Version:            int(s.Version),
+		Responses:          responses,
+		Score:              s.Score.Score,
+		SpontaneousCorrect: s.Score.SpontaneousCorrect,
+		CuedCorrect:        s.Score.SemanticCued + s.Score.PhonemicCued,
+		Total:              s.Score.Total,
+		ScoreDetail:        detail,
+		ScoringProfile:     s.Score.ScoringProfile,
+		AssistantAnalysis:  sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:          s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r namingRow) toDomain() (CNdomain.ConfrontationNamingSubtest, error) {
+	var responses []CNdomain.NamingResponse
+	if err := json.Unmarshal(r.Responses, &responses); err != nil {
+		return CNdomain.ConfrontationNamingSubtest{}, err
+	}
+	var score CNdomain.NamingScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return CNdomain.ConfrontationNamingSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return CNdomain.ConfrontationNamingSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Version:           CNdomain.NamingVersion(r.Version),
+		Responses:         responses,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *ConfrontationNamingMYSQLRepository) Save(ctx context.Context, subtest *CNdomain.ConfrontationNamingSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil CNdomain.ConfrontationNamingSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO confrontation_naming_subtests
+		    (id, evaluation_id, version, responses, score,
+		     spontaneous_correct, cued_correct, total,
+		     score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Version, row.Responses, row.Score,
+		row.SpontaneousCorrect, row.CuedCorrect, row.Total,
+		row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *ConfrontationNamingMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (CNdomain.ConfrontationNamingSubtest, error) {
+	if r == nil || r.DB == nil {
+		return CNdomain.ConfrontationNamingSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, version, responses, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM confrontation_naming_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row namingRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Version, &row.Responses, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return CNdomain.ConfrontationNamingSubtest{}, nil
+	}
+	if err != nil {
+		return CNdomain.ConfrontationNamingSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockConfrontationNamingRepository) Save(ctx context.Context, subtest *CNdomain.ConfrontationNamingSubtest) error {
+	return nil
+}
+
+func (r *MockConfrontationNamingRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (CNdomain.ConfrontationNamingSubtest, error) {
+	return *MockConfrontationNamingSubtests[0], nil
+}
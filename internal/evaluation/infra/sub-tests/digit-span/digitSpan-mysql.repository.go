@@ -0,0 +1,174 @@
+package DSinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+)
+
+type DigitSpanMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockDigitSpanRepository struct{}
+
+var MockDigitSpanSubtests []*DSdomain.DigitSpanSubtest = []*DSdomain.DigitSpanSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Trials: []DSdomain.DigitSpanTrial{
+			{Condition: DSdomain.DigitSpanForward, SpanLength: 3, Presented: []int{5, 8, 2}, Response: []int{5, 8, 2}, Correct: true, Scored: true},
+			{Condition: DSdomain.DigitSpanBackward, SpanLength: 2, Presented: []int{2, 4}, Response: []int{4, 2}, Correct: true, Scored: true},
+		},
+		Score: DSdomain.DigitSpanScore{
+			Score:    7,
+			Forward:  DSdomain.DigitSpanConditionScore{Present: true, LongestSpan: 3, TotalCorrect: 1, TrialsAdministered: 1},
+			Backward: DSdomain.DigitSpanConditionScore{Present: true, LongestSpan: 2, TotalCorrect: 1, TrialsAdministered: 1},
+			TotalRaw: 2,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewDigitSpanMYSQLRepository(db *sql.DB) *DigitSpanMYSQLRepository {
+	return &DigitSpanMYSQLRepository{DB: db}
+}
+
+func NewMockDigitSpanRepository() *MockDigitSpanRepository {
+	return &MockDigitSpanRepository{}
+}
+
+type digitSpanRow struct {
+	ID                string
+	EvaluationID      string
+	Trials            []byte
+	Score             int
+	TotalRaw          int
+	ForwardLongest    int
+	ForwardCorrect    int
+	BackwardLongest   int
+	BackwardCorrect   int
+	SequencingLongest int
+	SequencingCorrect int
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(d *DSdomain.DigitSpanSubtest) (digitSpanRow, error) {
+	trials, err := json.Marshal(d.Trials)
+	if err != nil {
+		return digitSpanRow{}, err
+	}
+	detail, err := json.Marshal(d.Score)
+	if err != nil {
+		return digitSpanRow{}, err
+	}
+	return digitSpanRow{
+		ID:                d.PK,
+		EvaluationID:      d.EvaluationID,
+		Trials:            trials,
+		Score:             d.Score.Score,
+		TotalRaw:          d.Score.TotalRaw,
+		ForwardLongest:    d.Score.Forward.LongestSpan,
+		ForwardCorrect:    d.Score.Forward.TotalCorrect,
+		BackwardLongest:   d.Score.Backward.LongestSpan,
+		BackwardCorrect:   d.Score.Backward.TotalCorrect,
+		SequencingLongest: d.Score.Sequencing.LongestSpan,
+		SequencingCorrect: d.Score.Sequencing.TotalCorrect,
+		ScoreDetail:       detail,
+		ScoringProfile:    d.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: d.AssistantAnalysis, Valid: true},
+		CreatedAt:         d.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r digitSpanRow) toDomain() (DSdomain.DigitSpanSubtest, error) {
+	var trials []DSdomain.DigitSpanTrial
+	if err := json.Unmarshal(r.Trials, &trials); err != nil {
+		return DSdomain.DigitSpanSubtest{}, err
+	}
+	// El detalle por condición (z, percentil) se guarda completo en score_detail;
+	// las columnas planas existen para consultas.
+	var score DSdomain.DigitSpanScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return DSdomain.DigitSpanSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return DSdomain.DigitSpanSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Trials:            trials,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *DigitSpanMYSQLRepository) Save(ctx context.Context, subtest *DSdomain.DigitSpanSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil DSdomain.DigitSpanSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO digit_span_subtests
+		    (id, evaluation_id, trials, score, total_raw,
+		     forward_longest_span, forward_total_correct,
+		     backward_longest_span, backward_total_correct,
+		     sequencing_longest_span, sequencing_total_correct,
+		     score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Trials, row.Score, row.TotalRaw,
+		row.ForwardLongest, row.ForwardCorrect,
+		row.BackwardLongest, row.BackwardCorrect,
+		row.SequencingLongest, row.SequencingCorrect,
+		row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *DigitSpanMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (DSdomain.DigitSpanSubtest, error) {
+	if r == nil || r.DB == nil {
+		return DSdomain.DigitSpanSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, trials, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM digit_span_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row digitSpanRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Trials, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return DSdomain.DigitSpanSubtest{}, nil
+	}
+	if err != nil {
+		return DSdomain.DigitSpanSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockDigitSpanRepository) Save(ctx context.Context, subtest *DSdomain.DigitSpanSubtest) error {
+	return nil
+}
+
+func (r *MockDigitSpanRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (DSdomain.DigitSpanSubtest, error) {
+	return *MockDigitSpanSubtests[0], nil
+}
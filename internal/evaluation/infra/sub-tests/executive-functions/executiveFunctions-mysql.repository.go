@@ -47,7 +47,7 @@ func NewMockExecutiveFunctionsRepository() *MockExecutiveFunctionsRepository {
 	return &MockExecutiveFunctionsRepository{}
 }
 
-func domainToDBExecutiveFunctions(s EFdomain.ExecutiveFunctionsSubtest) *dbmodels.ExecutiveFunctionsSubtest {
+func DomainToDBExecutiveFunctions(s EFdomain.ExecutiveFunctionsSubtest) *dbmodels.ExecutiveFunctionsSubtest {
 	return &dbmodels.ExecutiveFunctionsSubtest{
 		ID:                s.PK,
 		EvaluationID:      s.EvauluationId,
@@ -55,20 +55,21 @@ func domainToDBExecutiveFunctions(s EFdomain.ExecutiveFunctionsSubtest) *dbmodel
 		TotalClicks:       s.TotalClicks,
 		TotalErrors:       s.TotalErrors,
 		TotalCorrect:      s.TotalCorrect,
-		TotalTimeSec:      float64(s.Score.Score),
+		TotalTimeSec:      s.TotalTime.Seconds(),
 		Type:              string(s.Type),
 		Score:             s.Score.Score,
 		Accuracy:          s.Score.Accuracy,
 		SpeedIndex:        s.Score.SpeedIndex,
 		CommissionRate:    s.Score.CommissionRate,
 		DurationSec:       s.Score.DurationSec,
+		ScoringProfile:    s.Score.ScoringProfile,
 		AssistantAnalysis: null.StringFrom(s.AssistanAnalys),
 		CreatedAt:         s.CreatedAt,
 	}
 }
 
 // DB (sqlboiler) -> Domain
-func dBToDomainExecutiveFunctions(m *dbmodels.ExecutiveFunctionsSubtest) EFdomain.ExecutiveFunctionsSubtest {
+func DBToDomainExecutiveFunctions(m *dbmodels.ExecutiveFunctionsSubtest) EFdomain.ExecutiveFunctionsSubtest {
 	d := EFdomain.ExecutiveFunctionsSubtest{
 		PK:            m.ID,
 		EvauluationId: m.EvaluationID,
@@ -84,6 +85,7 @@ func dBToDomainExecutiveFunctions(m *dbmodels.ExecutiveFunctionsSubtest) EFdomai
 			SpeedIndex:     m.SpeedIndex,
 			CommissionRate: m.CommissionRate,
 			DurationSec:    m.DurationSec,
+			ScoringProfile: m.ScoringProfile,
 		},
 		AssistanAnalys: m.AssistantAnalysis.String,
 		CreatedAt:      m.CreatedAt,
@@ -92,7 +94,7 @@ func dBToDomainExecutiveFunctions(m *dbmodels.ExecutiveFunctionsSubtest) EFdomai
 }
 
 func (m *ExecutivefunctionsMYSQLRepository) Save(ctx context.Context, subtest EFdomain.ExecutiveFunctionsSubtest) error {
-	dbExecutiveFunctionSubtest := domainToDBExecutiveFunctions(subtest)
+	dbExecutiveFunctionSubtest := DomainToDBExecutiveFunctions(subtest)
 	return dbExecutiveFunctionSubtest.Insert(ctx, m.Exec, boil.Infer())
 }
 func (m *ExecutivefunctionsMYSQLRepository) GetByID(ctx context.Context, id string) (EFdomain.ExecutiveFunctionsSubtest, error) {
@@ -102,7 +104,7 @@ func (m *ExecutivefunctionsMYSQLRepository) GetByID(ctx context.Context, id stri
 	if err != nil {
 		return EFdomain.ExecutiveFunctionsSubtest{}, nil
 	}
-	return dBToDomainExecutiveFunctions(dbExecutiveFunctionSubtest), nil
+	return DBToDomainExecutiveFunctions(dbExecutiveFunctionSubtest), nil
 }
 
 func (m *ExecutivefunctionsMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) ([]EFdomain.ExecutiveFunctionsSubtest, error) {
@@ -117,7 +119,7 @@ func (m *ExecutivefunctionsMYSQLRepository) GetByEvaluationID(ctx context.Contex
 		return []EFdomain.ExecutiveFunctionsSubtest{}, errors.New("no executive functions subtest found for evaluation")
 	}
 	for _, subtest := range dbExecutiveFunctionSubtest {
-		out = append(out, dBToDomainExecutiveFunctions(subtest))
+		out = append(out, DBToDomainExecutiveFunctions(subtest))
 	}
 	return out, nil
 }
@@ -0,0 +1,155 @@
+package FTinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+)
+
+type FingerTappingMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockFingerTappingRepository struct{}
+
+var MockFingerTappingSubtests []*FTdomain.FingerTappingSubtest = []*FTdomain.FingerTappingSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Trials: []FTdomain.HandTrial{
+			{Hand: FTdomain.HandRight, WindowMs: 10_000, Taps: []FTdomain.Tap{{AtMs: 0, X: 100, Y: 300}, {AtMs: 220, X: 300, Y: 300}}},
+		},
+		Score: FTdomain.FingerTappingScore{
+			Score: 4,
+			Right: FTdomain.HandScore{Present: true, Taps: 2, RateHz: 0.2, MeanIntervalMs: 220, MeanAmplitude: 200},
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewFingerTappingMYSQLRepository(db *sql.DB) *FingerTappingMYSQLRepository {
+	return &FingerTappingMYSQLRepository{DB: db}
+}
+
+func NewMockFingerTappingRepository() *MockFingerTappingRepository {
+	return &MockFingerTappingRepository{}
+}
+
+type fingerTappingRow struct {
+	ID                string
+	EvaluationID      string
+	Trials            []byte
+	Score             int
+	LeftRateHz        float64
+	RightRateHz       float64
+	RateAsymmetryPct  float64
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *FTdomain.FingerTappingSubtest) (fingerTappingRow, error) {
+	trials, err := json.Marshal(s.Trials)
+	if err != nil {
+		return fingerTappingRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return fingerTappingRow{}, err
+	}
+	return fingerTappingRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Trials:            trials,
+		Score:             s.Score.Score,
+		LeftRateHz:        s.Score.Left.RateHz,
+		RightRateHz:       s.Score.Right.RateHz,
+		RateAsymmetryPct:  s.Score.RateAsymmetryPct,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r fingerTappingRow) toDomain() (FTdomain.FingerTappingSubtest, error) {
+	var trials []FTdomain.HandTrial
+	if err := json.Unmarshal(r.Trials, &trials); err != nil {
+		return FTdomain.FingerTappingSubtest{}, err
+	}
+	var score FTdomain.FingerTappingScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return FTdomain.FingerTappingSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return FTdomain.FingerTappingSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Trials:            trials,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *FingerTappingMYSQLRepository) Save(ctx context.Context, subtest *FTdomain.FingerTappingSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil FTdomain.FingerTappingSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO finger_tapping_subtests
+		    (id, evaluation_id, trials, score, left_rate_hz,
+		     right_rate_hz, rate_asymmetry_pct, score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Trials, row.Score, row.LeftRateHz,
+		row.RightRateHz, row.RateAsymmetryPct, row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *FingerTappingMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (FTdomain.FingerTappingSubtest, error) {
+	if r == nil || r.DB == nil {
+		return FTdomain.FingerTappingSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, trials, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM finger_tapping_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row fingerTappingRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Trials, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return FTdomain.FingerTappingSubtest{}, nil
+	}
+	if err != nil {
+		return FTdomain.FingerTappingSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockFingerTappingRepository) Save(ctx context.Context, subtest *FTdomain.FingerTappingSubtest) error {
+	return nil
+}
+
+func (r *MockFingerTappingRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (FTdomain.FingerTappingSubtest, error) {
+	return *MockFingerTappingSubtests[0], nil
+}
@@ -0,0 +1,163 @@
+package GNGinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
+)
+
+type GoNoGoMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockGoNoGoRepository struct{}
+
+var MockGoNoGoSubtests []*GNGdomain.GoNoGoSubtest = []*GNGdomain.GoNoGoSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Blocks:       4,
+		Trials: []GNGdomain.GoNoGoTrial{
+			{Stimulus: GNGdomain.StimulusGo, OnsetMs: 0},
+			{Stimulus: GNGdomain.StimulusNoGo, OnsetMs: 1500},
+		},
+		Score: GNGdomain.GoNoGoScore{
+			Score:          90,
+			GoTrials:       60,
+			NoGoTrials:     20,
+			Commissions:    2,
+			CommissionRate: 0.1,
+			DPrime:         2.9,
+			MedianRTMs:     410,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewGoNoGoMYSQLRepository(db *sql.DB) *GoNoGoMYSQLRepository {
+	return &GoNoGoMYSQLRepository{DB: db}
+}
+
+func NewMockGoNoGoRepository() *MockGoNoGoRepository {
+	return &MockGoNoGoRepository{}
+}
+
+type goNoGoRow struct {
+	ID                string
+	EvaluationID      string
+	Trials            []byte
+	Blocks            int
+	Score             int
+	CommissionRate    float64
+	DPrime            float64
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *GNGdomain.GoNoGoSubtest) (goNoGoRow, error) {
+	trials, err := json.Marshal(s.Trials)
+	if err != nil {
+		return goNoGoRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return goNoGoRow{}, err
+	}
+	return goNoGoRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Trials:            trials,
+		Blocks:            s.Blocks,
+		Score:             s.Score.Score,
+		CommissionRate:    s.Score.CommissionRate,
+		DPrime:            s.Score.DPrime,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r goNoGoRow) toDomain() (GNGdomain.GoNoGoSubtest, error) {
+	var trials []GNGdomain.GoNoGoTrial
+	if err := json.Unmarshal(r.Trials, &trials); err != nil {
+		return GNGdomain.GoNoGoSubtest{}, err
+	}
+	var score GNGdomain.GoNoGoScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return GNGdomain.GoNoGoSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return GNGdomain.GoNoGoSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Blocks:            r.Blocks,
+		Trials:            trials,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *GoNoGoMYSQLRepository) Save(ctx context.Context, subtest *GNGdomain.GoNoGoSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil GNGdomain.GoNoGoSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO go_no_go_subtests
+		    (id, evaluation_id, trials, blocks, score,
+		     commission_rate, d_prime, score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Trials, row.Blocks, row.Score,
+		row.CommissionRate, row.DPrime, row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *GoNoGoMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (GNGdomain.GoNoGoSubtest, error) {
+	if r == nil || r.DB == nil {
+		return GNGdomain.GoNoGoSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, trials, blocks, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM go_no_go_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row goNoGoRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Trials, &row.Blocks, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return GNGdomain.GoNoGoSubtest{}, nil
+	}
+	if err != nil {
+		return GNGdomain.GoNoGoSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockGoNoGoRepository) Save(ctx context.Context, subtest *GNGdomain.GoNoGoSubtest) error {
+	return nil
+}
+
+func (r *MockGoNoGoRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (GNGdomain.GoNoGoSubtest, error) {
+	return *MockGoNoGoSubtests[0], nil
+}
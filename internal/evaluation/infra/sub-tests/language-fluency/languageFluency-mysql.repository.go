@@ -79,6 +79,7 @@ func DomainToDBLanguageFluency(s LFdomain.LanguageFluency) *dbmodels.LanguageFlu
 		WordsPerMinute:    s.Score.WordsPerMinute,
 		IntrusionRate:     s.Score.IntrusionRate,
 		PersevRate:        s.Score.PersevRate,
+		ScoringProfile:    s.Score.ScoringProfile,
 		AssistantAnalysis: null.StringFrom(s.AssistantAnalysis),
 		CreatedAt:         s.CreatedAt, // if your column is TIMESTAMP NOT NULL
 	}
@@ -105,6 +106,7 @@ func DBToDomainLanguageFluency(m *dbmodels.LanguageFluency) LFdomain.LanguageFlu
 			WordsPerMinute: m.WordsPerMinute,
 			IntrusionRate:  m.IntrusionRate,
 			PersevRate:     m.PersevRate,
+			ScoringProfile: m.ScoringProfile,
 		},
 		AssistantAnalysis: m.AssistantAnalysis.String,
 		CreatedAt:         m.CreatedAt,
@@ -67,7 +67,7 @@ func NewMYSQLLetterCancellationRepository(db *sql.DB) *LetterCancellationMYSQLRe
 	}
 }
 
-func domainToDBLetterCancellation(subtest LCdomain.LettersCancellationSubtest) *dbmodels.LettersCancellationSubtest {
+func DomainToDBLetterCancellation(subtest LCdomain.LettersCancellationSubtest) *dbmodels.LettersCancellationSubtest {
 	return &dbmodels.LettersCancellationSubtest{
 		ID:                subtest.PK,
 		EvaluationID:      subtest.EvaluationID,
@@ -84,11 +84,12 @@ func domainToDBLetterCancellation(subtest LCdomain.LettersCancellationSubtest) *
 		CommissionRate:    subtest.CancellationScore.CommissionRate,
 		HitsPerMin:        subtest.CancellationScore.HitsPerMin,
 		ErrorsPerMin:      subtest.CancellationScore.ErrorsPerMin,
+		ScoringProfile:    subtest.CancellationScore.ScoringProfile,
 		CreatedAt:         subtest.CreatedAt,
 	}
 }
 
-func dbToDomainLetterCancellation(model *dbmodels.LettersCancellationSubtest) LCdomain.LettersCancellationSubtest {
+func DBToDomainLetterCancellation(model *dbmodels.LettersCancellationSubtest) LCdomain.LettersCancellationSubtest {
 	return LCdomain.LettersCancellationSubtest{
 		PK:                model.ID,
 		EvaluationID:      model.EvaluationID,
@@ -107,12 +108,13 @@ func dbToDomainLetterCancellation(model *dbmodels.LettersCancellationSubtest) LC
 			CommissionRate: model.CommissionRate,
 			HitsPerMin:     model.HitsPerMin,
 			ErrorsPerMin:   model.ErrorsPerMin,
+			ScoringProfile: model.ScoringProfile,
 		},
 	}
 }
 
 func (repo *LetterCancellationMYSQLRepository) Save(ctx context.Context, subtest *LCdomain.LettersCancellationSubtest) error {
-	dbLetterCancellation := domainToDBLetterCancellation(*subtest)
+	dbLetterCancellation := DomainToDBLetterCancellation(*subtest)
 	return dbLetterCancellation.Insert(ctx, repo.Exec, boil.Infer())
 }
 
@@ -124,7 +126,7 @@ func (repo *LetterCancellationMYSQLRepository) GetByEvaluationID(ctx context.Con
 		return LCdomain.LettersCancellationSubtest{}, err
 	}
 
-	return dbToDomainLetterCancellation(dbLetterCancellation), nil
+	return DBToDomainLetterCancellation(dbLetterCancellation), nil
 
 }
 
@@ -0,0 +1,162 @@
+package JLOinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+)
+
+type JLOMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockJLORepository struct{}
+
+var MockJLOSubtests []*JLOdomain.JLOSubtest = []*JLOdomain.JLOSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Form:         JLOdomain.JLOFormFull,
+		Sex:          JLOdomain.SexMale,
+		Responses: []JLOdomain.JLOResponse{
+			{Item: 1, PresentedAtMs: 0, RespondedAtMs: 3500, Selected: [2]int{1, 6}},
+		},
+		Score: JLOdomain.JLOScore{
+			Score:          24,
+			RawCorrect:     21,
+			Prorated:       21,
+			AgeCorrection:  3,
+			Classification: JLOdomain.JLOLowAverage,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewJLOMYSQLRepository(db *sql.DB) *JLOMYSQLRepository {
+	return &JLOMYSQLRepository{DB: db}
+}
+
+func NewMockJLORepository() *MockJLORepository {
+	return &MockJLORepository{}
+}
+
+type jloRow struct {
+	ID                string
+	EvaluationID      string
+	Responses         []byte
+	Form              string
+	Sex               string
+	Score             int
+	RawCorrect        int
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *JLOdomain.JLOSubtest) (jloRow, error) {
+	responses, err := json.Marshal(s.Responses)
+	if err != nil {
+		return jloRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return jloRow{}, err
+	}
+	return jloRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Responses:         responses,
+		Form:              string(s.Form),
+		Sex:               string(s.Sex),
+		Score:             s.Score.Score,
+		RawCorrect:        s.Score.RawCorrect,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r jloRow) toDomain() (JLOdomain.JLOSubtest, error) {
+	var responses []JLOdomain.JLOResponse
+	if err := json.Unmarshal(r.Responses, &responses); err != nil {
+		return JLOdomain.JLOSubtest{}, err
+	}
+	var score JLOdomain.JLOScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return JLOdomain.JLOSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return JLOdomain.JLOSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Form:              JLOdomain.JLOForm(r.Form),
+		Sex:               JLOdomain.Sex(r.Sex),
+		Responses:         responses,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *JLOMYSQLRepository) Save(ctx context.Context, subtest *JLOdomain.JLOSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil JLOdomain.JLOSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO jlo_subtests
+		    (id, evaluation_id, responses, form, sex,
+		     score, raw_correct, score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Responses, row.Form, row.Sex,
+		row.Score, row.RawCorrect, row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *JLOMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (JLOdomain.JLOSubtest, error) {
+	if r == nil || r.DB == nil {
+		return JLOdomain.JLOSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, responses, form, sex, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM jlo_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row jloRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Responses, &row.Form, &row.Sex, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return JLOdomain.JLOSubtest{}, nil
+	}
+	if err != nil {
+		return JLOdomain.JLOSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockJLORepository) Save(ctx context.Context, subtest *JLOdomain.JLOSubtest) error {
+	return nil
+}
+
+func (r *MockJLORepository) GetByEvaluationID(ctx context.Context, evaluationID string) (JLOdomain.JLOSubtest, error) {
+	return *MockJLOSubtests[0], nil
+}
@@ -0,0 +1,181 @@
+package MOCAinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+)
+
+type MoCAMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockMoCARepository struct{}
+
+var MockMoCASubtests []*MOCAdomain.MoCASubtest = []*MOCAdomain.MoCASubtest{
+	{
+		PK:             "subtest1",
+		EvaluationID:   "eval1",
+		Version:        MOCAdomain.MoCAVersion71,
+		EducationYears: 10,
+		Items: MOCAdomain.MoCAItems{
+			Trails:         1,
+			Cube:           1,
+			Clock:          &MOCAdomain.MoCAClock{Contour: true, Numbers: true},
+			Naming:         3,
+			DigitsForward:  true,
+			DigitsBackward: true,
+			Serial7Correct: 4,
+			Abstraction:    2,
+			Orientation:    6,
+		},
+		ClockSource:   MOCAdomain.SourceEntered,
+		FluencySource: MOCAdomain.SourceEntered,
+		Score: MOCAdomain.MoCAScore{
+			Total:          25,
+			RawTotal:       24,
+			EducationPoint: 1,
+			Classification: "mild",
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewMoCAMYSQLRepository(db *sql.DB) *MoCAMYSQLRepository {
+	return &MoCAMYSQLRepository{DB: db}
+}
+
+func NewMockMoCARepository() *MockMoCARepository {
+	return &MockMoCARepository{}
+}
+
+type mocaRow struct {
+	ID                string
+	EvaluationID      string
+	Items             []byte
+	Version           string
+	EducationYears    int
+	ClockSource       string
+	FluencySource     string
+	Score             int
+	Classification    string
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *MOCAdomain.MoCASubtest) (mocaRow, error) {
+	items, err := json.Marshal(s.Items)
+	if err != nil {
+		return mocaRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return mocaRow{}, err
+	}
+	return mocaRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Items:             items,
+		Version:           string(s.Version),
+		EducationYears:    s.EducationYears,
+		ClockSource:       string(s.ClockSource),
+		FluencySource:     string(s.FluencySource),
+		Score:             s.Score.Total,
+		Classification:    s.Score.Classification,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r mocaRow) toDomain() (MOCAdomain.MoCASubtest, error) {
+	var items MOCAdomain.MoCAItems
+	if err := json.Unmarshal(r.Items, &items); err != nil {
+		return MOCAdomain.MoCASubtest{}, err
+	}
+	var score MOCAdomain.MoCAScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return MOCAdomain.MoCASubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return MOCAdomain.MoCASubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Version:           MOCAdomain.MoCAVersion(r.Version),
+		EducationYears:    r.EducationYears,
+		Items:             items,
+		ClockSource:       MOCAdomain.ItemSource(r.ClockSource),
+		FluencySource:     MOCAdomain.ItemSource(r.FluencySource),
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *MoCAMYSQLRepository) Save(ctx context.Context, subtest *MOCAdomain.MoCASubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil MOCAdomain.MoCASubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO moca_subtests
+		    (id, evaluation_id, items, version, education_years,
+		     clock_source, fluency_source, score, classification, score_detail, scoring_profile,
+		     assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Items, row.Version, row.EducationYears,
+		row.ClockSource, row.FluencySource, row.Score, row.Classification, row.ScoreDetail, row.ScoringProfile,
+		row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *MoCAMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (MOCAdomain.MoCASubtest, error) {
+	if r == nil || r.DB == nil {
+		return MOCAdomain.MoCASubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, version, education_years, items, clock_source, fluency_source,
+		       score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM moca_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row mocaRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Version, &row.EducationYears, &row.Items, &row.ClockSource, &row.FluencySource,
+		&row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return MOCAdomain.MoCASubtest{}, nil
+	}
+	if err != nil {
+		return MOCAdomain.MoCASubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockMoCARepository) Save(ctx context.Context, subtest *MOCAdomain.MoCASubtest) error {
+	return nil
+}
+
+func (r *MockMoCARepository) GetByEvaluationID(ctx context.Context, evaluationID string) (MOCAdomain.MoCASubtest, error) {
+	return *MockMoCASubtests[0], nil
+}
@@ -0,0 +1,162 @@
+package RTinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+)
+
+type ReactionTimeMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockReactionTimeRepository struct{}
+
+var MockReactionTimeSubtests []*RTdomain.ReactionTimeSubtest = []*RTdomain.ReactionTimeSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Trials: []RTdomain.RTTrial{
+			{Condition: RTdomain.RTSimple, StimulusAtMs: 1000, ResponseAtMs: 1310},
+			{Condition: RTdomain.RTChoice, Stimulus: "left", Response: "left", StimulusAtMs: 5000, ResponseAtMs: 5420},
+		},
+		Score: RTdomain.ReactionTimeScore{
+			Score:      42,
+			Simple:     RTdomain.RTConditionScore{Present: true, Trials: 1, Valid: 1, MedianMs: 310, MeanMs: 310},
+			Choice:     RTdomain.RTConditionScore{Present: true, Trials: 1, Valid: 1, MedianMs: 420, MeanMs: 420},
+			MotorSpeed: RTdomain.MotorSpeedCovariate{Available: true, SimpleMedianMs: 310, ZScore: 0.2, DecisionTimeMs: 110},
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewReactionTimeMYSQLRepository(db *sql.DB) *ReactionTimeMYSQLRepository {
+	return &ReactionTimeMYSQLRepository{DB: db}
+}
+
+func NewMockReactionTimeRepository() *MockReactionTimeRepository {
+	return &MockReactionTimeRepository{}
+}
+
+type reactionTimeRow struct {
+	ID                string
+	EvaluationID      string
+	Trials            []byte
+	Score             int
+	SimpleMedianMs    float64
+	ChoiceMedianMs    float64
+	MotorZ            float64
+	MotorSlowed       bool
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *RTdomain.ReactionTimeSubtest) (reactionTimeRow, error) {
+	trials, err := json.Marshal(s.Trials)
+	if err != nil {
+		return reactionTimeRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return reactionTimeRow{}, err
+	}
+	return reactionTimeRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Trials:            trials,
+		Score:             s.Score.Score,
+		SimpleMedianMs:    s.Score.Simple.MedianMs,
+		ChoiceMedianMs:    s.Score.Choice.MedianMs,
+		MotorZ:            s.Score.MotorSpeed.ZScore,
+		MotorSlowed:       s.Score.MotorSpeed.Slowed,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r reactionTimeRow) toDomain() (RTdomain.ReactionTimeSubtest, error) {
+	var trials []RTdomain.RTTrial
+	if err := json.Unmarshal(r.Trials, &trials); err != nil {
+		return RTdomain.ReactionTimeSubtest{}, err
+	}
+	var score RTdomain.ReactionTimeScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return RTdomain.ReactionTimeSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return RTdomain.ReactionTimeSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Trials:            trials,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *ReactionTimeMYSQLRepository) Save(ctx context.Context, subtest *RTdomain.ReactionTimeSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil RTdomain.ReactionTimeSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO reaction_time_subtests
+		    (id, evaluation_id, trials, score,
+		     simple_median_ms, choice_median_ms, motor_z, motor_slowed,
+		     score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Trials, row.Score,
+		row.SimpleMedianMs, row.ChoiceMedianMs, row.MotorZ, row.MotorSlowed,
+		row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *ReactionTimeMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (RTdomain.ReactionTimeSubtest, error) {
+	if r == nil || r.DB == nil {
+		return RTdomain.ReactionTimeSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, trials, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM reaction_time_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row reactionTimeRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Trials, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return RTdomain.ReactionTimeSubtest{}, nil
+	}
+	if err != nil {
+		return RTdomain.ReactionTimeSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockReactionTimeRepository) Save(ctx context.Context, subtest *RTdomain.ReactionTimeSubtest) error {
+	return nil
+}
+
+func (r *MockReactionTimeRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (RTdomain.ReactionTimeSubtest, error) {
+	return *MockReactionTimeSubtests[0], nil
+}
@@ -0,0 +1,160 @@
+package SDMTinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+)
+
+type SDMTMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockSDMTRepository struct{}
+
+var MockSDMTSubtests []*SDMTdomain.SDMTSubtest = []*SDMTdomain.SDMTSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Mode:         SDMTdomain.SDMTWritten,
+		Responses: []SDMTdomain.SDMTResponse{
+			{Item: 0, Expected: 3, Given: 3, ElapsedMs: 1500, Correct: true},
+			{Item: 1, Expected: 7, Given: 1, ElapsedMs: 3200, Correct: false},
+		},
+		Score: SDMTdomain.SDMTScore{
+			Score:     1,
+			Correct:   1,
+			Errors:    1,
+			Attempted: 2,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewSDMTMYSQLRepository(db *sql.DB) *SDMTMYSQLRepository {
+	return &SDMTMYSQLRepository{DB: db}
+}
+
+func NewMockSDMTRepository() *MockSDMTRepository {
+	return &MockSDMTRepository{}
+}
+
+type sdmtRow struct {
+	ID                string
+	EvaluationID      string
+	Mode              string
+	Responses         []byte
+	Score             int
+	Correct           int
+	Errors            int
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+func toRow(s *SDMTdomain.SDMTSubtest) (sdmtRow, error) {
+	responses, err := json.Marshal(s.Responses)
+	if err != nil {
+		return sdmtRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return sdmtRow{}, err
+	}
+	return sdmtRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Mode:              string(s.Mode),
+		Responses:         responses,
+		Score:             s.Score.Score,
+		Correct:           s.Score.Correct,
+		Errors:            s.Score.Errors,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r sdmtRow) toDomain() (SDMTdomain.SDMTSubtest, error) {
+	var responses []SDMTdomain.SDMTResponse
+	if err := json.Unmarshal(r.Responses, &responses); err != nil {
+		return SDMTdomain.SDMTSubtest{}, err
+	}
+	var score SDMTdomain.SDMTScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return SDMTdomain.SDMTSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return SDMTdomain.SDMTSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Mode:              SDMTdomain.SDMTMode(r.Mode),
+		Responses:         responses,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *SDMTMYSQLRepository) Save(ctx context.Context, subtest *SDMTdomain.SDMTSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil SDMTdomain.SDMTSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO sdmt_subtests
+		    (id, evaluation_id, mode, responses, score, correct, errors,
+		     score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Mode, row.Responses, row.Score, row.Correct, row.Errors,
+		row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *SDMTMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SDMTdomain.SDMTSubtest, error) {
+	if r == nil || r.DB == nil {
+		return SDMTdomain.SDMTSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, mode, responses, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM sdmt_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row sdmtRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Mode, &row.Responses, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return SDMTdomain.SDMTSubtest{}, nil
+	}
+	if err != nil {
+		return SDMTdomain.SDMTSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockSDMTRepository) Save(ctx context.Context, subtest *SDMTdomain.SDMTSubtest) error {
+	return nil
+}
+
+func (r *MockSDMTRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (SDMTdomain.SDMTSubtest, error) {
+	return *MockSDMTSubtests[0], nil
+}
@@ -0,0 +1,169 @@
+package STRinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
+)
+
+type StroopMYSQLRepository struct {
+	DB *sql.DB
+}
+
+type MockStroopRepository struct{}
+
+var MockStroopSubtests []*STRdomain.StroopSubtest = []*STRdomain.StroopSubtest{
+	{
+		PK:           "subtest1",
+		EvaluationID: "eval1",
+		Word:         STRdomain.StroopConditionResult{Condition: STRdomain.StroopWord, ItemsCompleted: 95, Errors: 1},
+		Color:        STRdomain.StroopConditionResult{Condition: STRdomain.StroopColor, ItemsCompleted: 70, Errors: 2},
+		ColorWord:    STRdomain.StroopConditionResult{Condition: STRdomain.StroopColorWord, ItemsCompleted: 40, Errors: 3, SelfCorrections: 2},
+		Score: STRdomain.StroopScore{
+			Score:              50,
+			PredictedColorWord: 40.3,
+			Interference:       -0.3,
+			InterferenceT:      49.6,
+		},
+		AssistantAnalysis: "Good performance.",
+	},
+}
+
+func NewStroopMYSQLRepository(db *sql.DB) *StroopMYSQLRepository {
+	return &StroopMYSQLRepository{DB: db}
+}
+
+func NewMockStroopRepository() *MockStroopRepository {
+	return &MockStroopRepository{}
+}
+
+type stroopRow struct {
+	ID                string
+	EvaluationID      string
+	Conditions        []byte
+	Score             int
+	WordItems         int
+	ColorItems        int
+	ColorWordItems    int
+	Interference      float64
+	ScoreDetail       []byte
+	ScoringProfile    string
+	AssistantAnalysis sql.NullString
+	CreatedAt         time.Time
+}
+
+type stroopConditions struct {
+	Word      STRdomain.StroopConditionResult `json:"word"`
+	Color     STRdomain.StroopConditionResult `json:"color"`
+	ColorWord STRdomain.StroopConditionResult `json:"colorWord"`
+}
+
+func toRow(s *STRdomain.StroopSubtest) (stroopRow, error) {
+	conditions, err := json.Marshal(stroopConditions{Word: s.Word, Color: s.Color, ColorWord: s.ColorWord})
+	if err != nil {
+		return stroopRow{}, err
+	}
+	detail, err := json.Marshal(s.Score)
+	if err != nil {
+		return stroopRow{}, err
+	}
+	return stroopRow{
+		ID:                s.PK,
+		EvaluationID:      s.EvaluationID,
+		Conditions:        conditions,
+		Score:             s.Score.Score,
+		WordItems:         s.Word.ItemsCompleted,
+		ColorItems:        s.Color.ItemsCompleted,
+		ColorWordItems:    s.ColorWord.ItemsCompleted,
+		Interference:      s.Score.Interference,
+		ScoreDetail:       detail,
+		ScoringProfile:    s.Score.ScoringProfile,
+		AssistantAnalysis: sql.NullString{String: s.AssistantAnalysis, Valid: true},
+		CreatedAt:         s.CreatedAt.Truncate(time.Millisecond),
+	}, nil
+}
+
+func (r stroopRow) toDomain() (STRdomain.StroopSubtest, error) {
+	var conditions stroopConditions
+	if err := json.Unmarshal(r.Conditions, &conditions); err != nil {
+		return STRdomain.StroopSubtest{}, err
+	}
+	var score STRdomain.StroopScore
+	if err := json.Unmarshal(r.ScoreDetail, &score); err != nil {
+		return STRdomain.StroopSubtest{}, err
+	}
+	score.ScoringProfile = r.ScoringProfile
+	return STRdomain.StroopSubtest{
+		PK:                r.ID,
+		EvaluationID:      r.EvaluationID,
+		Word:              conditions.Word,
+		Color:             conditions.Color,
+		ColorWord:         conditions.ColorWord,
+		Score:             score,
+		AssistantAnalysis: r.AssistantAnalysis.String,
+		CreatedAt:         r.CreatedAt,
+	}, nil
+}
+
+func (r *StroopMYSQLRepository) Save(ctx context.Context, subtest *STRdomain.StroopSubtest) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	if subtest == nil {
+		return errors.New("nil STRdomain.StroopSubtest")
+	}
+	row, err := toRow(subtest)
+	if err != nil {
+		return err
+	}
+	const insertSQL = `
+		INSERT INTO stroop_subtests
+		    (id, evaluation_id, conditions, score,
+		     word_items, color_items, color_word_items, interference,
+		     score_detail, scoring_profile, assistant_analysis, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.DB.ExecContext(ctx, insertSQL,
+		row.ID, row.EvaluationID, row.Conditions, row.Score,
+		row.WordItems, row.ColorItems, row.ColorWordItems, row.Interference,
+		row.ScoreDetail, row.ScoringProfile, row.AssistantAnalysis, row.CreatedAt,
+	)
+	return err
+}
+
+func (r *StroopMYSQLRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (STRdomain.StroopSubtest, error) {
+	if r == nil || r.DB == nil {
+		return STRdomain.StroopSubtest{}, errors.New("nil repo or DB")
+	}
+	const q = `
+		SELECT id, evaluation_id, conditions, score_detail, scoring_profile, assistant_analysis, created_at
+		  FROM stroop_subtests
+		 WHERE evaluation_id = ?
+		 ORDER BY created_at DESC
+		 LIMIT 1
+	`
+	var row stroopRow
+	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
+		&row.ID, &row.EvaluationID, &row.Conditions, &row.ScoreDetail, &row.ScoringProfile, &row.AssistantAnalysis, &row.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Subtest opcional: no administrado en esta evaluación
+		return STRdomain.StroopSubtest{}, nil
+	}
+	if err != nil {
+		return STRdomain.StroopSubtest{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *MockStroopRepository) Save(ctx context.Context, subtest *STRdomain.StroopSubtest) error {
+	return nil
+}
+
+func (r *MockStroopRepository) GetByEvaluationID(ctx context.Context, evaluationID string) (STRdomain.StroopSubtest, error) {
+	return *MockStroopSubtests[0], nil
+}
@@ -75,7 +75,7 @@ func DBToDomainVerbalMemory(m *dbmodels.VerbalMemorySubtest) (VEMdomain.VerbalMe
 	_ = json.Unmarshal(m.GivenWords, &given)
 
 	var recalled []string
-	_ = json.Unmarshal(m.GivenWords, &recalled)
+	_ = json.Unmarshal(m.RecalledWords, &recalled)
 
 	vm := VEMdomain.VerbalMemorySubtest{
 		Pk:               m.ID,
@@ -93,6 +93,7 @@ func DBToDomainVerbalMemory(m *dbmodels.VerbalMemorySubtest) (VEMdomain.VerbalMe
 			Accuracy:          m.ScoreAccuracy,
 			IntrusionRate:     m.ScoreIntrusionRate,
 			PerseverationRate: m.ScorePerseverationRate,
+			ScoringProfile:    m.ScoringProfile,
 		},
 		AssistanAnalysis: m.AssistanAnalysis,
 		CreatedAt:        m.CreatedAt,
@@ -131,6 +132,7 @@ func DomainToDBVerbalMemory(d VEMdomain.VerbalMemorySubtest) (*dbmodels.VerbalMe
 		ScoreAccuracy:          d.Score.Accuracy,
 		ScoreIntrusionRate:     d.Score.IntrusionRate,
 		ScorePerseverationRate: d.Score.PerseverationRate,
+		ScoringProfile:         d.Score.ScoringProfile,
 		AssistanAnalysis:       d.AssistanAnalysis,
 		CreatedAt:              d.CreatedAt,
 	}, nil
@@ -12,16 +12,18 @@ import (
 
 func transformDomain(d VIMdomain.VisualMemorySubtest) *dbmodels.VisualMemorySubtest {
 	return &dbmodels.VisualMemorySubtest{
-		ID:           d.PK,
-		EvaluationID: d.EvaluationID,
-		Note:         d.Note.Val,
-		Score:        d.Score.Val,
-		CreatedAt:    d.CreatedAt,
-		UpdatedAt:    d.UpdatedAt,
+		ID:             d.PK,
+		EvaluationID:   d.EvaluationID,
+		Note:           d.Note.Val,
+		Score:          d.Score.Val,
+		ScoringProfile: d.Score.ScoringProfile,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
 	}
 }
 func transformDB(db *dbmodels.VisualMemorySubtest) *VIMdomain.VisualMemorySubtest {
 	d, _ := VIMdomain.NewVisualMemorySubtestFromDB(db.ID, db.EvaluationID, &db.ImageSRC.String, int(db.Score), db.Note, db.CreatedAt, db.UpdatedAt)
+	d.Score.ScoringProfile = db.ScoringProfile
 	return &d
 }
 
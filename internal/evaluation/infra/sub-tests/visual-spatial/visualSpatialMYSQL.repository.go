@@ -44,14 +44,15 @@ func (r *VisualSpatialMYSQLRepo) Save(ctx context.Context, res *VPdomain.VisualS
 	// UPDATE primero (optimista)
 	const updateSQL = `
 		UPDATE visual_spatial_subtest
-		   SET evaluation_id = ?,
-		       score         = ?,
-		       note          = ?,
-		       updated_at    = ?
+		   SET evaluation_id   = ?,
+		       score           = ?,
+		       note            = ?,
+		       scoring_profile = ?,
+		       updated_at      = ?
 		 WHERE id = ?
 	`
 	ur, err := r.DB.ExecContext(ctx, updateSQL,
-		row.EvaluationID, row.Score, row.Note, row.UpdatedAt, row.ID,
+		row.EvaluationID, row.Score, row.Note, row.ScoringProfile, row.UpdatedAt, row.ID,
 	)
 	if err != nil {
 		return err
@@ -67,11 +68,11 @@ func (r *VisualSpatialMYSQLRepo) Save(ctx context.Context, res *VPdomain.VisualS
 	// Si no existe, INSERT
 	const insertSQL = `
 		INSERT INTO visual_spatial_subtest
-		    (id, evaluation_id, score, note, created_at, updated_at)
-		VALUES (?,  ?,            ?,     ?,    ?,          ?)
+		    (id, evaluation_id, score, note, scoring_profile, created_at, updated_at)
+		VALUES (?,  ?,            ?,     ?,    ?,               ?,          ?)
 	`
 	_, err = r.DB.ExecContext(ctx, insertSQL,
-		row.ID, row.EvaluationID, row.Score, row.Note, row.CreatedAt, row.UpdatedAt,
+		row.ID, row.EvaluationID, row.Score, row.Note, row.ScoringProfile, row.CreatedAt, row.UpdatedAt,
 	)
 	return err
 }
@@ -81,14 +82,14 @@ func (r *VisualSpatialMYSQLRepo) GetByID(ctx context.Context, id string) (*VPdom
 		return nil, errors.New("nil repo or DB")
 	}
 	const q = `
-		SELECT id, evaluation_id, score, note, created_at, updated_at
+		SELECT id, evaluation_id, score, note, scoring_profile, created_at, updated_at
 		  FROM visual_spatial_subtest
 		 WHERE id = ?
 		 LIMIT 1
 	`
 	var row visualSpatialRow
 	err := r.DB.QueryRowContext(ctx, q, id).Scan(
-		&row.ID, &row.EvaluationID, &row.Score, &row.Note, &row.CreatedAt, &row.UpdatedAt,
+		&row.ID, &row.EvaluationID, &row.Score, &row.Note, &row.ScoringProfile, &row.CreatedAt, &row.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err // sql.ErrNoRows si no existe
@@ -102,7 +103,7 @@ func (r *VisualSpatialMYSQLRepo) GetByEvaluationID(ctx context.Context, evaluati
 	}
 	// Si hubiera más de un registro por evaluation_id, devolvemos el más reciente.
 	const q = `
-		SELECT id, evaluation_id, score, note, created_at, updated_at
+		SELECT id, evaluation_id, score, note, scoring_profile, created_at, updated_at
 		  FROM visual_spatial_subtest
 		 WHERE evaluation_id = ?
 		 ORDER BY created_at DESC
@@ -110,7 +111,7 @@ func (r *VisualSpatialMYSQLRepo) GetByEvaluationID(ctx context.Context, evaluati
 	`
 	var row visualSpatialRow
 	err := r.DB.QueryRowContext(ctx, q, evaluationID).Scan(
-		&row.ID, &row.EvaluationID, &row.Score, &row.Note, &row.CreatedAt, &row.UpdatedAt,
+		&row.ID, &row.EvaluationID, &row.Score, &row.Note, &row.ScoringProfile, &row.CreatedAt, &row.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err // sql.ErrNoRows si no existe
@@ -119,28 +120,30 @@ func (r *VisualSpatialMYSQLRepo) GetByEvaluationID(ctx context.Context, evaluati
 }
 
 type visualSpatialRow struct {
-	ID           string
-	EvaluationID string
-	Score        int
-	Note         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID             string
+	EvaluationID   string
+	Score          int
+	Note           string
+	ScoringProfile string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func toRow(d *VPdomain.VisualSpatialSubtest) visualSpatialRow {
 	// Mapea el typo del dominio: EvalautionId -> evaluation_id
 	return visualSpatialRow{
-		ID:           d.Id,
-		EvaluationID: d.EvalautionId,
-		Score:        d.Score.Val,
-		Note:         d.Note.Val,
-		CreatedAt:    d.CreatedAt.Truncate(time.Millisecond),
-		UpdatedAt:    d.UpdatedAt.Truncate(time.Millisecond),
+		ID:             d.Id,
+		EvaluationID:   d.EvalautionId,
+		Score:          d.Score.Val,
+		Note:           d.Note.Val,
+		ScoringProfile: d.Score.ScoringProfile,
+		CreatedAt:      d.CreatedAt.Truncate(time.Millisecond),
+		UpdatedAt:      d.UpdatedAt.Truncate(time.Millisecond),
 	}
 }
 
 func (r visualSpatialRow) toDomain() (*VPdomain.VisualSpatialSubtest, error) {
-	return VPdomain.NewVisualSpatialSubtestFromExisting(
+	d, err := VPdomain.NewVisualSpatialSubtestFromExisting(
 		r.ID,
 		r.EvaluationID,
 		r.Note,
@@ -148,6 +151,11 @@ func (r visualSpatialRow) toDomain() (*VPdomain.VisualSpatialSubtest, error) {
 		r.CreatedAt,
 		r.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	d.Score.ScoringProfile = r.ScoringProfile
+	return d, nil
 }
 
 func (r *MockVisualSpatialRepository) Save(ctx context.Context, res *VPdomain.VisualSpatialSubtest) error {
@@ -0,0 +1,81 @@
+package STCinfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+)
+
+// commentaryColumns: tabla y columna de análisis de cada subtest (memoria verbal arrastra la
+// errata original de la columna)
+var commentaryColumns = map[string][2]string{
+	STCdomain.LettersCancellation: {"letters_cancellation_subtests", "assistant_analysis"},
+	STCdomain.VerbalMemory:        {"verbal_memory_subtests", "assistan_analysis"},
+	STCdomain.ExecutiveFunctions:  {"executive_functions_subtests", "assistant_analysis"},
+	STCdomain.LanguageFluency:     {"language_fluencies", "assistant_analysis"},
+	STCdomain.DigitSpan:           {"digit_span_subtests", "assistant_analysis"},
+	STCdomain.Stroop:              {"stroop_subtests", "assistant_analysis"},
+	STCdomain.SDMT:                {"sdmt_subtests", "assistant_analysis"},
+	STCdomain.ConfrontationNaming: {"confrontation_naming_subtests", "assistant_analysis"},
+	STCdomain.MotorSpeed:          {"reaction_time_subtests", "assistant_analysis"},
+	STCdomain.FingerTapping:       {"finger_tapping_subtests", "assistant_analysis"},
+	STCdomain.ArchimedesSpiral:    {"archimedes_spiral_subtests", "assistant_analysis"},
+	STCdomain.JLO:                 {"jlo_subtests", "assistant_analysis"},
+	STCdomain.GoNoGo:              {"go_no_go_subtests", "assistant_analysis"},
+	STCdomain.CardSorting:         {"card_sorting_subtests", "assistant_analysis"},
+	STCdomain.MoCA:                {"moca_subtests", "assistant_analysis"},
+}
+
+type SubtestCommentaryMYSQLRepository struct {
+	DB *sql.DB
+}
+
+// MockSubtestCommentaryRepository guarda en memoria los comentarios por subtest/fila
+type MockSubtestCommentaryRepository struct {
+	mu         sync.Mutex
+	commentary map[string]string
+}
+
+func NewSubtestCommentaryMYSQLRepository(db *sql.DB) *SubtestCommentaryMYSQLRepository {
+	return &SubtestCommentaryMYSQLRepository{DB: db}
+}
+
+func NewMockSubtestCommentaryRepository() *MockSubtestCommentaryRepository {
+	return &MockSubtestCommentaryRepository{commentary: map[string]string{}}
+}
+
+func (r *SubtestCommentaryMYSQLRepository) Save(ctx context.Context, subtest, subtestID, commentary string) error {
+	if r == nil || r.DB == nil {
+		return errors.New("nil repo or DB")
+	}
+	col, ok := commentaryColumns[subtest]
+	if !ok {
+		return fmt.Errorf("no commentary column for subtest %q", subtest)
+	}
+	// tabla y columna salen del mapa fijo de arriba, nunca de la entrada
+	q := fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", col[0], col[1])
+	_, err := r.DB.ExecContext(ctx, q, commentary, subtestID)
+	return err
+}
+
+func (r *MockSubtestCommentaryRepository) Save(ctx context.Context, subtest, subtestID, commentary string) error {
+	if _, ok := commentaryColumns[subtest]; !ok {
+		return fmt.Errorf("no commentary column for subtest %q", subtest)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commentary[subtest+"/"+subtestID] = commentary
+	return nil
+}
+
+// Get devuelve lo guardado para una fila; solo lo usan las pruebas
+func (r *MockSubtestCommentaryRepository) Get(subtest, subtestID string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.commentary[subtest+"/"+subtestID]
+	return c, ok
+}
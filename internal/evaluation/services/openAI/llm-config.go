@@ -0,0 +1,70 @@
+package services
+
+import (
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"neuro.app.jordi/internal/shared/config"
+)
+
+const (
+	ProviderOpenAI = "openai"
+	ProviderLocal  = "local" // llama.cpp, Ollama u otro servidor con /v1/chat/completions
+
+	defaultLocalBaseURL = "http://localhost:11434/v1" // Ollama
+	defaultLocalModel   = "llama3.1"
+	defaultLLMTimeout   = 2 * time.Minute
+)
+
+type LLMConfig struct {
+	Provider string
+	BaseURL  string // vacío = api.openai.com
+	APIKey   string
+	Model    string
+	Timeout  time.Duration
+	// AllowedModels son los que una petición puede pedir en LLMOptions.Model además de Model
+	AllowedModels []string
+}
+
+// LLMConfigFromAppConfig toma los ajustes LLM_* de la configuración de la app, que en local
+// también lee .env.local; sin configuración se usan los valores por defecto
+func LLMConfigFromAppConfig(c *config.AppConfig) LLMConfig {
+	if c == nil {
+		return LLMConfig{}
+	}
+	cfg := LLMConfig{
+		Provider: c.LLMProvider,
+		BaseURL:  c.LLMBaseURL,
+		APIKey:   c.LLMAPIKey,
+		Model:    c.LLMModel,
+
+		AllowedModels: c.LLMAllowedModels,
+	}
+	// OPENAI_API_KEY se mantiene por compatibilidad
+	if cfg.APIKey == "" && (cfg.Provider == "" || cfg.Provider == ProviderOpenAI) {
+		cfg.APIKey = c.OpenAIKey
+	}
+	if c.LLMTimeoutSeconds > 0 {
+		cfg.Timeout = time.Duration(c.LLMTimeoutSeconds) * time.Second
+	}
+	return cfg
+}
+
+func (c LLMConfig) withDefaults() LLMConfig {
+	if c.Provider == "" {
+		c.Provider = ProviderOpenAI
+	}
+	if c.Provider == ProviderLocal && c.BaseURL == "" {
+		c.BaseURL = defaultLocalBaseURL
+	}
+	if c.Model == "" {
+		c.Model = openai.GPT4Dot1
+		if c.Provider == ProviderLocal {
+			c.Model = defaultLocalModel
+		}
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultLLMTimeout
+	}
+	return c
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"neuro.app.jordi/internal/shared/config"
+)
+
+func TestLLMConfigFromAppConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *config.AppConfig
+		want LLMConfig
+	}{
+		{
+			name: "Valid - OPENAI_API_KEY from .env.local is used for openai",
+			app:  &config.AppConfig{OpenAIKey: "sk-env-local"},
+			want: LLMConfig{APIKey: "sk-env-local"},
+		},
+		{
+			name: "Valid - LLM_API_KEY wins over OPENAI_API_KEY",
+			app:  &config.AppConfig{OpenAIKey: "sk-old", LLMAPIKey: "sk-new", LLMModel: "gpt-4.1-mini", LLMTimeoutSeconds: 30, LLMAllowedModels: []string{"gpt-4.1"}},
+			want: LLMConfig{APIKey: "sk-new", Model: "gpt-4.1-mini", Timeout: 30 * time.Second, AllowedModels: []string{"gpt-4.1"}},
+		},
+		{
+			name: "Valid - local provider does not send the OpenAI key",
+			app:  &config.AppConfig{OpenAIKey: "sk-old", LLMProvider: ProviderLocal, LLMBaseURL: "http://llm:8080/v1"},
+			want: LLMConfig{Provider: ProviderLocal, BaseURL: "http://llm:8080/v1"},
+		},
+		{
+			name: "Valid - missing config leaves the defaults",
+			app:  nil,
+			want: LLMConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LLMConfigFromAppConfig(tt.app); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
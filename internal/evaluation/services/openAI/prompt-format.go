@@ -4,11 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"regexp"
 	"strings"
 	"time"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
 )
 
 // =============== PUBLIC API =================
@@ -31,14 +38,49 @@ func formatEvaluationForLLM(ev domain.Evaluation) string {
 // =============== SANITIZE ===================
 
 func sanitizeForLLM(ev domain.Evaluation) domain.Evaluation {
+	name := ev.PatientName
 	ev.PatientName = ""
 	ev.SpecialistMail = ""
 	ev.SpecialistID = ""
 	ev.StorageKey = ""
 	ev.StorageURL = ""
+	// el contexto clínico viaja sin identificadores ni fechas
+	ev.ClinicalContext.EvaluationID = ""
+	ev.ClinicalContext.CreatedAt = time.Time{}
+	ev.ClinicalContext.UpdatedAt = time.Time{}
+	// la anamnesis y las observaciones son texto libre: se retira el nombre del paciente si aparece
+	ev.Anamnesis.EvaluationID = ""
+	ev.Anamnesis.CreatedAt = time.Time{}
+	ev.Anamnesis.UpdatedAt = time.Time{}
+	ev.Anamnesis.ReferralReason = redactName(ev.Anamnesis.ReferralReason, name)
+	observations := make([]EOdomain.ExaminerObservation, 0, len(ev.ExaminerObservations))
+	for _, o := range ev.ExaminerObservations {
+		o.EvaluationID = ""
+		o.CreatedAt, o.UpdatedAt = time.Time{}, time.Time{}
+		o.Notes = redactName(o.Notes, name)
+		observations = append(observations, o)
+	}
+	ev.ExaminerObservations = observations
 	return ev
 }
 
+// redactName sustituye el nombre completo y cada parte del nombre (≥3 letras) por [paciente]
+func redactName(text, name string) string {
+	if text == "" || strings.TrimSpace(name) == "" {
+		return text
+	}
+	parts := append([]string{strings.TrimSpace(name)}, strings.Fields(name)...)
+	for _, p := range parts {
+		if len([]rune(p)) < 3 {
+			continue
+		}
+		// \b de RE2 solo reconoce ASCII; se delimita por letras Unicode para nombres con tilde
+		re := regexp.MustCompile(`(?i)(^|[^\p{L}])` + regexp.QuoteMeta(p) + `([^\p{L}]|$)`)
+		text = re.ReplaceAllString(text, "${1}[paciente]${2}")
+	}
+	return text
+}
+
 // =============== SUMMARY DTOs ===============
 
 type LLMVisualMemorySummary struct {
@@ -59,6 +101,7 @@ type LLMLettersSummary struct {
 	ErrorsPerMin   float64 `json:"errorsPerMin"`
 	CpPerMin       float64 `json:"cpPerMin"`
 	TimeSec        int     `json:"time_sec"`
+	MotorNote      string  `json:"motor_note,omitempty"`
 }
 
 type LLMVerbalMemorySummary struct {
@@ -87,6 +130,7 @@ type LLMExecOnePart struct {
 type LLMExecutiveSummary struct {
 	TMTA      LLMExecOnePart `json:"tmt_a"`        // type == "a"
 	TMTAplusB LLMExecOnePart `json:"tmt_a_plus_b"` // type == "a+b"
+	MotorNote string         `json:"motor_note,omitempty"`
 }
 
 type LLMLanguageFluencySummary struct {
@@ -106,25 +150,339 @@ type LLMVisualSpatialSummary struct {
 	Alias   string `json:"alias"`
 }
 
+type LLMDigitSpanCondition struct {
+	Present      bool    `json:"present"`
+	LongestSpan  int     `json:"longestSpan"`
+	TotalCorrect int     `json:"totalCorrect"`
+	ZScore       float64 `json:"zScore"`
+	Percentile   float64 `json:"percentile"`
+	Discontinued bool    `json:"discontinued"`
+}
+
+type LLMDigitSpanSummary struct {
+	Present     bool                  `json:"present"`
+	Score0to100 int                   `json:"score_0_100"`
+	Forward     LLMDigitSpanCondition `json:"forward"`
+	Backward    LLMDigitSpanCondition `json:"backward"`
+	Sequencing  LLMDigitSpanCondition `json:"sequencing"`
+}
+
+type LLMStroopSummary struct {
+	Present                bool    `json:"present"`
+	Score0to100            int     `json:"score_0_100"`
+	WordItems              int     `json:"word_items"`
+	ColorItems             int     `json:"color_items"`
+	ColorWordItems         int     `json:"color_word_items"`
+	PredictedColorWord     float64 `json:"predicted_color_word"`
+	Interference           float64 `json:"interference"`
+	InterferenceT          float64 `json:"interference_t"`
+	ColorWordErrorRatio    float64 `json:"color_word_error_ratio"`
+	InterferenceErrorRatio float64 `json:"interference_error_ratio"`
+	SelfCorrections        int     `json:"self_corrections"`
+}
+
+type LLMSDMTSummary struct {
+	Present          bool    `json:"present"`
+	Mode             string  `json:"mode"`
+	Correct          int     `json:"correct"`
+	Errors           int     `json:"errors"`
+	CorrectPer30s    []int   `json:"correct_per_30s,omitempty"`
+	ZScore           float64 `json:"z_score"`
+	Percentile0to100 float64 `json:"percentile_0_100"`
+}
+
+type LLMConfrontationNamingSummary struct {
+	Present            bool           `json:"present"`
+	Version            int            `json:"version"`
+	Score0to100        int            `json:"score_0_100"`
+	SpontaneousCorrect int            `json:"spontaneous_correct"`
+	SemanticCued       int            `json:"semantic_cued"`
+	PhonemicCued       int            `json:"phonemic_cued"`
+	Total              int            `json:"total"`
+	ErrorTypes         map[string]int `json:"error_types,omitempty"`
+}
+
+type LLMReactionTimeCondition struct {
+	Present       bool    `json:"present"`
+	MedianMs      float64 `json:"median_ms"`
+	CV            float64 `json:"cv"`
+	Anticipations int     `json:"anticipations"`
+	Lapses        int     `json:"lapses"`
+	Errors        int     `json:"errors"`
+}
+
+type LLMMotorSpeedSummary struct {
+	Present        bool                     `json:"present"`
+	Simple         LLMReactionTimeCondition `json:"simple"`
+	Choice         LLMReactionTimeCondition `json:"choice"`
+	ZScore         float64                  `json:"z_score"` // positivo = más lento
+	Slowed         bool                     `json:"slowed"`
+	DecisionTimeMs float64                  `json:"decision_time_ms"`
+}
+
+type LLMFingerTappingHand struct {
+	Present               bool    `json:"present"`
+	RateHz                float64 `json:"rate_hz"`
+	IntervalCV            float64 `json:"interval_cv"`
+	Hesitations           int     `json:"hesitations"`
+	AmplitudeDecrementPct float64 `json:"amplitude_decrement_pct"`
+}
+
+type LLMFingerTappingSummary struct {
+	Present          bool                 `json:"present"`
+	Left             LLMFingerTappingHand `json:"left"`
+	Right            LLMFingerTappingHand `json:"right"`
+	RateAsymmetryPct float64              `json:"rate_asymmetry_pct"`
+	MoreAffectedSide string               `json:"more_affected_side,omitempty"`
+}
+
+type LLMSpiralHand struct {
+	Present               bool    `json:"present"`
+	TremorFrequencyHz     float64 `json:"tremor_frequency_hz"`
+	TremorPowerFraction   float64 `json:"tremor_power_fraction"`
+	TremorDetected        bool    `json:"tremor_detected"`
+	FirstOrderSmoothness  float64 `json:"first_order_smoothness"`
+	SecondOrderSmoothness float64 `json:"second_order_smoothness"`
+	SpeedPxPerSec         float64 `json:"speed_px_per_sec"`
+	SizeDecrementPct      float64 `json:"size_decrement_pct"`
+}
+
+type LLMSpiralSummary struct {
+	Present bool          `json:"present"`
+	Score   int           `json:"score"`
+	Left    LLMSpiralHand `json:"left"`
+	Right   LLMSpiralHand `json:"right"`
+}
+
+type LLMSpeechSummary struct {
+	Present             bool    `json:"present"`
+	LoudnessDBFS        float64 `json:"loudness_dbfs"`
+	PitchMeanHz         float64 `json:"pitch_mean_hz"`
+	PitchSDSemitones    float64 `json:"pitch_sd_semitones"`
+	PitchRangeSemitones float64 `json:"pitch_range_semitones"`
+	PauseRatio          float64 `json:"pause_ratio"`
+	ArticulationRate    float64 `json:"articulation_rate"`
+	Hypophonia          bool    `json:"hypophonia"`
+	Monotone            bool    `json:"monotone"`
+}
+
+type LLMJLOSummary struct {
+	Present           bool   `json:"present"`
+	Form              string `json:"form"`
+	RawCorrect        int    `json:"raw_correct"`
+	ItemsAdministered int    `json:"items_administered"`
+	CorrectedScore    int    `json:"corrected_score"`
+	Classification    string `json:"classification"`
+	PartialResponses  int    `json:"partial_responses"`
+	MedianResponseMs  int64  `json:"median_response_ms"`
+}
+
+type LLMGoNoGoBlock struct {
+	CommissionRate float64 `json:"commission_rate"`
+	OmissionRate   float64 `json:"omission_rate"`
+	MedianRTMs     float64 `json:"median_rt_ms"`
+}
+
+type LLMGoNoGoSummary struct {
+	Present              bool             `json:"present"`
+	CommissionRate       float64          `json:"commission_rate"`
+	OmissionRate         float64          `json:"omission_rate"`
+	DPrime               float64          `json:"d_prime"`
+	Criterion            float64          `json:"criterion"`
+	MedianRTMs           float64          `json:"median_rt_ms"`
+	RTCV                 float64          `json:"rt_cv"`
+	Anticipations        int              `json:"anticipations"`
+	Blocks               []LLMGoNoGoBlock `json:"blocks,omitempty"`
+	CommissionRateChange float64          `json:"commission_rate_change"`
+	MotorNote            string           `json:"motor_note,omitempty"`
+}
+
+type LLMCardSortingSummary struct {
+	Present                bool    `json:"present"`
+	MaxCards               int     `json:"max_cards"`
+	TrialsAdministered     int     `json:"trials_administered"`
+	CategoriesCompleted    int     `json:"categories_completed"`
+	TrialsToFirstCategory  int     `json:"trials_to_first_category"`
+	PerseverativeErrors    int     `json:"perseverative_errors"`
+	PerseverativeErrorsPct float64 `json:"perseverative_errors_pct"`
+	NonPerseverativeErrors int     `json:"non_perseverative_errors"`
+	FailuresToMaintainSet  int     `json:"failures_to_maintain_set"`
+	ConceptualLevelPct     float64 `json:"conceptual_level_pct"`
+}
+
+type LLMQuestionnaireSubscale struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+	Valid bool    `json:"valid"`
+}
+
+type LLMQuestionnaireSummary struct {
+	Code           string                     `json:"code"`
+	Name           string                     `json:"name"`
+	Construct      string                     `json:"construct"`
+	Version        int                        `json:"version"`
+	Valid          bool                       `json:"valid"`
+	Total          float64                    `json:"total"`
+	MaxTotal       float64                    `json:"max_total"`
+	Classification string                     `json:"classification,omitempty"`
+	Abnormal       bool                       `json:"abnormal"`
+	MissingItems   int                        `json:"missing_items"`
+	Imputed        bool                       `json:"imputed"`
+	Subscales      []LLMQuestionnaireSubscale `json:"subscales,omitempty"`
+}
+
+type LLMMoCASections struct {
+	VisuospatialExecutive int `json:"visuospatial_executive"`
+	Naming                int `json:"naming"`
+	Attention             int `json:"attention"`
+	Language              int `json:"language"`
+	Abstraction           int `json:"abstraction"`
+	DelayedRecall         int `json:"delayed_recall"`
+	Orientation           int `json:"orientation"`
+}
+
+type LLMMoCAIndexes struct {
+	Memory       int `json:"memory"`
+	Executive    int `json:"executive"`
+	Attention    int `json:"attention"`
+	Language     int `json:"language"`
+	Visuospatial int `json:"visuospatial"`
+	Orientation  int `json:"orientation"`
+}
+
+type LLMMoCASummary struct {
+	Present        bool            `json:"present"`
+	Version        string          `json:"version"`
+	Total          int             `json:"total"`
+	EducationPoint int             `json:"education_point"`
+	Classification string          `json:"classification"`
+	Sections       LLMMoCASections `json:"sections"`
+	Indexes        LLMMoCAIndexes  `json:"indexes"`
+	ClockSource    string          `json:"clock_source"`
+	FluencySource  string          `json:"fluency_source"`
+	MotorNote      string          `json:"motor_note,omitempty"`
+}
+
+type LLMClinicalContext struct {
+	Present                  bool     `json:"present"`
+	MedicationState          string   `json:"medication_state"`
+	MinutesSinceLastLevodopa *int     `json:"minutes_since_last_levodopa,omitempty"`
+	LEDDTotalMg              float64  `json:"ledd_total_mg"`
+	LEDDLevodopaMg           float64  `json:"ledd_levodopa_mg"`
+	LEDDAgonistsMg           float64  `json:"ledd_agonists_mg"`
+	Drugs                    []string `json:"drugs,omitempty"`
+	HoehnYahr                *float64 `json:"hoehn_yahr,omitempty"`
+	DBS                      string   `json:"dbs"`
+	DiseaseDurationYears     *float64 `json:"disease_duration_years,omitempty"`
+}
+
+type LLMAnamnesis struct {
+	Present            bool     `json:"present"`
+	ReferralReason     string   `json:"referral_reason"`
+	Comorbidities      []string `json:"comorbidities,omitempty"`
+	Vision             string   `json:"vision"`
+	Hearing            string   `json:"hearing"`
+	SleepQuality       string   `json:"sleep_quality"`
+	SleepHours         *float64 `json:"sleep_hours,omitempty"`
+	DaytimeSleepiness  bool     `json:"daytime_sleepiness"`
+	RBDSuspected       bool     `json:"rbd_suspected"`
+	MoodComplaints     []string `json:"mood_complaints,omitempty"` // depression | anxiety | apathy
+	CurrentMedications []string `json:"current_medications,omitempty"`
+	Alerts             []string `json:"alerts"`
+}
+
+type LLMExaminerObservation struct {
+	Subtest             string   `json:"subtest"`
+	Fatigue             string   `json:"fatigue"`
+	Cooperation         string   `json:"cooperation"`
+	ComprehensionIssues bool     `json:"comprehension_issues"`
+	Interruptions       int      `json:"interruptions"`
+	Notes               string   `json:"notes,omitempty"`
+	Alerts              []string `json:"alerts"`
+}
+
+type LLMCompositeScore struct {
+	Domain         string  `json:"domain,omitempty"`
+	Index          float64 `json:"index"`
+	CILow          float64 `json:"ci_low"`
+	CIHigh         float64 `json:"ci_high"`
+	Percentile     float64 `json:"percentile"`
+	Classification string  `json:"classification"`
+	Indicators     int     `json:"indicators,omitempty"` // nº de métricas que entran en el dominio
+}
+
+type LLMCognitiveProfile struct {
+	Present         bool                `json:"present"`
+	ConfigRef       string              `json:"config_ref"`
+	ConfidenceLevel float64             `json:"confidence_level"`
+	Domains         []LLMCompositeScore `json:"domains"`
+	Global          *LLMCompositeScore  `json:"global,omitempty"`
+}
+
+type LLMDataQualityFlag struct {
+	Subtest  string `json:"subtest"`
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
 type LLMSummary struct {
-	LettersCancellation LLMLettersSummary         `json:"letters_cancellation"`
-	VisualMemory        LLMVisualMemorySummary    `json:"visual_memory"`
-	VerbalMemory        []LLMVerbalMemorySummary  `json:"verbal_memory"`
-	ExecutiveFunctions  LLMExecutiveSummary       `json:"executive_functions"`
-	LanguageFluency     LLMLanguageFluencySummary `json:"language_fluency"`
-	VisualSpatial       LLMVisualSpatialSummary   `json:"visual_spatial"`
+	ClinicalContext      LLMClinicalContext            `json:"clinical_context"`
+	Anamnesis            LLMAnamnesis                  `json:"anamnesis"`
+	ExaminerObservations []LLMExaminerObservation      `json:"examiner_observations"`
+	LettersCancellation  LLMLettersSummary             `json:"letters_cancellation"`
+	VisualMemory         LLMVisualMemorySummary        `json:"visual_memory"`
+	VerbalMemory         []LLMVerbalMemorySummary      `json:"verbal_memory"`
+	ExecutiveFunctions   LLMExecutiveSummary           `json:"executive_functions"`
+	LanguageFluency      LLMLanguageFluencySummary     `json:"language_fluency"`
+	VisualSpatial        LLMVisualSpatialSummary       `json:"visual_spatial"`
+	DigitSpan            LLMDigitSpanSummary           `json:"digit_span"`
+	Stroop               LLMStroopSummary              `json:"stroop"`
+	SDMT                 LLMSDMTSummary                `json:"sdmt"`
+	ConfrontationNaming  LLMConfrontationNamingSummary `json:"confrontation_naming"`
+	MotorSpeed           LLMMotorSpeedSummary          `json:"motor_speed"`
+	FingerTapping        LLMFingerTappingSummary       `json:"finger_tapping"`
+	ArchimedesSpiral     LLMSpiralSummary              `json:"archimedes_spiral"`
+	Speech               LLMSpeechSummary              `json:"speech"`
+	JLO                  LLMJLOSummary                 `json:"judgment_of_line_orientation"`
+	GoNoGo               LLMGoNoGoSummary              `json:"go_no_go"`
+	CardSorting          LLMCardSortingSummary         `json:"card_sorting"`
+	Questionnaires       []LLMQuestionnaireSummary     `json:"questionnaires"`
+	MoCA                 LLMMoCASummary                `json:"moca"`
+	CognitiveProfile     LLMCognitiveProfile           `json:"cognitive_profile"`
+	DataQuality          []LLMDataQualityFlag          `json:"data_quality"`
+	SubtestCommentary    []LLMSubtestCommentary        `json:"subtest_commentary"`
 }
 
 // =============== BUILD SUMMARY ==============
 
 func buildLLMSummary(ev domain.Evaluation) LLMSummary {
 	return LLMSummary{
-		LettersCancellation: buildLetters(ev),
-		VisualMemory:        buildVisualMemory(ev),
-		VerbalMemory:        buildVerbalMemory(ev),
-		ExecutiveFunctions:  buildExecutive(ev),
-		LanguageFluency:     buildLanguage(ev),
-		VisualSpatial:       buildVisualSpatial(ev),
+		ClinicalContext:      buildClinicalContext(ev),
+		Anamnesis:            buildAnamnesis(ev),
+		ExaminerObservations: buildExaminerObservations(ev),
+		LettersCancellation:  buildLetters(ev),
+		VisualMemory:         buildVisualMemory(ev),
+		VerbalMemory:         buildVerbalMemory(ev),
+		ExecutiveFunctions:   buildExecutive(ev),
+		LanguageFluency:      buildLanguage(ev),
+		VisualSpatial:        buildVisualSpatial(ev),
+		DigitSpan:            buildDigitSpan(ev),
+		Stroop:               buildStroop(ev),
+		SDMT:                 buildSDMT(ev),
+		ConfrontationNaming:  buildConfrontationNaming(ev),
+		MotorSpeed:           buildMotorSpeed(ev),
+		FingerTapping:        buildFingerTapping(ev),
+		ArchimedesSpiral:     buildArchimedesSpiral(ev),
+		Speech:               buildSpeech(ev),
+		JLO:                  buildJLO(ev),
+		GoNoGo:               buildGoNoGo(ev),
+		CardSorting:          buildCardSorting(ev),
+		Questionnaires:       buildQuestionnaires(ev),
+		MoCA:                 buildMoCA(ev),
+		CognitiveProfile:     buildCognitiveProfile(ev),
+		DataQuality:          buildDataQuality(ev),
+		SubtestCommentary:    buildSubtestCommentary(ev),
 	}
 }
 
@@ -140,6 +498,7 @@ func buildLetters(ev domain.Evaluation) LLMLettersSummary {
 		ErrorsPerMin:   lc.CancellationScore.ErrorsPerMin,
 		CpPerMin:       lc.CancellationScore.CpPerMin,
 		TimeSec:        lc.TimeInSecs,
+		MotorNote:      motorSlowingNote(ev),
 	}
 }
 
@@ -165,6 +524,8 @@ func buildVerbalMemory(ev domain.Evaluation) []LLMVerbalMemorySummary {
 	var out []LLMVerbalMemorySummary
 	for _, subtest := range vm {
 		llmSubtest := LLMVerbalMemorySummary{
+			Present:           subtest.Pk != "",
+			Subtype:           string(subtest.Type),
 			Score0to100:       subtest.Score.Score,
 			Hits:              subtest.Score.Hits,
 			Omissions:         subtest.Score.Omissions,
@@ -203,6 +564,7 @@ func buildExecutive(ev domain.Evaluation) LLMExecutiveSummary {
 			// si en el futuro agregas otros tipos, puedes agregarlos aquí
 		}
 	}
+	out.MotorNote = motorSlowingNote(ev)
 	return out
 }
 
@@ -230,6 +592,374 @@ func buildVisualSpatial(ev domain.Evaluation) LLMVisualSpatialSummary {
 	}
 }
 
+func buildDigitSpan(ev domain.Evaluation) LLMDigitSpanSummary {
+	ds := ev.DigitSpanSubTest
+	condition := func(c DSdomain.DigitSpanConditionScore) LLMDigitSpanCondition {
+		return LLMDigitSpanCondition{
+			Present:      c.Present,
+			LongestSpan:  c.LongestSpan,
+			TotalCorrect: c.TotalCorrect,
+			ZScore:       math.Round(c.ZScore*100) / 100,
+			Percentile:   math.Round(c.Percentile),
+			Discontinued: c.Discontinued,
+		}
+	}
+	return LLMDigitSpanSummary{
+		Present:     ds.PK != "",
+		Score0to100: ds.Score.Score,
+		Forward:     condition(ds.Score.Forward),
+		Backward:    condition(ds.Score.Backward),
+		Sequencing:  condition(ds.Score.Sequencing),
+	}
+}
+
+func buildStroop(ev domain.Evaluation) LLMStroopSummary {
+	st := ev.StroopSubTest
+	return LLMStroopSummary{
+		Present:                st.PK != "",
+		Score0to100:            st.Score.Score,
+		WordItems:              st.Word.ItemsCompleted,
+		ColorItems:             st.Color.ItemsCompleted,
+		ColorWordItems:         st.ColorWord.ItemsCompleted,
+		PredictedColorWord:     st.Score.PredictedColorWord,
+		Interference:           st.Score.Interference,
+		InterferenceT:          st.Score.InterferenceT,
+		ColorWordErrorRatio:    st.Score.ColorWordErrorRatio,
+		InterferenceErrorRatio: st.Score.InterferenceErrorRatio,
+		SelfCorrections:        st.Score.SelfCorrections,
+	}
+}
+
+func buildSDMT(ev domain.Evaluation) LLMSDMTSummary {
+	sd := ev.SDMTSubTest
+	var perInterval []int
+	for _, in := range sd.Score.Intervals {
+		perInterval = append(perInterval, in.Correct)
+	}
+	return LLMSDMTSummary{
+		Present:          sd.PK != "",
+		Mode:             string(sd.Mode),
+		Correct:          sd.Score.Correct,
+		Errors:           sd.Score.Errors,
+		CorrectPer30s:    perInterval,
+		ZScore:           sd.Score.ZScore,
+		Percentile0to100: sd.Score.Percentile,
+	}
+}
+
+func buildConfrontationNaming(ev domain.Evaluation) LLMConfrontationNamingSummary {
+	cn := ev.ConfrontationNamingSubTest
+	var errorTypes map[string]int
+	for k, v := range cn.Score.Errors {
+		if errorTypes == nil {
+			errorTypes = map[string]int{}
+		}
+		errorTypes[string(k)] = v
+	}
+	return LLMConfrontationNamingSummary{
+		Present:            cn.PK != "",
+		Version:            int(cn.Version),
+		Score0to100:        cn.Score.Score,
+		SpontaneousCorrect: cn.Score.SpontaneousCorrect,
+		SemanticCued:       cn.Score.SemanticCued,
+		PhonemicCued:       cn.Score.PhonemicCued,
+		Total:              cn.Score.Total,
+		ErrorTypes:         errorTypes,
+	}
+}
+
+func buildMotorSpeed(ev domain.Evaluation) LLMMotorSpeedSummary {
+	rt := ev.ReactionTimeSubTest
+	condition := func(c RTdomain.RTConditionScore) LLMReactionTimeCondition {
+		return LLMReactionTimeCondition{
+			Present:       c.Present,
+			MedianMs:      c.MedianMs,
+			CV:            c.CV,
+			Anticipations: c.Anticipations,
+			Lapses:        c.Lapses,
+			Errors:        c.Errors,
+		}
+	}
+	return LLMMotorSpeedSummary{
+		Present:        rt.PK != "" && rt.Score.MotorSpeed.Available,
+		Simple:         condition(rt.Score.Simple),
+		Choice:         condition(rt.Score.Choice),
+		ZScore:         rt.Score.MotorSpeed.ZScore,
+		Slowed:         rt.Score.MotorSpeed.Slowed,
+		DecisionTimeMs: rt.Score.MotorSpeed.DecisionTimeMs,
+	}
+}
+
+func buildFingerTapping(ev domain.Evaluation) LLMFingerTappingSummary {
+	ft := ev.FingerTappingSubTest
+	hand := func(h FTdomain.HandScore) LLMFingerTappingHand {
+		return LLMFingerTappingHand{
+			Present:               h.Present,
+			RateHz:                h.RateHz,
+			IntervalCV:            h.IntervalCV,
+			Hesitations:           h.Hesitations,
+			AmplitudeDecrementPct: h.AmplitudeDecrement,
+		}
+	}
+	return LLMFingerTappingSummary{
+		Present:          ft.PK != "",
+		Left:             hand(ft.Score.Left),
+		Right:            hand(ft.Score.Right),
+		RateAsymmetryPct: ft.Score.RateAsymmetryPct,
+		MoreAffectedSide: string(ft.Score.MoreAffectedSide),
+	}
+}
+
+func buildArchimedesSpiral(ev domain.Evaluation) LLMSpiralSummary {
+	as := ev.ArchimedesSpiralSubTest
+	hand := func(h ASdomain.HandSpiralScore) LLMSpiralHand {
+		return LLMSpiralHand{
+			Present:               h.Present,
+			TremorFrequencyHz:     h.TremorFrequencyHz,
+			TremorPowerFraction:   h.TremorPowerFraction,
+			TremorDetected:        h.TremorDetected,
+			FirstOrderSmoothness:  h.FirstOrderSmoothness,
+			SecondOrderSmoothness: h.SecondOrderSmoothness,
+			SpeedPxPerSec:         h.SpeedPxPerSec,
+			SizeDecrementPct:      h.SizeDecrementPct,
+		}
+	}
+	return LLMSpiralSummary{
+		Present: as.PK != "",
+		Score:   as.Score.Score,
+		Left:    hand(as.Score.Left),
+		Right:   hand(as.Score.Right),
+	}
+}
+
+func buildSpeech(ev domain.Evaluation) LLMSpeechSummary {
+	sp := ev.SpeechProfile
+	return LLMSpeechSummary{
+		Present:             sp.PK != "",
+		LoudnessDBFS:        sp.Metrics.LoudnessDBFS,
+		PitchMeanHz:         sp.Metrics.PitchMeanHz,
+		PitchSDSemitones:    sp.Metrics.PitchSDSemitones,
+		PitchRangeSemitones: sp.Metrics.PitchRangeSemitones,
+		PauseRatio:          sp.Metrics.PauseRatio,
+		ArticulationRate:    sp.Metrics.ArticulationRate,
+		Hypophonia:          sp.Metrics.Hypophonia,
+		Monotone:            sp.Metrics.Monotone,
+	}
+}
+
+func buildJLO(ev domain.Evaluation) LLMJLOSummary {
+	jlo := ev.JLOSubTest
+	return LLMJLOSummary{
+		Present:           jlo.PK != "",
+		Form:              string(jlo.Form),
+		RawCorrect:        jlo.Score.RawCorrect,
+		ItemsAdministered: len(jlo.Score.Items),
+		CorrectedScore:    jlo.Score.Score,
+		Classification:    string(jlo.Score.Classification),
+		PartialResponses:  jlo.Score.PartialResponses,
+		MedianResponseMs:  jlo.Score.MedianResponseMs,
+	}
+}
+
+func buildGoNoGo(ev domain.Evaluation) LLMGoNoGoSummary {
+	gng := ev.GoNoGoSubTest
+	if gng.PK == "" {
+		return LLMGoNoGoSummary{}
+	}
+	out := LLMGoNoGoSummary{
+		Present:              true,
+		CommissionRate:       gng.Score.CommissionRate,
+		OmissionRate:         gng.Score.OmissionRate,
+		DPrime:               gng.Score.DPrime,
+		Criterion:            gng.Score.Criterion,
+		MedianRTMs:           gng.Score.MedianRTMs,
+		RTCV:                 gng.Score.RTCV,
+		Anticipations:        gng.Score.Anticipations,
+		CommissionRateChange: gng.Score.CommissionRateChange,
+		MotorNote:            motorSlowingNote(ev),
+	}
+	for _, b := range gng.Score.Blocks {
+		out.Blocks = append(out.Blocks, LLMGoNoGoBlock{CommissionRate: b.CommissionRate, OmissionRate: b.OmissionRate, MedianRTMs: b.MedianRTMs})
+	}
+	return out
+}
+
+func buildCardSorting(ev domain.Evaluation) LLMCardSortingSummary {
+	wcst := ev.CardSortingSubTest
+	if wcst.PK == "" {
+		return LLMCardSortingSummary{}
+	}
+	return LLMCardSortingSummary{
+		Present:                true,
+		MaxCards:               wcst.MaxCards,
+		TrialsAdministered:     wcst.Score.TrialsAdministered,
+		CategoriesCompleted:    wcst.Score.CategoriesCompleted,
+		TrialsToFirstCategory:  wcst.Score.TrialsToFirstCategory,
+		PerseverativeErrors:    wcst.Score.PerseverativeErrors,
+		PerseverativeErrorsPct: wcst.Score.PerseverativeErrorsPct,
+		NonPerseverativeErrors: wcst.Score.NonPerseverativeErrors,
+		FailuresToMaintainSet:  wcst.Score.FailuresToMaintainSet,
+		ConceptualLevelPct:     wcst.Score.ConceptualLevelPct,
+	}
+}
+
+func buildQuestionnaires(ev domain.Evaluation) []LLMQuestionnaireSummary {
+	out := make([]LLMQuestionnaireSummary, 0, len(ev.Questionnaires))
+	for _, q := range ev.Questionnaires {
+		sum := LLMQuestionnaireSummary{
+			Code:           q.Code,
+			Name:           q.Name,
+			Construct:      q.Construct,
+			Version:        q.Version,
+			Valid:          q.Score.Valid,
+			Total:          q.Score.Total,
+			MaxTotal:       q.Score.MaxTotal,
+			Classification: q.Score.Label,
+			Abnormal:       q.Score.Abnormal,
+			MissingItems:   len(q.Score.MissingItems),
+			Imputed:        q.Score.Imputed,
+		}
+		for _, sc := range q.Score.Subscales {
+			sum.Subscales = append(sum.Subscales, LLMQuestionnaireSubscale{Name: sc.Name, Score: sc.Score, Valid: sc.Valid})
+		}
+		out = append(out, sum)
+	}
+	return out
+}
+
+func buildMoCA(ev domain.Evaluation) LLMMoCASummary {
+	moca := ev.MoCASubTest
+	if moca.PK == "" {
+		return LLMMoCASummary{}
+	}
+	return LLMMoCASummary{
+		Present:        true,
+		Version:        string(moca.Version),
+		Total:          moca.Score.Total,
+		EducationPoint: moca.Score.EducationPoint,
+		Classification: moca.Score.Classification,
+		Sections:       LLMMoCASections(moca.Score.Sections),
+		Indexes:        LLMMoCAIndexes(moca.Score.Indexes),
+		ClockSource:    string(moca.ClockSource),
+		FluencySource:  string(moca.FluencySource),
+		MotorNote:      motorSlowingNote(ev),
+	}
+}
+
+func buildClinicalContext(ev domain.Evaluation) LLMClinicalContext {
+	cc := ev.ClinicalContext
+	if cc.MedicationState == "" {
+		return LLMClinicalContext{}
+	}
+	out := LLMClinicalContext{
+		Present:                  true,
+		MedicationState:          string(cc.MedicationState),
+		MinutesSinceLastLevodopa: cc.MinutesSinceLastLevodopa,
+		LEDDTotalMg:              cc.LEDD.Total,
+		LEDDLevodopaMg:           cc.LEDD.Levodopa,
+		LEDDAgonistsMg:           cc.LEDD.Agonists,
+		HoehnYahr:                cc.HoehnYahr,
+		DBS:                      string(cc.DBS.Stimulation),
+		DiseaseDurationYears:     cc.DiseaseDurationYears,
+	}
+	if cc.DBS.Target != "" {
+		out.DBS += " (" + cc.DBS.Target + ")"
+	}
+	for _, m := range cc.Medications {
+		out.Drugs = append(out.Drugs, m.Drug)
+	}
+	return out
+}
+
+func buildAnamnesis(ev domain.Evaluation) LLMAnamnesis {
+	a := ev.Anamnesis
+	if a.ReferralReason == "" {
+		return LLMAnamnesis{Alerts: []string{}}
+	}
+	out := LLMAnamnesis{
+		Present:            true,
+		ReferralReason:     a.ReferralReason,
+		Comorbidities:      a.Comorbidities,
+		Vision:             string(a.Sensory.Vision),
+		Hearing:            string(a.Sensory.Hearing),
+		SleepQuality:       string(a.Sleep.Quality),
+		SleepHours:         a.Sleep.HoursPerNight,
+		DaytimeSleepiness:  a.Sleep.DaytimeSleepiness,
+		RBDSuspected:       a.Sleep.RBDSuspected,
+		CurrentMedications: a.CurrentMedications,
+		Alerts:             []string{},
+	}
+	if a.MoodComplaints.Depression {
+		out.MoodComplaints = append(out.MoodComplaints, "depression")
+	}
+	if a.MoodComplaints.Anxiety {
+		out.MoodComplaints = append(out.MoodComplaints, "anxiety")
+	}
+	if a.MoodComplaints.Apathy {
+		out.MoodComplaints = append(out.MoodComplaints, "apathy")
+	}
+	out.Alerts = a.Alerts()
+	return out
+}
+
+func buildExaminerObservations(ev domain.Evaluation) []LLMExaminerObservation {
+	out := []LLMExaminerObservation{}
+	for _, o := range ev.ExaminerObservations {
+		out = append(out, LLMExaminerObservation{
+			Subtest:             o.Subtest,
+			Fatigue:             string(o.Fatigue),
+			Cooperation:         string(o.Cooperation),
+			ComprehensionIssues: o.ComprehensionIssues,
+			Interruptions:       o.Interruptions,
+			Notes:               o.Notes,
+			Alerts:              o.Alerts(),
+		})
+	}
+	return out
+}
+
+func buildCognitiveProfile(ev domain.Evaluation) LLMCognitiveProfile {
+	cp := ev.CognitiveProfile
+	out := LLMCognitiveProfile{Present: len(cp.Domains) > 0, ConfigRef: cp.ConfigRef, ConfidenceLevel: cp.ConfidenceLevel, Domains: []LLMCompositeScore{}}
+	score := func(s COMPdomain.Score) LLMCompositeScore {
+		return LLMCompositeScore{
+			Index:          math.Round(s.Index),
+			CILow:          math.Round(s.CILow),
+			CIHigh:         math.Round(s.CIHigh),
+			Percentile:     math.Round(s.Percentile),
+			Classification: string(s.Classification),
+		}
+	}
+	for _, d := range cp.Domains {
+		c := score(d.Score)
+		c.Domain = string(d.Domain)
+		c.Indicators = len(d.Indicators)
+		out.Domains = append(out.Domains, c)
+	}
+	if g := cp.Global; g != nil {
+		c := score(g.Score)
+		out.Global = &c
+	}
+	return out
+}
+
+func buildDataQuality(ev domain.Evaluation) []LLMDataQualityFlag {
+	out := []LLMDataQualityFlag{}
+	for _, f := range ev.DataQuality.Flags {
+		out = append(out, LLMDataQualityFlag{Subtest: f.Subtest, Code: string(f.Code), Severity: string(f.Severity), Message: f.Message})
+	}
+	return out
+}
+
+// motorSlowingNote marca las pruebas cronometradas cuando el TR simple indica enlentecimiento motor
+func motorSlowingNote(ev domain.Evaluation) string {
+	ms := ev.ReactionTimeSubTest.Score.MotorSpeed
+	if ev.ReactionTimeSubTest.PK == "" || !ms.Available || !ms.Slowed {
+		return ""
+	}
+	return fmt.Sprintf("TR simple enlentecido (z=%.2f): el tiempo de ejecución puede reflejar bradicinesia más que déficit cognitivo.", ms.ZScore)
+}
+
 // =============== UTILS ======================
 
 func clamp(v, lo, hi int) int {
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	SAdomain "neuro.app.jordi/internal/evaluation/domain/structured-analysis"
+)
+
+// AnalysisPromptID es el prompt del análisis global de la evaluación
+const AnalysisPromptID = "clinical-analysis"
+
+// PromptData es lo que ven las plantillas: Payload es el JSON anónimo que se envía al
+// modelo y Summary el mismo resumen tipado, para citar campos concretos ({{.Summary.MoCA.Total}}).
+// Subtest y Part solo se rellenan en los comentarios por subtest; Schema es el JSON Schema
+// de la respuesta estructurada del análisis
+type PromptData struct {
+	Locale  PTdomain.Locale
+	Subtest string
+	Part    string
+	Payload string
+	Summary LLMSummary
+	Schema  string
+}
+
+type RenderedPrompt struct {
+	System string
+	User   string
+	Ref    string
+}
+
+// PromptRenderer ejecuta las plantillas con missingkey=error: un campo que no existe en
+// PromptData falla al validar, no al generar un informe
+type PromptRenderer struct{}
+
+func NewPromptRenderer() PromptRenderer {
+	return PromptRenderer{}
+}
+
+func (PromptRenderer) parse(t PTdomain.PromptTemplate) (*template.Template, error) {
+	tpl, err := template.New(t.ID).Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", PTdomain.ErrInvalidTemplate, err)
+	}
+	return tpl, nil
+}
+
+func (r PromptRenderer) Render(t PTdomain.PromptTemplate, data PromptData) (RenderedPrompt, error) {
+	tpl, err := r.parse(t)
+	if err != nil {
+		return RenderedPrompt{}, err
+	}
+	var user bytes.Buffer
+	if err := tpl.Execute(&user, data); err != nil {
+		return RenderedPrompt{}, fmt.Errorf("%w: %v", PTdomain.ErrInvalidTemplate, err)
+	}
+	out := RenderedPrompt{User: strings.TrimSpace(user.String()), Ref: t.Ref()}
+	if sys := tpl.Lookup("system"); sys != nil {
+		var b bytes.Buffer
+		if err := sys.Execute(&b, data); err != nil {
+			return RenderedPrompt{}, fmt.Errorf("%w: %v", PTdomain.ErrInvalidTemplate, err)
+		}
+		out.System = strings.TrimSpace(b.String())
+	}
+	return out, nil
+}
+
+// Validate ejecuta la plantilla con un payload vacío; además exige que envíe los datos
+func (r PromptRenderer) Validate(t PTdomain.PromptTemplate) error {
+	rendered, err := r.Render(t, PromptData{Locale: t.Locale, Payload: "{}", Schema: SAdomain.Schema})
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(t.Body, ".Payload") && !strings.Contains(t.Body, ".Summary") {
+		return fmt.Errorf("%w: template never references .Payload or .Summary", PTdomain.ErrInvalidTemplate)
+	}
+	if rendered.User == "" {
+		return fmt.Errorf("%w: template renders an empty prompt", PTdomain.ErrInvalidTemplate)
+	}
+	// El análisis se valida contra el esquema: una plantilla que no lo envía nunca obtendría JSON válido
+	if t.ID == AnalysisPromptID && !strings.Contains(t.Body, ".Schema") {
+		return fmt.Errorf("%w: analysis template never references .Schema", PTdomain.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// newPromptData arma el payload anónimo de la evaluación
+func newPromptData(ev domain.Evaluation, locale PTdomain.Locale) PromptData {
+	safe := sanitizeEvaluation(ev)
+	safe.PK = ""
+	return PromptData{Locale: locale, Payload: formatEvaluationForLLM(safe), Summary: buildLLMSummary(sanitizeForLLM(safe)), Schema: SAdomain.Schema}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	SAdomain "neuro.app.jordi/internal/evaluation/domain/structured-analysis"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+	logging "neuro.app.jordi/internal/shared/logger"
+)
+
+// RuleBasedService genera el análisis sin red con el clasificador de reglas (NPdomain)
+type RuleBasedService struct{}
+
+func NewRuleBasedService() RuleBasedService {
+	return RuleBasedService{}
+}
+
+// ProviderRules identifica en la evaluación los análisis generados sin modelo
+const ProviderRules = "rules"
+
+func (RuleBasedService) GenerateAnalysis(ctx context.Context, ev domain.Evaluation, opts domain.LLMOptions) (domain.LLMResult, error) {
+	started := time.Now()
+	locale, err := PTdomain.ParseLocale(opts.Locale)
+	if err != nil {
+		return domain.LLMResult{}, err
+	}
+	c := NPdomain.Classify(ev.NeuroProfileInput())
+	text := ruleBasedAnalysis(c, ev.SubtestCommentaries(), locale)
+	return domain.LLMResult{Text: text, Analysis: ruleBasedStructure(c, locale), Usage: domain.NewLLMUsage(ProviderRules, NPdomain.RulesVersion, started)}, nil
+}
+
+// FallbackService usa Primary y, si falla, Fallback; así finalizar no depende del proveedor externo.
+// El fallo del principal se registra en Logger para que el respaldo no pase desapercibido.
+type FallbackService struct {
+	Primary  domain.LLMService
+	Fallback domain.LLMService
+	Logger   logging.Logger
+}
+
+func NewFallbackService(primary, fallback domain.LLMService, logger logging.Logger) FallbackService {
+	return FallbackService{Primary: primary, Fallback: fallback, Logger: logger}
+}
+
+func (s FallbackService) primaryFailed(ctx context.Context, msg string, err error) {
+	if s.Logger != nil {
+		s.Logger.Error(ctx, msg, err)
+	}
+}
+
+// CheckModel delega en el principal: es el único que llama a un modelo
+func (s FallbackService) CheckModel(model string) error {
+	if c, ok := s.Primary.(domain.ModelChecker); ok {
+		return c.CheckModel(model)
+	}
+	return nil
+}
+
+func (s FallbackService) GenerateAnalysis(ctx context.Context, ev domain.Evaluation, opts domain.LLMOptions) (domain.LLMResult, error) {
+	res, err := s.Primary.GenerateAnalysis(ctx, ev, opts)
+	if err == nil || errors.Is(err, domain.ErrModelNotAllowed) {
+		return res, err
+	}
+	s.primaryFailed(ctx, "LLM analysis failed, using fallback", err)
+	return s.Fallback.GenerateAnalysis(ctx, ev, opts)
+}
+
+// ruleBasedAnalysis mantiene los títulos del formato del prompt para que el informe se lea igual;
+// los comentarios por subtest ya guardados se incluyen tal cual
+func ruleBasedAnalysis(c NPdomain.Classification, commentaries []STCdomain.Target, locale PTdomain.Locale) string {
+	t := ruleTextsFor(locale)
+	var b strings.Builder
+	b.WriteString(t.header + "\n\n")
+	fmt.Fprintf(&b, "**%s**\n", t.profile)
+	fmt.Fprintf(&b, "%s\n\n", SAdomain.ProfileLabel(c.Profile, string(locale)))
+
+	fmt.Fprintf(&b, "**%s**\n", t.findings)
+	if len(c.Findings) == 0 {
+		fmt.Fprintf(&b, "- %s\n", t.noFindings)
+	}
+	for _, f := range c.Findings {
+		evidence := make([]string, len(f.Evidence))
+		for i, e := range f.Evidence {
+			evidence[i] = fmt.Sprintf("%s = %g (%s)", e.Metric, e.Value, e.Threshold)
+		}
+		fmt.Fprintf(&b, "- %s [%s]\n", t.explanation(f.Rule, f.Mechanism, f.Explanation), strings.Join(evidence, "; "))
+	}
+
+	if len(c.Scores) > 0 {
+		fmt.Fprintf(&b, "\n**%s**\n", t.weights)
+		for _, p := range NPdomain.Profiles {
+			if s := c.Scores[p]; s > 0 {
+				fmt.Fprintf(&b, "- %s: %d\n", SAdomain.ProfileLabel(p, string(locale)), s)
+			}
+		}
+	}
+	if len(commentaries) > 0 {
+		fmt.Fprintf(&b, "\n**%s**\n", t.commentary)
+		for _, target := range commentaries {
+			fmt.Fprintf(&b, "- **%s:** %s\n", t.commentaryLabel(target), strings.ReplaceAll(target.Commentary, "\n", " "))
+		}
+	}
+	if len(c.Limitations) > 0 {
+		fmt.Fprintf(&b, "\n**%s**\n", t.limitations)
+		for _, l := range c.Limitations {
+			fmt.Fprintf(&b, "- %s\n", t.limitationTexts[l])
+		}
+	}
+	fmt.Fprintf(&b, "\n_"+t.footer+"_\n", c.RulesVersion)
+	return b.String()
+}
+
+// ruleMechanismDomains agrupa los mecanismos de las reglas en los dominios del análisis estructurado
+var ruleMechanismDomains = map[string]SAdomain.Domain{
+	"attention_span":         SAdomain.DomainAttention,
+	"sustained_attention":    SAdomain.DomainAttention,
+	"working_memory":         SAdomain.DomainAttention,
+	"processing_speed":       SAdomain.DomainProcessingSpeed,
+	"consolidation":          SAdomain.DomainMemory,
+	"encoding":               SAdomain.DomainMemory,
+	"encoding+consolidation": SAdomain.DomainMemory,
+	"visual_memory":          SAdomain.DomainMemory,
+	"monitoring":             SAdomain.DomainExecutive,
+	"set_shifting":           SAdomain.DomainExecutive,
+	"inhibition":             SAdomain.DomainExecutive,
+	"disinhibition":          SAdomain.DomainExecutive,
+	"lexical_access":         SAdomain.DomainLanguage,
+	"lexical_executive":      SAdomain.DomainLanguage,
+	"semantic_degradation":   SAdomain.DomainLanguage,
+	"visuoconstruction":      SAdomain.DomainVisuospatial,
+	"mood":                   SAdomain.DomainMood,
+	"apathy":                 SAdomain.DomainMood,
+}
+
+// ruleSubtests relaciona el prefijo de cada regla con el código de subtest del resumen
+var ruleSubtests = []struct{ prefix, subtest string }{
+	{"verbal_", "verbal_memory"},
+	{"visual_memory", "visual_memory"},
+	{"tmt_", "executive_functions"},
+	{"digit_span", "digit_span"},
+	{"stroop", "stroop"},
+	{"sdmt", "sdmt"},
+	{"letters_cancellation", "letters_cancellation"},
+	{"naming", "confrontation_naming"},
+	{"fluency", "language_fluency"},
+	{"clock", "visual_spatial"},
+	{"go_no_go", "go_no_go"},
+	{"card_sorting", "card_sorting"},
+	{"gds", "questionnaires"},
+	{"apathy", "questionnaires"},
+}
+
+// ruleBasedStructure rellena el análisis estructurado desde la clasificación; las reglas solo
+// marcan resultados bajo umbral, así que no gradúan la severidad más allá de leve
+func ruleBasedStructure(c NPdomain.Classification, locale PTdomain.Locale) SAdomain.Analysis {
+	t := ruleTextsFor(locale)
+	a := SAdomain.Analysis{
+		Title:           t.title,
+		Profile:         c.Profile,
+		Justification:   c.SummaryAs(SAdomain.ProfileLabel(c.Profile, string(locale))),
+		Findings:        []SAdomain.DomainFinding{},
+		Summary:         fmt.Sprintf(t.summary, c.RulesVersion),
+		Recommendations: []string{},
+		Uncertainty:     SAdomain.UncertaintyMedium,
+	}
+	byDomain := map[SAdomain.Domain]int{}
+	for _, f := range c.Findings {
+		d, ok := ruleMechanismDomains[f.Mechanism]
+		if !ok {
+			continue
+		}
+		i, seen := byDomain[d]
+		if !seen {
+			i = len(a.Findings)
+			byDomain[d] = i
+			a.Findings = append(a.Findings, SAdomain.DomainFinding{Domain: d, Severity: SAdomain.SeverityMild, Subtests: []string{}})
+		}
+		df := &a.Findings[i]
+		df.Finding = strings.TrimSpace(df.Finding + " " + t.explanation(f.Rule, f.Mechanism, f.Explanation))
+		for _, rs := range ruleSubtests {
+			if strings.HasPrefix(f.Rule, rs.prefix) && !contains(df.Subtests, rs.subtest) {
+				df.Subtests = append(df.Subtests, rs.subtest)
+			}
+		}
+	}
+	if c.Profile == NPdomain.ProfileInconclusive || contains(c.Limitations, "no_data") {
+		a.Uncertainty = SAdomain.UncertaintyHigh
+	}
+	return a
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+)
+
+func TestRuleBasedAnalysisLocale(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	// consolidación (amnésico, peso 3) y cambio de set (disejecutivo, peso 2), con limitación motora
+	c := NPdomain.Classify(NPdomain.Input{VerbalImmediate: f(80), VerbalDelayed: f(30), TMTASec: f(60), TMTABSec: f(400), MotorSlowed: true})
+	commentaries := []STCdomain.Target{{Subtest: STCdomain.VerbalMemory, Part: "delayed", Commentary: "Diferido bajo."}}
+
+	tests := []struct {
+		name    string
+		locale  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "Valid - default locale keeps the Spanish report",
+			locale: "",
+			want: []string{
+				"**Análisis automático por reglas**", "**Perfil predominante**\nAmnésico",
+				"Recuerdo inmediato preservado con diferido bajo", "**Memoria verbal — Diferida:** Diferido bajo.",
+				"bradicinesia", "_Reglas: np-rules@1.",
+			},
+		},
+		{
+			name:   "Valid - English report",
+			locale: "en",
+			want: []string{
+				"**Automatic rule-based analysis**", "**Predominant profile**\nAmnestic",
+				"Preserved immediate recall with low delayed recall", "Normal TMT A with slow A+B",
+				"- Amnestic: 3", "**Verbal memory — Delayed:** Diferido bajo.", "bradykinesia", "_Rules: np-rules@1.",
+			},
+			notWant: []string{"Hallazgos", "Recuerdo", "Limitaciones"},
+		},
+		{
+			name:   "Valid - Catalan report",
+			locale: "ca",
+			want: []string{
+				"**Anàlisi automàtica per regles**", "**Perfil predominant**\nAmnèsic",
+				"Record immediat preservat amb diferit baix", "- Disexecutiu (vascular): 2",
+				"**Memòria verbal — Diferida:** Diferido bajo.", "bradicinèsia", "_Regles: np-rules@1.",
+			},
+			notWant: []string{"Hallazgos", "Recuerdo", "Limitaciones"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, err := PTdomain.ParseLocale(tt.locale)
+			if err != nil {
+				t.Fatalf("unexpected locale error: %v", err)
+			}
+			text := ruleBasedAnalysis(c, commentaries, locale)
+			for _, s := range tt.want {
+				if !strings.Contains(text, s) {
+					t.Errorf("expected %q in:\n%s", s, text)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(text, s) {
+					t.Errorf("unexpected %q in:\n%s", s, text)
+				}
+			}
+			// el contraste con las reglas reconoce el perfil en cualquier idioma
+			if check := NPdomain.CompareWithLLM(text, c); !check.Agrees {
+				t.Errorf("expected the cross-check to recognise the rule profile, got %+v", check)
+			}
+			a := ruleBasedStructure(c, locale)
+			if a.Profile != c.Profile || len(a.Findings) == 0 {
+				t.Fatalf("unexpected structure: %+v", a)
+			}
+			if want := ruleTextsFor(locale).title; a.Title != want {
+				t.Errorf("expected title %q, got %q", want, a.Title)
+			}
+		})
+	}
+
+	t.Run("Invalid - unsupported locale", func(t *testing.T) {
+		_, err := RuleBasedService{}.GenerateAnalysis(context.TODO(), domain.Evaluation{}, domain.LLMOptions{Locale: "fr"})
+		if !errors.Is(err, PTdomain.ErrUnsupportedLocale) {
+			t.Errorf("expected ErrUnsupportedLocale, got %v", err)
+		}
+	})
+
+	t.Run("Valid - every rule explanation is translated to ca and en", func(t *testing.T) {
+		for rule := range ruleTextsEN.explanations {
+			if _, ok := ruleTextsCA.explanations[rule]; !ok {
+				t.Errorf("missing ca explanation for %s", rule)
+			}
+		}
+		if len(ruleTextsCA.explanations) != len(ruleTextsEN.explanations) {
+			t.Errorf("expected the same rules in ca and en, got %d and %d", len(ruleTextsCA.explanations), len(ruleTextsEN.explanations))
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+)
+
+// ruleTexts son los textos fijos del análisis por reglas en un idioma. El encabezado del perfil
+// conserva la forma que reconoce NPdomain.CompareWithLLM.
+type ruleTexts struct {
+	header, profile, findings, noFindings, weights, commentary, limitations, footer string
+	title, summary                                                                  string
+	limitationTexts                                                                 map[string]string
+	subtests, parts                                                                 map[string]string
+	// explanations por regla (o regla:mecanismo si el mecanismo cambia el texto); en español
+	// se usa la explicación de NPdomain tal cual
+	explanations map[string]string
+}
+
+var ruleTextsES = ruleTexts{
+	header:      "**Análisis automático por reglas** (generado sin modelo de lenguaje)",
+	profile:     "Perfil predominante",
+	findings:    "Hallazgos",
+	noFindings:  "Sin hallazgos por debajo de los umbrales de referencia.",
+	weights:     "Peso por perfil",
+	commentary:  "Interpretación por subtest",
+	limitations: "Limitaciones",
+	footer:      "Reglas: %s. Revise el caso clínicamente; este texto no sustituye la interpretación del especialista.",
+	title:       "Análisis automático por reglas",
+	summary:     "Análisis generado sin modelo de lenguaje (%s); revise el caso clínicamente.",
+	limitationTexts: map[string]string{
+		"verbal_memory_partial": "Solo hay recuerdo inmediato o diferido: no se puede separar codificación de consolidación.",
+		"motor_slowing":         "TR simple enlentecido: las pruebas cronometradas pesan menos por posible bradicinesia.",
+		"no_data":               "No hay subtests administrados con datos válidos.",
+	},
+	subtests: map[string]string{
+		STCdomain.LettersCancellation: "Letters Cancellation",
+		STCdomain.VerbalMemory:        "Memoria verbal",
+		STCdomain.ExecutiveFunctions:  "Funciones ejecutivas (TMT)",
+		STCdomain.LanguageFluency:     "Fluencia verbal",
+		STCdomain.DigitSpan:           "Dígitos",
+		STCdomain.Stroop:              "Stroop",
+		STCdomain.SDMT:                "SDMT",
+		STCdomain.ConfrontationNaming: "Denominación por confrontación",
+		STCdomain.MotorSpeed:          "Tiempo de reacción",
+		STCdomain.FingerTapping:       "Finger tapping",
+		STCdomain.ArchimedesSpiral:    "Espiral de Arquímedes",
+		STCdomain.JLO:                 "Orientación de líneas (JLO)",
+		STCdomain.GoNoGo:              "Go/No-Go",
+		STCdomain.CardSorting:         "Clasificación de tarjetas",
+		STCdomain.MoCA:                "MoCA",
+	},
+	parts: map[string]string{"immediate": "Inmediata", "delayed": "Diferida", "a": "A", "a+b": "A+B"},
+}
+
+var ruleTextsCA = ruleTexts{
+	header:      "**Anàlisi automàtica per regles** (generada sense model de llenguatge)",
+	profile:     "Perfil predominant",
+	findings:    "Troballes",
+	noFindings:  "Sense troballes per sota dels llindars de referència.",
+	weights:     "Pes per perfil",
+	commentary:  "Interpretació per subtest",
+	limitations: "Limitacions",
+	footer:      "Regles: %s. Reviseu el cas clínicament; aquest text no substitueix la interpretació de l'especialista.",
+	title:       "Anàlisi automàtica per regles",
+	summary:     "Anàlisi generada sense model de llenguatge (%s); reviseu el cas clínicament.",
+	limitationTexts: map[string]string{
+		"verbal_memory_partial": "Només hi ha record immediat o diferit: no es pot separar la codificació de la consolidació.",
+		"motor_slowing":         "TR simple alentit: les proves cronometrades pesen menys per possible bradicinèsia.",
+		"no_data":               "No hi ha subtests administrats amb dades vàlides.",
+	},
+	subtests: map[string]string{
+		STCdomain.LettersCancellation: "Cancel·lació de lletres",
+		STCdomain.VerbalMemory:        "Memòria verbal",
+		STCdomain.ExecutiveFunctions:  "Funcions executives (TMT)",
+		STCdomain.LanguageFluency:     "Fluència verbal",
+		STCdomain.DigitSpan:           "Dígits",
+		STCdomain.Stroop:              "Stroop",
+		STCdomain.SDMT:                "SDMT",
+		STCdomain.ConfrontationNaming: "Denominació per confrontació",
+		STCdomain.MotorSpeed:          "Temps de reacció",
+		STCdomain.FingerTapping:       "Finger tapping",
+		STCdomain.ArchimedesSpiral:    "Espiral d'Arquimedes",
+		STCdomain.JLO:                 "Orientació de línies (JLO)",
+		STCdomain.GoNoGo:              "Go/No-Go",
+		STCdomain.CardSorting:         "Classificació de targetes",
+		STCdomain.MoCA:                "MoCA",
+	},
+	parts: map[string]string{"immediate": "Immediata", "delayed": "Diferida", "a": "A", "a+b": "A+B"},
+	explanations: map[string]string{
+		"verbal_consolidation":             "Record immediat preservat amb diferit baix: dèficit de consolidació/recuperació.",
+		"verbal_encoding":                  "Record immediat i diferit baixos en proporció similar: fallada de codificació/atenció.",
+		"verbal_encoding_consolidation":    "Codificació baixa amb pèrdua addicional en el diferit: component de consolidació.",
+		"verbal_monitoring":                "Intrusions/perseveracions elevades: fallada de monitoratge i control executiu.",
+		"tmt_set_shifting":                 "TMT A normal amb A+B lent: dèficit de canvi de set (component executiu).",
+		"tmt_a_slow":                       "TMT A lent: velocitat de processament/atenció compromesa.",
+		"digit_span_forward":               "Span directe baix: amplitud atencional reduïda.",
+		"digit_span_working_memory":        "Directe preservat amb invers/seqüenciació baixos: fallada de manipulació en memòria de treball.",
+		"stroop_interference":              "Interferència Stroop baixa: dificultat per inhibir la resposta automàtica.",
+		"sdmt_slow":                        "SDMT baix: alentiment de la velocitat de processament.",
+		"letters_cancellation_low":         "Cancel·lació de lletres baixa: atenció sostinguda compromesa.",
+		"naming_semantic":                  "Denominació baixa amb errors semàntics i poc benefici de claus: possible degradació semàntica.",
+		"naming_lexical_access":            "Denominació baixa que millora amb clau fonèmica: fallada d'accés lèxic (freqüent en Parkinson).",
+		"fluency_low":                      "Fluència verbal reduïda: dèficit lèxic/executiu.",
+		"fluency_low:semantic_degradation": "Fluència reduïda juntament amb denominació amb errors semàntics: dona suport a afectació semàntica.",
+		"go_no_go_commissions":             "Comissions altes en Go/No-Go: impulsivitat/desinhibició.",
+		"card_sorting_perseveration":       "Errors perseveratius alts: rigidesa / fallada de canvi de set.",
+		"visual_memory_low":                "Reproducció visual compromesa (BVMT).",
+		"clock_low":                        "Rellotge baix: alteració visuoespacial/executiva.",
+		"gds_abnormal":                     "GDS-15 alterada: simptomatologia depressiva.",
+		"apathy_without_depression":        "Apatia sense depressió: pot explicar poca iniciativa sense dèficit executiu primari.",
+	},
+}
+
+var ruleTextsEN = ruleTexts{
+	header:      "**Automatic rule-based analysis** (generated without a language model)",
+	profile:     "Predominant profile",
+	findings:    "Findings",
+	noFindings:  "No findings below the reference thresholds.",
+	weights:     "Weight by profile",
+	commentary:  "Interpretation by subtest",
+	limitations: "Limitations",
+	footer:      "Rules: %s. Review the case clinically; this text does not replace the specialist's interpretation.",
+	title:       "Automatic rule-based analysis",
+	summary:     "Analysis generated without a language model (%s); review the case clinically.",
+	limitationTexts: map[string]string{
+		"verbal_memory_partial": "Only immediate or delayed recall is available: encoding cannot be separated from consolidation.",
+		"motor_slowing":         "Slow simple RT: timed tests weigh less because of possible bradykinesia.",
+		"no_data":               "No administered subtests with valid data.",
+	},
+	subtests: map[string]string{
+		STCdomain.LettersCancellation: "Letter cancellation",
+		STCdomain.VerbalMemory:        "Verbal memory",
+		STCdomain.ExecutiveFunctions:  "Executive functions (TMT)",
+		STCdomain.LanguageFluency:     "Verbal fluency",
+		STCdomain.DigitSpan:           "Digit span",
+		STCdomain.Stroop:              "Stroop",
+		STCdomain.SDMT:                "SDMT",
+		STCdomain.ConfrontationNaming: "Confrontation naming",
+		STCdomain.MotorSpeed:          "Reaction time",
+		STCdomain.FingerTapping:       "Finger tapping",
+		STCdomain.ArchimedesSpiral:    "Archimedes spiral",
+		STCdomain.JLO:                 "Judgment of line orientation (JLO)",
+		STCdomain.GoNoGo:              "Go/No-Go",
+		STCdomain.CardSorting:         "Card sorting",
+		STCdomain.MoCA:                "MoCA",
+	},
+	parts: map[string]string{"immediate": "Immediate", "delayed": "Delayed", "a": "A", "a+b": "A+B"},
+	explanations: map[string]string{
+		"verbal_consolidation":             "Preserved immediate recall with low delayed recall: consolidation/retrieval deficit.",
+		"verbal_encoding":                  "Immediate and delayed recall similarly low: encoding/attention failure.",
+		"verbal_encoding_consolidation":    "Low encoding with additional loss on delayed recall: consolidation component.",
+		"verbal_monitoring":                "High intrusions/perseverations: monitoring and executive control failure.",
+		"tmt_set_shifting":                 "Normal TMT A with slow A+B: set-shifting deficit (executive component).",
+		"tmt_a_slow":                       "Slow TMT A: processing speed/attention compromised.",
+		"digit_span_forward":               "Low forward span: reduced attention span.",
+		"digit_span_working_memory":        "Preserved forward span with low backward/sequencing: working memory manipulation failure.",
+		"stroop_interference":              "Low Stroop interference: difficulty inhibiting the automatic response.",
+		"sdmt_slow":                        "Low SDMT: slowed processing speed.",
+		"letters_cancellation_low":         "Low letter cancellation: sustained attention compromised.",
+		"naming_semantic":                  "Low naming with semantic errors and little benefit from cues: possible semantic degradation.",
+		"naming_lexical_access":            "Low naming that improves with phonemic cues: lexical access failure (common in Parkinson's).",
+		"fluency_low":                      "Reduced verbal fluency: lexical/executive deficit.",
+		"fluency_low:semantic_degradation": "Reduced fluency together with naming with semantic errors: supports semantic involvement.",
+		"go_no_go_commissions":             "High Go/No-Go commissions: impulsivity/disinhibition.",
+		"card_sorting_perseveration":       "High perseverative errors: rigidity / set-shifting failure.",
+		"visual_memory_low":                "Compromised visual reproduction (BVMT).",
+		"clock_low":                        "Low clock score: visuospatial/executive impairment.",
+		"gds_abnormal":                     "Abnormal GDS-15: depressive symptoms.",
+		"apathy_without_depression":        "Apathy without depression: may explain low initiative without a primary executive deficit.",
+	},
+}
+
+func ruleTextsFor(locale PTdomain.Locale) ruleTexts {
+	switch locale {
+	case PTdomain.LocaleCA:
+		return ruleTextsCA
+	case PTdomain.LocaleEN:
+		return ruleTextsEN
+	default:
+		return ruleTextsES
+	}
+}
+
+// explanation traduce la explicación de un hallazgo; sin traducción se queda la de NPdomain
+func (t ruleTexts) explanation(rule, mechanism, fallback string) string {
+	if s, ok := t.explanations[rule+":"+mechanism]; ok {
+		return s
+	}
+	if s, ok := t.explanations[rule]; ok {
+		return s
+	}
+	return fallback
+}
+
+func (t ruleTexts) commentaryLabel(target STCdomain.Target) string {
+	label := t.subtests[target.Subtest]
+	if part, ok := t.parts[target.Part]; ok {
+		label += " — " + part
+	}
+	return label
+}
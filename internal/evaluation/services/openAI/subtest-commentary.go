@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"neuro.app.jordi/internal/evaluation/domain"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
+)
+
+// CommentaryPromptID es el prompt del comentario breve de cada subtest
+const CommentaryPromptID = "subtest-commentary"
+
+// commentaryMaxTokens acota la respuesta cuando la llamada no fija MaxTokens
+const commentaryMaxTokens = 350
+
+// LLMCommentaryInput es el payload de un comentario: solo la sección del resumen de ese
+// subtest más lo que condiciona su lectura (edad, medicación, calidad, observaciones)
+type LLMCommentaryInput struct {
+	Subtest             string                  `json:"subtest"`
+	Part                string                  `json:"part,omitempty"`
+	PatientAge          int                     `json:"patient_age,omitempty"`
+	Metrics             json.RawMessage         `json:"metrics"`
+	DataQuality         []LLMDataQualityFlag    `json:"data_quality"`
+	ExaminerObservation *LLMExaminerObservation `json:"examiner_observation,omitempty"`
+	ClinicalContext     LLMClinicalContext      `json:"clinical_context"`
+}
+
+type LLMSubtestCommentary struct {
+	Subtest    string `json:"subtest"`
+	Part       string `json:"part,omitempty"`
+	Commentary string `json:"commentary"`
+}
+
+// executivePartKeys: tipo de fila de funciones ejecutivas -> clave en el resumen
+var executivePartKeys = map[string]string{"a": "tmt_a", "a+b": "tmt_a_plus_b"}
+
+func (oa OpenAIService) CommentSubtest(ctx context.Context, ev domain.Evaluation, target STCdomain.Target, opts domain.LLMOptions) (domain.LLMResult, error) {
+	started := time.Now()
+	locale, err := PTdomain.ParseLocale(opts.Locale)
+	if err != nil {
+		return domain.LLMResult{}, err
+	}
+	data, err := newCommentaryData(ev, target, locale)
+	if err != nil {
+		return domain.LLMResult{}, err
+	}
+	prompt, err := oa.prompt(ctx, CommentaryPromptID, data)
+	if err != nil {
+		return domain.LLMResult{}, err
+	}
+	if opts.MaxTokens == 0 {
+		opts.MaxTokens = commentaryMaxTokens
+	}
+	resp, model, err := oa.Ask(ctx, prompt.System, prompt.User, opts)
+	if err != nil {
+		return domain.LLMResult{}, err
+	}
+	usage := domain.NewLLMUsage(oa.Provider, model, started)
+	usage.PromptVersion = prompt.Ref
+	return domain.LLMResult{Text: STCdomain.Normalize(resp), Usage: usage}, nil
+}
+
+// CommentSubtest del respaldo: si el principal no comenta subtests o falla, prueba con el
+// de respaldo; las reglas no comentan subtests y devuelven ErrCommentaryUnavailable
+func (s FallbackService) CommentSubtest(ctx context.Context, ev domain.Evaluation, target STCdomain.Target, opts domain.LLMOptions) (domain.LLMResult, error) {
+	err := STCdomain.ErrCommentaryUnavailable
+	if c, ok := s.Primary.(domain.SubtestCommentator); ok {
+		var res domain.LLMResult
+		if res, err = c.CommentSubtest(ctx, ev, target, opts); err == nil || errors.Is(err, domain.ErrModelNotAllowed) {
+			return res, err
+		}
+		s.primaryFailed(ctx, "LLM subtest commentary failed, using fallback", err)
+	}
+	if c, ok := s.Fallback.(domain.SubtestCommentator); ok {
+		return c.CommentSubtest(ctx, ev, target, opts)
+	}
+	return domain.LLMResult{}, err
+}
+
+// newCommentaryData arma el payload anónimo de una fila de subtest
+func newCommentaryData(ev domain.Evaluation, target STCdomain.Target, locale PTdomain.Locale) (PromptData, error) {
+	safe := sanitizeForLLM(sanitizeEvaluation(ev))
+	summary := buildLLMSummary(safe)
+	metrics, err := commentaryMetrics(summary, target)
+	if err != nil {
+		return PromptData{}, err
+	}
+	in := LLMCommentaryInput{
+		Subtest:         target.Subtest,
+		Part:            target.Part,
+		PatientAge:      safe.PatientAge,
+		Metrics:         metrics,
+		DataQuality:     []LLMDataQualityFlag{},
+		ClinicalContext: summary.ClinicalContext,
+	}
+	for _, f := range summary.DataQuality {
+		if f.Subtest == target.Subtest {
+			in.DataQuality = append(in.DataQuality, f)
+		}
+	}
+	for _, o := range summary.ExaminerObservations {
+		if o.Subtest == target.Subtest {
+			o := o
+			in.ExaminerObservation = &o
+		}
+	}
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return PromptData{}, err
+	}
+	return PromptData{Locale: locale, Subtest: target.Subtest, Part: target.Part, Payload: string(raw), Summary: summary}, nil
+}
+
+// commentaryMetrics extrae del resumen la sección del subtest (por su clave JSON) y, en los
+// subtests con varias filas, la de la fila comentada
+func commentaryMetrics(summary LLMSummary, target STCdomain.Target) (json.RawMessage, error) {
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return nil, err
+	}
+	section, ok := sections[target.Subtest]
+	if !ok {
+		return nil, fmt.Errorf("no summary section for subtest %q", target.Subtest)
+	}
+	switch target.Subtest {
+	case STCdomain.VerbalMemory:
+		var rows []json.RawMessage
+		if err := json.Unmarshal(section, &rows); err != nil {
+			return nil, err
+		}
+		if target.Index < 0 || target.Index >= len(rows) {
+			return nil, fmt.Errorf("verbal memory row %d out of range", target.Index)
+		}
+		return rows[target.Index], nil
+	case STCdomain.ExecutiveFunctions:
+		var parts map[string]json.RawMessage
+		if err := json.Unmarshal(section, &parts); err != nil {
+			return nil, err
+		}
+		part, ok := parts[executivePartKeys[target.Part]]
+		if !ok {
+			return nil, fmt.Errorf("unknown executive functions part %q", target.Part)
+		}
+		return part, nil
+	}
+	return section, nil
+}
+
+func buildSubtestCommentary(ev domain.Evaluation) []LLMSubtestCommentary {
+	out := []LLMSubtestCommentary{}
+	for _, t := range ev.SubtestCommentaries() {
+		out = append(out, LLMSubtestCommentary{Subtest: t.Subtest, Part: t.Part, Commentary: t.Commentary})
+	}
+	return out
+}
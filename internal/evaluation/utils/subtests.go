@@ -1,6 +1,9 @@
 package utils
 
-import "strings"
+import (
+	"math"
+	"strings"
+)
 
 func Clamp01(x float64) float64 {
 	if x < 0 {
@@ -23,3 +26,13 @@ func ReplaceAccentsES(s string) string {
 	)
 	return replacer.Replace(s)
 }
+
+// ZToPercentile convierte una puntuación z en percentil (0..100) con la normal estándar.
+func ZToPercentile(z float64) float64 {
+	return 50 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// ProbabilityToZ es la inversa de la normal estándar (probit) para p en (0, 1).
+func ProbabilityToZ(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
@@ -0,0 +1,91 @@
+package llmtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	services "neuro.app.jordi/internal/evaluation/services/openAI"
+)
+
+// ScriptedReply es una respuesta del servidor falso; Status distinto de 200 simula un fallo del proveedor.
+// JSONContent, si no está vacío, responde a las peticiones en modo JSON (el análisis estructurado)
+type ScriptedReply struct {
+	Content     string
+	JSONContent string
+	Status      int
+	Delay       time.Duration
+}
+
+// ScriptedLLMServer imita /v1/chat/completions de un servidor compatible con OpenAI y
+// devuelve las respuestas en orden (la última se repite); sirve para tests sin red
+type ScriptedLLMServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	replies  []ScriptedReply
+	requests []openai.ChatCompletionRequest
+}
+
+func NewScriptedLLMServer(replies ...ScriptedReply) *ScriptedLLMServer {
+	s := &ScriptedLLMServer{replies: replies}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Config apunta el adaptador compatible al servidor falso
+func (s *ScriptedLLMServer) Config() services.LLMConfig {
+	return services.LLMConfig{Provider: "scripted", BaseURL: s.URL + "/v1", APIKey: "test", Model: "scripted-model"}
+}
+
+// Requests devuelve las peticiones recibidas, para comprobar modelo y opciones
+func (s *ScriptedLLMServer) Requests() []openai.ChatCompletionRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]openai.ChatCompletionRequest(nil), s.requests...)
+}
+
+func (s *ScriptedLLMServer) handle(w http.ResponseWriter, r *http.Request) {
+	var req openai.ChatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	var reply ScriptedReply
+	if n := len(s.requests); len(s.replies) > 0 {
+		reply = s.replies[min(n, len(s.replies)-1)]
+	}
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	if reply.Delay > 0 {
+		select {
+		case <-time.After(reply.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if reply.Status != 0 && reply.Status != http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(reply.Status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": "scripted failure", "type": "server_error"}})
+		return
+	}
+	content := reply.Content
+	if reply.JSONContent != "" && req.ResponseFormat != nil && req.ResponseFormat.Type == openai.ChatCompletionResponseFormatTypeJSONObject {
+		content = reply.JSONContent
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+		ID:     "scripted",
+		Object: "chat.completion",
+		Model:  req.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+			FinishReason: openai.FinishReasonStop,
+		}},
+	})
+}
@@ -5,21 +5,67 @@ import (
 
 	authD "neuro.app.jordi/internal/auth/domain"
 	"neuro.app.jordi/internal/evaluation/domain"
+	ANinfra "neuro.app.jordi/internal/evaluation/infra/anamnesis"
+	CCinfra "neuro.app.jordi/internal/evaluation/infra/clinical-context"
+	COMPinfra "neuro.app.jordi/internal/evaluation/infra/composites"
+	DQinfra "neuro.app.jordi/internal/evaluation/infra/data-quality"
+	EOinfra "neuro.app.jordi/internal/evaluation/infra/examiner-observations"
+	PTinfra "neuro.app.jordi/internal/evaluation/infra/prompt-templates"
+	QNinfra "neuro.app.jordi/internal/evaluation/infra/questionnaires"
+	RSinfra "neuro.app.jordi/internal/evaluation/infra/rescoring"
+	SCPinfra "neuro.app.jordi/internal/evaluation/infra/scoring-profiles"
+	SPinfra "neuro.app.jordi/internal/evaluation/infra/speech-profile"
+	ASinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/archimedes-spiral"
+	WCSTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/card-sorting"
+	CNinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/confrontation-naming"
+	DSinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/digit-span"
 	EFinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/executive-functions"
+	FTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/finger-tapping"
+	GNGinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/go-no-go"
 	LCinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/letter-cancellation"
+	JLOinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/line-orientation"
+	MOCAinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/moca"
+	RTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/reaction-time"
+	SDMTinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/sdmt"
+	STRinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/stroop"
 	VEMinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/verbal-memory"
 	VIMinfra "neuro.app.jordi/internal/evaluation/infra/sub-tests/visual-memory"
 	INFRAvisualspatial "neuro.app.jordi/internal/evaluation/infra/sub-tests/visual-spatial"
+	STCinfra "neuro.app.jordi/internal/evaluation/infra/subtest-commentary"
 
 	"neuro.app.jordi/internal/auth/infra"
 	infraE "neuro.app.jordi/internal/evaluation/infra"
+	audiodecoder "neuro.app.jordi/internal/evaluation/infra/audio-decoder"
 
+	appservices "neuro.app.jordi/internal/evaluation/application/services"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	PTdomain "neuro.app.jordi/internal/evaluation/domain/prompt-templates"
+	QNdomain "neuro.app.jordi/internal/evaluation/domain/questionnaires"
+	RSdomain "neuro.app.jordi/internal/evaluation/domain/rescoring"
+	SCPdomain "neuro.app.jordi/internal/evaluation/domain/scoring-profiles"
+	SPdomain "neuro.app.jordi/internal/evaluation/domain/speech-profile"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	WCSTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/card-sorting"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
 	EFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/executive-functions"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	GNGdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/go-no-go"
 	LFdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/language-fluency"
 	LCdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/letter-cancellation"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
+	SDMTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/sdmt"
+	STRdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/stroop"
 	VEMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/verbal-memory"
 	VIMdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-memory"
 	VPdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/visual-spatial"
+	STCdomain "neuro.app.jordi/internal/evaluation/domain/subtest-commentary"
 	services "neuro.app.jordi/internal/evaluation/services/openAI"
 	"neuro.app.jordi/internal/shared/encryption"
 	fileformatter "neuro.app.jordi/internal/shared/file-formatter"
@@ -43,10 +89,68 @@ type Repositories struct {
 	VisualMemorySubtestRepository       VIMdomain.VisualMemoryRepository             //TODO: add this implementation
 	ExecutiveFunctionsSubtestRepository EFdomain.ExecutiveFunctionsSubtestRepository //TODO: add this implementation
 	VisualSpatialRepository             VPdomain.ResultRepository
+	DigitSpanRepository                 DSdomain.DigitSpanRepository
+	StroopRepository                    STRdomain.StroopRepository
+	SDMTRepository                      SDMTdomain.SDMTRepository
+	ConfrontationNamingRepository       CNdomain.ConfrontationNamingRepository
+	ReactionTimeRepository              RTdomain.ReactionTimeRepository
+	FingerTappingRepository             FTdomain.FingerTappingRepository
+	ArchimedesSpiralRepository          ASdomain.ArchimedesSpiralRepository
+	SpeechProfileRepository             SPdomain.SpeechProfileRepository
+	JLORepository                       JLOdomain.JLORepository
+	GoNoGoRepository                    GNGdomain.GoNoGoRepository
+	CardSortingRepository               WCSTdomain.CardSortingRepository
+	QuestionnaireRepository             QNdomain.QuestionnaireRepository
+	MoCARepository                      MOCAdomain.MoCARepository
+	ClinicalContextRepository           CCdomain.ClinicalContextRepository
+	AnamnesisRepository                 ANdomain.AnamnesisRepository
+	ExaminerObservationRepository       EOdomain.ExaminerObservationRepository
+	ScoringSelectionRepository          SCPdomain.ScoringSelectionRepository
+	RescoringRepository                 RSdomain.RescoringRepository
+	QuestionnaireCatalog                QNdomain.QuestionnaireCatalog
+	ScoringProfileCatalog               SCPdomain.ScoringProfileCatalog
+	CompositeConfigProvider             COMPdomain.ConfigProvider
+	PromptTemplateRepository            PTdomain.PromptTemplateRepository
+	SubtestCommentaryRepository         STCdomain.CommentaryRepository
+	DataQualityRepository               DQdomain.DataQualityRepository
 	UserRepository                      authD.UserRepository
 }
+
+// Subtests reúne los repositorios con que se monta una evaluación completa
+func (r Repositories) Subtests() appservices.SubtestRepositories {
+	return appservices.SubtestRepositories{
+		VerbalMemory:         r.VerbalMemorySubtestRepository,
+		VisualMemory:         r.VisualMemorySubtestRepository,
+		ExecutiveFunctions:   r.ExecutiveFunctionsSubtestRepository,
+		LetterCancellation:   r.LetterCancellationRepository,
+		LanguageFluency:      r.LanguageFluencyRepository,
+		VisualSpatial:        r.VisualSpatialRepository,
+		DigitSpan:            r.DigitSpanRepository,
+		Stroop:               r.StroopRepository,
+		SDMT:                 r.SDMTRepository,
+		ConfrontationNaming:  r.ConfrontationNamingRepository,
+		ReactionTime:         r.ReactionTimeRepository,
+		FingerTapping:        r.FingerTappingRepository,
+		ArchimedesSpiral:     r.ArchimedesSpiralRepository,
+		SpeechProfile:        r.SpeechProfileRepository,
+		JLO:                  r.JLORepository,
+		GoNoGo:               r.GoNoGoRepository,
+		CardSorting:          r.CardSortingRepository,
+		Questionnaires:       r.QuestionnaireRepository,
+		MoCA:                 r.MoCARepository,
+		ClinicalContext:      r.ClinicalContextRepository,
+		Anamnesis:            r.AnamnesisRepository,
+		ExaminerObservations: r.ExaminerObservationRepository,
+		ScoringSelection:     r.ScoringSelectionRepository,
+		DataQuality:          r.DataQualityRepository,
+		CompositeConfig:      r.CompositeConfigProvider,
+	}
+}
+
 type Services struct {
 	LLMService        domain.LLMService
+	SpeechToText      domain.SpeechToTextService
+	AudioDecoder      domain.AudioDecoder
 	MailService       mail.MailProvider
 	JwtService        *jwtService.Service
 	EncryptionService authD.EncryptionService
@@ -63,6 +167,30 @@ func getAppMockRepositories() Repositories {
 		VisualMemorySubtestRepository:       VIMinfra.NewMockVisualMemoryRepository(),
 		ExecutiveFunctionsSubtestRepository: EFinfra.NewMockExecutiveFunctionsRepository(),
 		VisualSpatialRepository:             INFRAvisualspatial.NewMockVisualSpatialRepository(),
+		DigitSpanRepository:                 DSinfra.NewMockDigitSpanRepository(),
+		StroopRepository:                    STRinfra.NewMockStroopRepository(),
+		SDMTRepository:                      SDMTinfra.NewMockSDMTRepository(),
+		ConfrontationNamingRepository:       CNinfra.NewMockConfrontationNamingRepository(),
+		ReactionTimeRepository:              RTinfra.NewMockReactionTimeRepository(),
+		FingerTappingRepository:             FTinfra.NewMockFingerTappingRepository(),
+		ArchimedesSpiralRepository:          ASinfra.NewMockArchimedesSpiralRepository(),
+		SpeechProfileRepository:             SPinfra.NewMockSpeechProfileRepository(),
+		JLORepository:                       JLOinfra.NewMockJLORepository(),
+		GoNoGoRepository:                    GNGinfra.NewMockGoNoGoRepository(),
+		CardSortingRepository:               WCSTinfra.NewMockCardSortingRepository(),
+		QuestionnaireRepository:             QNinfra.NewMockQuestionnaireRepository(),
+		MoCARepository:                      MOCAinfra.NewMockMoCARepository(),
+		ClinicalContextRepository:           CCinfra.NewMockClinicalContextRepository(),
+		AnamnesisRepository:                 ANinfra.NewMockAnamnesisRepository(),
+		ExaminerObservationRepository:       EOinfra.NewMockExaminerObservationRepository(),
+		ScoringSelectionRepository:          SCPinfra.NewMockScoringSelectionRepository(),
+		RescoringRepository:                 RSinfra.NewMockRescoringRepository(),
+		QuestionnaireCatalog:                QNinfra.NewEmbeddedQuestionnaireCatalog(),
+		ScoringProfileCatalog:               SCPinfra.NewEmbeddedScoringProfileCatalog(),
+		CompositeConfigProvider:             COMPinfra.NewEmbeddedCompositeConfigProvider(),
+		PromptTemplateRepository:            PTinfra.NewMockPromptTemplateRepository(),
+		SubtestCommentaryRepository:         STCinfra.NewMockSubtestCommentaryRepository(),
+		DataQualityRepository:               DQinfra.NewMockDataQualityRepository(),
 
 		UserRepository: infra.NewMockUsersRepository(),
 	}
@@ -71,6 +199,8 @@ func getAppMockRepositories() Repositories {
 func getAppMockServices() Services {
 	return Services{
 		LLMService:        services.NewMockOpenAIService(),
+		SpeechToText:      &domain.MockSpeechToText{},
+		AudioDecoder:      audiodecoder.NewAudioDecoder(),
 		MailService:       mail.NewMockMailService(),
 		EncryptionService: encryption.NewEncryptionService(),
 		JwtService:        jwtService.New(),
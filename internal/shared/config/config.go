@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -23,6 +24,14 @@ type AppConfig struct {
 	SMTP_USERNAME string
 	SMTP_PASSWORD string
 	SMTP_FROM     string
+	// Proveedor LLM compatible con OpenAI; LLM_API_KEY cae en OPENAI_API_KEY si falta
+	LLMProvider       string
+	LLMBaseURL        string
+	LLMAPIKey         string
+	LLMModel          string
+	LLMTimeoutSeconds int
+	// LLMAllowedModels: modelos que una petición puede pedir además de LLMModel
+	LLMAllowedModels []string
 }
 
 func GetConfig() *AppConfig {
@@ -40,6 +49,8 @@ func GetConfig() *AppConfig {
 		fmt.Println("Error parsing SMTP_PORT:", err)
 		port = 587 // Default SMTP port
 	}
+	// 0 = tiempo por defecto del adaptador
+	llmTimeout, _ := strconv.Atoi(os.Getenv("LLM_TIMEOUT_SECONDS"))
 	return &AppConfig{
 		SMTP_FROM:     os.Getenv("SMTP_FROM"),
 		SMTP_HOST:     os.Getenv("SMTP_HOST"),
@@ -47,8 +58,27 @@ func GetConfig() *AppConfig {
 		SMTP_PASSWORD: os.Getenv("SMTP_PASSWORD"),
 		SMTP_PORT:     port,
 		OpenAIKey:     os.Getenv("OPENAI_API_KEY"),
+
+		LLMProvider:       os.Getenv("LLM_PROVIDER"),
+		LLMBaseURL:        os.Getenv("LLM_BASE_URL"),
+		LLMAPIKey:         os.Getenv("LLM_API_KEY"),
+		LLMModel:          os.Getenv("LLM_MODEL"),
+		LLMTimeoutSeconds: llmTimeout,
+		LLMAllowedModels:  splitList(os.Getenv("LLM_ALLOWED_MODELS")),
+	}
+}
+
+// splitList separa una lista por comas ignorando espacios y entradas vacías
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
 	}
+	return out
 }
+
 func GetS3BucketName() (string, error) {
 	bucketName := os.Getenv("S3_BUCKET_NAME")
 	if bucketName == "" {
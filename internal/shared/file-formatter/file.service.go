@@ -2,11 +2,28 @@ package fileformatter
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"html"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"neuro.app.jordi/internal/evaluation/domain"
+	ANdomain "neuro.app.jordi/internal/evaluation/domain/anamnesis"
+	CCdomain "neuro.app.jordi/internal/evaluation/domain/clinical-context"
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+	DQdomain "neuro.app.jordi/internal/evaluation/domain/data-quality"
+	EOdomain "neuro.app.jordi/internal/evaluation/domain/examiner-observations"
+	NPdomain "neuro.app.jordi/internal/evaluation/domain/neuro-profile"
+	PDMCIdomain "neuro.app.jordi/internal/evaluation/domain/pd-mci"
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+	CNdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/confrontation-naming"
+	DSdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/digit-span"
+	FTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/finger-tapping"
+	JLOdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/line-orientation"
+	MOCAdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/moca"
+	RTdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/reaction-time"
 
 	fpdf "github.com/go-pdf/fpdf"
 	"github.com/yuin/goldmark"
@@ -31,16 +48,537 @@ func (f *WKHTMLFileFormatter) GenerateHTML(evaluation domain.Evaluation) (string
 			<h1>Informe Neuropsicológico</h1>
 			<p><strong>Paciente:</strong> %s</p>
 			<p><strong>Especialista:</strong> %s</p>
+			%s
 			<hr>
 			<h2>Resultados</h2>
 			<p>%s</p>
+			%s
+			%s
 		</body>
 		</html>
-	`, evaluation.PatientName, evaluation.SpecialistMail, htmlAssistantAnalysis)
+	`, evaluation.PatientName, evaluation.SpecialistMail, clinicalContextHTML(evaluation.ClinicalContext), htmlAssistantAnalysis, analysisUsageHTML(evaluation.AnalysisUsage), subtestResultsHTML(evaluation))
 
 	return html, nil
 }
 
+// analysisUsageHTML deja constancia de qué proveedor y modelo redactaron el análisis
+func analysisUsageHTML(u domain.LLMUsage) string {
+	if u.Provider == "" {
+		return ""
+	}
+	out := fmt.Sprintf("Análisis generado con %s (%s) en %.1f s", html.EscapeString(u.Model), html.EscapeString(u.Provider), float64(u.LatencyMs)/1000)
+	if u.PromptVersion != "" {
+		out += fmt.Sprintf("; prompt %s", html.EscapeString(u.PromptVersion))
+	}
+	return "<p><small>" + out + "</small></p>"
+}
+
+// clinicalContextHTML resume en una línea el contexto clínico de Parkinson para la cabecera
+func clinicalContextHTML(cc CCdomain.ClinicalContext) string {
+	if cc.MedicationState == "" {
+		return ""
+	}
+	parts := []string{medicationStateES[cc.MedicationState]}
+	if m := cc.MinutesSinceLastLevodopa; m != nil {
+		parts[0] += fmt.Sprintf(" (%d min desde la última levodopa)", *m)
+	}
+	if cc.LEDD.Total > 0 {
+		parts = append(parts, fmt.Sprintf("LEDD %.0f mg/día", cc.LEDD.Total))
+	}
+	if h := cc.HoehnYahr; h != nil {
+		parts = append(parts, fmt.Sprintf("H&Y %g", *h))
+	}
+	if cc.DBS.Stimulation == CCdomain.DBSOn || cc.DBS.Stimulation == CCdomain.DBSOff {
+		parts = append(parts, fmt.Sprintf("DBS %s %s", strings.ToUpper(cc.DBS.Target), strings.ToUpper(string(cc.DBS.Stimulation))))
+	}
+	if d := cc.DiseaseDurationYears; d != nil {
+		parts = append(parts, fmt.Sprintf("evolución %g años", *d))
+	}
+	return fmt.Sprintf("<p><strong>Contexto clínico:</strong> %s</p>", strings.Join(parts, " · "))
+}
+
+// subtestResultsHTML añade un bloque por cada subtest administrado bajo el análisis
+func subtestResultsHTML(ev domain.Evaluation) string {
+	var b strings.Builder
+
+	if a := ev.Anamnesis; a.ReferralReason != "" {
+		b.WriteString("<h3>Anamnesis</h3><ul>")
+		fmt.Fprintf(&b, "<li>Motivo de consulta: %s</li>", html.EscapeString(a.ReferralReason))
+		if len(a.Comorbidities) > 0 {
+			fmt.Fprintf(&b, "<li>Comorbilidades: %s</li>", html.EscapeString(strings.Join(a.Comorbidities, ", ")))
+		}
+		fmt.Fprintf(&b, "<li>Visión: %s; audición: %s</li>", sensoryStatusES[a.Sensory.Vision], sensoryStatusES[a.Sensory.Hearing])
+		fmt.Fprintf(&b, "<li>Sueño: %s", sleepQualityES[a.Sleep.Quality])
+		if h := a.Sleep.HoursPerNight; h != nil {
+			fmt.Fprintf(&b, ", %g h/noche", *h)
+		}
+		if a.Sleep.DaytimeSleepiness {
+			b.WriteString(", somnolencia diurna")
+		}
+		if a.Sleep.RBDSuspected {
+			b.WriteString(", sospecha de trastorno de conducta del sueño REM")
+		}
+		b.WriteString("</li>")
+		var mood []string
+		if a.MoodComplaints.Depression {
+			mood = append(mood, "ánimo bajo")
+		}
+		if a.MoodComplaints.Anxiety {
+			mood = append(mood, "ansiedad")
+		}
+		if a.MoodComplaints.Apathy {
+			mood = append(mood, "apatía")
+		}
+		if len(mood) > 0 {
+			fmt.Fprintf(&b, "<li>Quejas afectivas: %s</li>", strings.Join(mood, ", "))
+		}
+		if len(a.CurrentMedications) > 0 {
+			fmt.Fprintf(&b, "<li>Otra medicación: %s</li>", html.EscapeString(strings.Join(a.CurrentMedications, ", ")))
+		}
+		b.WriteString("</ul>")
+	}
+
+	if ds := ev.DigitSpanSubTest; ds.PK != "" {
+		b.WriteString("<h3>Dígitos (Digit Span)</h3><ul>")
+		for _, c := range []struct {
+			label string
+			score DSdomain.DigitSpanConditionScore
+		}{
+			{"Directo", ds.Score.Forward},
+			{"Inverso", ds.Score.Backward},
+			{"Secuenciación", ds.Score.Sequencing},
+		} {
+			if !c.score.Present {
+				continue
+			}
+			fmt.Fprintf(&b, "<li>%s: span máximo %d, aciertos %d, z %.2f (percentil %.0f)</li>",
+				c.label, c.score.LongestSpan, c.score.TotalCorrect, c.score.ZScore, c.score.Percentile)
+		}
+		fmt.Fprintf(&b, "<li>Puntuación global: %d/100</li></ul>", ds.Score.Score)
+	}
+
+	if st := ev.StroopSubTest; st.PK != "" {
+		b.WriteString("<h3>Stroop</h3><ul>")
+		fmt.Fprintf(&b, "<li>Palabra: %d ítems, %d errores</li>", st.Word.ItemsCompleted, st.Word.Errors)
+		fmt.Fprintf(&b, "<li>Color: %d ítems, %d errores</li>", st.Color.ItemsCompleted, st.Color.Errors)
+		fmt.Fprintf(&b, "<li>Palabra-color: %d ítems, %d errores, %d autocorrecciones</li>", st.ColorWord.ItemsCompleted, st.ColorWord.Errors, st.ColorWord.SelfCorrections)
+		fmt.Fprintf(&b, "<li>Interferencia: %.2f (PC esperado %.2f, T %.0f)</li></ul>", st.Score.Interference, st.Score.PredictedColorWord, st.Score.InterferenceT)
+	}
+
+	if sd := ev.SDMTSubTest; sd.PK != "" {
+		b.WriteString("<h3>SDMT (Symbol Digit Modalities Test)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Modalidad: %s</li>", sd.Mode)
+		fmt.Fprintf(&b, "<li>Correctas en 90 s: %d, errores: %d</li>", sd.Score.Correct, sd.Score.Errors)
+		for _, in := range sd.Score.Intervals {
+			fmt.Fprintf(&b, "<li>%d–%d s: %d correctas</li>", in.StartSec, in.EndSec, in.Correct)
+		}
+		fmt.Fprintf(&b, "<li>z %.2f (percentil %.0f)</li></ul>", sd.Score.ZScore, sd.Score.Percentile)
+	}
+
+	if cn := ev.ConfrontationNamingSubTest; cn.PK != "" {
+		b.WriteString("<h3>Denominación por confrontación</h3><ul>")
+		fmt.Fprintf(&b, "<li>Versión: %d láminas (%d administradas)</li>", cn.Version, cn.Score.ItemsAdministered)
+		fmt.Fprintf(&b, "<li>Espontáneas: %d, tras clave semántica: %d, tras clave fonémica: %d</li>", cn.Score.SpontaneousCorrect, cn.Score.SemanticCued, cn.Score.PhonemicCued)
+		fmt.Fprintf(&b, "<li>Total: %d/%d</li>", cn.Score.Total, cn.Version)
+		for _, t := range []CNdomain.NamingErrorType{
+			CNdomain.NamingErrorOmission, CNdomain.NamingErrorPhonemic, CNdomain.NamingErrorSemantic,
+			CNdomain.NamingErrorCircumlocution, CNdomain.NamingErrorVisual, CNdomain.NamingErrorSubstitution,
+		} {
+			if n := cn.Score.Errors[t]; n > 0 {
+				fmt.Fprintf(&b, "<li>Errores (%s): %d</li>", t, n)
+			}
+		}
+		b.WriteString("</ul>")
+	}
+
+	if rt := ev.ReactionTimeSubTest; rt.PK != "" {
+		b.WriteString("<h3>Tiempo de reacción</h3><ul>")
+		for _, c := range []struct {
+			label string
+			score RTdomain.RTConditionScore
+		}{
+			{"Simple", rt.Score.Simple},
+			{"Elección", rt.Score.Choice},
+		} {
+			if !c.score.Present {
+				continue
+			}
+			fmt.Fprintf(&b, "<li>%s: mediana %.0f ms, CV %.2f, anticipaciones %d, lapsos %d, errores %d</li>",
+				c.label, c.score.MedianMs, c.score.CV, c.score.Anticipations, c.score.Lapses, c.score.Errors)
+		}
+		if ms := rt.Score.MotorSpeed; ms.Available {
+			fmt.Fprintf(&b, "<li>Velocidad motora: z %.2f</li>", ms.ZScore)
+			if ms.Slowed {
+				b.WriteString("<li>Enlentecimiento motor: interpretar los tiempos de TMT y cancelación de letras con cautela</li>")
+			}
+		}
+		b.WriteString("</ul>")
+	}
+
+	if ft := ev.FingerTappingSubTest; ft.PK != "" {
+		b.WriteString("<h3>Finger tapping</h3><ul>")
+		for _, h := range []struct {
+			label string
+			score FTdomain.HandScore
+		}{
+			{"Mano derecha", ft.Score.Right},
+			{"Mano izquierda", ft.Score.Left},
+		} {
+			if !h.score.Present {
+				continue
+			}
+			fmt.Fprintf(&b, "<li>%s: %.2f toques/s, CV del ritmo %.2f, pausas %d, decremento de amplitud %.0f%%</li>",
+				h.label, h.score.RateHz, h.score.IntervalCV, h.score.Hesitations, h.score.AmplitudeDecrement)
+		}
+		if ft.Score.Left.Present && ft.Score.Right.Present {
+			fmt.Fprintf(&b, "<li>Asimetría de frecuencia: %.0f%%", ft.Score.RateAsymmetryPct)
+			switch ft.Score.MoreAffectedSide {
+			case FTdomain.HandLeft:
+				b.WriteString(" (más afectada: izquierda)")
+			case FTdomain.HandRight:
+				b.WriteString(" (más afectada: derecha)")
+			}
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ul>")
+	}
+
+	if as := ev.ArchimedesSpiralSubTest; as.PK != "" {
+		b.WriteString("<h3>Espiral de Arquímedes</h3>")
+		for _, d := range as.Drawings {
+			label, score := "Mano derecha", as.Score.Right
+			if d.Hand == ASdomain.HandLeft {
+				label, score = "Mano izquierda", as.Score.Left
+			}
+			if img, err := spiralPNGBase64(d.Points); err == nil {
+				fmt.Fprintf(&b, `<img alt="Espiral (%s)" src="data:image/png;base64,%s">`, label, img)
+			}
+			fmt.Fprintf(&b, "<ul><li>%s: %.1f vueltas, velocidad %.0f px/s, suavidad 1.er orden %.2f, 2.º orden %.2f</li>",
+				label, score.Loops, score.SpeedPxPerSec, score.FirstOrderSmoothness, score.SecondOrderSmoothness)
+			if score.TremorDetected {
+				fmt.Fprintf(&b, "<li>Temblor detectado: %.1f Hz (%.0f%% de la potencia en 3–12 Hz)</li>", score.TremorFrequencyHz, score.TremorPowerFraction*100)
+			} else {
+				b.WriteString("<li>Sin temblor significativo</li>")
+			}
+			fmt.Fprintf(&b, "<li>Decremento de tamaño entre vueltas: %.0f%%</li></ul>", score.SizeDecrementPct)
+		}
+		fmt.Fprintf(&b, "<p>Puntuación global: %d/100</p>", as.Score.Score)
+	}
+
+	if jlo := ev.JLOSubTest; jlo.PK != "" {
+		b.WriteString("<h3>Orientación de líneas (JLO)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Aciertos: %d/%d (forma %s)</li>", jlo.Score.RawCorrect, len(jlo.Score.Items), jlo.Form)
+		fmt.Fprintf(&b, "<li>Puntuación corregida: %d/30 (edad +%d, sexo +%d) — %s</li>",
+			jlo.Score.Score, jlo.Score.AgeCorrection, jlo.Score.SexCorrection, jloClassificationES[jlo.Score.Classification])
+		fmt.Fprintf(&b, "<li>Respuestas parciales: %d, omisiones: %d</li></ul>", jlo.Score.PartialResponses, jlo.Score.Omissions)
+	}
+
+	if gng := ev.GoNoGoSubTest; gng.PK != "" {
+		sc := gng.Score
+		b.WriteString("<h3>Go/No-Go (inhibición)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Comisiones: %d/%d (%.0f%%), omisiones: %d/%d (%.0f%%)</li>",
+			sc.Commissions, sc.NoGoTrials, sc.CommissionRate*100, sc.Omissions, sc.GoTrials, sc.OmissionRate*100)
+		fmt.Fprintf(&b, "<li>d' %.2f, criterio %.2f; TR mediano %.0f ms (CV %.2f)</li>", sc.DPrime, sc.Criterion, sc.MedianRTMs, sc.RTCV)
+		if len(sc.Blocks) >= 2 {
+			fmt.Fprintf(&b, "<li>Evolución por bloques: comisiones %+.0f pp, TR %+.0f ms (último vs primero)</li>",
+				sc.CommissionRateChange*100, sc.MedianRTChangeMs)
+		}
+		b.WriteString("</ul>")
+	}
+
+	if wcst := ev.CardSortingSubTest; wcst.PK != "" {
+		sc := wcst.Score
+		b.WriteString("<h3>Clasificación de tarjetas (flexibilidad cognitiva)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Categorías completadas: %d/6 en %d tarjetas (de %d)</li>", sc.CategoriesCompleted, sc.TrialsAdministered, wcst.MaxCards)
+		fmt.Fprintf(&b, "<li>Errores perseverativos: %d (%.0f%%), no perseverativos: %d</li>", sc.PerseverativeErrors, sc.PerseverativeErrorsPct, sc.NonPerseverativeErrors)
+		fmt.Fprintf(&b, "<li>Ensayos hasta la 1ª categoría: %d; fallos en mantener el set: %d; nivel conceptual: %.0f%%</li></ul>",
+			sc.TrialsToFirstCategory, sc.FailuresToMaintainSet, sc.ConceptualLevelPct)
+	}
+
+	if moca := ev.MoCASubTest; moca.PK != "" {
+		sc := moca.Score
+		b.WriteString("<h3>MoCA (cribado cognitivo)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Total: %d/30 (versión %s, escolaridad +%d) — %s</li>", sc.Total, moca.Version, sc.EducationPoint, mocaClassificationES[sc.Classification])
+		fmt.Fprintf(&b, "<li>Visuoespacial/ejecutiva %d/5, denominación %d/3, atención %d/6, lenguaje %d/3, abstracción %d/2, recuerdo diferido %d/5, orientación %d/6</li>",
+			sc.Sections.VisuospatialExecutive, sc.Sections.Naming, sc.Sections.Attention, sc.Sections.Language, sc.Sections.Abstraction, sc.Sections.DelayedRecall, sc.Sections.Orientation)
+		fmt.Fprintf(&b, "<li>Índices: memoria %d/15, ejecutivo %d/13, atención %d/18, lenguaje %d/6, visuoespacial %d/7, orientación %d/6</li>",
+			sc.Indexes.Memory, sc.Indexes.Executive, sc.Indexes.Attention, sc.Indexes.Language, sc.Indexes.Visuospatial, sc.Indexes.Orientation)
+		if moca.ClockSource == MOCAdomain.SourceClockDrawing || moca.FluencySource == MOCAdomain.SourceLanguageFluency {
+			b.WriteString("<li>Reloj y/o fluencia reutilizados de los subtests de la batería</li>")
+		}
+		b.WriteString("</ul>")
+	}
+
+	if len(ev.Questionnaires) > 0 {
+		b.WriteString("<h3>Escalas y cuestionarios</h3><ul>")
+		for _, q := range ev.Questionnaires {
+			if !q.Score.Valid {
+				fmt.Fprintf(&b, "<li>%s: no puntuable (%d ítems sin respuesta)</li>", q.Name, len(q.Score.MissingItems))
+				continue
+			}
+			fmt.Fprintf(&b, "<li>%s: %g/%g", q.Name, q.Score.Total, q.Score.MaxTotal)
+			if q.Score.Label != "" {
+				fmt.Fprintf(&b, " — %s", q.Score.Label)
+			}
+			if q.Score.Imputed {
+				b.WriteString(" (con imputación de ítems perdidos)")
+			}
+			b.WriteString("</li>")
+			if len(q.Score.Subscales) > 0 {
+				parts := make([]string, 0, len(q.Score.Subscales))
+				for _, sc := range q.Score.Subscales {
+					if sc.Valid {
+						parts = append(parts, fmt.Sprintf("%s %g", sc.Name, sc.Score))
+					} else {
+						parts = append(parts, sc.Name+" n/p")
+					}
+				}
+				fmt.Fprintf(&b, "<li>Subescalas: %s</li>", strings.Join(parts, ", "))
+			}
+		}
+		b.WriteString("</ul>")
+	}
+
+	if sp := ev.SpeechProfile; sp.PK != "" {
+		m := sp.Metrics
+		b.WriteString("<h3>Habla (análisis acústico)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Intensidad media: %.1f dBFS", m.LoudnessDBFS)
+		if m.Hypophonia {
+			b.WriteString(" (hipofonía)")
+		}
+		b.WriteString("</li>")
+		fmt.Fprintf(&b, "<li>F0 media: %.0f Hz, variabilidad %.1f semitonos", m.PitchMeanHz, m.PitchSDSemitones)
+		if m.Monotone {
+			b.WriteString(" (monótona)")
+		}
+		b.WriteString("</li>")
+		fmt.Fprintf(&b, "<li>Pausas: %d (%.0f%% del tiempo), velocidad articulatoria %.1f sílabas/s</li></ul>",
+			m.Pauses, m.PauseRatio*100, m.ArticulationRate)
+	}
+
+	if cp := ev.CognitiveProfile; len(cp.Domains) > 0 {
+		b.WriteString("<h3>Perfil cognitivo por dominios</h3>")
+		if img, err := radarPNGBase64(cp.Domains); err == nil {
+			fmt.Fprintf(&b, `<img alt="Perfil cognitivo" src="data:image/png;base64,%s">`, img)
+		}
+		axes := make([]string, len(COMPdomain.Domains))
+		for i, d := range COMPdomain.Domains {
+			axes[i] = cognitiveDomainES[d]
+		}
+		fmt.Fprintf(&b, "<p><small>Ejes en sentido horario desde arriba: %s. Referencias: media (100) y límite de normalidad (85).</small></p><ul>", strings.Join(axes, ", "))
+		ci := fmt.Sprintf("IC %.0f%%", cp.ConfidenceLevel*100)
+		for _, d := range cp.Domains {
+			fmt.Fprintf(&b, "<li>%s: %s</li>", cognitiveDomainES[d.Domain], compositeScoreHTML(d.Score, ci))
+		}
+		if g := cp.Global; g != nil {
+			fmt.Fprintf(&b, "<li><strong>Índice cognitivo global: %s</strong></li>", compositeScoreHTML(g.Score, ci))
+		}
+		fmt.Fprintf(&b, "</ul><p><small>Configuración de compuestos: %s</small></p>", html.EscapeString(cp.ConfigRef))
+	}
+
+	if np := ev.NeuroProfile; np.Profile != "" {
+		b.WriteString("<h3>Perfil por reglas (contraste)</h3><ul>")
+		fmt.Fprintf(&b, "<li>Perfil según reglas: <strong>%s</strong></li>", html.EscapeString(np.Summary()))
+		if chk := ev.NeuroProfileCheck; chk.RuleProfile != "" {
+			switch {
+			case chk.LLMProfile == "":
+				b.WriteString("<li>No se reconoce el perfil del análisis para contrastarlo</li>")
+			case chk.Agrees:
+				b.WriteString("<li>Coincide con el perfil del análisis</li>")
+			default:
+				fmt.Fprintf(&b, "<li><strong>Discrepa del análisis</strong> (%s): revisar</li>", NPdomain.Labels[chk.LLMProfile])
+			}
+		}
+		fmt.Fprintf(&b, "</ul><p><small>Reglas: %s</small></p>", np.RulesVersion)
+	}
+
+	if pc := ev.PDMCICriteria; pc.Status != "" {
+		b.WriteString("<h3>Criterios PD-MCI (MDS, nivel II)</h3><ul>")
+		status := pdMCIStatusES[pc.Status]
+		if pc.Status == PDMCIdomain.StatusMet {
+			status += " — " + pdMCISubtypeES[pc.Subtype]
+		}
+		fmt.Fprintf(&b, "<li><strong>%s</strong> (deterioro: z ≤ −%g en ≥2 tests)</li>", status, pc.ImpairmentSD)
+		if len(pc.DrivingTests) > 0 {
+			tests := make([]string, len(pc.DrivingTests))
+			for i, t := range pc.DrivingTests {
+				tests[i] = fmt.Sprintf("%s (%s, z=%.2f)", t.Test, pdMCIDomainES[t.Domain], t.Z)
+			}
+			fmt.Fprintf(&b, "<li>Tests alterados: %s</li>", strings.Join(tests, ", "))
+		}
+		if len(pc.MissingDomains) > 0 {
+			domains := make([]string, len(pc.MissingDomains))
+			for i, d := range pc.MissingDomains {
+				domains[i] = pdMCIDomainES[d]
+			}
+			fmt.Fprintf(&b, "<li>Dominios con menos de 2 tests: %s</li>", strings.Join(domains, ", "))
+		}
+		for _, n := range pc.Notes {
+			fmt.Fprintf(&b, "<li>%s</li>", pdMCINoteES[n])
+		}
+		fmt.Fprintf(&b, "</ul><p><small>Criterios: %s</small></p>", html.EscapeString(pc.CriteriaRef))
+	}
+
+	if dq := ev.DataQuality; len(dq.Flags) > 0 {
+		b.WriteString("<h3>Calidad de los datos</h3><ul>")
+		for _, fl := range dq.Flags {
+			label := dataQualitySeverityES[fl.Severity]
+			if fl.Severity != DQdomain.SeverityInfo {
+				label = "<strong>" + label + "</strong>"
+			}
+			fmt.Fprintf(&b, "<li>%s — %s: %s</li>", label, fl.Subtest, html.EscapeString(fl.Message))
+		}
+		b.WriteString("</ul>")
+		if dq.Warnings > 0 {
+			b.WriteString("<p><small>Los resultados con avisos deben interpretarse con cautela.</small></p>")
+		}
+	}
+
+	if len(ev.ExaminerObservations) > 0 {
+		b.WriteString("<h3>Observaciones del evaluador</h3><ul>")
+		for _, o := range ev.ExaminerObservations {
+			fmt.Fprintf(&b, "<li>%s: fatiga %s, colaboración %s", o.Subtest, fatigueES[o.Fatigue], cooperationES[o.Cooperation])
+			if o.ComprehensionIssues {
+				b.WriteString(", dificultades de comprensión")
+			}
+			if o.Interruptions > 0 {
+				fmt.Fprintf(&b, ", %d interrupción(es)", o.Interruptions)
+			}
+			if o.Notes != "" {
+				fmt.Fprintf(&b, " — %s", html.EscapeString(o.Notes))
+			}
+			if len(o.Alerts()) > 0 {
+				b.WriteString(" <strong>(validez comprometida)</strong>")
+			}
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ul>")
+	}
+
+	if refs := scoringProfileRefs(ev); len(refs) > 0 {
+		fmt.Fprintf(&b, "<p><small>Perfil de puntuación: %s</small></p>", html.EscapeString(strings.Join(refs, ", ")))
+	}
+
+	return b.String()
+}
+
+// scoringProfileRefs lista, sin repetir, los id@versión con los que se puntuaron los subtests
+func scoringProfileRefs(ev domain.Evaluation) []string {
+	refs := []string{ev.LetterCancellationSubTest.CancellationScore.ScoringProfile, ev.LanguageFluencySubTest.Score.ScoringProfile}
+	for _, s := range ev.ExecutiveFunctionSubTest {
+		refs = append(refs, s.Score.ScoringProfile)
+	}
+	for _, s := range ev.VerbalmemorySubTest {
+		refs = append(refs, s.Score.ScoringProfile)
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, r := range refs {
+		if r != "" && !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// compositeScoreHTML resume un compuesto como índice, intervalo, percentil y clasificación
+func compositeScoreHTML(s COMPdomain.Score, ci string) string {
+	return fmt.Sprintf("%.0f (%s %.0f–%.0f, percentil %.0f) — %s", s.Index, ci, s.CILow, s.CIHigh, s.Percentile, compositeClassificationES[s.Classification])
+}
+
+var cognitiveDomainES = map[COMPdomain.CognitiveDomain]string{
+	COMPdomain.Attention:       "Atención",
+	COMPdomain.ProcessingSpeed: "Velocidad de procesamiento",
+	COMPdomain.Memory:          "Memoria",
+	COMPdomain.Executive:       "Funciones ejecutivas",
+	COMPdomain.Language:        "Lenguaje",
+	COMPdomain.Visuospatial:    "Visuoespacial",
+}
+
+var compositeClassificationES = map[COMPdomain.Classification]string{
+	COMPdomain.ClassificationNormal:   "normal",
+	COMPdomain.ClassificationLow:      "bajo",
+	COMPdomain.ClassificationImpaired: "alterado",
+}
+
+var pdMCIStatusES = map[PDMCIdomain.Status]string{
+	PDMCIdomain.StatusMet:                 "Cumple criterios de PD-MCI",
+	PDMCIdomain.StatusNotMet:              "No cumple criterios de PD-MCI",
+	PDMCIdomain.StatusInsufficientBattery: "Batería insuficiente para el nivel II",
+}
+
+var pdMCISubtypeES = map[PDMCIdomain.Subtype]string{
+	PDMCIdomain.SubtypeSingleDomain:   "dominio único",
+	PDMCIdomain.SubtypeMultipleDomain: "multidominio",
+}
+
+var pdMCIDomainES = map[PDMCIdomain.Domain]string{
+	PDMCIdomain.AttentionWorkingMemory: "atención y memoria de trabajo",
+	PDMCIdomain.Executive:              "funciones ejecutivas",
+	PDMCIdomain.Language:               "lenguaje",
+	PDMCIdomain.Memory:                 "memoria",
+	PDMCIdomain.Visuospatial:           "visuoespacial",
+}
+
+var pdMCINoteES = map[string]string{
+	PDMCIdomain.NoteAbbreviatedBattery:   "Hay ≥2 tests alterados: compatible con PD-MCI de nivel I (batería abreviada, sin subtipo)",
+	PDMCIdomain.NoteFunctionalImpairment: "FAQ alterado: PD-MCI exige autonomía funcional preservada; valorar demencia asociada a Parkinson",
+}
+
+var dataQualitySeverityES = map[DQdomain.Severity]string{
+	DQdomain.SeverityInfo:    "Nota",
+	DQdomain.SeverityWarning: "Aviso",
+	DQdomain.SeverityError:   "Dato imposible",
+}
+
+var medicationStateES = map[CCdomain.MedicationState]string{
+	CCdomain.MedicationOn:        "ON",
+	CCdomain.MedicationOff:       "OFF",
+	CCdomain.MedicationUntreated: "Sin tratamiento dopaminérgico",
+}
+
+var sensoryStatusES = map[ANdomain.SensoryStatus]string{
+	ANdomain.SensoryNone:        "sin déficit",
+	ANdomain.SensoryCorrected:   "déficit corregido",
+	ANdomain.SensoryUncorrected: "déficit sin corregir",
+}
+
+var sleepQualityES = map[ANdomain.SleepQuality]string{
+	ANdomain.SleepGood: "buena calidad",
+	ANdomain.SleepFair: "calidad regular",
+	ANdomain.SleepPoor: "mala calidad",
+}
+
+var fatigueES = map[EOdomain.Fatigue]string{
+	EOdomain.FatigueNone:   "ausente",
+	EOdomain.FatigueMild:   "leve",
+	EOdomain.FatigueMarked: "marcada",
+}
+
+var cooperationES = map[EOdomain.Cooperation]string{
+	EOdomain.CooperationGood: "buena",
+	EOdomain.CooperationFair: "regular",
+	EOdomain.CooperationPoor: "escasa",
+}
+
+var mocaClassificationES = map[string]string{
+	"normal":   "normal",
+	"mild":     "deterioro leve",
+	"moderate": "deterioro moderado",
+	"severe":   "deterioro grave",
+}
+
+var jloClassificationES = map[JLOdomain.JLOClassification]string{
+	JLOdomain.JLOSuperior:            "superior",
+	JLOdomain.JLOHighAverage:         "medio-alto",
+	JLOdomain.JLOAverage:             "medio",
+	JLOdomain.JLOLowAverage:          "medio-bajo",
+	JLOdomain.JLOBorderline:          "límite",
+	JLOdomain.JLOModeratelyDefective: "moderadamente deficitario",
+	JLOdomain.JLOSeverelyDefective:   "gravemente deficitario",
+}
+
 func (f *WKHTMLFileFormatter) ConvertHTMLtoPDF(html string) ([]byte, error) {
 	// ==== Branding / estilos ====
 	const (
@@ -67,7 +605,9 @@ func (f *WKHTMLFileFormatter) ConvertHTMLtoPDF(html string) ([]byte, error) {
 	sectionBg := color{230, 244, 244}
 
 	// ==== Parsear HTML de entrada ====
+	html, images := extractImages(html)
 	patient, specialist, plainResults := extractFromHTML(html)
+	clinical := extractClinicalContext(html)
 
 	// ==== PDF base ====
 	pdf := fpdf.New("P", "mm", "A4", "")
@@ -112,7 +652,7 @@ func (f *WKHTMLFileFormatter) ConvertHTMLtoPDF(html string) ([]byte, error) {
 	pdf.Ln(2)
 
 	// ==== Tarjeta de información Paciente/Especialista ====
-	drawInfoCard(pdf, tr, patient, specialist, darkText, mutedText, lightGray, cardRadius, infoLabelSize, infoValueSize, lineH)
+	drawInfoCard(pdf, tr, patient, specialist, clinical, darkText, mutedText, lightGray, cardRadius, infoLabelSize, infoValueSize, lineH)
 
 	pdf.Ln(3)
 
@@ -123,7 +663,7 @@ func (f *WKHTMLFileFormatter) ConvertHTMLtoPDF(html string) ([]byte, error) {
 	// Cuerpo de resultados (multicell bonito)
 	setText(pdf, darkText)
 	pdf.SetFont("Helvetica", "", bodySize)
-	writeBody(pdf, tr, plainResults, lineH)
+	writeBody(pdf, tr, plainResults, images, lineH)
 
 	// ==== Salida ====
 	var out bytes.Buffer
@@ -144,7 +684,7 @@ func setText(pdf *fpdf.Fpdf, c color) { pdf.SetTextColor(c.R, c.G, c.B) }
 func drawInfoCard(
 	pdf *fpdf.Fpdf,
 	tr func(string) string,
-	patient, specialist string,
+	patient, specialist, clinical string,
 	labelCol, mutedCol, bg color,
 	radius, labelSize, valueSize, lineH float64,
 ) {
@@ -152,6 +692,9 @@ func drawInfoCard(
 	y := pdf.GetY()
 	w := 0.0 // ancho automático (hasta margen derecho)
 	h := 22.0
+	if clinical != "" {
+		h += lineH
+	}
 	// Fondo tarjeta (rounded)
 	setFill(pdf, bg)
 	setDraw(pdf, color{220, 225, 228})
@@ -181,6 +724,17 @@ func drawInfoCard(
 	pdf.SetFont("Helvetica", "B", valueSize)
 	pdf.CellFormat(w, lineH, tr(nonEmpty(specialist, "—")), "", 1, "L", false, 0, "")
 
+	if clinical != "" {
+		pdf.SetX(x + 6)
+		setText(pdf, mutedCol)
+		pdf.SetFont("Helvetica", "", labelSize)
+		pdf.CellFormat(w, lineH, tr("Contexto clínico"), "", 0, "L", false, 0, "")
+		pdf.SetX(x + 35)
+		setText(pdf, labelCol)
+		pdf.SetFont("Helvetica", "", valueSize)
+		pdf.CellFormat(w, lineH, tr(clinical), "", 1, "L", false, 0, "")
+	}
+
 	// Mueve el cursor bajo la tarjeta
 	pdf.SetY(y + h + 2)
 }
@@ -207,8 +761,10 @@ func drawSectionTitle(pdf *fpdf.Fpdf, tr func(string) string, title string, bran
 	pdf.CellFormat(0, barH, tr(title), "", 1, "L", false, 0, "")
 }
 
-func writeBody(pdf *fpdf.Fpdf, tr func(string) string, text string, lineH float64) {
-	// Soporte simple de listas y párrafos
+func writeBody(pdf *fpdf.Fpdf, tr func(string) string, text string, images []string, lineH float64) {
+	// Soporte simple de listas, párrafos e imágenes PNG embebidas
+	const imageW = 60.0
+	reImg := regexp.MustCompile(`^\[\[img:(\d+)\]\]$`)
 	lines := strings.Split(normalizeWhitespace(text), "\n")
 	for _, ln := range lines {
 		l := strings.TrimSpace(ln)
@@ -216,6 +772,26 @@ func writeBody(pdf *fpdf.Fpdf, tr func(string) string, text string, lineH float6
 			pdf.Ln(lineH / 2)
 			continue
 		}
+		if m := reImg.FindStringSubmatch(l); m != nil {
+			idx, _ := strconv.Atoi(m[1])
+			data, err := base64.StdEncoding.DecodeString(images[idx])
+			if err != nil {
+				continue
+			}
+			name := "img" + m[1]
+			info := pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(data))
+			if info == nil {
+				continue
+			}
+			imageH := imageW * info.Height() / info.Width()
+			_, pageH := pdf.GetPageSize()
+			if _, _, _, bottom := pdf.GetMargins(); pdf.GetY()+imageH > pageH-bottom {
+				pdf.AddPage()
+			}
+			pdf.ImageOptions(name, pdf.GetX(), pdf.GetY(), imageW, imageH, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			pdf.SetY(pdf.GetY() + imageH + 1)
+			continue
+		}
 		if strings.HasPrefix(l, "- ") || strings.HasPrefix(l, "• ") || strings.HasPrefix(l, "* ") {
 			// viñeta
 			pdf.SetX(pdf.GetX() + 2)
@@ -244,6 +820,17 @@ func normalizeWhitespace(s string) string {
 
 // ======== PRIVADO: parser HTML MUY sencillo ========
 
+// extractImages sustituye las <img> PNG en base64 por marcadores [[img:N]] que writeBody dibuja
+func extractImages(html string) (string, []string) {
+	reImg := regexp.MustCompile(`(?is)<img[^>]*src="data:image/png;base64,([^"]+)"[^>]*>`)
+	var images []string
+	html = reImg.ReplaceAllStringFunc(html, func(tag string) string {
+		images = append(images, reImg.FindStringSubmatch(tag)[1])
+		return fmt.Sprintf("<br>[[img:%d]]<br>", len(images)-1)
+	})
+	return html, images
+}
+
 func extractFromHTML(html string) (patient string, specialist string, plainResults string) {
 	// Paciente
 	rePac := regexp.MustCompile(`(?is)<strong>\s*Paciente:\s*</strong>\s*([^<]+)`)
@@ -265,6 +852,14 @@ func extractFromHTML(html string) (patient string, specialist string, plainResul
 	return
 }
 
+func extractClinicalContext(html string) string {
+	re := regexp.MustCompile(`(?is)<strong>\s*Contexto clínico:\s*</strong>\s*([^<]+)`)
+	if m := re.FindStringSubmatch(html); len(m) > 1 {
+		return strings.TrimSpace(htmlToText(m[1]))
+	}
+	return ""
+}
+
 func htmlToText(s string) string {
 	// Cambios rápidos para listas
 	s = strings.ReplaceAll(s, "</li>", "\n")
@@ -278,6 +873,7 @@ func htmlToText(s string) string {
 	s = strings.ReplaceAll(s, "</h1>", "\n\n")
 	s = strings.ReplaceAll(s, "</h2>", "\n\n")
 	s = strings.ReplaceAll(s, "</h2 >", "\n\n")
+	s = strings.ReplaceAll(s, "</h3>", "\n")
 
 	// Quitar el resto de tags
 	reTags := regexp.MustCompile(`(?is)<[^>]+>`)
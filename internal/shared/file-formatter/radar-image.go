@@ -0,0 +1,83 @@
+package fileformatter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"math"
+
+	COMPdomain "neuro.app.jordi/internal/evaluation/domain/composites"
+)
+
+const (
+	radarImageSize = 360
+	radarMinIndex  = 40.0
+	radarMaxIndex  = 160.0
+)
+
+// radarPNGBase64 dibuja el perfil por dominios (un eje por dominio, índice 40–160 del centro al borde)
+// con las referencias de la media (100) y del límite de normalidad (85)
+func radarPNGBase64(domains []COMPdomain.DomainComposite) (string, error) {
+	img := image.NewRGBA(image.Rect(0, 0, radarImageSize, radarImageSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	const margin = 16.0
+	center := radarImageSize / 2.0
+	radius := center - margin
+	axes := len(COMPdomain.Domains)
+	point := func(axis int, index float64) (float64, float64) {
+		t := (math.Max(radarMinIndex, math.Min(radarMaxIndex, index)) - radarMinIndex) / (radarMaxIndex - radarMinIndex)
+		angle := -math.Pi/2 + 2*math.Pi*float64(axis)/float64(axes)
+		return center + t*radius*math.Cos(angle), center + t*radius*math.Sin(angle)
+	}
+
+	grid := imagecolor.RGBA{200, 200, 200, 255}
+	for a := 0; a < axes; a++ {
+		x, y := point(a, radarMaxIndex)
+		drawLine(img, center, center, x, y, grid)
+	}
+	ring := func(index float64, c imagecolor.RGBA) {
+		for a := 0; a < axes; a++ {
+			x0, y0 := point(a, index)
+			x1, y1 := point((a+1)%axes, index)
+			drawLine(img, x0, y0, x1, y1, c)
+		}
+	}
+	ring(radarMaxIndex, grid)
+	ring(100, imagecolor.RGBA{120, 120, 120, 255})
+	ring(85, imagecolor.RGBA{220, 120, 120, 255})
+
+	// Solo se unen los ejes con dominio calculado; los ausentes no se dibujan como 0
+	byDomain := map[COMPdomain.CognitiveDomain]float64{}
+	for _, d := range domains {
+		byDomain[d.Domain] = d.Index
+	}
+	type vertex struct{ x, y float64 }
+	var profile []vertex
+	for a, d := range COMPdomain.Domains {
+		if index, ok := byDomain[d]; ok {
+			x, y := point(a, index)
+			profile = append(profile, vertex{x, y})
+		}
+	}
+	ink := imagecolor.RGBA{32, 140, 140, 255}
+	for i := range profile {
+		next := profile[(i+1)%len(profile)]
+		if len(profile) > 2 || i+1 < len(profile) {
+			drawLine(img, profile[i].x, profile[i].y, next.x, next.y, ink)
+		}
+		for dx := -2.0; dx <= 2; dx++ {
+			drawLine(img, profile[i].x+dx, profile[i].y-2, profile[i].x+dx, profile[i].y+2, ink)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,67 @@
+package fileformatter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"math"
+
+	ASdomain "neuro.app.jordi/internal/evaluation/domain/sub-tests/archimedes-spiral"
+)
+
+const spiralImageSize = 360
+
+// spiralPNGBase64 dibuja el trazo de la espiral (escalado y centrado) como PNG en base64
+func spiralPNGBase64(points []ASdomain.SpiralPoint) (string, error) {
+	img := image.NewRGBA(image.Rect(0, 0, spiralImageSize, spiralImageSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	if len(points) > 1 {
+		minX, minY, maxX, maxY := points[0].X, points[0].Y, points[0].X, points[0].Y
+		for _, p := range points {
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+		const margin = 12.0
+		span := math.Max(maxX-minX, maxY-minY)
+		if span == 0 {
+			span = 1
+		}
+		scale := (spiralImageSize - 2*margin) / span
+		offX := margin + ((spiralImageSize-2*margin)-(maxX-minX)*scale)/2
+		offY := margin + ((spiralImageSize-2*margin)-(maxY-minY)*scale)/2
+		project := func(p ASdomain.SpiralPoint) (float64, float64) {
+			return offX + (p.X-minX)*scale, offY + (p.Y-minY)*scale
+		}
+		ink := imagecolor.RGBA{32, 140, 140, 255}
+		for i := 1; i < len(points); i++ {
+			x0, y0 := project(points[i-1])
+			x1, y1 := project(points[i])
+			drawLine(img, x0, y0, x1, y1, ink)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// drawLine traza un segmento de ~2 px de grosor muestreando cada medio píxel
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, c imagecolor.RGBA) {
+	steps := int(math.Ceil(math.Hypot(x1-x0, y1-y0)*2)) + 1
+	for s := 0; s <= steps; s++ {
+		t := float64(s) / float64(steps)
+		x := int(math.Round(x0 + t*(x1-x0)))
+		y := int(math.Round(y0 + t*(y1-y0)))
+		for dx := 0; dx <= 1; dx++ {
+			for dy := 0; dy <= 1; dy++ {
+				img.SetRGBA(x+dx, y+dy, c)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package midleware
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"io"
 	"net/http"
 	"regexp"
@@ -249,6 +250,24 @@ func ExtractJWTFromRequest(jwtSvc *jwtService.Service) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireAdminToken protege las rutas de administración con el token compartido de la cabecera
+// X-Admin-Token. Sin token configurado las rutas quedan deshabilitadas.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		got := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token_invalido"})
+			return
+		}
+		c.Next()
+	}
+}
+
 func GetUserIdFromRequest(c *gin.Context) (string, bool) {
 	id, exists := c.Get("id")
 	if !exists {
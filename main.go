@@ -26,6 +26,11 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Subcomandos de administración: go run . rescore [-apply] ...
+	if len(os.Args) > 1 && os.Args[1] == "rescore" {
+		os.Exit(runRescore(db, os.Args[2:]))
+	}
+
 	router := api.NewApp(db).SetupRouter()
 
 	router.Use(gin.Logger())
@@ -46,7 +51,7 @@ func main() {
 		Handler: router,
 	}
 
-	log.Printf("Server listening on port: " + port)
+	log.Printf("Server listening on port: %s", port)
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Listen error: %s\n", err)
 	}
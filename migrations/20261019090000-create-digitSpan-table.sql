@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS digit_span_subtests (
+  id                        CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id             CHAR(36)     NOT NULL,
+  trials                    JSON         NOT NULL, -- []DigitSpanTrial (presentado, respuesta, corrección)
+  score                     INT          NOT NULL, -- DigitSpanScore.Score 0..100
+  total_raw                 INT          NOT NULL,
+  forward_longest_span      INT          NOT NULL,
+  forward_total_correct     INT          NOT NULL,
+  backward_longest_span     INT          NOT NULL,
+  backward_total_correct    INT          NOT NULL,
+  sequencing_longest_span   INT          NOT NULL,
+  sequencing_total_correct  INT          NOT NULL,
+  score_detail              JSON         NOT NULL, -- DigitSpanScore completo (z, percentil, discontinuación)
+  assistant_analysis        TEXT         NULL,
+  created_at                DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_digit_span_eval (evaluation_id, created_at),
+  CONSTRAINT fk_digit_span_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS digit_span_subtests;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS stroop_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  conditions         JSON         NOT NULL, -- palabra, color y palabra-color (ítems, errores, autocorrecciones, tiempos)
+  score              INT          NOT NULL, -- StroopScore.Score 0..100
+  word_items         INT          NOT NULL,
+  color_items        INT          NOT NULL,
+  color_word_items   INT          NOT NULL,
+  interference       DECIMAL(6,2) NOT NULL, -- Golden: PC − (P × C) / (P + C)
+  score_detail       JSON         NOT NULL, -- StroopScore completo (ratios de error, medianas)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_stroop_eval (evaluation_id, created_at),
+  CONSTRAINT fk_stroop_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS stroop_subtests;
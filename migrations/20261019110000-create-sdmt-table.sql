@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS sdmt_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  mode               VARCHAR(16)  NOT NULL, -- written | oral
+  responses          JSON         NOT NULL, -- []SDMTResponse (esperado, respondido, ms desde el inicio)
+  score              INT          NOT NULL, -- SDMTScore.Score 0..100 (percentil)
+  correct            INT          NOT NULL, -- correctas en 90 s
+  errors             INT          NOT NULL,
+  score_detail       JSON         NOT NULL, -- SDMTScore completo (intervalos de 30 s, z, percentil)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_sdmt_eval (evaluation_id, created_at),
+  CONSTRAINT fk_sdmt_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS sdmt_subtests;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS confrontation_naming_subtests (
+  id                  CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id       CHAR(36)     NOT NULL,
+  version             INT          NOT NULL, -- 15 | 30 | 60 láminas
+  responses           JSON         NOT NULL, -- []NamingResponse (transcripción, claves, clasificación, corrección del evaluador)
+  score               INT          NOT NULL, -- NamingScore.Score 0..100
+  spontaneous_correct INT          NOT NULL,
+  cued_correct        INT          NOT NULL, -- tras clave semántica o fonémica
+  total               INT          NOT NULL, -- espontáneas + tras clave semántica
+  score_detail        JSON         NOT NULL, -- NamingScore completo (tipos de error)
+  assistant_analysis  TEXT         NULL,
+  created_at          DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_confrontation_naming_eval (evaluation_id, created_at),
+  CONSTRAINT fk_confrontation_naming_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS confrontation_naming_subtests;
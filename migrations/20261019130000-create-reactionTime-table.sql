@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS reaction_time_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  trials             JSON         NOT NULL, -- []RTTrial (marcas de estímulo y respuesta de la tableta)
+  score              INT          NOT NULL, -- ReactionTimeScore.Score 0..100
+  simple_median_ms   DECIMAL(8,2) NOT NULL,
+  choice_median_ms   DECIMAL(8,2) NOT NULL,
+  motor_z            DECIMAL(6,2) NOT NULL, -- covariable de velocidad motora (positivo = más lento)
+  motor_slowed       TINYINT(1)   NOT NULL,
+  score_detail       JSON         NOT NULL, -- ReactionTimeScore completo (variabilidad, anticipaciones, lapsos)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_reaction_time_eval (evaluation_id, created_at),
+  CONSTRAINT fk_reaction_time_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS reaction_time_subtests;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS finger_tapping_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  trials             JSON         NOT NULL, -- []HandTrial (toques con marca temporal y posición por mano)
+  score              INT          NOT NULL, -- FingerTappingScore.Score 0..100
+  left_rate_hz       DECIMAL(6,2) NOT NULL,
+  right_rate_hz      DECIMAL(6,2) NOT NULL,
+  rate_asymmetry_pct DECIMAL(6,2) NOT NULL, -- (D − I) / media × 100
+  score_detail       JSON         NOT NULL, -- FingerTappingScore completo (decremento de amplitud, ritmo, pausas)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_finger_tapping_eval (evaluation_id, created_at),
+  CONSTRAINT fk_finger_tapping_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS finger_tapping_subtests;
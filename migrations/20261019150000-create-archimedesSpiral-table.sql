@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS archimedes_spiral_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  drawings           JSON         NOT NULL, -- []SpiralDrawing (puntos del trazo con marca temporal por mano)
+  score              INT          NOT NULL, -- SpiralScore.Score 0..100
+  left_tremor_hz     DECIMAL(6,2) NOT NULL,
+  right_tremor_hz    DECIMAL(6,2) NOT NULL,
+  score_detail       JSON         NOT NULL, -- SpiralScore completo (temblor, suavidad, velocidad, separación entre vueltas)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_archimedes_spiral_eval (evaluation_id, created_at),
+  CONSTRAINT fk_archimedes_spiral_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS archimedes_spiral_subtests;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS speech_profiles (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  source             VARCHAR(32)  NOT NULL, -- subtest de origen del audio (language_fluency)
+  loudness_dbfs      DECIMAL(6,2) NOT NULL,
+  pitch_mean_hz      DECIMAL(6,2) NOT NULL,
+  pitch_sd_semitones DECIMAL(6,2) NOT NULL,
+  pause_ratio        DECIMAL(5,2) NOT NULL,
+  articulation_rate  DECIMAL(5,2) NOT NULL, -- sílabas / s de habla
+  metrics            JSON         NOT NULL, -- Metrics completo (sin audio: solo medidas derivadas)
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_speech_profiles_eval (evaluation_id, created_at),
+  CONSTRAINT fk_speech_profiles_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS speech_profiles;
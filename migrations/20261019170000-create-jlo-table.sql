@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS jlo_subtests (
+  id                 CHAR(36)    NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)    NOT NULL,
+  responses          JSON        NOT NULL, -- []JLOResponse (presentación y líneas elegidas por ítem)
+  form               VARCHAR(8)  NOT NULL, -- full | odd | even
+  sex                VARCHAR(8)  NOT NULL, -- para la corrección de Benton
+  score              INT         NOT NULL, -- JLOScore.Score corregido 0..30
+  raw_correct        INT         NOT NULL,
+  score_detail       JSON        NOT NULL, -- JLOScore completo (correcciones, clasificación, resultado por ítem)
+  assistant_analysis TEXT        NULL,
+  created_at         DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_jlo_eval (evaluation_id, created_at),
+  CONSTRAINT fk_jlo_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS jlo_subtests;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS go_no_go_subtests (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  trials             JSON         NOT NULL, -- []GoNoGoTrial (secuencia de estímulos y respuestas con marca temporal)
+  blocks             INT          NOT NULL,
+  score              INT          NOT NULL, -- GoNoGoScore.Score 0..100
+  commission_rate    DECIMAL(5,3) NOT NULL,
+  d_prime            DECIMAL(6,3) NOT NULL,
+  score_detail       JSON         NOT NULL, -- GoNoGoScore completo (omisiones, TR, bloques)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_go_no_go_eval (evaluation_id, created_at),
+  CONSTRAINT fk_go_no_go_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS go_no_go_subtests;
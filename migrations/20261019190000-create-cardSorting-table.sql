@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS card_sorting_subtests (
+  id                   CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id        CHAR(36)     NOT NULL,
+  status               VARCHAR(16)  NOT NULL, -- in_progress | completed (sesión controlada por el servidor)
+  max_cards            INT          NOT NULL, -- 64 | 128
+  trials_administered  INT          NOT NULL, -- control de concurrencia optimista
+  responses            JSON         NOT NULL, -- []SortResponse validadas por el servidor
+  score                INT          NOT NULL, -- CardSortingScore.Score 0..100
+  categories_completed INT          NOT NULL,
+  perseverative_errors INT          NOT NULL,
+  score_detail         JSON         NOT NULL, -- CardSortingScore completo
+  assistant_analysis   TEXT         NULL,
+  created_at           DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_card_sorting_eval (evaluation_id, status, created_at),
+  CONSTRAINT fk_card_sorting_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS card_sorting_subtests;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS questionnaire_responses (
+  id                 CHAR(36)     NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)     NOT NULL,
+  code               VARCHAR(64)  NOT NULL, -- código de la definición (gds-15, pdq-39, ...)
+  version            INT          NOT NULL, -- versión de la definición con la que se puntuó
+  name               VARCHAR(255) NOT NULL,
+  construct          VARCHAR(64)  NOT NULL,
+  answers            JSON         NOT NULL, -- id de ítem -> valor de la opción elegida
+  total              DECIMAL(6,1) NOT NULL,
+  valid              TINYINT(1)   NOT NULL, -- 0 si se superó el máximo de ítems perdidos
+  score_detail       JSON         NOT NULL, -- QuestionnaireScore completo (subescalas, clasificación)
+  assistant_analysis TEXT         NULL,
+  created_at         DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_questionnaire_eval (evaluation_id, code, created_at),
+  CONSTRAINT fk_questionnaire_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS questionnaire_responses;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS moca_subtests (
+  id                 CHAR(36)    NOT NULL PRIMARY KEY,
+  evaluation_id      CHAR(36)    NOT NULL,
+  items              JSON        NOT NULL, -- MoCAItems (resultados por ítem, reloj y fluencia ya resueltos)
+  version            VARCHAR(8)  NOT NULL,
+  education_years    INT         NOT NULL,
+  clock_source       VARCHAR(32) NOT NULL, -- entered | clock_drawing_test
+  fluency_source     VARCHAR(32) NOT NULL, -- entered | language_fluency
+  score              INT         NOT NULL, -- MoCAScore.Total 0..30
+  classification     VARCHAR(16) NOT NULL,
+  score_detail       JSON        NOT NULL, -- MoCAScore completo (secciones e índices por dominio)
+  assistant_analysis TEXT        NULL,
+  created_at         DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  KEY idx_moca_eval (evaluation_id, created_at),
+  CONSTRAINT fk_moca_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS moca_subtests;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_clinical_contexts (
+  evaluation_id               CHAR(36)     NOT NULL PRIMARY KEY, -- un contexto por evaluación
+  medication_state            VARCHAR(16)  NOT NULL, -- on | off | untreated
+  minutes_since_last_levodopa INT          NULL,
+  medications                 JSON         NOT NULL, -- []Medication (código de fármaco y dosis diaria en mg)
+  ledd_total                  DECIMAL(7,1) NOT NULL, -- dosis equivalente de levodopa, mg/día
+  ledd_levodopa               DECIMAL(7,1) NOT NULL,
+  ledd_agonists               DECIMAL(7,1) NOT NULL,
+  hoehn_yahr                  DECIMAL(2,1) NULL,
+  dbs_stimulation             VARCHAR(8)   NOT NULL, -- none | on | off
+  dbs_target                  VARCHAR(8)   NULL,     -- stn | gpi | vim
+  disease_duration_years      DECIMAL(4,1) NULL,
+  created_at                  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at                  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  CONSTRAINT fk_clinical_context_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_clinical_contexts;
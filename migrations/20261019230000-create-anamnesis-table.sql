@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_anamneses (
+  evaluation_id       CHAR(36)     NOT NULL PRIMARY KEY, -- una anamnesis por evaluación
+  referral_reason     TEXT         NOT NULL,
+  comorbidities       JSON         NOT NULL, -- []string
+  vision_status       VARCHAR(16)  NOT NULL, -- none | corrected | uncorrected
+  hearing_status      VARCHAR(16)  NOT NULL, -- none | corrected | uncorrected
+  sleep               JSON         NOT NULL, -- Sleep (calidad, horas, somnolencia diurna, sospecha de TCSR)
+  mood_complaints     JSON         NOT NULL, -- MoodComplaints (depresión, ansiedad, apatía)
+  current_medications JSON         NOT NULL, -- []string, medicación no antiparkinsoniana
+  created_at          DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at          DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  CONSTRAINT fk_anamnesis_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_anamneses;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_examiner_observations (
+  evaluation_id        CHAR(36)     NOT NULL,
+  subtest              VARCHAR(40)  NOT NULL, -- código del subtest (p. ej. stroop, verbal_memory)
+  fatigue              VARCHAR(8)   NOT NULL, -- none | mild | marked
+  cooperation          VARCHAR(8)   NOT NULL, -- good | fair | poor
+  comprehension_issues BOOLEAN      NOT NULL DEFAULT FALSE,
+  interruptions        INT          NOT NULL DEFAULT 0,
+  notes                TEXT         NULL,
+  created_at           DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at           DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  PRIMARY KEY (evaluation_id, subtest), -- una observación por subtest
+
+  CONSTRAINT fk_examiner_observation_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_examiner_observations;
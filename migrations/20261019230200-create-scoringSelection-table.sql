@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_scoring_selections (
+  evaluation_id CHAR(36)    NOT NULL PRIMARY KEY, -- una selección por evaluación, no se modifica
+  protocol      VARCHAR(40) NOT NULL,
+  profile_ref   VARCHAR(64) NOT NULL,             -- id@versión exacto del perfil de puntuación
+  created_at    DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  CONSTRAINT fk_scoring_selection_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_scoring_selections;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- Los resultados existentes se calcularon con las fórmulas fijas, que son exactamente standard@1
+ALTER TABLE letters_cancellation_subtests ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE executive_functions_subtests  ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE language_fluencies            ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE verbal_memory_subtests        ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+
+-- +migrate Down
+ALTER TABLE letters_cancellation_subtests DROP COLUMN scoring_profile;
+ALTER TABLE executive_functions_subtests  DROP COLUMN scoring_profile;
+ALTER TABLE language_fluencies            DROP COLUMN scoring_profile;
+ALTER TABLE verbal_memory_subtests        DROP COLUMN scoring_profile;
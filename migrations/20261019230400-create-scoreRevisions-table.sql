@@ -0,0 +1,25 @@
+-- +migrate Up
+-- Historial de scores recalculados; la revisión 1 es el score original de la fila
+CREATE TABLE IF NOT EXISTS subtest_score_revisions (
+  id              BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  subtest         VARCHAR(40) NOT NULL, -- letter_cancellation | verbal_memory | executive_functions | language_fluency
+  subtest_id      CHAR(36)    NOT NULL,
+  evaluation_id   CHAR(36)    NOT NULL,
+  revision        INT         NOT NULL,
+  scoring_profile VARCHAR(64) NOT NULL,
+  score           JSON        NOT NULL,
+  run_id          CHAR(36)    NULL,     -- ejecución de recálculo que produjo la revisión
+  created_at      DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  UNIQUE KEY uq_score_revision (subtest, subtest_id, revision),
+  KEY idx_score_revision_eval (evaluation_id),
+  KEY idx_score_revision_run (run_id),
+
+  CONSTRAINT fk_score_revision_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS subtest_score_revisions;
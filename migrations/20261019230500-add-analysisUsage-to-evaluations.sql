@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Proveedor, modelo y latencia del análisis; vacío en las evaluaciones anteriores
+ALTER TABLE evaluations ADD COLUMN analysis_provider   VARCHAR(64)  NOT NULL DEFAULT '';
+ALTER TABLE evaluations ADD COLUMN analysis_model      VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE evaluations ADD COLUMN analysis_latency_ms INT          NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE evaluations DROP COLUMN analysis_provider;
+ALTER TABLE evaluations DROP COLUMN analysis_model;
+ALTER TABLE evaluations DROP COLUMN analysis_latency_ms;
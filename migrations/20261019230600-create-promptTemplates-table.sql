@@ -0,0 +1,20 @@
+-- +migrate Up
+-- Versiones publicadas de los prompts; las v1 van empaquetadas con el binario
+CREATE TABLE IF NOT EXISTS prompt_templates (
+  id         VARCHAR(40)  NOT NULL,
+  locale     VARCHAR(8)   NOT NULL,
+  version    INT          NOT NULL,
+  body       MEDIUMTEXT   NOT NULL,
+  notes      VARCHAR(512) NOT NULL DEFAULT '',
+  checksum   CHAR(64)     NOT NULL,             -- sha256 del cuerpo
+  created_at DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  PRIMARY KEY (id, locale, version)            -- una versión publicada no se modifica
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- id/idioma@versión del prompt con que se generó el análisis
+ALTER TABLE evaluations ADD COLUMN analysis_prompt_version VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE evaluations DROP COLUMN analysis_prompt_version;
+DROP TABLE IF EXISTS prompt_templates;
@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Análisis estructurado validado contra el esquema; el Markdown de assistant_analysis se genera desde estos campos
+ALTER TABLE evaluations ADD COLUMN analysis_title           VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE evaluations ADD COLUMN analysis_profile         VARCHAR(32)  NOT NULL DEFAULT '';
+ALTER TABLE evaluations ADD COLUMN analysis_justification   TEXT         NULL;
+ALTER TABLE evaluations ADD COLUMN analysis_findings        JSON         NULL;  -- [{domain, severity, subtests, finding}]
+ALTER TABLE evaluations ADD COLUMN analysis_summary         TEXT         NULL;
+ALTER TABLE evaluations ADD COLUMN analysis_recommendations JSON         NULL;
+ALTER TABLE evaluations ADD COLUMN analysis_uncertainty     VARCHAR(16)  NOT NULL DEFAULT '';
+ALTER TABLE evaluations ADD COLUMN analysis_attempts        INT          NOT NULL DEFAULT 0;
+CREATE INDEX idx_evaluations_analysis_profile ON evaluations (analysis_profile);
+
+-- +migrate Down
+DROP INDEX idx_evaluations_analysis_profile ON evaluations;
+ALTER TABLE evaluations DROP COLUMN analysis_title;
+ALTER TABLE evaluations DROP COLUMN analysis_profile;
+ALTER TABLE evaluations DROP COLUMN analysis_justification;
+ALTER TABLE evaluations DROP COLUMN analysis_findings;
+ALTER TABLE evaluations DROP COLUMN analysis_summary;
+ALTER TABLE evaluations DROP COLUMN analysis_recommendations;
+ALTER TABLE evaluations DROP COLUMN analysis_uncertainty;
+ALTER TABLE evaluations DROP COLUMN analysis_attempts;
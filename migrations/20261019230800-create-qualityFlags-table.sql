@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_quality_flags (
+  id            BIGINT       NOT NULL AUTO_INCREMENT,
+  evaluation_id CHAR(36)     NOT NULL,
+  subtest       VARCHAR(40)  NOT NULL, -- código del subtest (p. ej. stroop, verbal_memory)
+  subtest_id    CHAR(36)     NOT NULL, -- fila del subtest que generó el aviso
+  code          VARCHAR(40)  NOT NULL, -- impossible_value | implausibly_fast | ceiling_effect | floor_effect
+  severity      VARCHAR(8)   NOT NULL, -- info | warning (los de severidad error se rechazan al crear)
+  field         VARCHAR(40)  NULL,
+  message       TEXT         NOT NULL,
+  created_at    DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+  PRIMARY KEY (id),
+  KEY idx_quality_flags_evaluation (evaluation_id),
+  KEY idx_quality_flags_subtest (subtest_id),
+
+  CONSTRAINT fk_quality_flags_eval
+    FOREIGN KEY (evaluation_id) REFERENCES evaluations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_quality_flags;
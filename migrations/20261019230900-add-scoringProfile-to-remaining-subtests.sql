@@ -0,0 +1,31 @@
+-- +migrate Up
+-- Las fórmulas de estos subtests no tienen parámetros en el perfil: son fijas y forman parte
+-- de standard@1, con el que se calcularon todos los resultados existentes
+ALTER TABLE digit_span_subtests           ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE stroop_subtests               ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE sdmt_subtests                 ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE go_no_go_subtests             ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE card_sorting_subtests         ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE finger_tapping_subtests       ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE reaction_time_subtests        ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE jlo_subtests                  ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE confrontation_naming_subtests ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE visual_memory_subtests        ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE visual_spatial_subtest        ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE moca_subtests                 ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+ALTER TABLE archimedes_spiral_subtests    ADD COLUMN scoring_profile VARCHAR(64) NOT NULL DEFAULT 'standard@1';
+
+-- +migrate Down
+ALTER TABLE digit_span_subtests           DROP COLUMN scoring_profile;
+ALTER TABLE stroop_subtests               DROP COLUMN scoring_profile;
+ALTER TABLE sdmt_subtests                 DROP COLUMN scoring_profile;
+ALTER TABLE go_no_go_subtests             DROP COLUMN scoring_profile;
+ALTER TABLE card_sorting_subtests         DROP COLUMN scoring_profile;
+ALTER TABLE finger_tapping_subtests       DROP COLUMN scoring_profile;
+ALTER TABLE reaction_time_subtests        DROP COLUMN scoring_profile;
+ALTER TABLE jlo_subtests                  DROP COLUMN scoring_profile;
+ALTER TABLE confrontation_naming_subtests DROP COLUMN scoring_profile;
+ALTER TABLE visual_memory_subtests        DROP COLUMN scoring_profile;
+ALTER TABLE visual_spatial_subtest        DROP COLUMN scoring_profile;
+ALTER TABLE moca_subtests                 DROP COLUMN scoring_profile;
+ALTER TABLE archimedes_spiral_subtests    DROP COLUMN scoring_profile;
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	rescoresubtests "neuro.app.jordi/internal/evaluation/application/commands/rescore-subtests"
+	RSinfra "neuro.app.jordi/internal/evaluation/infra/rescoring"
+	SCPinfra "neuro.app.jordi/internal/evaluation/infra/scoring-profiles"
+)
+
+// runRescore implementa el subcomando "rescore": dry run por defecto, -apply para escribir.
+// Imprime el informe en JSON; devuelve 1 si hay errores o filas que no se pudieron recalcular.
+func runRescore(db *sql.DB, args []string) int {
+	fs := flag.NewFlagSet("rescore", flag.ContinueOnError)
+	profile := fs.String("profile", "", "scoring profile id@version (default: each row's recorded profile)")
+	subtests := fs.String("subtests", "", "comma-separated subtests (default: all)")
+	evaluationID := fs.String("evaluation", "", "only rescore this evaluation")
+	apply := fs.Bool("apply", false, "write new score revisions instead of a dry run")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd := rescoresubtests.RescoreSubtestsCommand{
+		TargetProfile: *profile,
+		EvaluationID:  *evaluationID,
+		Apply:         *apply,
+	}
+	if *subtests != "" {
+		cmd.Subtests = strings.Split(*subtests, ",")
+	}
+
+	report, err := rescoresubtests.RescoreSubtestsCommandHandler(context.Background(), cmd, SCPinfra.NewScoringProfileCatalogFromEnv(), RSinfra.NewRescoringMYSQLRepository(db))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rescore:", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fmt.Fprintln(os.Stderr, "rescore:", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "scanned %d, changed %d, unchanged %d, failed %d (dry run: %v)\n",
+		report.Scanned, report.Changed, report.Unchanged, len(report.Failures), report.DryRun)
+	if len(report.Failures) > 0 {
+		return 1
+	}
+	return 0
+}